@@ -77,11 +77,19 @@ func main() {
 		Addr: cfg.Asynq.AsynqEndpoint,
 	})
 
+	asynqInspector := asynqPkg.NewAsynqInspector(asynq.RedisClientOpt{
+		Addr: cfg.Asynq.AsynqEndpoint,
+	})
+
 	taskProcessor := asynqPkg.NewRedisTaskProcessor(asynq.RedisClientOpt{
 		Addr: cfg.Asynq.AsynqEndpoint,
 	}, appLogger)
 
-	s := server.NewServer(cfg, psqlDB, redisClient, minioClient, asynqClient, taskProcessor, appLogger)
+	taskScheduler := asynqPkg.NewRedisTaskScheduler(asynq.RedisClientOpt{
+		Addr: cfg.Asynq.AsynqEndpoint,
+	}, appLogger)
+
+	s := server.NewServer(cfg, psqlDB, redisClient, minioClient, asynqClient, asynqInspector, taskProcessor, taskScheduler, appLogger)
 	if err = s.Run(); err != nil {
 		log.Fatal(err)
 	}
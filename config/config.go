@@ -8,13 +8,19 @@ import (
 )
 
 type Config struct {
-	Server   ServerConfig
-	Logger   LoggerConfig
-	Postgres PostgresConfig
-	Jaeger   JaegerConfig
-	Redis    RedisConfig
-	Minio    MinioConfig
-	Asynq    AsynqConfig
+	Server        ServerConfig
+	Logger        LoggerConfig
+	Postgres      PostgresConfig
+	Jaeger        JaegerConfig
+	Redis         RedisConfig
+	Minio         MinioConfig
+	Asynq         AsynqConfig
+	Mailer        MailerConfig
+	Login         LoginConfig
+	Comment       CommentConfig
+	ContentFilter ContentFilterConfig
+	BlogEvents    BlogEventsConfig
+	Feed          FeedConfig
 }
 
 type ServerConfig struct {
@@ -25,6 +31,11 @@ type ServerConfig struct {
 	ReadTimeout  time.Duration
 	WriteTimeout time.Duration
 	SymmetricKey string
+	// AccessTokenExpire and RefreshTokenExpire are token lifetimes in seconds
+	AccessTokenExpire  int
+	RefreshTokenExpire int
+	// RequireVerifiedEmail deny login until user verified email
+	RequireVerifiedEmail bool
 }
 
 type LoggerConfig struct {
@@ -73,6 +84,80 @@ type AsynqConfig struct {
 	AsynqDb       int
 }
 
+type MailerConfig struct {
+	// Driver is one of smtp, file or memory
+	Driver       string
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUser     string
+	SMTPPassword string
+	From         string
+	OutputDir    string
+	// LinkBaseURL is prefix of links sent by email, e.g. frontend url
+	LinkBaseURL string
+}
+
+// LoginConfig limits failed login attempts, durations are in seconds
+type LoginConfig struct {
+	// MaxAttempts and MaxIPAttempts are failures allowed per email and per client ip before lockout
+	MaxAttempts   int
+	MaxIPAttempts int
+	// AttemptWindow is how long failures are remembered after the last one
+	AttemptWindow int
+	// LockoutDuration is the first lockout, every further failure doubles it up to MaxLockoutDuration
+	LockoutDuration    int
+	MaxLockoutDuration int
+}
+
+// CommentConfig limits comment threads
+type CommentConfig struct {
+	// MaxReplyDepth is how many levels of replies a comment can have
+	MaxReplyDepth int
+	// HotLikesPerMinute is how many like changes a minute make comment hot, likes of hot comment are counted by write-behind
+	HotLikesPerMinute int
+	// LikesFlushInterval is how many seconds buffered likes wait before they are written to postgres
+	LikesFlushInterval int
+	// LikesReconcileInterval is how many seconds between recounts of likes
+	LikesReconcileInterval int
+	// ReportHideThreshold is how many pending reports hide comment until moderator reviews it
+	ReportHideThreshold int
+}
+
+// ContentFilterConfig configures rules comments and blogs are checked with.
+// Action of rule is reject, hold or mask, rule without action is disabled
+type ContentFilterConfig struct {
+	// BannedWords are matched as whole words ignoring case, mask replaces them with asterisks
+	BannedWords       []string
+	BannedWordsAction string
+	// MaxLinks is how many links content can have, mask removes every link
+	MaxLinks    int
+	LinksAction string
+	// MaxRepeatedChars is longest run of one character, mask shortens longer runs
+	MaxRepeatedChars    int
+	RepeatedCharsAction string
+	// DuplicateWindow is how many seconds same text of author counts as duplicate, duplicate can not be masked so mask holds it
+	DuplicateWindow int
+	DuplicateAction string
+}
+
+// BlogEventsConfig configures live events of blogs streamed to clients
+type BlogEventsConfig struct {
+	// HeartbeatInterval is how many seconds idle stream waits before heartbeat keeps connection open
+	HeartbeatInterval int
+	// BacklogSize is about how many recent events of blog are kept for clients which resume stream
+	BacklogSize int
+	// BacklogTTL is how many seconds backlog of blog is kept after its last event
+	BacklogTTL int
+}
+
+// FeedConfig configures home feeds precomputed for active users
+type FeedConfig struct {
+	// Size is how many recent blogs feed of user keeps, older ones are read from database
+	Size int
+	// ActiveTTL is how many seconds after reading feed user stays active and feed is kept up to date
+	ActiveTTL int
+}
+
 func LoadConfig(filename string) (*viper.Viper, error) {
 	v := viper.New()
 
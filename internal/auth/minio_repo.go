@@ -1,3 +1,4 @@
+//go:generate mockgen -source minio_repo.go -destination mock/minio_repo_mock.go -package mock
 package auth
 
 import (
@@ -10,5 +11,6 @@ import (
 type MinioRepository interface {
 	PutObject(ctx context.Context, input models.UploadInput) (*minio.UploadInfo, error)
 	GetObject(ctx context.Context, bucket string, fileName string) (*minio.Object, error)
+	ReadObject(ctx context.Context, bucket string, fileName string) ([]byte, error)
 	RemoveObject(ctx context.Context, bucket string, fileName string) error
 }
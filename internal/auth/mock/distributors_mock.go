@@ -0,0 +1,75 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: distributors.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	asynq "github.com/hibiken/asynq"
+	asynq0 "github.com/scul0405/blog-clean-architecture-rest-api/internal/auth/transport/asynq"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAuthTaskDistributor is a mock of AuthTaskDistributor interface.
+type MockAuthTaskDistributor struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthTaskDistributorMockRecorder
+}
+
+// MockAuthTaskDistributorMockRecorder is the mock recorder for MockAuthTaskDistributor.
+type MockAuthTaskDistributorMockRecorder struct {
+	mock *MockAuthTaskDistributor
+}
+
+// NewMockAuthTaskDistributor creates a new mock instance.
+func NewMockAuthTaskDistributor(ctrl *gomock.Controller) *MockAuthTaskDistributor {
+	mock := &MockAuthTaskDistributor{ctrl: ctrl}
+	mock.recorder = &MockAuthTaskDistributorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthTaskDistributor) EXPECT() *MockAuthTaskDistributorMockRecorder {
+	return m.recorder
+}
+
+// DistributeTaskProcessAvatar mocks base method.
+func (m *MockAuthTaskDistributor) DistributeTaskProcessAvatar(ctx context.Context, payload *asynq0.ProcessAvatarPayload, opts ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, payload}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskProcessAvatar", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskProcessAvatar indicates an expected call of DistributeTaskProcessAvatar.
+func (mr *MockAuthTaskDistributorMockRecorder) DistributeTaskProcessAvatar(ctx, payload interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, payload}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskProcessAvatar", reflect.TypeOf((*MockAuthTaskDistributor)(nil).DistributeTaskProcessAvatar), varargs...)
+}
+
+// DistributeTaskSendEmail mocks base method.
+func (m *MockAuthTaskDistributor) DistributeTaskSendEmail(ctx context.Context, payload *asynq0.SendEmailPayload, opts ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, payload}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskSendEmail", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskSendEmail indicates an expected call of DistributeTaskSendEmail.
+func (mr *MockAuthTaskDistributorMockRecorder) DistributeTaskSendEmail(ctx, payload interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, payload}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskSendEmail", reflect.TypeOf((*MockAuthTaskDistributor)(nil).DistributeTaskSendEmail), varargs...)
+}
@@ -0,0 +1,301 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: redis_repo.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRedisRepository is a mock of RedisRepository interface.
+type MockRedisRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRedisRepositoryMockRecorder
+}
+
+// MockRedisRepositoryMockRecorder is the mock recorder for MockRedisRepository.
+type MockRedisRepositoryMockRecorder struct {
+	mock *MockRedisRepository
+}
+
+// NewMockRedisRepository creates a new mock instance.
+func NewMockRedisRepository(ctrl *gomock.Controller) *MockRedisRepository {
+	mock := &MockRedisRepository{ctrl: ctrl}
+	mock.recorder = &MockRedisRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRedisRepository) EXPECT() *MockRedisRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddUserSessionCtx mocks base method.
+func (m *MockRedisRepository) AddUserSessionCtx(ctx context.Context, key string, seconds int, sessionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddUserSessionCtx", ctx, key, seconds, sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddUserSessionCtx indicates an expected call of AddUserSessionCtx.
+func (mr *MockRedisRepositoryMockRecorder) AddUserSessionCtx(ctx, key, seconds, sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUserSessionCtx", reflect.TypeOf((*MockRedisRepository)(nil).AddUserSessionCtx), ctx, key, seconds, sessionID)
+}
+
+// ConsumeTokenCtx mocks base method.
+func (m *MockRedisRepository) ConsumeTokenCtx(ctx context.Context, key string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeTokenCtx", ctx, key)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsumeTokenCtx indicates an expected call of ConsumeTokenCtx.
+func (mr *MockRedisRepositoryMockRecorder) ConsumeTokenCtx(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeTokenCtx", reflect.TypeOf((*MockRedisRepository)(nil).ConsumeTokenCtx), ctx, key)
+}
+
+// DeleteSessionCtx mocks base method.
+func (m *MockRedisRepository) DeleteSessionCtx(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSessionCtx", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSessionCtx indicates an expected call of DeleteSessionCtx.
+func (mr *MockRedisRepositoryMockRecorder) DeleteSessionCtx(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSessionCtx", reflect.TypeOf((*MockRedisRepository)(nil).DeleteSessionCtx), ctx, key)
+}
+
+// DeleteUserCtx mocks base method.
+func (m *MockRedisRepository) DeleteUserCtx(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserCtx", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserCtx indicates an expected call of DeleteUserCtx.
+func (mr *MockRedisRepositoryMockRecorder) DeleteUserCtx(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserCtx", reflect.TypeOf((*MockRedisRepository)(nil).DeleteUserCtx), ctx, key)
+}
+
+// GetByIDCtx mocks base method.
+func (m *MockRedisRepository) GetByIDCtx(ctx context.Context, key string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDCtx", ctx, key)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDCtx indicates an expected call of GetByIDCtx.
+func (mr *MockRedisRepositoryMockRecorder) GetByIDCtx(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDCtx", reflect.TypeOf((*MockRedisRepository)(nil).GetByIDCtx), ctx, key)
+}
+
+// GetLoginLockTTLCtx mocks base method.
+func (m *MockRedisRepository) GetLoginLockTTLCtx(ctx context.Context, key string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoginLockTTLCtx", ctx, key)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoginLockTTLCtx indicates an expected call of GetLoginLockTTLCtx.
+func (mr *MockRedisRepositoryMockRecorder) GetLoginLockTTLCtx(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoginLockTTLCtx", reflect.TypeOf((*MockRedisRepository)(nil).GetLoginLockTTLCtx), ctx, key)
+}
+
+// GetSessionCtx mocks base method.
+func (m *MockRedisRepository) GetSessionCtx(ctx context.Context, key string) (*models.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSessionCtx", ctx, key)
+	ret0, _ := ret[0].(*models.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSessionCtx indicates an expected call of GetSessionCtx.
+func (mr *MockRedisRepositoryMockRecorder) GetSessionCtx(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionCtx", reflect.TypeOf((*MockRedisRepository)(nil).GetSessionCtx), ctx, key)
+}
+
+// GetUserSessionsCtx mocks base method.
+func (m *MockRedisRepository) GetUserSessionsCtx(ctx context.Context, key string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserSessionsCtx", ctx, key)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserSessionsCtx indicates an expected call of GetUserSessionsCtx.
+func (mr *MockRedisRepositoryMockRecorder) GetUserSessionsCtx(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserSessionsCtx", reflect.TypeOf((*MockRedisRepository)(nil).GetUserSessionsCtx), ctx, key)
+}
+
+// IncrLoginFailuresCtx mocks base method.
+func (m *MockRedisRepository) IncrLoginFailuresCtx(ctx context.Context, key string, seconds int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrLoginFailuresCtx", ctx, key, seconds)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IncrLoginFailuresCtx indicates an expected call of IncrLoginFailuresCtx.
+func (mr *MockRedisRepositoryMockRecorder) IncrLoginFailuresCtx(ctx, key, seconds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrLoginFailuresCtx", reflect.TypeOf((*MockRedisRepository)(nil).IncrLoginFailuresCtx), ctx, key, seconds)
+}
+
+// IsRevokedCtx mocks base method.
+func (m *MockRedisRepository) IsRevokedCtx(ctx context.Context, key string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsRevokedCtx", ctx, key)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsRevokedCtx indicates an expected call of IsRevokedCtx.
+func (mr *MockRedisRepositoryMockRecorder) IsRevokedCtx(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsRevokedCtx", reflect.TypeOf((*MockRedisRepository)(nil).IsRevokedCtx), ctx, key)
+}
+
+// LockLoginCtx mocks base method.
+func (m *MockRedisRepository) LockLoginCtx(ctx context.Context, key string, seconds int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockLoginCtx", ctx, key, seconds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockLoginCtx indicates an expected call of LockLoginCtx.
+func (mr *MockRedisRepositoryMockRecorder) LockLoginCtx(ctx, key, seconds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockLoginCtx", reflect.TypeOf((*MockRedisRepository)(nil).LockLoginCtx), ctx, key, seconds)
+}
+
+// RemoveUserSessionCtx mocks base method.
+func (m *MockRedisRepository) RemoveUserSessionCtx(ctx context.Context, key, sessionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveUserSessionCtx", ctx, key, sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveUserSessionCtx indicates an expected call of RemoveUserSessionCtx.
+func (mr *MockRedisRepositoryMockRecorder) RemoveUserSessionCtx(ctx, key, sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveUserSessionCtx", reflect.TypeOf((*MockRedisRepository)(nil).RemoveUserSessionCtx), ctx, key, sessionID)
+}
+
+// ResetLoginAttemptsCtx mocks base method.
+func (m *MockRedisRepository) ResetLoginAttemptsCtx(ctx context.Context, keys ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range keys {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ResetLoginAttemptsCtx", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetLoginAttemptsCtx indicates an expected call of ResetLoginAttemptsCtx.
+func (mr *MockRedisRepositoryMockRecorder) ResetLoginAttemptsCtx(ctx interface{}, keys ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, keys...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetLoginAttemptsCtx", reflect.TypeOf((*MockRedisRepository)(nil).ResetLoginAttemptsCtx), varargs...)
+}
+
+// RevokeCtx mocks base method.
+func (m *MockRedisRepository) RevokeCtx(ctx context.Context, key string, seconds int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeCtx", ctx, key, seconds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeCtx indicates an expected call of RevokeCtx.
+func (mr *MockRedisRepositoryMockRecorder) RevokeCtx(ctx, key, seconds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeCtx", reflect.TypeOf((*MockRedisRepository)(nil).RevokeCtx), ctx, key, seconds)
+}
+
+// RotateSessionCtx mocks base method.
+func (m *MockRedisRepository) RotateSessionCtx(ctx context.Context, key string, seconds int, previousTokenID string, session *models.Session) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateSessionCtx", ctx, key, seconds, previousTokenID, session)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateSessionCtx indicates an expected call of RotateSessionCtx.
+func (mr *MockRedisRepositoryMockRecorder) RotateSessionCtx(ctx, key, seconds, previousTokenID, session interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateSessionCtx", reflect.TypeOf((*MockRedisRepository)(nil).RotateSessionCtx), ctx, key, seconds, previousTokenID, session)
+}
+
+// SetSessionCtx mocks base method.
+func (m *MockRedisRepository) SetSessionCtx(ctx context.Context, key string, seconds int, session *models.Session) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSessionCtx", ctx, key, seconds, session)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSessionCtx indicates an expected call of SetSessionCtx.
+func (mr *MockRedisRepositoryMockRecorder) SetSessionCtx(ctx, key, seconds, session interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSessionCtx", reflect.TypeOf((*MockRedisRepository)(nil).SetSessionCtx), ctx, key, seconds, session)
+}
+
+// SetTokenCtx mocks base method.
+func (m *MockRedisRepository) SetTokenCtx(ctx context.Context, key string, seconds int, value string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTokenCtx", ctx, key, seconds, value)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTokenCtx indicates an expected call of SetTokenCtx.
+func (mr *MockRedisRepositoryMockRecorder) SetTokenCtx(ctx, key, seconds, value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTokenCtx", reflect.TypeOf((*MockRedisRepository)(nil).SetTokenCtx), ctx, key, seconds, value)
+}
+
+// SetUserCtx mocks base method.
+func (m *MockRedisRepository) SetUserCtx(ctx context.Context, key string, seconds int, user *models.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserCtx", ctx, key, seconds, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUserCtx indicates an expected call of SetUserCtx.
+func (mr *MockRedisRepositoryMockRecorder) SetUserCtx(ctx, key, seconds, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserCtx", reflect.TypeOf((*MockRedisRepository)(nil).SetUserCtx), ctx, key, seconds, user)
+}
@@ -10,6 +10,7 @@ import (
 
 	uuid "github.com/google/uuid"
 	models "github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	paseto "github.com/scul0405/blog-clean-architecture-rest-api/pkg/paseto"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -36,6 +37,48 @@ func (m *MockUseCase) EXPECT() *MockUseCaseMockRecorder {
 	return m.recorder
 }
 
+// ChangePassword mocks base method.
+func (m *MockUseCase) ChangePassword(ctx context.Context, changePassword *models.ChangePassword) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", ctx, changePassword)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockUseCaseMockRecorder) ChangePassword(ctx, changePassword interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockUseCase)(nil).ChangePassword), ctx, changePassword)
+}
+
+// DeleteMe mocks base method.
+func (m *MockUseCase) DeleteMe(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMe", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMe indicates an expected call of DeleteMe.
+func (mr *MockUseCaseMockRecorder) DeleteMe(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMe", reflect.TypeOf((*MockUseCase)(nil).DeleteMe), ctx)
+}
+
+// ForgotPassword mocks base method.
+func (m *MockUseCase) ForgotPassword(ctx context.Context, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForgotPassword", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForgotPassword indicates an expected call of ForgotPassword.
+func (mr *MockUseCaseMockRecorder) ForgotPassword(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForgotPassword", reflect.TypeOf((*MockUseCase)(nil).ForgotPassword), ctx, email)
+}
+
 // GetByID mocks base method.
 func (m *MockUseCase) GetByID(ctx context.Context, userID uuid.UUID) (*models.User, error) {
 	m.ctrl.T.Helper()
@@ -51,19 +94,106 @@ func (mr *MockUseCaseMockRecorder) GetByID(ctx, userID interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockUseCase)(nil).GetByID), ctx, userID)
 }
 
+// GetMe mocks base method.
+func (m *MockUseCase) GetMe(ctx context.Context) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMe", ctx)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMe indicates an expected call of GetMe.
+func (mr *MockUseCaseMockRecorder) GetMe(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMe", reflect.TypeOf((*MockUseCase)(nil).GetMe), ctx)
+}
+
+// GetProfile mocks base method.
+func (m *MockUseCase) GetProfile(ctx context.Context, userID uuid.UUID) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProfile", ctx, userID)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProfile indicates an expected call of GetProfile.
+func (mr *MockUseCaseMockRecorder) GetProfile(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfile", reflect.TypeOf((*MockUseCase)(nil).GetProfile), ctx, userID)
+}
+
 // Login mocks base method.
-func (m *MockUseCase) Login(ctx context.Context, user *models.LoginUser) (*models.UserWithToken, error) {
+func (m *MockUseCase) Login(ctx context.Context, user *models.LoginUser, ipAddress string) (*models.UserWithToken, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Login", ctx, user)
+	ret := m.ctrl.Call(m, "Login", ctx, user, ipAddress)
 	ret0, _ := ret[0].(*models.UserWithToken)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Login indicates an expected call of Login.
-func (mr *MockUseCaseMockRecorder) Login(ctx, user interface{}) *gomock.Call {
+func (mr *MockUseCaseMockRecorder) Login(ctx, user, ipAddress interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockUseCase)(nil).Login), ctx, user, ipAddress)
+}
+
+// Logout mocks base method.
+func (m *MockUseCase) Logout(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logout", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockUseCaseMockRecorder) Logout(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockUseCase)(nil).Logout), ctx)
+}
+
+// LogoutAll mocks base method.
+func (m *MockUseCase) LogoutAll(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogoutAll", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LogoutAll indicates an expected call of LogoutAll.
+func (mr *MockUseCaseMockRecorder) LogoutAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogoutAll", reflect.TypeOf((*MockUseCase)(nil).LogoutAll), ctx)
+}
+
+// ProcessAvatar mocks base method.
+func (m *MockUseCase) ProcessAvatar(ctx context.Context, userID uuid.UUID, image *models.ImageInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessAvatar", ctx, userID, image)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProcessAvatar indicates an expected call of ProcessAvatar.
+func (mr *MockUseCaseMockRecorder) ProcessAvatar(ctx, userID, image interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockUseCase)(nil).Login), ctx, user)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessAvatar", reflect.TypeOf((*MockUseCase)(nil).ProcessAvatar), ctx, userID, image)
+}
+
+// RefreshToken mocks base method.
+func (m *MockUseCase) RefreshToken(ctx context.Context, refreshToken string) (*models.UserWithToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshToken", ctx, refreshToken)
+	ret0, _ := ret[0].(*models.UserWithToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefreshToken indicates an expected call of RefreshToken.
+func (mr *MockUseCaseMockRecorder) RefreshToken(ctx, refreshToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshToken", reflect.TypeOf((*MockUseCase)(nil).RefreshToken), ctx, refreshToken)
 }
 
 // Register mocks base method.
@@ -80,3 +210,104 @@ func (mr *MockUseCaseMockRecorder) Register(ctx, user interface{}) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockUseCase)(nil).Register), ctx, user)
 }
+
+// ResetPassword mocks base method.
+func (m *MockUseCase) ResetPassword(ctx context.Context, token, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", ctx, token, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockUseCaseMockRecorder) ResetPassword(ctx, token, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockUseCase)(nil).ResetPassword), ctx, token, password)
+}
+
+// UnlockLogin mocks base method.
+func (m *MockUseCase) UnlockLogin(ctx context.Context, unlock *models.UnlockLogin) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockLogin", ctx, unlock)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlockLogin indicates an expected call of UnlockLogin.
+func (mr *MockUseCaseMockRecorder) UnlockLogin(ctx, unlock interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockLogin", reflect.TypeOf((*MockUseCase)(nil).UnlockLogin), ctx, unlock)
+}
+
+// UpdateMe mocks base method.
+func (m *MockUseCase) UpdateMe(ctx context.Context, user *models.UpdateUser) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMe", ctx, user)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateMe indicates an expected call of UpdateMe.
+func (mr *MockUseCaseMockRecorder) UpdateMe(ctx, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMe", reflect.TypeOf((*MockUseCase)(nil).UpdateMe), ctx, user)
+}
+
+// UpdateRole mocks base method.
+func (m *MockUseCase) UpdateRole(ctx context.Context, userID uuid.UUID, role string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRole", ctx, userID, role)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRole indicates an expected call of UpdateRole.
+func (mr *MockUseCaseMockRecorder) UpdateRole(ctx, userID, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRole", reflect.TypeOf((*MockUseCase)(nil).UpdateRole), ctx, userID, role)
+}
+
+// UploadAvatar mocks base method.
+func (m *MockUseCase) UploadAvatar(ctx context.Context, userID uuid.UUID, image *models.ImageInput) (models.ImageVariants, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadAvatar", ctx, userID, image)
+	ret0, _ := ret[0].(models.ImageVariants)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadAvatar indicates an expected call of UploadAvatar.
+func (mr *MockUseCaseMockRecorder) UploadAvatar(ctx, userID, image interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadAvatar", reflect.TypeOf((*MockUseCase)(nil).UploadAvatar), ctx, userID, image)
+}
+
+// ValidateAccessToken mocks base method.
+func (m *MockUseCase) ValidateAccessToken(ctx context.Context, payload *paseto.Payload) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateAccessToken", ctx, payload)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateAccessToken indicates an expected call of ValidateAccessToken.
+func (mr *MockUseCaseMockRecorder) ValidateAccessToken(ctx, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateAccessToken", reflect.TypeOf((*MockUseCase)(nil).ValidateAccessToken), ctx, payload)
+}
+
+// VerifyEmail mocks base method.
+func (m *MockUseCase) VerifyEmail(ctx context.Context, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockUseCaseMockRecorder) VerifyEmail(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockUseCase)(nil).VerifyEmail), ctx, token)
+}
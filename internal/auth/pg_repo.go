@@ -12,4 +12,10 @@ type Repository interface {
 	GetByID(ctx context.Context, userID uuid.UUID) (*models.User, error)
 	FindByEmail(ctx context.Context, email string) (*models.User, error)
 	Update(ctx context.Context, user *models.User) (*models.User, error)
+	UpdatePassword(ctx context.Context, userID uuid.UUID, password string) error
+	VerifyEmail(ctx context.Context, userID uuid.UUID) error
+	GetPassword(ctx context.Context, userID uuid.UUID) (string, error)
+	Delete(ctx context.Context, userID uuid.UUID) error
+	UpdateLoginDate(ctx context.Context, userID uuid.UUID) error
+	CreateLoginEvent(ctx context.Context, event *models.LoginEvent) error
 }
@@ -1,3 +1,4 @@
+//go:generate mockgen -source redis_repo.go -destination mock/redis_repo_mock.go -package mock
 package auth
 
 import (
@@ -9,4 +10,19 @@ type RedisRepository interface {
 	GetByIDCtx(ctx context.Context, key string) (*models.User, error)
 	SetUserCtx(ctx context.Context, key string, seconds int, user *models.User) error
 	DeleteUserCtx(ctx context.Context, key string) error
+	GetSessionCtx(ctx context.Context, key string) (*models.Session, error)
+	SetSessionCtx(ctx context.Context, key string, seconds int, session *models.Session) error
+	RotateSessionCtx(ctx context.Context, key string, seconds int, previousTokenID string, session *models.Session) (bool, error)
+	DeleteSessionCtx(ctx context.Context, key string) error
+	AddUserSessionCtx(ctx context.Context, key string, seconds int, sessionID string) error
+	GetUserSessionsCtx(ctx context.Context, key string) ([]string, error)
+	RemoveUserSessionCtx(ctx context.Context, key string, sessionID string) error
+	RevokeCtx(ctx context.Context, key string, seconds int) error
+	IsRevokedCtx(ctx context.Context, key string) (bool, error)
+	SetTokenCtx(ctx context.Context, key string, seconds int, value string) error
+	ConsumeTokenCtx(ctx context.Context, key string) (string, error)
+	IncrLoginFailuresCtx(ctx context.Context, key string, seconds int) (int, error)
+	LockLoginCtx(ctx context.Context, key string, seconds int) error
+	GetLoginLockTTLCtx(ctx context.Context, key string) (int, error)
+	ResetLoginAttemptsCtx(ctx context.Context, keys ...string) error
 }
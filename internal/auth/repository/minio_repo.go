@@ -2,13 +2,12 @@ package repository
 
 import (
 	"context"
-	"fmt"
-	"github.com/google/uuid"
 	"github.com/minio/minio-go/v7"
 	"github.com/opentracing/opentracing-go"
 	"github.com/pkg/errors"
 	"github.com/scul0405/blog-clean-architecture-rest-api/internal/auth"
 	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	"io"
 )
 
 type authMinioRepo struct {
@@ -19,7 +18,7 @@ func NewAuthMinioRepository(client *minio.Client) auth.MinioRepository {
 	return &authMinioRepo{client: client}
 }
 
-// PutObject upload file to Minio
+// PutObject upload file to Minio, input name is used as object key
 func (r *authMinioRepo) PutObject(ctx context.Context, input models.UploadInput) (*minio.UploadInfo, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "authMinioRepo.PutObject")
 	defer span.Finish()
@@ -29,7 +28,7 @@ func (r *authMinioRepo) PutObject(ctx context.Context, input models.UploadInput)
 		UserMetadata: map[string]string{"x-amz-acl": "public-read"},
 	}
 
-	uploadInfo, err := r.client.PutObject(ctx, input.BucketName, r.generateFileName(input.Name), input.File, input.Size, options)
+	uploadInfo, err := r.client.PutObject(ctx, input.BucketName, input.Name, input.File, input.Size, options)
 	if err != nil {
 		return nil, errors.Wrap(err, "authMinioRepo.FileUpload.PutObject")
 	}
@@ -49,6 +48,24 @@ func (r *authMinioRepo) GetObject(ctx context.Context, bucket string, fileName s
 	return object, nil
 }
 
+// ReadObject download whole file from Minio, it is meant for small files like uploaded images
+func (r *authMinioRepo) ReadObject(ctx context.Context, bucket string, fileName string) ([]byte, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authMinioRepo.ReadObject")
+	defer span.Finish()
+
+	object, err := r.client.GetObject(ctx, bucket, fileName, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "authMinioRepo.ReadObject.GetObject")
+	}
+	defer object.Close()
+
+	data, err := io.ReadAll(object)
+	if err != nil {
+		return nil, errors.Wrap(err, "authMinioRepo.ReadObject.ReadAll")
+	}
+	return data, nil
+}
+
 // RemoveObject delete file from Minio
 func (r *authMinioRepo) RemoveObject(ctx context.Context, bucket string, fileName string) error {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "authMinioRepo.RemoveObject")
@@ -59,8 +76,3 @@ func (r *authMinioRepo) RemoveObject(ctx context.Context, bucket string, fileNam
 	}
 	return nil
 }
-
-func (r *authMinioRepo) generateFileName(fileName string) string {
-	uid := uuid.New().String()
-	return fmt.Sprintf("%s-%s", uid, fileName)
-}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	"github.com/opentracing/opentracing-go"
@@ -67,10 +68,111 @@ func (r *authRepo) Update(ctx context.Context, user *models.User) (*models.User,
 	u := &models.User{}
 	if err := r.db.GetContext(ctx, u, updateUserQuery, &user.FirstName, &user.LastName, &user.Email,
 		&user.Role, &user.About, &user.Avatar, &user.PhoneNumber, &user.Address, &user.City, &user.Gender,
-		&user.Postcode, &user.Birthday, &user.UserID,
+		&user.Postcode, &user.Birthday, &user.Country, &user.UserID,
 	); err != nil {
 		return nil, errors.Wrap(err, "authRepo.Update.GetContext")
 	}
 
 	return u, nil
 }
+
+// UpdatePassword set user's hashed password
+func (r *authRepo) UpdatePassword(ctx context.Context, userID uuid.UUID, password string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authRepo.UpdatePassword")
+	defer span.Finish()
+
+	result, err := r.db.ExecContext(ctx, updatePasswordQuery, password, userID)
+	if err != nil {
+		return errors.Wrap(err, "authRepo.UpdatePassword.ExecContext")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "authRepo.UpdatePassword.RowsAffected")
+	}
+	if rowsAffected == 0 {
+		return errors.Wrap(sql.ErrNoRows, "authRepo.UpdatePassword.rowsAffected")
+	}
+
+	return nil
+}
+
+// VerifyEmail mark user's email as verified
+func (r *authRepo) VerifyEmail(ctx context.Context, userID uuid.UUID) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authRepo.VerifyEmail")
+	defer span.Finish()
+
+	result, err := r.db.ExecContext(ctx, verifyEmailQuery, userID)
+	if err != nil {
+		return errors.Wrap(err, "authRepo.VerifyEmail.ExecContext")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "authRepo.VerifyEmail.RowsAffected")
+	}
+	if rowsAffected == 0 {
+		return errors.Wrap(sql.ErrNoRows, "authRepo.VerifyEmail.rowsAffected")
+	}
+
+	return nil
+}
+
+// GetPassword get user's hashed password
+func (r *authRepo) GetPassword(ctx context.Context, userID uuid.UUID) (string, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authRepo.GetPassword")
+	defer span.Finish()
+
+	var password string
+	if err := r.db.GetContext(ctx, &password, getPasswordQuery, userID); err != nil {
+		return "", errors.Wrap(err, "authRepo.GetPassword.GetContext")
+	}
+
+	return password, nil
+}
+
+// Delete soft delete user and anonymise personal data
+func (r *authRepo) Delete(ctx context.Context, userID uuid.UUID) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authRepo.Delete")
+	defer span.Finish()
+
+	result, err := r.db.ExecContext(ctx, deleteUserQuery, userID)
+	if err != nil {
+		return errors.Wrap(err, "authRepo.Delete.ExecContext")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "authRepo.Delete.RowsAffected")
+	}
+	if rowsAffected == 0 {
+		return errors.Wrap(sql.ErrNoRows, "authRepo.Delete.rowsAffected")
+	}
+
+	return nil
+}
+
+// UpdateLoginDate set user's last login to now
+func (r *authRepo) UpdateLoginDate(ctx context.Context, userID uuid.UUID) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authRepo.UpdateLoginDate")
+	defer span.Finish()
+
+	if _, err := r.db.ExecContext(ctx, updateLoginDateQuery, userID); err != nil {
+		return errors.Wrap(err, "authRepo.UpdateLoginDate.ExecContext")
+	}
+
+	return nil
+}
+
+// CreateLoginEvent record login attempt
+func (r *authRepo) CreateLoginEvent(ctx context.Context, event *models.LoginEvent) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authRepo.CreateLoginEvent")
+	defer span.Finish()
+
+	if _, err := r.db.ExecContext(ctx, createLoginEventQuery, event.UserID, event.Email, event.IPAddress,
+		event.Success, event.Reason); err != nil {
+		return errors.Wrap(err, "authRepo.CreateLoginEvent.ExecContext")
+	}
+
+	return nil
+}
@@ -8,9 +8,21 @@ import (
 	"github.com/redis/go-redis/v9"
 	"github.com/scul0405/blog-clean-architecture-rest-api/internal/auth"
 	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	"math"
 	"time"
 )
 
+// rotateSessionScript replace session only while it still holds refresh token of ARGV[1], so concurrent refreshes
+// with same token cannot both rotate it
+var rotateSessionScript = redis.NewScript(`
+local current = redis.call('GET', KEYS[1])
+if not current or cjson.decode(current).refresh_token_id ~= ARGV[1] then
+	return 0
+end
+redis.call('SET', KEYS[1], ARGV[2], 'EX', ARGV[3])
+return 1
+`)
+
 type authRedisRepo struct {
 	rdb *redis.Client
 }
@@ -57,3 +69,188 @@ func (r *authRedisRepo) DeleteUserCtx(ctx context.Context, key string) error {
 	}
 	return nil
 }
+
+func (r *authRedisRepo) GetSessionCtx(ctx context.Context, key string) (*models.Session, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authRedisRepo.GetSessionCtx")
+	defer span.Finish()
+
+	sessionBytes, err := r.rdb.Get(ctx, key).Bytes()
+	if err != nil {
+		return nil, errors.Wrap(err, "authRedisRepo.GetSessionCtx.redisClient.Get")
+	}
+	session := &models.Session{}
+	if err = json.Unmarshal(sessionBytes, session); err != nil {
+		return nil, errors.Wrap(err, "authRedisRepo.GetSessionCtx.json.Unmarshal")
+	}
+	return session, nil
+}
+
+func (r *authRedisRepo) SetSessionCtx(ctx context.Context, key string, seconds int, session *models.Session) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authRedisRepo.SetSessionCtx")
+	defer span.Finish()
+
+	sessionBytes, err := json.Marshal(session)
+	if err != nil {
+		return errors.Wrap(err, "authRedisRepo.SetSessionCtx.json.Marshal")
+	}
+	if err = r.rdb.Set(ctx, key, sessionBytes, time.Second*time.Duration(seconds)).Err(); err != nil {
+		return errors.Wrap(err, "authRedisRepo.SetSessionCtx.redisClient.Set")
+	}
+	return nil
+}
+
+// RotateSessionCtx store session if it still holds refresh token of previousTokenID, it reports false when session
+// is missing or its refresh token was rotated by someone else
+func (r *authRedisRepo) RotateSessionCtx(ctx context.Context, key string, seconds int, previousTokenID string, session *models.Session) (bool, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authRedisRepo.RotateSessionCtx")
+	defer span.Finish()
+
+	sessionBytes, err := json.Marshal(session)
+	if err != nil {
+		return false, errors.Wrap(err, "authRedisRepo.RotateSessionCtx.json.Marshal")
+	}
+	rotated, err := rotateSessionScript.Run(ctx, r.rdb, []string{key}, previousTokenID, sessionBytes, seconds).Int()
+	if err != nil {
+		return false, errors.Wrap(err, "authRedisRepo.RotateSessionCtx.rotateSessionScript.Run")
+	}
+	return rotated == 1, nil
+}
+
+func (r *authRedisRepo) DeleteSessionCtx(ctx context.Context, key string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authRedisRepo.DeleteSessionCtx")
+	defer span.Finish()
+
+	if err := r.rdb.Del(ctx, key).Err(); err != nil {
+		return errors.Wrap(err, "authRedisRepo.DeleteSessionCtx.redisClient.Del")
+	}
+	return nil
+}
+
+// AddUserSessionCtx add session id to user's set of sessions and extend set expiration
+func (r *authRedisRepo) AddUserSessionCtx(ctx context.Context, key string, seconds int, sessionID string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authRedisRepo.AddUserSessionCtx")
+	defer span.Finish()
+
+	pipe := r.rdb.TxPipeline()
+	pipe.SAdd(ctx, key, sessionID)
+	pipe.Expire(ctx, key, time.Second*time.Duration(seconds))
+	if _, err := pipe.Exec(ctx); err != nil {
+		return errors.Wrap(err, "authRedisRepo.AddUserSessionCtx.pipe.Exec")
+	}
+	return nil
+}
+
+func (r *authRedisRepo) GetUserSessionsCtx(ctx context.Context, key string) ([]string, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authRedisRepo.GetUserSessionsCtx")
+	defer span.Finish()
+
+	sessionIDs, err := r.rdb.SMembers(ctx, key).Result()
+	if err != nil {
+		return nil, errors.Wrap(err, "authRedisRepo.GetUserSessionsCtx.redisClient.SMembers")
+	}
+	return sessionIDs, nil
+}
+
+func (r *authRedisRepo) RemoveUserSessionCtx(ctx context.Context, key string, sessionID string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authRedisRepo.RemoveUserSessionCtx")
+	defer span.Finish()
+
+	if err := r.rdb.SRem(ctx, key, sessionID).Err(); err != nil {
+		return errors.Wrap(err, "authRedisRepo.RemoveUserSessionCtx.redisClient.SRem")
+	}
+	return nil
+}
+
+// RevokeCtx put key into revocation list, it should live at least as long as the revoked token
+func (r *authRedisRepo) RevokeCtx(ctx context.Context, key string, seconds int) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authRedisRepo.RevokeCtx")
+	defer span.Finish()
+
+	if err := r.rdb.Set(ctx, key, 1, time.Second*time.Duration(seconds)).Err(); err != nil {
+		return errors.Wrap(err, "authRedisRepo.RevokeCtx.redisClient.Set")
+	}
+	return nil
+}
+
+func (r *authRedisRepo) IsRevokedCtx(ctx context.Context, key string) (bool, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authRedisRepo.IsRevokedCtx")
+	defer span.Finish()
+
+	n, err := r.rdb.Exists(ctx, key).Result()
+	if err != nil {
+		return false, errors.Wrap(err, "authRedisRepo.IsRevokedCtx.redisClient.Exists")
+	}
+	return n > 0, nil
+}
+
+func (r *authRedisRepo) SetTokenCtx(ctx context.Context, key string, seconds int, value string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authRedisRepo.SetTokenCtx")
+	defer span.Finish()
+
+	if err := r.rdb.Set(ctx, key, value, time.Second*time.Duration(seconds)).Err(); err != nil {
+		return errors.Wrap(err, "authRedisRepo.SetTokenCtx.redisClient.Set")
+	}
+	return nil
+}
+
+// ConsumeTokenCtx get and delete token atomically, so token can be used only once
+func (r *authRedisRepo) ConsumeTokenCtx(ctx context.Context, key string) (string, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authRedisRepo.ConsumeTokenCtx")
+	defer span.Finish()
+
+	value, err := r.rdb.GetDel(ctx, key).Result()
+	if err != nil {
+		return "", errors.Wrap(err, "authRedisRepo.ConsumeTokenCtx.redisClient.GetDel")
+	}
+	return value, nil
+}
+
+// IncrLoginFailuresCtx count failed login, counter expires seconds after the last failure
+func (r *authRedisRepo) IncrLoginFailuresCtx(ctx context.Context, key string, seconds int) (int, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authRedisRepo.IncrLoginFailuresCtx")
+	defer span.Finish()
+
+	pipe := r.rdb.TxPipeline()
+	incr := pipe.Incr(ctx, key)
+	pipe.Expire(ctx, key, time.Second*time.Duration(seconds))
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, errors.Wrap(err, "authRedisRepo.IncrLoginFailuresCtx.redisClient.Exec")
+	}
+	return int(incr.Val()), nil
+}
+
+func (r *authRedisRepo) LockLoginCtx(ctx context.Context, key string, seconds int) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authRedisRepo.LockLoginCtx")
+	defer span.Finish()
+
+	if err := r.rdb.Set(ctx, key, 1, time.Second*time.Duration(seconds)).Err(); err != nil {
+		return errors.Wrap(err, "authRedisRepo.LockLoginCtx.redisClient.Set")
+	}
+	return nil
+}
+
+// GetLoginLockTTLCtx get seconds left of lockout, 0 when not locked
+func (r *authRedisRepo) GetLoginLockTTLCtx(ctx context.Context, key string) (int, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authRedisRepo.GetLoginLockTTLCtx")
+	defer span.Finish()
+
+	ttl, err := r.rdb.TTL(ctx, key).Result()
+	if err != nil {
+		return 0, errors.Wrap(err, "authRedisRepo.GetLoginLockTTLCtx.redisClient.TTL")
+	}
+	// Missing key gives negative ttl
+	if ttl <= 0 {
+		return 0, nil
+	}
+	return int(math.Ceil(ttl.Seconds())), nil
+}
+
+func (r *authRedisRepo) ResetLoginAttemptsCtx(ctx context.Context, keys ...string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authRedisRepo.ResetLoginAttemptsCtx")
+	defer span.Finish()
+
+	if err := r.rdb.Del(ctx, keys...).Err(); err != nil {
+		return errors.Wrap(err, "authRedisRepo.ResetLoginAttemptsCtx.redisClient.Del")
+	}
+	return nil
+}
@@ -7,14 +7,14 @@ const (
 						RETURNING *`
 
 	getUserQuery = `SELECT user_id, first_name, last_name, email, role, about, avatar, phone_number, 
-       				 address, city, gender, postcode, birthday, created_at, updated_at, login_date  
+       				 address, city, gender, postcode, birthday, email_verified, created_at, updated_at, login_date  
 					 FROM users 
-					 WHERE user_id = $1`
+					 WHERE user_id = $1 AND deleted_at IS NULL`
 
 	getUserByEmailQuery = `SELECT user_id, first_name, last_name, email, password, role, about, avatar, phone_number, 
-							address, city, gender, postcode, birthday, created_at, updated_at, login_date  
+							address, city, gender, postcode, birthday, email_verified, created_at, updated_at, login_date  
 							FROM users 
-							WHERE email = $1`
+							WHERE email = $1 AND deleted_at IS NULL`
 
 	updateUserQuery = `UPDATE users 
 						SET first_name = COALESCE(NULLIF($1, ''), first_name),
@@ -29,8 +29,37 @@ const (
 						    gender = COALESCE(NULLIF($10, ''), gender),
 						    postcode = COALESCE(NULLIF($11, 0), postcode),
 						    birthday = COALESCE(NULLIF($12, '')::date, birthday),
+						    country = COALESCE(NULLIF($13, ''), country),
 						    updated_at = now()
-						WHERE user_id = $13
+						WHERE user_id = $14 AND deleted_at IS NULL
 						RETURNING *
 						`
+
+	getPasswordQuery = `SELECT password FROM users WHERE user_id = $1 AND deleted_at IS NULL`
+
+	updatePasswordQuery = `UPDATE users SET password = $1, updated_at = now() WHERE user_id = $2 AND deleted_at IS NULL`
+
+	verifyEmailQuery = `UPDATE users SET email_verified = TRUE, updated_at = now() WHERE user_id = $1 AND deleted_at IS NULL`
+
+	updateLoginDateQuery = `UPDATE users SET login_date = now() WHERE user_id = $1`
+
+	createLoginEventQuery = `INSERT INTO login_events (user_id, email, ip_address, success, reason) VALUES ($1, $2, $3, $4, $5)`
+
+	// Keep row so authored comments stay but are shown as "Deleted User", '!' is never a valid bcrypt hash
+	deleteUserQuery = `UPDATE users 
+						SET first_name = 'Deleted',
+						    last_name = 'User',
+						    email = 'deleted-' || user_id || '@deleted.invalid',
+						    password = '!',
+						    about = '',
+						    avatar = NULL,
+						    phone_number = NULL,
+						    address = NULL,
+						    city = NULL,
+						    country = NULL,
+						    postcode = NULL,
+						    birthday = NULL,
+						    updated_at = now(),
+						    deleted_at = now()
+						WHERE user_id = $1 AND deleted_at IS NULL`
 )
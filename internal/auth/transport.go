@@ -6,5 +6,17 @@ type Handlers interface {
 	Register() echo.HandlerFunc
 	GetByID() echo.HandlerFunc
 	Login() echo.HandlerFunc
+	UnlockLogin() echo.HandlerFunc
 	UploadAvatar() echo.HandlerFunc
+	GetMe() echo.HandlerFunc
+	UpdateMe() echo.HandlerFunc
+	ChangePassword() echo.HandlerFunc
+	DeleteMe() echo.HandlerFunc
+	RefreshToken() echo.HandlerFunc
+	Logout() echo.HandlerFunc
+	LogoutAll() echo.HandlerFunc
+	UpdateRole() echo.HandlerFunc
+	VerifyEmail() echo.HandlerFunc
+	ForgotPassword() echo.HandlerFunc
+	ResetPassword() echo.HandlerFunc
 }
@@ -0,0 +1,61 @@
+//go:generate mockgen -source distributors.go -destination ../../mock/distributors_mock.go -package mock
+package asynq
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/hibiken/asynq"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/logger"
+)
+
+type AuthTaskDistributor interface {
+	DistributeTaskSendEmail(ctx context.Context, payload *SendEmailPayload, opts ...asynq.Option) error
+	DistributeTaskProcessAvatar(ctx context.Context, payload *ProcessAvatarPayload, opts ...asynq.Option) error
+}
+
+type authTaskDistributor struct {
+	client *asynq.Client
+	logger logger.Logger
+}
+
+func NewAuthTaskDistributor(client *asynq.Client, logger logger.Logger) AuthTaskDistributor {
+	return &authTaskDistributor{
+		client: client,
+		logger: logger,
+	}
+}
+
+func (distributor *authTaskDistributor) DistributeTaskSendEmail(ctx context.Context, payload *SendEmailPayload, opts ...asynq.Option) error {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task payload: %w", err)
+	}
+
+	task := asynq.NewTask(TypeSendEmailTask, jsonPayload, opts...)
+	info, err := distributor.client.EnqueueContext(ctx, task)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+
+	distributor.logger.Infof("type=%s, queue=%s, maxRetry=%d enqueued task", info.Type, info.Queue, info.MaxRetry)
+
+	return nil
+}
+
+func (distributor *authTaskDistributor) DistributeTaskProcessAvatar(ctx context.Context, payload *ProcessAvatarPayload, opts ...asynq.Option) error {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task payload: %w", err)
+	}
+
+	task := asynq.NewTask(TypeProcessAvatarTask, jsonPayload, opts...)
+	info, err := distributor.client.EnqueueContext(ctx, task)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+
+	distributor.logger.Infof("type=%s, queue=%s, maxRetry=%d enqueued task", info.Type, info.Queue, info.MaxRetry)
+
+	return nil
+}
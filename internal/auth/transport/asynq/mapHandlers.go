@@ -0,0 +1,8 @@
+package asynq
+
+import asynqPkg "github.com/scul0405/blog-clean-architecture-rest-api/pkg/asynq"
+
+func MapHandlers(tp *asynqPkg.RedisTaskProcessor, ap AuthProcessor) {
+	tp.RegisterHandler(TypeSendEmailTask, ap.ProcessTaskSendEmail)
+	tp.RegisterHandler(TypeProcessAvatarTask, ap.ProcessTaskProcessAvatar)
+}
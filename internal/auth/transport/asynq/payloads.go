@@ -0,0 +1,23 @@
+package asynq
+
+import (
+	"github.com/google/uuid"
+)
+
+const (
+	TypeSendEmailTask     = "auth:send_email"
+	TypeProcessAvatarTask = "auth:process_avatar"
+)
+
+type SendEmailPayload struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+type ProcessAvatarPayload struct {
+	UserUID    uuid.UUID
+	ImageID    string
+	BucketName string
+	ObjectKey  string
+}
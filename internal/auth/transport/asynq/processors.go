@@ -0,0 +1,71 @@
+package asynq
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/hibiken/asynq"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/auth"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/logger"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/mailer"
+)
+
+type AuthProcessor interface {
+	ProcessTaskSendEmail(ctx context.Context, t *asynq.Task) error
+	ProcessTaskProcessAvatar(ctx context.Context, t *asynq.Task) error
+}
+
+type authProcessor struct {
+	mailer mailer.Mailer
+	authUC auth.UseCase
+	logger logger.Logger
+}
+
+func NewAuthProcessor(mailer mailer.Mailer, authUC auth.UseCase, logger logger.Logger) AuthProcessor {
+	return &authProcessor{
+		mailer: mailer,
+		authUC: authUC,
+		logger: logger,
+	}
+}
+
+func (p *authProcessor) ProcessTaskSendEmail(ctx context.Context, t *asynq.Task) error {
+	var payload SendEmailPayload
+	if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+		return fmt.Errorf("json.Unmarshal failed: %v: %w", err, asynq.SkipRetry)
+	}
+
+	err := p.mailer.Send(ctx, &mailer.Mail{
+		To:      payload.To,
+		Subject: payload.Subject,
+		Body:    payload.Body,
+	})
+	if err != nil {
+		return err
+	}
+
+	p.logger.Infof("type=%s, to=%s sent email", t.Type(), payload.To)
+
+	return nil
+}
+
+func (p *authProcessor) ProcessTaskProcessAvatar(ctx context.Context, t *asynq.Task) error {
+	var payload ProcessAvatarPayload
+	if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+		return fmt.Errorf("json.Unmarshal failed: %v: %w", err, asynq.SkipRetry)
+	}
+
+	err := p.authUC.ProcessAvatar(ctx, payload.UserUID, &models.ImageInput{
+		ImageID:    payload.ImageID,
+		BucketName: payload.BucketName,
+		ObjectKey:  payload.ObjectKey,
+	})
+	if err != nil {
+		return err
+	}
+
+	p.logger.Infof("type=%s, user_id=%s processed avatar", t.Type(), payload.UserUID)
+
+	return nil
+}
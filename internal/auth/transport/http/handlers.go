@@ -1,7 +1,7 @@
 package http
 
 import (
-	"bytes"
+	"errors"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"github.com/opentracing/opentracing-go"
@@ -11,8 +11,8 @@ import (
 	httpErrors "github.com/scul0405/blog-clean-architecture-rest-api/pkg/http_errors"
 	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/logger"
 	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/utils"
-	"io"
 	"net/http"
+	"strconv"
 )
 
 type authHandlers struct {
@@ -59,7 +59,7 @@ func (h *authHandlers) Register() echo.HandlerFunc {
 
 // GetByID godoc
 // @Summary Get user
-// @Description Get user by user's id, return user
+// @Description Get user by user's id, return user with follow counts
 // @Tags Auth
 // @Accept json
 // @Param id path string true "id"
@@ -79,7 +79,7 @@ func (h *authHandlers) GetByID() echo.HandlerFunc {
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
-		user, err := h.authUC.GetByID(ctx, userID)
+		user, err := h.authUC.GetProfile(ctx, userID)
 		if err != nil {
 			utils.LogResponseError(c, h.logger, err)
 			return c.JSON(httpErrors.ErrorResponse(err))
@@ -98,6 +98,8 @@ func (h *authHandlers) GetByID() echo.HandlerFunc {
 // @Param request body models.User true "input data"
 // @Success 200 {object} models.User
 // @Failure 400 {object} httpErrors.RestError
+// @Failure 401 {object} httpErrors.RestError
+// @Failure 429 {object} httpErrors.TooManyRequestsError
 // @Failure 500 {object} httpErrors.RestError
 // @Router /auth/login [post]
 func (h *authHandlers) Login() echo.HandlerFunc {
@@ -111,8 +113,12 @@ func (h *authHandlers) Login() echo.HandlerFunc {
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
-		userWithToken, err := h.authUC.Login(ctx, user)
+		userWithToken, err := h.authUC.Login(ctx, user, c.RealIP())
 		if err != nil {
+			var tooManyRequestsErr httpErrors.TooManyRequestsError
+			if errors.As(err, &tooManyRequestsErr) {
+				c.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(tooManyRequestsErr.RetryAfter))
+			}
 			utils.LogResponseError(c, h.logger, err)
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
@@ -121,17 +127,56 @@ func (h *authHandlers) Login() echo.HandlerFunc {
 	}
 }
 
+// UnlockLogin godoc
+// @Summary Unlock login
+// @Description clear failed login attempts and lockout of email or client ip, admin only
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body models.UnlockLogin true "input data"
+// @Success 200 {string} string "success"
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 401 {object} httpErrors.RestError
+// @Failure 403 {object} httpErrors.RestError
+// @Failure 500 {object} httpErrors.RestError
+// @Router /auth/unlock-login [post]
+func (h *authHandlers) UnlockLogin() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "authHandlers.UnlockLogin")
+		defer span.Finish()
+
+		unlockReq := &models.UnlockLogin{}
+		if err := utils.ReadRequest(c, unlockReq); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		if err := h.authUC.UnlockLogin(ctx, unlockReq); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.NoContent(http.StatusOK)
+	}
+}
+
 // UploadAvatar godoc
 // @Summary Upload avatar user
-// @Description upload avatar user, returns user
+// @Description upload avatar user, only owner or admin can upload. Image is processed in background into variants,
+// @Description previous avatar is removed once they are stored, returns url of every variant
 // @Tags Auth
 // @Accept json
 // @Produce json
+// @Security Bearer
 // @Param file formData file  true "avatar"
 // @Param id path string true "user id"
 // @Param bucket query string true "minio bucket"
-// @Success 200 {object} models.User
+// @Success 202 {object} models.ImageVariants
 // @Failure 400 {object} httpErrors.RestError
+// @Failure 401 {object} httpErrors.RestError
+// @Failure 403 {object} httpErrors.RestError
+// @Failure 413 {object} httpErrors.RestError
 // @Failure 500 {object} httpErrors.RestError
 // @Router /auth/{id}/avatar [post]
 func (h *authHandlers) UploadAvatar() echo.HandlerFunc {
@@ -152,34 +197,258 @@ func (h *authHandlers) UploadAvatar() echo.HandlerFunc {
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
-		file, err := image.Open()
+		binaryImage, err := utils.ReadImageFile(image, utils.MaxImageSize)
 		if err != nil {
 			utils.LogResponseError(c, h.logger, err)
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
-		defer file.Close()
 
-		binaryImage := bytes.NewBuffer(nil)
-		if _, err = io.Copy(binaryImage, file); err != nil {
+		variants, err := h.authUC.UploadAvatar(ctx, uID, &models.ImageInput{
+			BucketName: bucket,
+			Image:      binaryImage,
+		})
+		if err != nil {
 			utils.LogResponseError(c, h.logger, err)
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
-		contentType, err := utils.CheckImageFileContentType(binaryImage.Bytes())
+		return c.JSON(http.StatusAccepted, variants)
+	}
+}
+
+// GetMe godoc
+// @Summary Get current user
+// @Description get profile of authenticated user, returns user
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} models.User
+// @Failure 401 {object} httpErrors.RestError
+// @Failure 500 {object} httpErrors.RestError
+// @Router /auth/me [get]
+func (h *authHandlers) GetMe() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "authHandlers.GetMe")
+		defer span.Finish()
+
+		user, err := h.authUC.GetMe(ctx)
 		if err != nil {
 			utils.LogResponseError(c, h.logger, err)
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
-		reader := bytes.NewReader(binaryImage.Bytes())
+		return c.JSON(http.StatusOK, user)
+	}
+}
 
-		updatedUser, err := h.authUC.UploadAvatar(ctx, uID, models.UploadInput{
-			File:        reader,
-			Name:        image.Filename,
-			Size:        image.Size,
-			ContentType: contentType,
-			BucketName:  bucket,
-		})
+// UpdateMe godoc
+// @Summary Update current user
+// @Description update profile of authenticated user, returns user
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body models.UpdateUser true "input data"
+// @Success 200 {object} models.User
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 401 {object} httpErrors.RestError
+// @Failure 500 {object} httpErrors.RestError
+// @Router /auth/me [patch]
+func (h *authHandlers) UpdateMe() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "authHandlers.UpdateMe")
+		defer span.Finish()
+
+		userReq := &models.UpdateUser{}
+		if err := utils.ReadRequest(c, userReq); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		updatedUser, err := h.authUC.UpdateMe(ctx, userReq)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, updatedUser)
+	}
+}
+
+// ChangePassword godoc
+// @Summary Change password
+// @Description change password of authenticated user, other sessions are revoked
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body models.ChangePassword true "input data"
+// @Success 200 {string} string "success"
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 401 {object} httpErrors.RestError
+// @Failure 500 {object} httpErrors.RestError
+// @Router /auth/me/password [post]
+func (h *authHandlers) ChangePassword() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "authHandlers.ChangePassword")
+		defer span.Finish()
+
+		passwordReq := &models.ChangePassword{}
+		if err := utils.ReadRequest(c, passwordReq); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		if err := h.authUC.ChangePassword(ctx, passwordReq); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.NoContent(http.StatusOK)
+	}
+}
+
+// DeleteMe godoc
+// @Summary Delete current user
+// @Description delete account of authenticated user, personal data is removed and authored content is anonymised
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Success 200 {string} string "success"
+// @Failure 401 {object} httpErrors.RestError
+// @Failure 500 {object} httpErrors.RestError
+// @Router /auth/me [delete]
+func (h *authHandlers) DeleteMe() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "authHandlers.DeleteMe")
+		defer span.Finish()
+
+		if err := h.authUC.DeleteMe(ctx); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.NoContent(http.StatusOK)
+	}
+}
+
+// RefreshToken godoc
+// @Summary Refresh access token
+// @Description exchange refresh token for a new access and refresh token, the used refresh token is revoked
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body models.RefreshTokenRequest true "input data"
+// @Success 200 {object} models.UserWithToken
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 401 {object} httpErrors.RestError
+// @Failure 500 {object} httpErrors.RestError
+// @Router /auth/refresh [post]
+func (h *authHandlers) RefreshToken() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "authHandlers.RefreshToken")
+		defer span.Finish()
+
+		refreshReq := &models.RefreshTokenRequest{}
+		if err := utils.ReadRequest(c, refreshReq); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		userWithToken, err := h.authUC.RefreshToken(ctx, refreshReq.RefreshToken)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, userWithToken)
+	}
+}
+
+// Logout godoc
+// @Summary Logout user
+// @Description revoke current session and its access token
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Success 200 {string} string "success"
+// @Failure 401 {object} httpErrors.RestError
+// @Failure 500 {object} httpErrors.RestError
+// @Router /auth/logout [post]
+func (h *authHandlers) Logout() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "authHandlers.Logout")
+		defer span.Finish()
+
+		if err := h.authUC.Logout(ctx); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.NoContent(http.StatusOK)
+	}
+}
+
+// LogoutAll godoc
+// @Summary Logout user everywhere
+// @Description revoke all sessions of user
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Success 200 {string} string "success"
+// @Failure 401 {object} httpErrors.RestError
+// @Failure 500 {object} httpErrors.RestError
+// @Router /auth/logout-all [post]
+func (h *authHandlers) LogoutAll() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "authHandlers.LogoutAll")
+		defer span.Finish()
+
+		if err := h.authUC.LogoutAll(ctx); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.NoContent(http.StatusOK)
+	}
+}
+
+// UpdateRole godoc
+// @Summary Update user role
+// @Description change role of user, user's sessions are revoked, returns user
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "user id"
+// @Param request body models.UpdateRole true "input data"
+// @Success 200 {object} models.User
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 403 {object} httpErrors.RestError
+// @Failure 500 {object} httpErrors.RestError
+// @Router /auth/{id}/role [patch]
+func (h *authHandlers) UpdateRole() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "authHandlers.UpdateRole")
+		defer span.Finish()
+
+		userID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		roleReq := &models.UpdateRole{}
+		if err = utils.ReadRequest(c, roleReq); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		updatedUser, err := h.authUC.UpdateRole(ctx, userID, roleReq.Role)
 		if err != nil {
 			utils.LogResponseError(c, h.logger, err)
 			return c.JSON(httpErrors.ErrorResponse(err))
@@ -188,3 +457,96 @@ func (h *authHandlers) UploadAvatar() echo.HandlerFunc {
 		return c.JSON(http.StatusOK, updatedUser)
 	}
 }
+
+// VerifyEmail godoc
+// @Summary Verify email
+// @Description verify user's email with token sent by email
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body models.VerifyEmail true "input data"
+// @Success 200 {string} string "success"
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 500 {object} httpErrors.RestError
+// @Router /auth/verify-email [post]
+func (h *authHandlers) VerifyEmail() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "authHandlers.VerifyEmail")
+		defer span.Finish()
+
+		verifyReq := &models.VerifyEmail{}
+		if err := utils.ReadRequest(c, verifyReq); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		if err := h.authUC.VerifyEmail(ctx, verifyReq.Token); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.NoContent(http.StatusOK)
+	}
+}
+
+// ForgotPassword godoc
+// @Summary Forgot password
+// @Description send reset password email, responds success whether email is registered or not
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body models.ForgotPassword true "input data"
+// @Success 200 {string} string "success"
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 500 {object} httpErrors.RestError
+// @Router /auth/forgot-password [post]
+func (h *authHandlers) ForgotPassword() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "authHandlers.ForgotPassword")
+		defer span.Finish()
+
+		forgotReq := &models.ForgotPassword{}
+		if err := utils.ReadRequest(c, forgotReq); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		if err := h.authUC.ForgotPassword(ctx, forgotReq.Email); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.NoContent(http.StatusOK)
+	}
+}
+
+// ResetPassword godoc
+// @Summary Reset password
+// @Description set new password with token sent by email, all sessions of user are revoked
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body models.ResetPassword true "input data"
+// @Success 200 {string} string "success"
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 500 {object} httpErrors.RestError
+// @Router /auth/reset-password [post]
+func (h *authHandlers) ResetPassword() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "authHandlers.ResetPassword")
+		defer span.Finish()
+
+		resetReq := &models.ResetPassword{}
+		if err := utils.ReadRequest(c, resetReq); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		if err := h.authUC.ResetPassword(ctx, resetReq.Token, resetReq.Password); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.NoContent(http.StatusOK)
+	}
+}
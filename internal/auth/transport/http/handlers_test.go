@@ -109,7 +109,7 @@ func TestAuthHandlers_GetByID(t *testing.T) {
 	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "auth.GetByID")
 	defer span.Finish()
 
-	mockAuthUC.EXPECT().GetByID(ctxWithTrace, gomock.Eq(user.UserID)).Return(user, nil)
+	mockAuthUC.EXPECT().GetProfile(ctxWithTrace, gomock.Eq(user.UserID)).Return(user, nil)
 
 	handlerFunc := authHandlers.GetByID()
 	err := handlerFunc(c)
@@ -161,7 +161,7 @@ func TestAuthHandlers_Login(t *testing.T) {
 		},
 	}
 
-	mockAuthUC.EXPECT().Login(ctxWithTrace, gomock.Eq(user)).Return(userWithToken, nil)
+	mockAuthUC.EXPECT().Login(ctxWithTrace, gomock.Eq(user), gomock.Any()).Return(userWithToken, nil)
 
 	handlerFunc := authHandlers.Login()
 	err = handlerFunc(c)
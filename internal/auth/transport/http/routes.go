@@ -4,11 +4,24 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/scul0405/blog-clean-architecture-rest-api/internal/auth"
 	"github.com/scul0405/blog-clean-architecture-rest-api/internal/middleware"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/rbac"
 )
 
 func MapAuthRoutes(authGroup *echo.Group, h auth.Handlers, mw *middleware.MiddlewareManager) {
 	authGroup.POST("/register", h.Register())
+	authGroup.GET("/me", h.GetMe(), mw.AuthPASETOMiddleware)
+	authGroup.PATCH("/me", h.UpdateMe(), mw.AuthPASETOMiddleware)
+	authGroup.POST("/me/password", h.ChangePassword(), mw.AuthPASETOMiddleware)
+	authGroup.DELETE("/me", h.DeleteMe(), mw.AuthPASETOMiddleware)
 	authGroup.GET("/:id", h.GetByID())
 	authGroup.POST("/login", h.Login())
-	authGroup.POST("/:id/avatar", h.UploadAvatar())
+	authGroup.POST("/unlock-login", h.UnlockLogin(), mw.AuthPASETOMiddleware, mw.RequirePermission(rbac.UserUnlockLogin))
+	authGroup.POST("/refresh", h.RefreshToken())
+	authGroup.POST("/logout", h.Logout(), mw.AuthPASETOMiddleware)
+	authGroup.POST("/logout-all", h.LogoutAll(), mw.AuthPASETOMiddleware)
+	authGroup.POST("/verify-email", h.VerifyEmail())
+	authGroup.POST("/forgot-password", h.ForgotPassword())
+	authGroup.POST("/reset-password", h.ResetPassword())
+	authGroup.PATCH("/:id/role", h.UpdateRole(), mw.AuthPASETOMiddleware, mw.RequirePermission(rbac.UserManageRoles))
+	authGroup.POST("/:id/avatar", h.UploadAvatar(), mw.AuthPASETOMiddleware)
 }
@@ -5,11 +5,27 @@ import (
 	"context"
 	"github.com/google/uuid"
 	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/paseto"
 )
 
 type UseCase interface {
 	Register(ctx context.Context, user *models.User) (*models.UserWithToken, error)
 	GetByID(ctx context.Context, userID uuid.UUID) (*models.User, error)
-	Login(ctx context.Context, user *models.LoginUser) (*models.UserWithToken, error)
-	UploadAvatar(ctx context.Context, userID uuid.UUID, file models.UploadInput) (*models.User, error)
+	GetProfile(ctx context.Context, userID uuid.UUID) (*models.User, error)
+	Login(ctx context.Context, user *models.LoginUser, ipAddress string) (*models.UserWithToken, error)
+	UnlockLogin(ctx context.Context, unlock *models.UnlockLogin) error
+	UploadAvatar(ctx context.Context, userID uuid.UUID, image *models.ImageInput) (models.ImageVariants, error)
+	ProcessAvatar(ctx context.Context, userID uuid.UUID, image *models.ImageInput) error
+	GetMe(ctx context.Context) (*models.User, error)
+	UpdateMe(ctx context.Context, user *models.UpdateUser) (*models.User, error)
+	ChangePassword(ctx context.Context, changePassword *models.ChangePassword) error
+	DeleteMe(ctx context.Context) error
+	RefreshToken(ctx context.Context, refreshToken string) (*models.UserWithToken, error)
+	Logout(ctx context.Context) error
+	LogoutAll(ctx context.Context) error
+	UpdateRole(ctx context.Context, userID uuid.UUID, role string) (*models.User, error)
+	VerifyEmail(ctx context.Context, token string) error
+	ForgotPassword(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token string, password string) error
+	ValidateAccessToken(ctx context.Context, payload *paseto.Payload) error
 }
@@ -1,30 +1,66 @@
 package usecase
 
 import (
+	"bytes"
 	"context"
+	"database/sql"
 	"fmt"
 	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/minio/minio-go/v7"
 	"github.com/opentracing/opentracing-go"
 	"github.com/pkg/errors"
 	"github.com/scul0405/blog-clean-architecture-rest-api/config"
 	"github.com/scul0405/blog-clean-architecture-rest-api/internal/auth"
+	authAsynq "github.com/scul0405/blog-clean-architecture-rest-api/internal/auth/transport/asynq"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/follow"
 	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	asynqPkg "github.com/scul0405/blog-clean-architecture-rest-api/pkg/asynq"
 	httpErrors "github.com/scul0405/blog-clean-architecture-rest-api/pkg/http_errors"
 	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/logger"
 	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/paseto"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/rbac"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/utils"
+	"net/http"
+	"strings"
+	"time"
 )
 
 const (
-	basePrefix    = "api-auth"
-	cacheDuration = 3600
+	basePrefix            = "api-auth"
+	sessionPrefix         = "api-auth-session"
+	userSessionsPrefix    = "api-auth-user-sessions"
+	revokedTokenPrefix    = "api-auth-revoked-token"
+	revokedSessionPrefix  = "api-auth-revoked-session"
+	verifyEmailPrefix     = "api-auth-verify-email"
+	resetPasswordPrefix   = "api-auth-reset-password"
+	loginFailuresPrefix   = "api-auth-login-failures"
+	loginLockPrefix       = "api-auth-login-lock"
+	cacheDuration         = 3600
+	verifyEmailDuration   = 24 * 3600
+	resetPasswordDuration = 3600
+	sendEmailMaxRetry     = 10
+	processImageMaxRetry  = 3
+	avatarKeyPrefix       = "avatars"
+
+	loginEmailScope = "email"
+	loginIPScope    = "ip"
+
+	defaultLoginMaxAttempts        = 5
+	defaultLoginMaxIPAttempts      = 20
+	defaultLoginAttemptWindow      = 3600
+	defaultLoginLockoutDuration    = 60
+	defaultLoginMaxLockoutDuration = 3600
 )
 
 type authUseCase struct {
-	cfg       *config.Config
-	authRepo  auth.Repository
-	redisRepo auth.RedisRepository
-	minioRepo auth.MinioRepository
-	logger    logger.Logger
+	cfg        *config.Config
+	authRepo   auth.Repository
+	redisRepo  auth.RedisRepository
+	minioRepo  auth.MinioRepository
+	followRepo follow.Repository
+	authTD     authAsynq.AuthTaskDistributor
+	logger     logger.Logger
 }
 
 func NewAuthUseCase(
@@ -32,14 +68,19 @@ func NewAuthUseCase(
 	authRepo auth.Repository,
 	redisRepo auth.RedisRepository,
 	minioRepo auth.MinioRepository,
+	followRepo follow.Repository,
+	authTD authAsynq.AuthTaskDistributor,
 	logger logger.Logger) auth.UseCase {
-	return &authUseCase{cfg: cfg, authRepo: authRepo, redisRepo: redisRepo, minioRepo: minioRepo, logger: logger}
+	return &authUseCase{cfg: cfg, authRepo: authRepo, redisRepo: redisRepo, minioRepo: minioRepo, followRepo: followRepo, authTD: authTD, logger: logger}
 }
 
 func (u *authUseCase) Register(ctx context.Context, user *models.User) (*models.UserWithToken, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "authUC.Register")
 	defer span.Finish()
 
+	// Roles are granted by admins only, self registered users always get the default role
+	user.Role = nil
+
 	if err := user.PrepareCreate(); err != nil {
 		return nil, httpErrors.NewBadRequestError(errors.Wrap(err, "authUC.Register.PrepareCreate"))
 	}
@@ -49,16 +90,21 @@ func (u *authUseCase) Register(ctx context.Context, user *models.User) (*models.
 		return nil, err
 	}
 
-	token, err := paseto.GeneratePASETOToken(createdUser, u.cfg)
+	if err = u.sendVerificationEmail(ctx, createdUser); err != nil {
+		u.logger.Errorf("authUC.Register.sendVerificationEmail: %v", err)
+	}
+
+	if u.cfg.Server.RequireVerifiedEmail {
+		createdUser.SanitizePassword()
+		return &models.UserWithToken{User: createdUser}, nil
+	}
+
+	userWithToken, err := u.createSession(ctx, createdUser)
 	if err != nil {
-		return nil, httpErrors.NewInternalServerError(errors.Wrap(err, "authUC.Register.GeneratePASETOToken"))
+		return nil, err
 	}
 
-	createdUser.SanitizePassword()
-	return &models.UserWithToken{
-		User:        createdUser,
-		AccessToken: token,
-	}, nil
+	return userWithToken, nil
 }
 
 func (u *authUseCase) GetByID(ctx context.Context, userID uuid.UUID) (*models.User, error) {
@@ -86,59 +132,851 @@ func (u *authUseCase) GetByID(ctx context.Context, userID uuid.UUID) (*models.Us
 	return user, nil
 }
 
-func (u *authUseCase) Login(ctx context.Context, loginReq *models.LoginUser) (*models.UserWithToken, error) {
+// GetProfile get user with follow counts, counts are not cached and profile is returned without them when they fail
+func (u *authUseCase) GetProfile(ctx context.Context, userID uuid.UUID) (*models.User, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authUC.GetProfile")
+	defer span.Finish()
+
+	user, err := u.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := u.followRepo.GetCounts(ctx, user.UserID)
+	if err != nil {
+		u.logger.Errorf("authUC.GetProfile.GetCounts: %v", err)
+		return user, nil
+	}
+	user.FollowCounts = counts
+
+	return user, nil
+}
+
+func (u *authUseCase) Login(ctx context.Context, loginReq *models.LoginUser, ipAddress string) (*models.UserWithToken, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "authUC.Login")
 	defer span.Finish()
 
-	user, err := u.authRepo.FindByEmail(ctx, loginReq.Email)
+	email := strings.ToLower(strings.TrimSpace(loginReq.Email))
+
+	if err := u.checkLoginLock(ctx, email, ipAddress); err != nil {
+		return nil, err
+	}
+
+	user, err := u.authRepo.FindByEmail(ctx, email)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			u.recordLoginFailure(ctx, nil, email, ipAddress, "unknown_email")
+		}
 		return nil, err
 	}
 
 	if err = user.ComparePassword(loginReq.Password); err != nil {
+		u.recordLoginFailure(ctx, &user.UserID, email, ipAddress, "wrong_password")
 		return nil, httpErrors.NewUnauthorizedError(errors.Wrap(err, "authUC.Login.ComparePasswords"))
 	}
 
-	token, err := paseto.GeneratePASETOToken(user, u.cfg)
+	if u.cfg.Server.RequireVerifiedEmail && !user.EmailVerified {
+		// Password was right, so it does not count as failure
+		u.recordLoginEvent(ctx, &user.UserID, email, ipAddress, false, "email_not_verified")
+		return nil, httpErrors.NewRestError(http.StatusForbidden, "Email is not verified", errors.New("authUC.Login.EmailVerified"))
+	}
+
+	userWithToken, err := u.createSession(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = u.redisRepo.ResetLoginAttemptsCtx(ctx,
+		u.generateLoginFailuresKey(loginEmailScope, email), u.generateLoginLockKey(loginEmailScope, email)); err != nil {
+		u.logger.Errorf("authUC.Login.ResetLoginAttemptsCtx: %v", err)
+	}
+
+	if err = u.authRepo.UpdateLoginDate(ctx, user.UserID); err != nil {
+		u.logger.Errorf("authUC.Login.UpdateLoginDate: %v", err)
+	}
+
+	u.recordLoginEvent(ctx, &user.UserID, email, ipAddress, true, "")
+
+	return userWithToken, nil
+}
+
+func (u *authUseCase) UnlockLogin(ctx context.Context, unlock *models.UnlockLogin) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authUC.UnlockLogin")
+	defer span.Finish()
+
+	keys := make([]string, 0, 4)
+	if unlock.Email != "" {
+		email := strings.ToLower(strings.TrimSpace(unlock.Email))
+		keys = append(keys, u.generateLoginFailuresKey(loginEmailScope, email), u.generateLoginLockKey(loginEmailScope, email))
+	}
+	if unlock.IPAddress != "" {
+		keys = append(keys, u.generateLoginFailuresKey(loginIPScope, unlock.IPAddress), u.generateLoginLockKey(loginIPScope, unlock.IPAddress))
+	}
+	if len(keys) == 0 {
+		return httpErrors.NewBadRequestError(errors.New("authUC.UnlockLogin: email or ip address is required"))
+	}
+
+	if err := u.redisRepo.ResetLoginAttemptsCtx(ctx, keys...); err != nil {
+		return httpErrors.NewInternalServerError(errors.Wrap(err, "authUC.UnlockLogin.ResetLoginAttemptsCtx"))
+	}
+
+	return nil
+}
+
+func (u *authUseCase) UploadAvatar(ctx context.Context, userID uuid.UUID, image *models.ImageInput) (models.ImageVariants, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authUC.UploadAvatar")
+	defer span.Finish()
+
+	if err := utils.ValidateIsOwnerOrHasPermission(ctx, userID.String(), rbac.UserUpdateAny, u.logger); err != nil {
+		return nil, err
+	}
+
+	if _, err := u.authRepo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	image.ImageID = uuid.New().String()
+	prefix := u.generateAvatarKeyPrefix(userID, image.ImageID)
+	contentType := http.DetectContentType(image.Image)
+
+	// Task only carries key of stored upload, image bytes are kept out of redis
+	image.ObjectKey = utils.ImageUploadKey(prefix)
+	if _, err := u.minioRepo.PutObject(ctx, models.UploadInput{
+		File:        bytes.NewReader(image.Image),
+		Name:        image.ObjectKey,
+		Size:        int64(len(image.Image)),
+		ContentType: contentType,
+		BucketName:  image.BucketName,
+	}); err != nil {
+		return nil, httpErrors.NewInternalServerError(errors.Wrap(err, "authUC.UploadAvatar.PutObject"))
+	}
+
+	opts := []asynq.Option{
+		asynq.MaxRetry(processImageMaxRetry),
+		asynq.Queue(asynqPkg.QueueDefault),
+	}
+	err := u.authTD.DistributeTaskProcessAvatar(ctx, &authAsynq.ProcessAvatarPayload{
+		UserUID:    userID,
+		ImageID:    image.ImageID,
+		BucketName: image.BucketName,
+		ObjectKey:  image.ObjectKey,
+	}, opts...)
+	if err != nil {
+		u.removeImageUpload(ctx, image.BucketName, image.ObjectKey)
+		return nil, httpErrors.NewInternalServerError(errors.Wrap(err, "authUC.UploadAvatar.DistributeTaskProcessAvatar"))
+	}
+
+	// Keys are deterministic so urls are known before variants are stored
+	variants := make(models.ImageVariants, len(utils.ImageVariants))
+	for _, variant := range utils.ImageVariants {
+		key := utils.ImageVariantKey(prefix, variant.Name, contentType)
+		variants[variant.Name] = u.generateMinioURL(image.BucketName, key)
+	}
+
+	return variants, nil
+}
+
+func (u *authUseCase) ProcessAvatar(ctx context.Context, userID uuid.UUID, image *models.ImageInput) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authUC.ProcessAvatar")
+	defer span.Finish()
+
+	user, err := u.authRepo.GetByID(ctx, userID)
+	if err != nil {
+		return errors.Wrap(err, "authUC.ProcessAvatar.GetByID")
+	}
+
+	data, err := u.minioRepo.ReadObject(ctx, image.BucketName, image.ObjectKey)
+	if err != nil {
+		if minio.ToErrorResponse(errors.Cause(err)).Code == "NoSuchKey" {
+			return fmt.Errorf("authUC.ProcessAvatar.ReadObject: %v: %w", err, asynq.SkipRetry)
+		}
+		return errors.Wrap(err, "authUC.ProcessAvatar.ReadObject")
+	}
+
+	variants, contentType, err := utils.ProcessImage(data)
+	if err != nil {
+		u.removeImageUpload(ctx, image.BucketName, image.ObjectKey)
+		return fmt.Errorf("authUC.ProcessAvatar.ProcessImage: %v: %w", err, asynq.SkipRetry)
+	}
+
+	prefix := u.generateAvatarKeyPrefix(userID, image.ImageID)
+	for name, data := range variants {
+		_, err = u.minioRepo.PutObject(ctx, models.UploadInput{
+			File:        bytes.NewReader(data),
+			Name:        utils.ImageVariantKey(prefix, name, contentType),
+			Size:        int64(len(data)),
+			ContentType: contentType,
+			BucketName:  image.BucketName,
+		})
+		if err != nil {
+			return errors.Wrap(err, "authUC.ProcessAvatar.PutObject")
+		}
+	}
+
+	originalKey := utils.ImageVariantKey(prefix, utils.ImageVariantOriginal, contentType)
+	avatarURL := u.generateMinioURL(image.BucketName, originalKey)
+
+	if _, err = u.authRepo.Update(ctx, &models.User{
+		UserID: userID,
+		Avatar: &avatarURL,
+	}); err != nil {
+		// Do not leave objects nobody points to
+		u.removeImageVariants(ctx, image.BucketName, originalKey)
+		return errors.Wrap(err, "authUC.ProcessAvatar.Update")
+	}
+
+	// Old avatar is removed only after user points to the new one, retried task finds itself as old avatar
+	if user.Avatar != nil && *user.Avatar != avatarURL {
+		if bucket, key, ok := u.parseMinioURL(*user.Avatar); ok {
+			u.removeImageVariants(ctx, bucket, key)
+		}
+	}
+
+	// Upload is kept until now so failed task can be retried
+	u.removeImageUpload(ctx, image.BucketName, image.ObjectKey)
+
+	if err = u.redisRepo.DeleteUserCtx(ctx, u.generateUserKey(userID.String())); err != nil {
+		u.logger.Errorf("authUC.ProcessAvatar.DeleteUserCtx: %v", err)
+	}
+
+	return nil
+}
+
+func (u *authUseCase) GetMe(ctx context.Context) (*models.User, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authUC.GetMe")
+	defer span.Finish()
+
+	userUID, err := utils.GetUserUIDFromCtx(ctx)
+	if err != nil {
+		return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "authUC.GetMe.GetUserUIDFromCtx"))
+	}
+
+	return u.GetByID(ctx, userUID)
+}
+
+func (u *authUseCase) UpdateMe(ctx context.Context, user *models.UpdateUser) (*models.User, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authUC.UpdateMe")
+	defer span.Finish()
+
+	userUID, err := utils.GetUserUIDFromCtx(ctx)
+	if err != nil {
+		return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "authUC.UpdateMe.GetUserUIDFromCtx"))
+	}
+
+	updatedUser, err := u.authRepo.Update(ctx, &models.User{
+		UserID:      userUID,
+		FirstName:   strings.TrimSpace(user.FirstName),
+		LastName:    strings.TrimSpace(user.LastName),
+		About:       user.About,
+		PhoneNumber: user.PhoneNumber,
+		Address:     user.Address,
+		City:        user.City,
+		Country:     user.Country,
+		Gender:      user.Gender,
+		Postcode:    user.Postcode,
+		Birthday:    user.Birthday,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = u.redisRepo.DeleteUserCtx(ctx, u.generateUserKey(userUID.String())); err != nil {
+		u.logger.Errorf("authUC.UpdateMe.DeleteUserCtx: %v", err)
+	}
+
+	updatedUser.SanitizePassword()
+
+	return updatedUser, nil
+}
+
+func (u *authUseCase) ChangePassword(ctx context.Context, changePassword *models.ChangePassword) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authUC.ChangePassword")
+	defer span.Finish()
+
+	userUID, err := utils.GetUserUIDFromCtx(ctx)
+	if err != nil {
+		return httpErrors.NewUnauthorizedError(errors.WithMessage(err, "authUC.ChangePassword.GetUserUIDFromCtx"))
+	}
+
+	password, err := u.authRepo.GetPassword(ctx, userUID)
+	if err != nil {
+		return err
+	}
+
+	user := &models.User{UserID: userUID, Password: password}
+	if err = user.ComparePassword(changePassword.CurrentPassword); err != nil {
+		return httpErrors.NewRestError(http.StatusBadRequest, "Current password is incorrect", errors.Wrap(err, "authUC.ChangePassword.ComparePassword"))
+	}
+
+	user.Password = strings.TrimSpace(changePassword.NewPassword)
+	if err = user.HashPassword(); err != nil {
+		return httpErrors.NewInternalServerError(errors.Wrap(err, "authUC.ChangePassword.HashPassword"))
+	}
+
+	if err = u.authRepo.UpdatePassword(ctx, userUID, user.Password); err != nil {
+		return err
+	}
+
+	if err = u.redisRepo.DeleteUserCtx(ctx, u.generateUserKey(userUID.String())); err != nil {
+		u.logger.Errorf("authUC.ChangePassword.DeleteUserCtx: %v", err)
+	}
+
+	// Keep the session password was changed from, sign out the others
+	sessionID, _ := utils.GetSessionIDFromCtx(ctx)
+	return u.revokeUserSessions(ctx, userUID.String(), sessionID)
+}
+
+func (u *authUseCase) DeleteMe(ctx context.Context) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authUC.DeleteMe")
+	defer span.Finish()
+
+	userUID, err := utils.GetUserUIDFromCtx(ctx)
 	if err != nil {
-		return nil, httpErrors.NewInternalServerError(errors.Wrap(err, "authUC.Login.GeneratePASETOToken"))
+		return httpErrors.NewUnauthorizedError(errors.WithMessage(err, "authUC.DeleteMe.GetUserUIDFromCtx"))
+	}
+
+	if err = u.authRepo.Delete(ctx, userUID); err != nil {
+		return err
+	}
+
+	if err = u.redisRepo.DeleteUserCtx(ctx, u.generateUserKey(userUID.String())); err != nil {
+		u.logger.Errorf("authUC.DeleteMe.DeleteUserCtx: %v", err)
+	}
+
+	return u.revokeUserSessions(ctx, userUID.String(), "")
+}
+
+func (u *authUseCase) RefreshToken(ctx context.Context, refreshToken string) (*models.UserWithToken, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authUC.RefreshToken")
+	defer span.Finish()
+
+	payload, err := paseto.VerifyPASETOToken(refreshToken, u.cfg)
+	if err != nil {
+		return nil, httpErrors.NewUnauthorizedError(errors.Wrap(err, "authUC.RefreshToken.VerifyPASETOToken"))
+	}
+	if payload.Type != paseto.RefreshToken {
+		return nil, httpErrors.NewUnauthorizedError(errors.Wrap(paseto.ErrInvalidToken, "authUC.RefreshToken.Type"))
+	}
+
+	session, err := u.redisRepo.GetSessionCtx(ctx, u.generateSessionKey(payload.SessionID))
+	if err != nil {
+		return nil, httpErrors.NewUnauthorizedError(errors.Wrap(err, "authUC.RefreshToken.GetSessionCtx"))
+	}
+
+	// Only the latest refresh token of a session can be used, an older one means it was stolen
+	if session.RefreshTokenID != payload.TokenID {
+		return nil, u.rejectRefreshTokenReuse(ctx, payload)
+	}
+
+	user, err := u.authRepo.GetByID(ctx, session.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	accessToken, newRefreshToken, err := u.issueTokens(user, session)
+	if err != nil {
+		return nil, err
+	}
+
+	// Session is rotated only if token was not used by concurrent refresh meanwhile, the loser is treated as reuse
+	rotated, err := u.redisRepo.RotateSessionCtx(ctx, u.generateSessionKey(payload.SessionID), paseto.RefreshTokenExpire(u.cfg), payload.TokenID, session)
+	if err != nil {
+		return nil, httpErrors.NewInternalServerError(errors.Wrap(err, "authUC.RefreshToken.RotateSessionCtx"))
+	}
+	if !rotated {
+		return nil, u.rejectRefreshTokenReuse(ctx, payload)
 	}
 
 	user.SanitizePassword()
+
 	return &models.UserWithToken{
-		User:        user,
-		AccessToken: token,
+		User:         user,
+		AccessToken:  accessToken,
+		RefreshToken: newRefreshToken,
 	}, nil
 }
 
-func (u *authUseCase) UploadAvatar(ctx context.Context, userID uuid.UUID, file models.UploadInput) (*models.User, error) {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "authUC.UploadAvatar")
+func (u *authUseCase) Logout(ctx context.Context) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authUC.Logout")
 	defer span.Finish()
 
-	uploadInfo, err := u.minioRepo.PutObject(ctx, file)
+	userUID, err := utils.GetUserUIDFromCtx(ctx)
 	if err != nil {
-		return nil, httpErrors.NewInternalServerError(errors.Wrap(err, "authUC.UploadAvatar.PutObject"))
+		return httpErrors.NewUnauthorizedError(errors.WithMessage(err, "authUC.Logout.GetUserUIDFromCtx"))
 	}
 
-	avatarURL := u.generateMinioURL(file.BucketName, uploadInfo.Key)
+	sessionID, err := utils.GetSessionIDFromCtx(ctx)
+	if err != nil {
+		return httpErrors.NewUnauthorizedError(errors.WithMessage(err, "authUC.Logout.GetSessionIDFromCtx"))
+	}
+
+	tokenID, err := utils.GetTokenIDFromCtx(ctx)
+	if err != nil {
+		return httpErrors.NewUnauthorizedError(errors.WithMessage(err, "authUC.Logout.GetTokenIDFromCtx"))
+	}
+
+	if err = u.redisRepo.RevokeCtx(ctx, u.generateRevokedTokenKey(tokenID), paseto.AccessTokenExpire(u.cfg)); err != nil {
+		return httpErrors.NewInternalServerError(errors.Wrap(err, "authUC.Logout.RevokeCtx"))
+	}
+
+	return u.revokeSession(ctx, userUID.String(), sessionID)
+}
+
+func (u *authUseCase) LogoutAll(ctx context.Context) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authUC.LogoutAll")
+	defer span.Finish()
+
+	userUID, err := utils.GetUserUIDFromCtx(ctx)
+	if err != nil {
+		return httpErrors.NewUnauthorizedError(errors.WithMessage(err, "authUC.LogoutAll.GetUserUIDFromCtx"))
+	}
+
+	return u.revokeUserSessions(ctx, userUID.String(), "")
+}
+
+func (u *authUseCase) UpdateRole(ctx context.Context, userID uuid.UUID, role string) (*models.User, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authUC.UpdateRole")
+	defer span.Finish()
+
+	if !rbac.IsValidRole(role) {
+		return nil, httpErrors.NewBadRequestError(errors.Errorf("authUC.UpdateRole: unknown role %s", role))
+	}
 
 	updatedUser, err := u.authRepo.Update(ctx, &models.User{
 		UserID: userID,
-		Avatar: &avatarURL,
+		Role:   &role,
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	if err = u.redisRepo.DeleteUserCtx(ctx, u.generateUserKey(userID.String())); err != nil {
+		u.logger.Errorf("authUC.UpdateRole.DeleteUserCtx: %v", err)
+	}
+
+	// Role is carried in tokens, so force user to login again to pick up the new one
+	if err = u.revokeUserSessions(ctx, userID.String(), ""); err != nil {
+		return nil, err
+	}
+
 	updatedUser.SanitizePassword()
 
 	return updatedUser, nil
 }
 
+func (u *authUseCase) ValidateAccessToken(ctx context.Context, payload *paseto.Payload) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authUC.ValidateAccessToken")
+	defer span.Finish()
+
+	if payload.Type != paseto.AccessToken {
+		return httpErrors.NewUnauthorizedError(errors.Wrap(paseto.ErrInvalidToken, "authUC.ValidateAccessToken.Type"))
+	}
+
+	for _, key := range []string{u.generateRevokedTokenKey(payload.TokenID), u.generateRevokedSessionKey(payload.SessionID)} {
+		revoked, err := u.redisRepo.IsRevokedCtx(ctx, key)
+		if err != nil {
+			return httpErrors.NewUnauthorizedError(errors.Wrap(err, "authUC.ValidateAccessToken.IsRevokedCtx"))
+		}
+		if revoked {
+			return httpErrors.NewUnauthorizedError(errors.Wrap(paseto.ErrInvalidToken, "authUC.ValidateAccessToken.revoked"))
+		}
+	}
+
+	return nil
+}
+
+func (u *authUseCase) VerifyEmail(ctx context.Context, token string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authUC.VerifyEmail")
+	defer span.Finish()
+
+	userID, err := u.consumeToken(ctx, verifyEmailPrefix, token)
+	if err != nil {
+		return err
+	}
+
+	if err = u.authRepo.VerifyEmail(ctx, userID); err != nil {
+		return err
+	}
+
+	if err = u.redisRepo.DeleteUserCtx(ctx, u.generateUserKey(userID.String())); err != nil {
+		u.logger.Errorf("authUC.VerifyEmail.DeleteUserCtx: %v", err)
+	}
+
+	return nil
+}
+
+func (u *authUseCase) ForgotPassword(ctx context.Context, email string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authUC.ForgotPassword")
+	defer span.Finish()
+
+	user, err := u.authRepo.FindByEmail(ctx, strings.ToLower(strings.TrimSpace(email)))
+	if err != nil {
+		// Do not reveal whether email is registered
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+
+	token, err := u.createToken(ctx, resetPasswordPrefix, resetPasswordDuration, user.UserID)
+	if err != nil {
+		return err
+	}
+
+	return u.sendEmail(ctx, &authAsynq.SendEmailPayload{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hi %s,\n\nUse the link below to reset your password, it expires in %d minutes:\n%s/reset-password?token=%s\n\nIf you did not request a password reset, ignore this email.",
+			user.FirstName, resetPasswordDuration/60, u.cfg.Mailer.LinkBaseURL, token),
+	})
+}
+
+func (u *authUseCase) ResetPassword(ctx context.Context, token string, password string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "authUC.ResetPassword")
+	defer span.Finish()
+
+	userID, err := u.consumeToken(ctx, resetPasswordPrefix, token)
+	if err != nil {
+		return err
+	}
+
+	user := &models.User{UserID: userID, Password: strings.TrimSpace(password)}
+	if err = user.HashPassword(); err != nil {
+		return httpErrors.NewInternalServerError(errors.Wrap(err, "authUC.ResetPassword.HashPassword"))
+	}
+
+	if err = u.authRepo.UpdatePassword(ctx, userID, user.Password); err != nil {
+		return err
+	}
+
+	if err = u.redisRepo.DeleteUserCtx(ctx, u.generateUserKey(userID.String())); err != nil {
+		u.logger.Errorf("authUC.ResetPassword.DeleteUserCtx: %v", err)
+	}
+
+	return u.revokeUserSessions(ctx, userID.String(), "")
+}
+
+// sendVerificationEmail issue verify email token and enqueue email with it
+func (u *authUseCase) sendVerificationEmail(ctx context.Context, user *models.User) error {
+	token, err := u.createToken(ctx, verifyEmailPrefix, verifyEmailDuration, user.UserID)
+	if err != nil {
+		return err
+	}
+
+	return u.sendEmail(ctx, &authAsynq.SendEmailPayload{
+		To:      user.Email,
+		Subject: "Verify your email",
+		Body: fmt.Sprintf("Hi %s,\n\nUse the link below to verify your email, it expires in %d hours:\n%s/verify-email?token=%s",
+			user.FirstName, verifyEmailDuration/3600, u.cfg.Mailer.LinkBaseURL, token),
+	})
+}
+
+func (u *authUseCase) sendEmail(ctx context.Context, payload *authAsynq.SendEmailPayload) error {
+	opts := []asynq.Option{
+		asynq.MaxRetry(sendEmailMaxRetry),
+		asynq.Queue(asynqPkg.QueueDefault),
+	}
+
+	if err := u.authTD.DistributeTaskSendEmail(ctx, payload, opts...); err != nil {
+		return httpErrors.NewInternalServerError(errors.Wrap(err, "authUC.sendEmail.DistributeTaskSendEmail"))
+	}
+
+	return nil
+}
+
+// createToken store signature of a new one time token for user, returns token to send to user
+func (u *authUseCase) createToken(ctx context.Context, prefix string, seconds int, userID uuid.UUID) (string, error) {
+	token, err := utils.GenerateOneTimeToken()
+	if err != nil {
+		return "", httpErrors.NewInternalServerError(errors.Wrap(err, "authUC.createToken.GenerateOneTimeToken"))
+	}
+
+	if err = u.redisRepo.SetTokenCtx(ctx, u.generateTokenKey(prefix, token), seconds, userID.String()); err != nil {
+		return "", httpErrors.NewInternalServerError(errors.Wrap(err, "authUC.createToken.SetTokenCtx"))
+	}
+
+	return token, nil
+}
+
+// consumeToken check one time token and invalidate it, returns user id the token was issued for
+func (u *authUseCase) consumeToken(ctx context.Context, prefix string, token string) (uuid.UUID, error) {
+	userID, err := u.redisRepo.ConsumeTokenCtx(ctx, u.generateTokenKey(prefix, token))
+	if err != nil {
+		return uuid.Nil, httpErrors.NewBadRequestError(errors.Wrap(err, "authUC.consumeToken.ConsumeTokenCtx"))
+	}
+
+	userUID, err := uuid.Parse(userID)
+	if err != nil {
+		return uuid.Nil, httpErrors.NewInternalServerError(errors.Wrap(err, "authUC.consumeToken.uuid.Parse"))
+	}
+
+	return userUID, nil
+}
+
+// createSession start a new refresh token family for user and issue its first token pair
+func (u *authUseCase) createSession(ctx context.Context, user *models.User) (*models.UserWithToken, error) {
+	session := &models.Session{
+		SessionID: uuid.New(),
+		UserID:    user.UserID,
+		CreatedAt: time.Now(),
+	}
+
+	accessToken, refreshToken, err := u.generateTokens(ctx, user, session)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = u.redisRepo.AddUserSessionCtx(ctx, u.generateUserSessionsKey(user.UserID.String()), paseto.RefreshTokenExpire(u.cfg), session.SessionID.String()); err != nil {
+		return nil, httpErrors.NewInternalServerError(errors.Wrap(err, "authUC.createSession.AddUserSessionCtx"))
+	}
+
+	user.SanitizePassword()
+
+	return &models.UserWithToken{
+		User:         user,
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+// generateTokens issue access and refresh token for session, the new refresh token replaces the previous one
+func (u *authUseCase) generateTokens(ctx context.Context, user *models.User, session *models.Session) (string, string, error) {
+	accessToken, refreshToken, err := u.issueTokens(user, session)
+	if err != nil {
+		return "", "", err
+	}
+
+	if err = u.redisRepo.SetSessionCtx(ctx, u.generateSessionKey(session.SessionID.String()), paseto.RefreshTokenExpire(u.cfg), session); err != nil {
+		return "", "", httpErrors.NewInternalServerError(errors.Wrap(err, "authUC.generateTokens.SetSessionCtx"))
+	}
+
+	return accessToken, refreshToken, nil
+}
+
+// issueTokens make access and refresh token for session and set refresh token of session, session is not stored
+func (u *authUseCase) issueTokens(user *models.User, session *models.Session) (string, string, error) {
+	accessPayload := paseto.NewPayload(user, session.SessionID.String(), paseto.AccessToken,
+		time.Second*time.Duration(paseto.AccessTokenExpire(u.cfg)))
+	accessToken, err := paseto.GeneratePASETOToken(accessPayload, u.cfg)
+	if err != nil {
+		return "", "", httpErrors.NewInternalServerError(errors.Wrap(err, "authUC.issueTokens.GeneratePASETOToken"))
+	}
+
+	refreshPayload := paseto.NewPayload(user, session.SessionID.String(), paseto.RefreshToken,
+		time.Second*time.Duration(paseto.RefreshTokenExpire(u.cfg)))
+	refreshToken, err := paseto.GeneratePASETOToken(refreshPayload, u.cfg)
+	if err != nil {
+		return "", "", httpErrors.NewInternalServerError(errors.Wrap(err, "authUC.issueTokens.GeneratePASETOToken"))
+	}
+
+	session.RefreshTokenID = refreshPayload.TokenID
+	session.UpdatedAt = time.Now()
+
+	return accessToken, refreshToken, nil
+}
+
+// rejectRefreshTokenReuse revoke session of refresh token which was already used, whoever holds the session has to login again
+func (u *authUseCase) rejectRefreshTokenReuse(ctx context.Context, payload *paseto.Payload) error {
+	u.logger.Warnf("authUC.RefreshToken: refresh token reuse detected, userID: %s, sessionID: %s", payload.ID, payload.SessionID)
+	if err := u.revokeSession(ctx, payload.ID, payload.SessionID); err != nil {
+		u.logger.Errorf("authUC.RefreshToken.revokeSession: %v", err)
+	}
+	return httpErrors.NewUnauthorizedError(errors.Wrap(paseto.ErrInvalidToken, "authUC.RefreshToken.reuse"))
+}
+
+// revokeUserSessions revoke every session of user except keepSessionID, pass empty string to revoke all
+func (u *authUseCase) revokeUserSessions(ctx context.Context, userID string, keepSessionID string) error {
+	sessionIDs, err := u.redisRepo.GetUserSessionsCtx(ctx, u.generateUserSessionsKey(userID))
+	if err != nil {
+		return httpErrors.NewInternalServerError(errors.Wrap(err, "authUC.revokeUserSessions.GetUserSessionsCtx"))
+	}
+
+	for _, sessionID := range sessionIDs {
+		if sessionID == keepSessionID {
+			continue
+		}
+		if err = u.revokeSession(ctx, userID, sessionID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// revokeSession drop session's refresh token and reject access tokens already issued for it
+func (u *authUseCase) revokeSession(ctx context.Context, userID string, sessionID string) error {
+	if err := u.redisRepo.RevokeCtx(ctx, u.generateRevokedSessionKey(sessionID), paseto.AccessTokenExpire(u.cfg)); err != nil {
+		return httpErrors.NewInternalServerError(errors.Wrap(err, "authUC.revokeSession.RevokeCtx"))
+	}
+
+	if err := u.redisRepo.DeleteSessionCtx(ctx, u.generateSessionKey(sessionID)); err != nil {
+		return httpErrors.NewInternalServerError(errors.Wrap(err, "authUC.revokeSession.DeleteSessionCtx"))
+	}
+
+	if err := u.redisRepo.RemoveUserSessionCtx(ctx, u.generateUserSessionsKey(userID), sessionID); err != nil {
+		u.logger.Errorf("authUC.revokeSession.RemoveUserSessionCtx: %v", err)
+	}
+
+	return nil
+}
+
+// checkLoginLock deny login while email or client ip is locked out, redis failures let login through
+func (u *authUseCase) checkLoginLock(ctx context.Context, email string, ipAddress string) error {
+	retryAfter := 0
+
+	scopes := map[string]string{loginEmailScope: email, loginIPScope: ipAddress}
+	for scope, value := range scopes {
+		if value == "" {
+			continue
+		}
+
+		ttl, err := u.redisRepo.GetLoginLockTTLCtx(ctx, u.generateLoginLockKey(scope, value))
+		if err != nil {
+			u.logger.Errorf("authUC.checkLoginLock.GetLoginLockTTLCtx: %v", err)
+			continue
+		}
+		if ttl > retryAfter {
+			retryAfter = ttl
+		}
+	}
+
+	if retryAfter > 0 {
+		return httpErrors.NewTooManyRequestsError(retryAfter, errors.New("authUC.checkLoginLock: login is locked"))
+	}
+
+	return nil
+}
+
+// recordLoginFailure count failure against email and client ip, and lock them out once attempts are exhausted
+func (u *authUseCase) recordLoginFailure(ctx context.Context, userID *uuid.UUID, email string, ipAddress string, reason string) {
+	u.recordLoginEvent(ctx, userID, email, ipAddress, false, reason)
+
+	u.countLoginFailure(ctx, loginEmailScope, email, positiveOrDefault(u.cfg.Login.MaxAttempts, defaultLoginMaxAttempts))
+	if ipAddress != "" {
+		u.countLoginFailure(ctx, loginIPScope, ipAddress, positiveOrDefault(u.cfg.Login.MaxIPAttempts, defaultLoginMaxIPAttempts))
+	}
+}
+
+func (u *authUseCase) countLoginFailure(ctx context.Context, scope string, value string, maxAttempts int) {
+	window := positiveOrDefault(u.cfg.Login.AttemptWindow, defaultLoginAttemptWindow)
+
+	failures, err := u.redisRepo.IncrLoginFailuresCtx(ctx, u.generateLoginFailuresKey(scope, value), window)
+	if err != nil {
+		u.logger.Errorf("authUC.countLoginFailure.IncrLoginFailuresCtx: %v", err)
+		return
+	}
+
+	if failures < maxAttempts {
+		return
+	}
+
+	if err = u.redisRepo.LockLoginCtx(ctx, u.generateLoginLockKey(scope, value), u.loginLockoutDuration(failures-maxAttempts)); err != nil {
+		u.logger.Errorf("authUC.countLoginFailure.LockLoginCtx: %v", err)
+	}
+}
+
+// loginLockoutDuration double lockout for every failure after attempts are exhausted
+func (u *authUseCase) loginLockoutDuration(extraFailures int) int {
+	lockout := positiveOrDefault(u.cfg.Login.LockoutDuration, defaultLoginLockoutDuration)
+	maxLockout := positiveOrDefault(u.cfg.Login.MaxLockoutDuration, defaultLoginMaxLockoutDuration)
+
+	for i := 0; i < extraFailures && lockout < maxLockout; i++ {
+		lockout *= 2
+	}
+	if lockout > maxLockout {
+		return maxLockout
+	}
+	return lockout
+}
+
+// recordLoginEvent store login attempt, failure to store it must not break login
+func (u *authUseCase) recordLoginEvent(ctx context.Context, userID *uuid.UUID, email string, ipAddress string, success bool, reason string) {
+	err := u.authRepo.CreateLoginEvent(ctx, &models.LoginEvent{
+		UserID:    userID,
+		Email:     email,
+		IPAddress: ipAddress,
+		Success:   success,
+		Reason:    reason,
+	})
+	if err != nil {
+		u.logger.Errorf("authUC.recordLoginEvent.CreateLoginEvent: %v", err)
+	}
+}
+
+func (u *authUseCase) generateLoginFailuresKey(scope string, value string) string {
+	return fmt.Sprintf("%s: %s: %s", loginFailuresPrefix, scope, value)
+}
+
+func (u *authUseCase) generateLoginLockKey(scope string, value string) string {
+	return fmt.Sprintf("%s: %s: %s", loginLockPrefix, scope, value)
+}
+
+func positiveOrDefault(value int, defaultValue int) int {
+	if value <= 0 {
+		return defaultValue
+	}
+	return value
+}
+
 func (u *authUseCase) generateUserKey(userID string) string {
 	return fmt.Sprintf("%s: %s", basePrefix, userID)
 }
 
+func (u *authUseCase) generateSessionKey(sessionID string) string {
+	return fmt.Sprintf("%s: %s", sessionPrefix, sessionID)
+}
+
+func (u *authUseCase) generateUserSessionsKey(userID string) string {
+	return fmt.Sprintf("%s: %s", userSessionsPrefix, userID)
+}
+
+func (u *authUseCase) generateRevokedTokenKey(tokenID string) string {
+	return fmt.Sprintf("%s: %s", revokedTokenPrefix, tokenID)
+}
+
+func (u *authUseCase) generateRevokedSessionKey(sessionID string) string {
+	return fmt.Sprintf("%s: %s", revokedSessionPrefix, sessionID)
+}
+
+func (u *authUseCase) generateTokenKey(prefix string, token string) string {
+	return fmt.Sprintf("%s: %s", prefix, utils.SignOneTimeToken(token, u.cfg.Server.SymmetricKey))
+}
+
+func (u *authUseCase) generateAvatarKeyPrefix(userID uuid.UUID, imageID string) string {
+	return fmt.Sprintf("%s/%s/%s", avatarKeyPrefix, userID.String(), imageID)
+}
+
+// removeImageUpload remove upload once it is not needed, failures only leave garbage so they are logged
+func (u *authUseCase) removeImageUpload(ctx context.Context, bucket string, key string) {
+	if err := u.minioRepo.RemoveObject(ctx, bucket, key); err != nil {
+		u.logger.Errorf("authUC.removeImageUpload.RemoveObject: %v", err)
+	}
+}
+
+// removeImageVariants remove every variant stored next to key, failures only leave garbage so they are logged
+func (u *authUseCase) removeImageVariants(ctx context.Context, bucket string, key string) {
+	for _, variantKey := range utils.ImageVariantKeys(key) {
+		if err := u.minioRepo.RemoveObject(ctx, bucket, variantKey); err != nil {
+			u.logger.Errorf("authUC.removeImageVariants.RemoveObject: %v", err)
+		}
+	}
+}
+
 func (u *authUseCase) generateMinioURL(bucket string, key string) string {
 	return fmt.Sprintf("%s/minio/%s/%s", u.cfg.Minio.MinioEndpoint, bucket, key)
 }
+
+// parseMinioURL get bucket and key back from url made by generateMinioURL
+func (u *authUseCase) parseMinioURL(url string) (string, string, bool) {
+	path, ok := strings.CutPrefix(url, fmt.Sprintf("%s/minio/", u.cfg.Minio.MinioEndpoint))
+	if !ok {
+		return "", "", false
+	}
+
+	bucket, key, ok := strings.Cut(path, "/")
+	if !ok || bucket == "" || key == "" {
+		return "", "", false
+	}
+
+	return bucket, key, true
+}
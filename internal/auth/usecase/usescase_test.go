@@ -1,16 +1,33 @@
 package usecase
 
 import (
+	"bytes"
 	"context"
+	"database/sql"
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/minio/minio-go/v7"
 	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
 	"github.com/scul0405/blog-clean-architecture-rest-api/config"
 	"github.com/scul0405/blog-clean-architecture-rest-api/internal/auth/mock"
+	authAsynq "github.com/scul0405/blog-clean-architecture-rest-api/internal/auth/transport/asynq"
+	followMock "github.com/scul0405/blog-clean-architecture-rest-api/internal/follow/mock"
 	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	httpErrors "github.com/scul0405/blog-clean-architecture-rest-api/pkg/http_errors"
 	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/logger"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/paseto"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/rbac"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/utils"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 	"golang.org/x/crypto/bcrypt"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
 	"testing"
+	"time"
 )
 
 func TestAuthUseCase_Register(t *testing.T) {
@@ -33,7 +50,9 @@ func TestAuthUseCase_Register(t *testing.T) {
 
 	apiLogger := logger.NewApiLogger(cfg)
 	mockAuthRepo := mock.NewMockRepository(ctrl)
-	authUC := NewAuthUseCase(cfg, mockAuthRepo, apiLogger)
+	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
+	mockAuthTD := mock.NewMockAuthTaskDistributor(ctrl)
+	authUC := NewAuthUseCase(cfg, mockAuthRepo, mockRedisRepo, nil, nil, mockAuthTD, apiLogger)
 
 	user := &models.User{
 		Password: "123456",
@@ -53,6 +72,10 @@ func TestAuthUseCase_Register(t *testing.T) {
 	defer span.Finish()
 
 	mockAuthRepo.EXPECT().Register(ctxWithTrace, gomock.Eq(user)).Return(mockUser, nil)
+	mockRedisRepo.EXPECT().SetTokenCtx(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	mockAuthTD.EXPECT().DistributeTaskSendEmail(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	mockRedisRepo.EXPECT().SetSessionCtx(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	mockRedisRepo.EXPECT().AddUserSessionCtx(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 
 	createdUserWithToken, err := authUC.Register(ctx, user)
 	require.NoError(t, err)
@@ -77,7 +100,10 @@ func TestAuthUseCase_GetByID(t *testing.T) {
 
 	apiLogger := logger.NewApiLogger(cfg)
 	mockAuthRepo := mock.NewMockRepository(ctrl)
-	authUC := NewAuthUseCase(cfg, mockAuthRepo, apiLogger)
+	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
+	mockAuthTD := mock.NewMockAuthTaskDistributor(ctrl)
+	mockFollowRepo := followMock.NewMockRepository(ctrl)
+	authUC := NewAuthUseCase(cfg, mockAuthRepo, mockRedisRepo, nil, mockFollowRepo, mockAuthTD, apiLogger)
 
 	user := &models.User{
 		Password: "123456",
@@ -88,12 +114,67 @@ func TestAuthUseCase_GetByID(t *testing.T) {
 	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "authUC.GetByID")
 	defer span.Finish()
 
+	mockRedisRepo.EXPECT().GetByIDCtx(ctxWithTrace, gomock.Any()).Return(nil, nil)
 	mockAuthRepo.EXPECT().GetByID(ctxWithTrace, gomock.Eq(user.UserID)).Return(user, nil)
+	mockRedisRepo.EXPECT().SetUserCtx(ctxWithTrace, gomock.Any(), gomock.Any(), gomock.Eq(user)).Return(nil)
 
 	testUser, err := authUC.GetByID(ctx, user.UserID)
 	require.NoError(t, err)
 	require.NotNil(t, testUser)
-	require.Nil(t, err)
+	require.Nil(t, testUser.FollowCounts)
+
+	t.Run("Cached", func(t *testing.T) {
+		cachedUser := &models.User{UserID: user.UserID, Email: user.Email}
+		mockRedisRepo.EXPECT().GetByIDCtx(ctxWithTrace, gomock.Any()).Return(cachedUser, nil)
+
+		testUser, err := authUC.GetByID(ctx, user.UserID)
+		require.NoError(t, err)
+		require.Equal(t, cachedUser, testUser)
+	})
+}
+
+func TestAuthUseCase_GetProfile(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Logger: config.LoggerConfig{
+			Development:       true,
+			DisableCaller:     false,
+			DisableStacktrace: false,
+			Encoding:          "json",
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	apiLogger.InitLogger()
+	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
+	mockFollowRepo := followMock.NewMockRepository(ctrl)
+	authUC := NewAuthUseCase(cfg, nil, mockRedisRepo, nil, mockFollowRepo, nil, apiLogger)
+
+	userUID := uuid.New()
+	counts := &models.FollowCounts{FollowersCount: 3, FollowingCount: 1}
+
+	t.Run("Counts", func(t *testing.T) {
+		mockRedisRepo.EXPECT().GetByIDCtx(gomock.Any(), gomock.Any()).Return(&models.User{UserID: userUID}, nil)
+		mockFollowRepo.EXPECT().GetCounts(gomock.Any(), gomock.Eq(userUID)).Return(counts, nil)
+
+		testUser, err := authUC.GetProfile(context.Background(), userUID)
+		require.NoError(t, err)
+		require.Equal(t, counts, testUser.FollowCounts)
+	})
+
+	t.Run("Counts failed", func(t *testing.T) {
+		mockRedisRepo.EXPECT().GetByIDCtx(gomock.Any(), gomock.Any()).Return(&models.User{UserID: userUID}, nil)
+		mockFollowRepo.EXPECT().GetCounts(gomock.Any(), gomock.Eq(userUID)).Return(nil, errors.New("db is down"))
+
+		testUser, err := authUC.GetProfile(context.Background(), userUID)
+		require.NoError(t, err)
+		require.Equal(t, userUID, testUser.UserID)
+		require.Nil(t, testUser.FollowCounts)
+	})
 }
 
 func TestAuthUseCase_Login(t *testing.T) {
@@ -116,7 +197,9 @@ func TestAuthUseCase_Login(t *testing.T) {
 
 	apiLogger := logger.NewApiLogger(cfg)
 	mockAuthRepo := mock.NewMockRepository(ctrl)
-	authUC := NewAuthUseCase(cfg, mockAuthRepo, apiLogger)
+	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
+	mockAuthTD := mock.NewMockAuthTaskDistributor(ctrl)
+	authUC := NewAuthUseCase(cfg, mockAuthRepo, mockRedisRepo, nil, nil, mockAuthTD, apiLogger)
 
 	user := &models.LoginUser{
 		Password: "123456",
@@ -135,10 +218,562 @@ func TestAuthUseCase_Login(t *testing.T) {
 	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "authUC.Login")
 	defer span.Finish()
 
+	mockRedisRepo.EXPECT().GetLoginLockTTLCtx(gomock.Any(), gomock.Any()).Return(0, nil).Times(2)
 	mockAuthRepo.EXPECT().FindByEmail(ctxWithTrace, gomock.Eq(user.Email)).Return(mockUser, nil)
+	mockRedisRepo.EXPECT().SetSessionCtx(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	mockRedisRepo.EXPECT().AddUserSessionCtx(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	mockRedisRepo.EXPECT().ResetLoginAttemptsCtx(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	mockAuthRepo.EXPECT().UpdateLoginDate(gomock.Any(), gomock.Eq(mockUser.UserID)).Return(nil)
+	mockAuthRepo.EXPECT().CreateLoginEvent(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, event *models.LoginEvent) error {
+			require.True(t, event.Success)
+			return nil
+		})
 
-	createdUserWithToken, err := authUC.Login(ctx, user)
+	createdUserWithToken, err := authUC.Login(ctx, user, "127.0.0.1")
 	require.NoError(t, err)
 	require.NotNil(t, createdUserWithToken)
 	require.Nil(t, err)
 }
+
+func TestAuthUseCase_LoginLockout(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Server: config.ServerConfig{
+			SymmetricKey: "secret_token_symmetric_key_12345",
+		},
+		Login: config.LoginConfig{
+			MaxAttempts:        3,
+			MaxIPAttempts:      10,
+			AttemptWindow:      3600,
+			LockoutDuration:    60,
+			MaxLockoutDuration: 300,
+		},
+		Logger: config.LoggerConfig{
+			Development:       true,
+			DisableCaller:     false,
+			DisableStacktrace: false,
+			Encoding:          "json",
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	mockAuthRepo := mock.NewMockRepository(ctrl)
+	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
+	authUC := NewAuthUseCase(cfg, mockAuthRepo, mockRedisRepo, nil, nil, nil, apiLogger)
+
+	hashPassword, err := bcrypt.GenerateFromPassword([]byte("123456"), bcrypt.DefaultCost)
+	require.NoError(t, err)
+
+	mockUser := &models.User{
+		UserID:   uuid.New(),
+		Email:    "email@gmail.com",
+		Password: string(hashPassword),
+	}
+	loginReq := &models.LoginUser{Email: "email@gmail.com", Password: "wrong_password"}
+
+	t.Run("LockAfterMaxAttempts", func(t *testing.T) {
+		emailLockKey := authUC.(*authUseCase).generateLoginLockKey(loginEmailScope, mockUser.Email)
+
+		mockRedisRepo.EXPECT().GetLoginLockTTLCtx(gomock.Any(), gomock.Any()).Return(0, nil).Times(2)
+		mockAuthRepo.EXPECT().FindByEmail(gomock.Any(), gomock.Eq(mockUser.Email)).Return(mockUser, nil)
+		mockAuthRepo.EXPECT().CreateLoginEvent(gomock.Any(), gomock.Any()).Return(nil)
+		mockRedisRepo.EXPECT().IncrLoginFailuresCtx(gomock.Any(), gomock.Any(), gomock.Eq(3600)).DoAndReturn(
+			func(_ context.Context, key string, _ int) (int, error) {
+				if key == authUC.(*authUseCase).generateLoginFailuresKey(loginEmailScope, mockUser.Email) {
+					return 5, nil
+				}
+				return 1, nil
+			}).Times(2)
+		// 2 failures past the limit double the lockout twice
+		mockRedisRepo.EXPECT().LockLoginCtx(gomock.Any(), gomock.Eq(emailLockKey), gomock.Eq(240)).Return(nil)
+
+		_, err := authUC.Login(context.Background(), loginReq, "127.0.0.1")
+		require.Error(t, err)
+		require.Equal(t, http.StatusUnauthorized, httpErrors.ParseErrors(err).Status())
+	})
+
+	t.Run("Locked", func(t *testing.T) {
+		mockRedisRepo.EXPECT().GetLoginLockTTLCtx(gomock.Any(), gomock.Any()).Return(120, nil).Times(2)
+
+		_, err := authUC.Login(context.Background(), loginReq, "127.0.0.1")
+		require.Error(t, err)
+		require.Equal(t, http.StatusTooManyRequests, httpErrors.ParseErrors(err).Status())
+
+		var tooManyRequestsErr httpErrors.TooManyRequestsError
+		require.ErrorAs(t, err, &tooManyRequestsErr)
+		require.Equal(t, 120, tooManyRequestsErr.RetryAfter)
+	})
+
+	t.Run("LockoutIsCapped", func(t *testing.T) {
+		require.Equal(t, 300, authUC.(*authUseCase).loginLockoutDuration(10))
+	})
+}
+
+func TestAuthUseCase_RefreshToken(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Server: config.ServerConfig{
+			SymmetricKey: "secret_token_symmetric_key_12345",
+		},
+		Logger: config.LoggerConfig{
+			Development:       true,
+			DisableCaller:     false,
+			DisableStacktrace: false,
+			Encoding:          "json",
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	apiLogger.InitLogger()
+	mockAuthRepo := mock.NewMockRepository(ctrl)
+	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
+	mockAuthTD := mock.NewMockAuthTaskDistributor(ctrl)
+	authUC := NewAuthUseCase(cfg, mockAuthRepo, mockRedisRepo, nil, nil, mockAuthTD, apiLogger)
+
+	user := &models.User{
+		UserID: uuid.New(),
+		Email:  "email@gmail.com",
+	}
+	sessionID := uuid.New()
+
+	payload := paseto.NewPayload(user, sessionID.String(), paseto.RefreshToken, time.Hour)
+	refreshToken, err := paseto.GeneratePASETOToken(payload, cfg)
+	require.NoError(t, err)
+
+	t.Run("Rotate", func(t *testing.T) {
+		session := &models.Session{
+			SessionID:      sessionID,
+			UserID:         user.UserID,
+			RefreshTokenID: payload.TokenID,
+		}
+
+		mockRedisRepo.EXPECT().GetSessionCtx(gomock.Any(), gomock.Any()).Return(session, nil)
+		mockAuthRepo.EXPECT().GetByID(gomock.Any(), gomock.Eq(user.UserID)).Return(user, nil)
+		mockRedisRepo.EXPECT().RotateSessionCtx(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Eq(payload.TokenID), gomock.Eq(session)).Return(true, nil)
+
+		userWithToken, err := authUC.RefreshToken(context.Background(), refreshToken)
+		require.NoError(t, err)
+		require.NotEmpty(t, userWithToken.AccessToken)
+		require.NotEqual(t, refreshToken, userWithToken.RefreshToken)
+		require.NotEqual(t, payload.TokenID, session.RefreshTokenID)
+	})
+
+	t.Run("Concurrent rotate", func(t *testing.T) {
+		session := &models.Session{
+			SessionID:      sessionID,
+			UserID:         user.UserID,
+			RefreshTokenID: payload.TokenID,
+		}
+
+		mockRedisRepo.EXPECT().GetSessionCtx(gomock.Any(), gomock.Any()).Return(session, nil)
+		mockAuthRepo.EXPECT().GetByID(gomock.Any(), gomock.Eq(user.UserID)).Return(user, nil)
+		mockRedisRepo.EXPECT().RotateSessionCtx(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Eq(payload.TokenID), gomock.Any()).Return(false, nil)
+		mockRedisRepo.EXPECT().RevokeCtx(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		mockRedisRepo.EXPECT().DeleteSessionCtx(gomock.Any(), gomock.Any()).Return(nil)
+		mockRedisRepo.EXPECT().RemoveUserSessionCtx(gomock.Any(), gomock.Any(), gomock.Eq(sessionID.String())).Return(nil)
+
+		userWithToken, err := authUC.RefreshToken(context.Background(), refreshToken)
+		require.Error(t, err)
+		require.Equal(t, http.StatusUnauthorized, httpErrors.ParseErrors(err).Status())
+		require.Nil(t, userWithToken)
+	})
+
+	t.Run("Reuse", func(t *testing.T) {
+		session := &models.Session{
+			SessionID:      sessionID,
+			UserID:         user.UserID,
+			RefreshTokenID: uuid.New().String(),
+		}
+
+		mockRedisRepo.EXPECT().GetSessionCtx(gomock.Any(), gomock.Any()).Return(session, nil)
+		mockRedisRepo.EXPECT().RevokeCtx(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		mockRedisRepo.EXPECT().DeleteSessionCtx(gomock.Any(), gomock.Any()).Return(nil)
+		mockRedisRepo.EXPECT().RemoveUserSessionCtx(gomock.Any(), gomock.Any(), gomock.Eq(sessionID.String())).Return(nil)
+
+		userWithToken, err := authUC.RefreshToken(context.Background(), refreshToken)
+		require.Error(t, err)
+		require.Nil(t, userWithToken)
+	})
+}
+
+func TestAuthUseCase_ForgotPassword(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Server: config.ServerConfig{
+			SymmetricKey: "secret_token_symmetric_key_12345",
+		},
+		Logger: config.LoggerConfig{
+			Development:       true,
+			DisableCaller:     false,
+			DisableStacktrace: false,
+			Encoding:          "json",
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	mockAuthRepo := mock.NewMockRepository(ctrl)
+	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
+	mockAuthTD := mock.NewMockAuthTaskDistributor(ctrl)
+	authUC := NewAuthUseCase(cfg, mockAuthRepo, mockRedisRepo, nil, nil, mockAuthTD, apiLogger)
+
+	t.Run("Registered", func(t *testing.T) {
+		user := &models.User{
+			UserID: uuid.New(),
+			Email:  "email@gmail.com",
+		}
+
+		mockAuthRepo.EXPECT().FindByEmail(gomock.Any(), gomock.Eq(user.Email)).Return(user, nil)
+		mockRedisRepo.EXPECT().SetTokenCtx(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Eq(user.UserID.String())).Return(nil)
+		mockAuthTD.EXPECT().DistributeTaskSendEmail(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, payload *authAsynq.SendEmailPayload, _ ...asynq.Option) error {
+				require.Equal(t, user.Email, payload.To)
+				return nil
+			})
+
+		err := authUC.ForgotPassword(context.Background(), " Email@gmail.com ")
+		require.NoError(t, err)
+	})
+
+	t.Run("NotRegistered", func(t *testing.T) {
+		mockAuthRepo.EXPECT().FindByEmail(gomock.Any(), gomock.Eq("unknown@gmail.com")).Return(nil, errors.Wrap(sql.ErrNoRows, "FindByEmail"))
+
+		err := authUC.ForgotPassword(context.Background(), "unknown@gmail.com")
+		require.NoError(t, err)
+	})
+}
+
+func TestAuthUseCase_ResetPassword(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Server: config.ServerConfig{
+			SymmetricKey: "secret_token_symmetric_key_12345",
+		},
+		Logger: config.LoggerConfig{
+			Development:       true,
+			DisableCaller:     false,
+			DisableStacktrace: false,
+			Encoding:          "json",
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	mockAuthRepo := mock.NewMockRepository(ctrl)
+	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
+	mockAuthTD := mock.NewMockAuthTaskDistributor(ctrl)
+	authUC := NewAuthUseCase(cfg, mockAuthRepo, mockRedisRepo, nil, nil, mockAuthTD, apiLogger)
+
+	userUID := uuid.New()
+
+	t.Run("Reset", func(t *testing.T) {
+		mockRedisRepo.EXPECT().ConsumeTokenCtx(gomock.Any(), gomock.Any()).Return(userUID.String(), nil)
+		mockAuthRepo.EXPECT().UpdatePassword(gomock.Any(), gomock.Eq(userUID), gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ uuid.UUID, password string) error {
+				require.NoError(t, bcrypt.CompareHashAndPassword([]byte(password), []byte("new_password")))
+				return nil
+			})
+		mockRedisRepo.EXPECT().DeleteUserCtx(gomock.Any(), gomock.Any()).Return(nil)
+		mockRedisRepo.EXPECT().GetUserSessionsCtx(gomock.Any(), gomock.Any()).Return([]string{}, nil)
+
+		err := authUC.ResetPassword(context.Background(), "token", "new_password")
+		require.NoError(t, err)
+	})
+
+	t.Run("UsedToken", func(t *testing.T) {
+		mockRedisRepo.EXPECT().ConsumeTokenCtx(gomock.Any(), gomock.Any()).Return("", errors.New("redis: nil"))
+
+		err := authUC.ResetPassword(context.Background(), "token", "new_password")
+		require.Error(t, err)
+		require.Equal(t, http.StatusBadRequest, httpErrors.ParseErrors(err).Status())
+	})
+}
+
+func TestAuthUseCase_ChangePassword(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Server: config.ServerConfig{
+			SymmetricKey: "secret_token_symmetric_key_12345",
+		},
+		Logger: config.LoggerConfig{
+			Development:       true,
+			DisableCaller:     false,
+			DisableStacktrace: false,
+			Encoding:          "json",
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	mockAuthRepo := mock.NewMockRepository(ctrl)
+	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
+	authUC := NewAuthUseCase(cfg, mockAuthRepo, mockRedisRepo, nil, nil, nil, apiLogger)
+
+	userUID := uuid.New()
+	currentSessionID := uuid.New().String()
+	otherSessionID := uuid.New().String()
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte("current_password"), bcrypt.DefaultCost)
+	require.NoError(t, err)
+
+	ctx := context.WithValue(context.Background(), "user_id", userUID.String())
+	ctx = context.WithValue(ctx, "session_id", currentSessionID)
+
+	t.Run("Change", func(t *testing.T) {
+		mockAuthRepo.EXPECT().GetPassword(gomock.Any(), gomock.Eq(userUID)).Return(string(hashedPassword), nil)
+		mockAuthRepo.EXPECT().UpdatePassword(gomock.Any(), gomock.Eq(userUID), gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ uuid.UUID, password string) error {
+				require.NoError(t, bcrypt.CompareHashAndPassword([]byte(password), []byte("new_password")))
+				return nil
+			})
+		mockRedisRepo.EXPECT().DeleteUserCtx(gomock.Any(), gomock.Any()).Return(nil)
+		mockRedisRepo.EXPECT().GetUserSessionsCtx(gomock.Any(), gomock.Any()).Return([]string{currentSessionID, otherSessionID}, nil)
+		mockRedisRepo.EXPECT().RevokeCtx(gomock.Any(), gomock.Eq(authUC.(*authUseCase).generateRevokedSessionKey(otherSessionID)), gomock.Any()).Return(nil)
+		mockRedisRepo.EXPECT().DeleteSessionCtx(gomock.Any(), gomock.Eq(authUC.(*authUseCase).generateSessionKey(otherSessionID))).Return(nil)
+		mockRedisRepo.EXPECT().RemoveUserSessionCtx(gomock.Any(), gomock.Any(), gomock.Eq(otherSessionID)).Return(nil)
+
+		err := authUC.ChangePassword(ctx, &models.ChangePassword{
+			CurrentPassword: "current_password",
+			NewPassword:     "new_password",
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("WrongCurrentPassword", func(t *testing.T) {
+		mockAuthRepo.EXPECT().GetPassword(gomock.Any(), gomock.Eq(userUID)).Return(string(hashedPassword), nil)
+
+		err := authUC.ChangePassword(ctx, &models.ChangePassword{
+			CurrentPassword: "wrong_password",
+			NewPassword:     "new_password",
+		})
+		require.Error(t, err)
+		require.Equal(t, http.StatusBadRequest, httpErrors.ParseErrors(err).Status())
+	})
+}
+
+func TestAuthUseCase_UploadAvatar(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Server: config.ServerConfig{
+			SymmetricKey: "secret_token_symmetric_key_12345",
+		},
+		Minio: config.MinioConfig{
+			MinioEndpoint: "localhost:9000",
+		},
+		Logger: config.LoggerConfig{
+			Development:       true,
+			DisableCaller:     false,
+			DisableStacktrace: false,
+			Encoding:          "json",
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	apiLogger.InitLogger()
+	mockAuthRepo := mock.NewMockRepository(ctrl)
+	mockMinioRepo := mock.NewMockMinioRepository(ctrl)
+	mockAuthTD := mock.NewMockAuthTaskDistributor(ctrl)
+	authUC := NewAuthUseCase(cfg, mockAuthRepo, nil, mockMinioRepo, nil, mockAuthTD, apiLogger)
+
+	userUID := uuid.New()
+	image := encodeTestPNG(t, 300, 200)
+
+	t.Run("Owner", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), "user_id", userUID.String())
+
+		var uploadKey string
+		mockAuthRepo.EXPECT().GetByID(gomock.Any(), gomock.Eq(userUID)).Return(&models.User{UserID: userUID}, nil)
+		mockMinioRepo.EXPECT().PutObject(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, input models.UploadInput) (*minio.UploadInfo, error) {
+				require.Equal(t, "avatars", input.BucketName)
+				require.Regexp(t, "^avatars/"+userUID.String()+"/[^/]+/upload$", input.Name)
+				require.Equal(t, int64(len(image)), input.Size)
+				uploadKey = input.Name
+				return &minio.UploadInfo{Key: input.Name}, nil
+			})
+		mockAuthTD.EXPECT().DistributeTaskProcessAvatar(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, payload *authAsynq.ProcessAvatarPayload, _ ...asynq.Option) error {
+				require.Equal(t, userUID, payload.UserUID)
+				require.Equal(t, uploadKey, payload.ObjectKey)
+				return nil
+			})
+
+		variants, err := authUC.UploadAvatar(ctx, userUID, &models.ImageInput{BucketName: "avatars", Image: image})
+		require.NoError(t, err)
+		require.Len(t, variants, len(utils.ImageVariants))
+		require.Regexp(t, "^localhost:9000/minio/avatars/avatars/"+userUID.String()+"/[^/]+/thumbnail.png$", variants[utils.ImageVariantThumbnail])
+	})
+
+	t.Run("NotOwner", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), "user_id", uuid.New().String())
+
+		variants, err := authUC.UploadAvatar(ctx, userUID, &models.ImageInput{BucketName: "avatars", Image: image})
+		require.Error(t, err)
+		require.Nil(t, variants)
+		require.Equal(t, http.StatusForbidden, httpErrors.ParseErrors(err).Status())
+	})
+
+	t.Run("Admin", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), "user_id", uuid.New().String())
+		ctx = context.WithValue(ctx, "role", rbac.RoleAdmin)
+
+		mockAuthRepo.EXPECT().GetByID(gomock.Any(), gomock.Eq(userUID)).Return(&models.User{UserID: userUID}, nil)
+		mockMinioRepo.EXPECT().PutObject(gomock.Any(), gomock.Any()).Return(&minio.UploadInfo{}, nil)
+		mockAuthTD.EXPECT().DistributeTaskProcessAvatar(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+		variants, err := authUC.UploadAvatar(ctx, userUID, &models.ImageInput{BucketName: "avatars", Image: image})
+		require.NoError(t, err)
+		require.NotNil(t, variants)
+	})
+
+	t.Run("DistributeFailed", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), "user_id", userUID.String())
+
+		mockAuthRepo.EXPECT().GetByID(gomock.Any(), gomock.Eq(userUID)).Return(&models.User{UserID: userUID}, nil)
+		mockMinioRepo.EXPECT().PutObject(gomock.Any(), gomock.Any()).Return(&minio.UploadInfo{}, nil)
+		mockAuthTD.EXPECT().DistributeTaskProcessAvatar(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("redis is down"))
+		mockMinioRepo.EXPECT().RemoveObject(gomock.Any(), gomock.Eq("avatars"), gomock.Any()).Return(nil)
+
+		variants, err := authUC.UploadAvatar(ctx, userUID, &models.ImageInput{BucketName: "avatars", Image: image})
+		require.Error(t, err)
+		require.Nil(t, variants)
+	})
+}
+
+func TestAuthUseCase_ProcessAvatar(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Server: config.ServerConfig{
+			SymmetricKey: "secret_token_symmetric_key_12345",
+		},
+		Minio: config.MinioConfig{
+			MinioEndpoint: "localhost:9000",
+		},
+		Logger: config.LoggerConfig{
+			Development:       true,
+			DisableCaller:     false,
+			DisableStacktrace: false,
+			Encoding:          "json",
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	apiLogger.InitLogger()
+	mockAuthRepo := mock.NewMockRepository(ctrl)
+	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
+	mockMinioRepo := mock.NewMockMinioRepository(ctrl)
+	authUC := NewAuthUseCase(cfg, mockAuthRepo, mockRedisRepo, mockMinioRepo, nil, nil, apiLogger)
+
+	userUID := uuid.New()
+	oldAvatar := "localhost:9000/minio/avatars/avatars/" + userUID.String() + "/old/original.png"
+	uploadKey := "avatars/" + userUID.String() + "/new/upload"
+	uploaded := encodeTestPNG(t, 1200, 600)
+	image := &models.ImageInput{
+		ImageID:    "new",
+		BucketName: "avatars",
+		ObjectKey:  uploadKey,
+	}
+
+	t.Run("ReplaceOldAvatar", func(t *testing.T) {
+		mockAuthRepo.EXPECT().GetByID(gomock.Any(), gomock.Eq(userUID)).Return(&models.User{UserID: userUID, Avatar: &oldAvatar}, nil)
+		mockMinioRepo.EXPECT().ReadObject(gomock.Any(), gomock.Eq("avatars"), gomock.Eq(uploadKey)).Return(uploaded, nil)
+		mockMinioRepo.EXPECT().PutObject(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, input models.UploadInput) (*minio.UploadInfo, error) {
+				require.Equal(t, "image/png", input.ContentType)
+				require.Regexp(t, "^avatars/"+userUID.String()+"/new/(thumbnail|medium|original).png$", input.Name)
+				return &minio.UploadInfo{Key: input.Name}, nil
+			}).Times(len(utils.ImageVariants))
+		mockAuthRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, user *models.User) (*models.User, error) {
+				require.Equal(t, "localhost:9000/minio/avatars/avatars/"+userUID.String()+"/new/original.png", *user.Avatar)
+				return user, nil
+			})
+		for _, variant := range utils.ImageVariants {
+			key := "avatars/" + userUID.String() + "/old/" + variant.Name + ".png"
+			mockMinioRepo.EXPECT().RemoveObject(gomock.Any(), gomock.Eq("avatars"), gomock.Eq(key)).Return(nil)
+		}
+		mockMinioRepo.EXPECT().RemoveObject(gomock.Any(), gomock.Eq("avatars"), gomock.Eq(uploadKey)).Return(nil)
+		mockRedisRepo.EXPECT().DeleteUserCtx(gomock.Any(), gomock.Any()).Return(nil)
+
+		err := authUC.ProcessAvatar(context.Background(), userUID, image)
+		require.NoError(t, err)
+	})
+
+	t.Run("UpdateFailed", func(t *testing.T) {
+		mockAuthRepo.EXPECT().GetByID(gomock.Any(), gomock.Eq(userUID)).Return(&models.User{UserID: userUID, Avatar: &oldAvatar}, nil)
+		mockMinioRepo.EXPECT().ReadObject(gomock.Any(), gomock.Eq("avatars"), gomock.Eq(uploadKey)).Return(uploaded, nil)
+		mockMinioRepo.EXPECT().PutObject(gomock.Any(), gomock.Any()).Return(&minio.UploadInfo{}, nil).Times(len(utils.ImageVariants))
+		mockAuthRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil, errors.New("update failed"))
+		for _, variant := range utils.ImageVariants {
+			key := "avatars/" + userUID.String() + "/new/" + variant.Name + ".png"
+			mockMinioRepo.EXPECT().RemoveObject(gomock.Any(), gomock.Eq("avatars"), gomock.Eq(key)).Return(nil)
+		}
+
+		err := authUC.ProcessAvatar(context.Background(), userUID, image)
+		require.Error(t, err)
+	})
+
+	t.Run("InvalidImage", func(t *testing.T) {
+		invalidKey := "avatars/" + userUID.String() + "/invalid/upload"
+		mockAuthRepo.EXPECT().GetByID(gomock.Any(), gomock.Eq(userUID)).Return(&models.User{UserID: userUID}, nil)
+		mockMinioRepo.EXPECT().ReadObject(gomock.Any(), gomock.Eq("avatars"), gomock.Eq(invalidKey)).Return([]byte("not an image"), nil)
+		mockMinioRepo.EXPECT().RemoveObject(gomock.Any(), gomock.Eq("avatars"), gomock.Eq(invalidKey)).Return(nil)
+
+		err := authUC.ProcessAvatar(context.Background(), userUID, &models.ImageInput{
+			ImageID:    "invalid",
+			BucketName: "avatars",
+			ObjectKey:  invalidKey,
+		})
+		require.ErrorIs(t, err, asynq.SkipRetry)
+	})
+
+	t.Run("UploadMissing", func(t *testing.T) {
+		mockAuthRepo.EXPECT().GetByID(gomock.Any(), gomock.Eq(userUID)).Return(&models.User{UserID: userUID}, nil)
+		mockMinioRepo.EXPECT().ReadObject(gomock.Any(), gomock.Eq("avatars"), gomock.Eq(uploadKey)).Return(nil, minio.ErrorResponse{Code: "NoSuchKey"})
+
+		err := authUC.ProcessAvatar(context.Background(), userUID, image)
+		require.ErrorIs(t, err, asynq.SkipRetry)
+	})
+}
+
+func encodeTestPNG(t *testing.T, width int, height int) []byte {
+	t.Helper()
+
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+
+	buf := bytes.NewBuffer(nil)
+	require.NoError(t, png.Encode(buf, img))
+
+	return buf.Bytes()
+}
@@ -0,0 +1,16 @@
+//go:generate mockgen -source minio_repo.go -destination mock/minio_repo_mock.go -package mock
+package blog
+
+import (
+	"context"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+
+	"github.com/minio/minio-go/v7"
+)
+
+type MinioRepository interface {
+	PutObject(ctx context.Context, input models.UploadInput) (*minio.UploadInfo, error)
+	GetObject(ctx context.Context, bucket string, fileName string) (*minio.Object, error)
+	ReadObject(ctx context.Context, bucket string, fileName string) ([]byte, error)
+	RemoveObject(ctx context.Context, bucket string, fileName string) error
+}
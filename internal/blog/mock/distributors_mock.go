@@ -0,0 +1,75 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: distributors.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	asynq "github.com/hibiken/asynq"
+	asynq0 "github.com/scul0405/blog-clean-architecture-rest-api/internal/blog/transport/asynq"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBlogTaskDistributor is a mock of BlogTaskDistributor interface.
+type MockBlogTaskDistributor struct {
+	ctrl     *gomock.Controller
+	recorder *MockBlogTaskDistributorMockRecorder
+}
+
+// MockBlogTaskDistributorMockRecorder is the mock recorder for MockBlogTaskDistributor.
+type MockBlogTaskDistributorMockRecorder struct {
+	mock *MockBlogTaskDistributor
+}
+
+// NewMockBlogTaskDistributor creates a new mock instance.
+func NewMockBlogTaskDistributor(ctrl *gomock.Controller) *MockBlogTaskDistributor {
+	mock := &MockBlogTaskDistributor{ctrl: ctrl}
+	mock.recorder = &MockBlogTaskDistributorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBlogTaskDistributor) EXPECT() *MockBlogTaskDistributorMockRecorder {
+	return m.recorder
+}
+
+// DistributeTaskProcessCover mocks base method.
+func (m *MockBlogTaskDistributor) DistributeTaskProcessCover(ctx context.Context, payload *asynq0.ProcessCoverPayload, opts ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, payload}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskProcessCover", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskProcessCover indicates an expected call of DistributeTaskProcessCover.
+func (mr *MockBlogTaskDistributorMockRecorder) DistributeTaskProcessCover(ctx, payload interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, payload}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskProcessCover", reflect.TypeOf((*MockBlogTaskDistributor)(nil).DistributeTaskProcessCover), varargs...)
+}
+
+// DistributeTaskPublishBlog mocks base method.
+func (m *MockBlogTaskDistributor) DistributeTaskPublishBlog(ctx context.Context, payload *asynq0.PublishBlogPayload, opts ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, payload}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskPublishBlog", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskPublishBlog indicates an expected call of DistributeTaskPublishBlog.
+func (mr *MockBlogTaskDistributorMockRecorder) DistributeTaskPublishBlog(ctx, payload interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, payload}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskPublishBlog", reflect.TypeOf((*MockBlogTaskDistributor)(nil).DistributeTaskPublishBlog), varargs...)
+}
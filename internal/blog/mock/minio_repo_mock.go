@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: minio_repo.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	minio "github.com/minio/minio-go/v7"
+	models "github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMinioRepository is a mock of MinioRepository interface.
+type MockMinioRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMinioRepositoryMockRecorder
+}
+
+// MockMinioRepositoryMockRecorder is the mock recorder for MockMinioRepository.
+type MockMinioRepositoryMockRecorder struct {
+	mock *MockMinioRepository
+}
+
+// NewMockMinioRepository creates a new mock instance.
+func NewMockMinioRepository(ctrl *gomock.Controller) *MockMinioRepository {
+	mock := &MockMinioRepository{ctrl: ctrl}
+	mock.recorder = &MockMinioRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMinioRepository) EXPECT() *MockMinioRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetObject mocks base method.
+func (m *MockMinioRepository) GetObject(ctx context.Context, bucket, fileName string) (*minio.Object, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetObject", ctx, bucket, fileName)
+	ret0, _ := ret[0].(*minio.Object)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetObject indicates an expected call of GetObject.
+func (mr *MockMinioRepositoryMockRecorder) GetObject(ctx, bucket, fileName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObject", reflect.TypeOf((*MockMinioRepository)(nil).GetObject), ctx, bucket, fileName)
+}
+
+// PutObject mocks base method.
+func (m *MockMinioRepository) PutObject(ctx context.Context, input models.UploadInput) (*minio.UploadInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutObject", ctx, input)
+	ret0, _ := ret[0].(*minio.UploadInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutObject indicates an expected call of PutObject.
+func (mr *MockMinioRepositoryMockRecorder) PutObject(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutObject", reflect.TypeOf((*MockMinioRepository)(nil).PutObject), ctx, input)
+}
+
+// ReadObject mocks base method.
+func (m *MockMinioRepository) ReadObject(ctx context.Context, bucket, fileName string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadObject", ctx, bucket, fileName)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadObject indicates an expected call of ReadObject.
+func (mr *MockMinioRepositoryMockRecorder) ReadObject(ctx, bucket, fileName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadObject", reflect.TypeOf((*MockMinioRepository)(nil).ReadObject), ctx, bucket, fileName)
+}
+
+// RemoveObject mocks base method.
+func (m *MockMinioRepository) RemoveObject(ctx context.Context, bucket, fileName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveObject", ctx, bucket, fileName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveObject indicates an expected call of RemoveObject.
+func (mr *MockMinioRepositoryMockRecorder) RemoveObject(ctx, bucket, fileName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveObject", reflect.TypeOf((*MockMinioRepository)(nil).RemoveObject), ctx, bucket, fileName)
+}
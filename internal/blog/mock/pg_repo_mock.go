@@ -7,6 +7,7 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	uuid "github.com/google/uuid"
 	models "github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
@@ -53,17 +54,17 @@ func (mr *MockRepositoryMockRecorder) Create(ctx, blog interface{}) *gomock.Call
 }
 
 // Delete mocks base method.
-func (m *MockRepository) Delete(ctx context.Context, id uuid.UUID) error {
+func (m *MockRepository) Delete(ctx context.Context, id uuid.UUID, version int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret := m.ctrl.Call(m, "Delete", ctx, id, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) Delete(ctx, id, version interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), ctx, id, version)
 }
 
 // GetByID mocks base method.
@@ -81,32 +82,182 @@ func (mr *MockRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockRepository)(nil).GetByID), ctx, id)
 }
 
+// GetIDBySlug mocks base method.
+func (m *MockRepository) GetIDBySlug(ctx context.Context, slug string) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIDBySlug", ctx, slug)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIDBySlug indicates an expected call of GetIDBySlug.
+func (mr *MockRepositoryMockRecorder) GetIDBySlug(ctx, slug interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIDBySlug", reflect.TypeOf((*MockRepository)(nil).GetIDBySlug), ctx, slug)
+}
+
+// GetRevision mocks base method.
+func (m *MockRepository) GetRevision(ctx context.Context, blogID uuid.UUID, revision int) (*models.BlogRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevision", ctx, blogID, revision)
+	ret0, _ := ret[0].(*models.BlogRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevision indicates an expected call of GetRevision.
+func (mr *MockRepositoryMockRecorder) GetRevision(ctx, blogID, revision interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevision", reflect.TypeOf((*MockRepository)(nil).GetRevision), ctx, blogID, revision)
+}
+
 // List mocks base method.
-func (m *MockRepository) List(ctx context.Context, pq *utils.PaginationQuery) (*models.BlogsList, error) {
+func (m *MockRepository) List(ctx context.Context, filter *models.BlogFilter, pq *utils.PaginationQuery) (*models.BlogsList, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List", ctx, pq)
+	ret := m.ctrl.Call(m, "List", ctx, filter, pq)
 	ret0, _ := ret[0].(*models.BlogsList)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // List indicates an expected call of List.
-func (mr *MockRepositoryMockRecorder) List(ctx, pq interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) List(ctx, filter, pq interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRepository)(nil).List), ctx, pq)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRepository)(nil).List), ctx, filter, pq)
+}
+
+// ListFeed mocks base method.
+func (m *MockRepository) ListFeed(ctx context.Context, followerID uuid.UUID, pq *utils.PaginationQuery) (*models.BlogsList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFeed", ctx, followerID, pq)
+	ret0, _ := ret[0].(*models.BlogsList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFeed indicates an expected call of ListFeed.
+func (mr *MockRepositoryMockRecorder) ListFeed(ctx, followerID, pq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFeed", reflect.TypeOf((*MockRepository)(nil).ListFeed), ctx, followerID, pq)
+}
+
+// ListPublishedByIDs mocks base method.
+func (m *MockRepository) ListPublishedByIDs(ctx context.Context, ids []uuid.UUID) ([]*models.BlogBase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPublishedByIDs", ctx, ids)
+	ret0, _ := ret[0].([]*models.BlogBase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPublishedByIDs indicates an expected call of ListPublishedByIDs.
+func (mr *MockRepositoryMockRecorder) ListPublishedByIDs(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPublishedByIDs", reflect.TypeOf((*MockRepository)(nil).ListPublishedByIDs), ctx, ids)
+}
+
+// ListRevisions mocks base method.
+func (m *MockRepository) ListRevisions(ctx context.Context, blogID uuid.UUID, pq *utils.PaginationQuery) (*models.BlogRevisionsList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRevisions", ctx, blogID, pq)
+	ret0, _ := ret[0].(*models.BlogRevisionsList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRevisions indicates an expected call of ListRevisions.
+func (mr *MockRepositoryMockRecorder) ListRevisions(ctx, blogID, pq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRevisions", reflect.TypeOf((*MockRepository)(nil).ListRevisions), ctx, blogID, pq)
+}
+
+// PublishScheduled mocks base method.
+func (m *MockRepository) PublishScheduled(ctx context.Context, id uuid.UUID, publishAt time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishScheduled", ctx, id, publishAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PublishScheduled indicates an expected call of PublishScheduled.
+func (mr *MockRepositoryMockRecorder) PublishScheduled(ctx, id, publishAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishScheduled", reflect.TypeOf((*MockRepository)(nil).PublishScheduled), ctx, id, publishAt)
+}
+
+// Restore mocks base method.
+func (m *MockRepository) Restore(ctx context.Context, blogID uuid.UUID, revision int, editorID uuid.UUID) (*models.BlogBase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, blogID, revision, editorID)
+	ret0, _ := ret[0].(*models.BlogBase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockRepositoryMockRecorder) Restore(ctx, blogID, revision, editorID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockRepository)(nil).Restore), ctx, blogID, revision, editorID)
+}
+
+// Search mocks base method.
+func (m *MockRepository) Search(ctx context.Context, query *models.BlogSearchQuery, pq *utils.PaginationQuery) (*models.BlogsList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, query, pq)
+	ret0, _ := ret[0].(*models.BlogsList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockRepositoryMockRecorder) Search(ctx, query, pq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockRepository)(nil).Search), ctx, query, pq)
+}
+
+// SetHeld mocks base method.
+func (m *MockRepository) SetHeld(ctx context.Context, id uuid.UUID, held bool) (*models.BlogBase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHeld", ctx, id, held)
+	ret0, _ := ret[0].(*models.BlogBase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetHeld indicates an expected call of SetHeld.
+func (mr *MockRepositoryMockRecorder) SetHeld(ctx, id, held interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeld", reflect.TypeOf((*MockRepository)(nil).SetHeld), ctx, id, held)
 }
 
 // Update mocks base method.
-func (m *MockRepository) Update(ctx context.Context, blog *models.BlogBase) (*models.BlogBase, error) {
+func (m *MockRepository) Update(ctx context.Context, blog *models.BlogBase, editorID uuid.UUID) (*models.BlogBase, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", ctx, blog)
+	ret := m.ctrl.Call(m, "Update", ctx, blog, editorID)
 	ret0, _ := ret[0].(*models.BlogBase)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockRepositoryMockRecorder) Update(ctx, blog interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) Update(ctx, blog, editorID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRepository)(nil).Update), ctx, blog, editorID)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status string, publishedAt *time.Time) (*models.BlogBase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, id, status, publishedAt)
+	ret0, _ := ret[0].(*models.BlogBase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockRepositoryMockRecorder) UpdateStatus(ctx, id, status, publishedAt interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRepository)(nil).Update), ctx, blog)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockRepository)(nil).UpdateStatus), ctx, id, status, publishedAt)
 }
@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: redis_repo.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	models "github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRedisRepository is a mock of RedisRepository interface.
+type MockRedisRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRedisRepositoryMockRecorder
+}
+
+// MockRedisRepositoryMockRecorder is the mock recorder for MockRedisRepository.
+type MockRedisRepositoryMockRecorder struct {
+	mock *MockRedisRepository
+}
+
+// NewMockRedisRepository creates a new mock instance.
+func NewMockRedisRepository(ctrl *gomock.Controller) *MockRedisRepository {
+	mock := &MockRedisRepository{ctrl: ctrl}
+	mock.recorder = &MockRedisRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRedisRepository) EXPECT() *MockRedisRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteBlogCtx mocks base method.
+func (m *MockRedisRepository) DeleteBlogCtx(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBlogCtx", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBlogCtx indicates an expected call of DeleteBlogCtx.
+func (mr *MockRedisRepositoryMockRecorder) DeleteBlogCtx(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBlogCtx", reflect.TypeOf((*MockRedisRepository)(nil).DeleteBlogCtx), ctx, key)
+}
+
+// GetBlogByIDCtx mocks base method.
+func (m *MockRedisRepository) GetBlogByIDCtx(ctx context.Context, key string) (*models.BlogBase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlogByIDCtx", ctx, key)
+	ret0, _ := ret[0].(*models.BlogBase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlogByIDCtx indicates an expected call of GetBlogByIDCtx.
+func (mr *MockRedisRepositoryMockRecorder) GetBlogByIDCtx(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlogByIDCtx", reflect.TypeOf((*MockRedisRepository)(nil).GetBlogByIDCtx), ctx, key)
+}
+
+// GetBlogIDBySlugCtx mocks base method.
+func (m *MockRedisRepository) GetBlogIDBySlugCtx(ctx context.Context, key string) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlogIDBySlugCtx", ctx, key)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlogIDBySlugCtx indicates an expected call of GetBlogIDBySlugCtx.
+func (mr *MockRedisRepositoryMockRecorder) GetBlogIDBySlugCtx(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlogIDBySlugCtx", reflect.TypeOf((*MockRedisRepository)(nil).GetBlogIDBySlugCtx), ctx, key)
+}
+
+// SetBlogCtx mocks base method.
+func (m *MockRedisRepository) SetBlogCtx(ctx context.Context, key string, seconds int, blog *models.BlogBase) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBlogCtx", ctx, key, seconds, blog)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetBlogCtx indicates an expected call of SetBlogCtx.
+func (mr *MockRedisRepositoryMockRecorder) SetBlogCtx(ctx, key, seconds, blog interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBlogCtx", reflect.TypeOf((*MockRedisRepository)(nil).SetBlogCtx), ctx, key, seconds, blog)
+}
+
+// SetBlogSlugCtx mocks base method.
+func (m *MockRedisRepository) SetBlogSlugCtx(ctx context.Context, key string, seconds int, blogID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBlogSlugCtx", ctx, key, seconds, blogID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetBlogSlugCtx indicates an expected call of SetBlogSlugCtx.
+func (mr *MockRedisRepositoryMockRecorder) SetBlogSlugCtx(ctx, key, seconds, blogID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBlogSlugCtx", reflect.TypeOf((*MockRedisRepository)(nil).SetBlogSlugCtx), ctx, key, seconds, blogID)
+}
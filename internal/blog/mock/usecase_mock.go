@@ -7,6 +7,7 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	uuid "github.com/google/uuid"
 	models "github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
@@ -37,6 +38,21 @@ func (m *MockUseCase) EXPECT() *MockUseCaseMockRecorder {
 	return m.recorder
 }
 
+// Archive mocks base method.
+func (m *MockUseCase) Archive(ctx context.Context, id uuid.UUID) (*models.BlogBase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Archive", ctx, id)
+	ret0, _ := ret[0].(*models.BlogBase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Archive indicates an expected call of Archive.
+func (mr *MockUseCaseMockRecorder) Archive(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Archive", reflect.TypeOf((*MockUseCase)(nil).Archive), ctx, id)
+}
+
 // Create mocks base method.
 func (m *MockUseCase) Create(ctx context.Context, blog *models.Blog) (*models.BlogBase, error) {
 	m.ctrl.T.Helper()
@@ -53,17 +69,32 @@ func (mr *MockUseCaseMockRecorder) Create(ctx, blog interface{}) *gomock.Call {
 }
 
 // Delete mocks base method.
-func (m *MockUseCase) Delete(ctx context.Context, id uuid.UUID) error {
+func (m *MockUseCase) Delete(ctx context.Context, id uuid.UUID, version int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret := m.ctrl.Call(m, "Delete", ctx, id, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockUseCaseMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+func (mr *MockUseCaseMockRecorder) Delete(ctx, id, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUseCase)(nil).Delete), ctx, id, version)
+}
+
+// DiffRevisions mocks base method.
+func (m *MockUseCase) DiffRevisions(ctx context.Context, id uuid.UUID, from, to int) (*models.BlogRevisionDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffRevisions", ctx, id, from, to)
+	ret0, _ := ret[0].(*models.BlogRevisionDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiffRevisions indicates an expected call of DiffRevisions.
+func (mr *MockUseCaseMockRecorder) DiffRevisions(ctx, id, from, to interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUseCase)(nil).Delete), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffRevisions", reflect.TypeOf((*MockUseCase)(nil).DiffRevisions), ctx, id, from, to)
 }
 
 // GetByID mocks base method.
@@ -81,19 +112,152 @@ func (mr *MockUseCaseMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockUseCase)(nil).GetByID), ctx, id)
 }
 
+// GetBySlug mocks base method.
+func (m *MockUseCase) GetBySlug(ctx context.Context, slug string) (*models.BlogBase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBySlug", ctx, slug)
+	ret0, _ := ret[0].(*models.BlogBase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBySlug indicates an expected call of GetBySlug.
+func (mr *MockUseCaseMockRecorder) GetBySlug(ctx, slug interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBySlug", reflect.TypeOf((*MockUseCase)(nil).GetBySlug), ctx, slug)
+}
+
+// GetRevision mocks base method.
+func (m *MockUseCase) GetRevision(ctx context.Context, id uuid.UUID, revision int) (*models.BlogRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevision", ctx, id, revision)
+	ret0, _ := ret[0].(*models.BlogRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevision indicates an expected call of GetRevision.
+func (mr *MockUseCaseMockRecorder) GetRevision(ctx, id, revision interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevision", reflect.TypeOf((*MockUseCase)(nil).GetRevision), ctx, id, revision)
+}
+
 // List mocks base method.
-func (m *MockUseCase) List(ctx context.Context, pq *utils.PaginationQuery) (*models.BlogsList, error) {
+func (m *MockUseCase) List(ctx context.Context, filter *models.BlogFilter, pq *utils.PaginationQuery) (*models.BlogsList, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List", ctx, pq)
+	ret := m.ctrl.Call(m, "List", ctx, filter, pq)
 	ret0, _ := ret[0].(*models.BlogsList)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // List indicates an expected call of List.
-func (mr *MockUseCaseMockRecorder) List(ctx, pq interface{}) *gomock.Call {
+func (mr *MockUseCaseMockRecorder) List(ctx, filter, pq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockUseCase)(nil).List), ctx, filter, pq)
+}
+
+// ListRevisions mocks base method.
+func (m *MockUseCase) ListRevisions(ctx context.Context, id uuid.UUID, pq *utils.PaginationQuery) (*models.BlogRevisionsList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRevisions", ctx, id, pq)
+	ret0, _ := ret[0].(*models.BlogRevisionsList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRevisions indicates an expected call of ListRevisions.
+func (mr *MockUseCaseMockRecorder) ListRevisions(ctx, id, pq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRevisions", reflect.TypeOf((*MockUseCase)(nil).ListRevisions), ctx, id, pq)
+}
+
+// ProcessCover mocks base method.
+func (m *MockUseCase) ProcessCover(ctx context.Context, blogID uuid.UUID, image *models.ImageInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessCover", ctx, blogID, image)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProcessCover indicates an expected call of ProcessCover.
+func (mr *MockUseCaseMockRecorder) ProcessCover(ctx, blogID, image interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessCover", reflect.TypeOf((*MockUseCase)(nil).ProcessCover), ctx, blogID, image)
+}
+
+// ProcessPublish mocks base method.
+func (m *MockUseCase) ProcessPublish(ctx context.Context, id uuid.UUID, publishAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessPublish", ctx, id, publishAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProcessPublish indicates an expected call of ProcessPublish.
+func (mr *MockUseCaseMockRecorder) ProcessPublish(ctx, id, publishAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessPublish", reflect.TypeOf((*MockUseCase)(nil).ProcessPublish), ctx, id, publishAt)
+}
+
+// Publish mocks base method.
+func (m *MockUseCase) Publish(ctx context.Context, id uuid.UUID, publish *models.BlogPublish) (*models.BlogBase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", ctx, id, publish)
+	ret0, _ := ret[0].(*models.BlogBase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockUseCaseMockRecorder) Publish(ctx, id, publish interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockUseCase)(nil).List), ctx, pq)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockUseCase)(nil).Publish), ctx, id, publish)
+}
+
+// Release mocks base method.
+func (m *MockUseCase) Release(ctx context.Context, id uuid.UUID) (*models.BlogBase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, id)
+	ret0, _ := ret[0].(*models.BlogBase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockUseCaseMockRecorder) Release(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockUseCase)(nil).Release), ctx, id)
+}
+
+// RestoreRevision mocks base method.
+func (m *MockUseCase) RestoreRevision(ctx context.Context, id uuid.UUID, revision int) (*models.BlogBase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreRevision", ctx, id, revision)
+	ret0, _ := ret[0].(*models.BlogBase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreRevision indicates an expected call of RestoreRevision.
+func (mr *MockUseCaseMockRecorder) RestoreRevision(ctx, id, revision interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreRevision", reflect.TypeOf((*MockUseCase)(nil).RestoreRevision), ctx, id, revision)
+}
+
+// Search mocks base method.
+func (m *MockUseCase) Search(ctx context.Context, query *models.BlogSearchQuery, pq *utils.PaginationQuery) (*models.BlogsList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, query, pq)
+	ret0, _ := ret[0].(*models.BlogsList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockUseCaseMockRecorder) Search(ctx, query, pq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockUseCase)(nil).Search), ctx, query, pq)
 }
 
 // Update mocks base method.
@@ -110,3 +274,18 @@ func (mr *MockUseCaseMockRecorder) Update(ctx, blog interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUseCase)(nil).Update), ctx, blog)
 }
+
+// UploadCover mocks base method.
+func (m *MockUseCase) UploadCover(ctx context.Context, blogID uuid.UUID, image *models.ImageInput) (models.ImageVariants, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadCover", ctx, blogID, image)
+	ret0, _ := ret[0].(models.ImageVariants)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadCover indicates an expected call of UploadCover.
+func (mr *MockUseCaseMockRecorder) UploadCover(ctx, blogID, image interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadCover", reflect.TypeOf((*MockUseCase)(nil).UploadCover), ctx, blogID, image)
+}
@@ -6,12 +6,23 @@ import (
 	"github.com/google/uuid"
 	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
 	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/utils"
+	"time"
 )
 
 type Repository interface {
 	Create(ctx context.Context, blog *models.Blog) (*models.BlogBase, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*models.BlogBase, error)
-	Update(ctx context.Context, blog *models.BlogBase) (*models.BlogBase, error)
-	Delete(ctx context.Context, id uuid.UUID) error
-	List(ctx context.Context, pq *utils.PaginationQuery) (*models.BlogsList, error)
+	GetIDBySlug(ctx context.Context, slug string) (uuid.UUID, error)
+	Update(ctx context.Context, blog *models.BlogBase, editorID uuid.UUID) (*models.BlogBase, error)
+	Restore(ctx context.Context, blogID uuid.UUID, revision int, editorID uuid.UUID) (*models.BlogBase, error)
+	GetRevision(ctx context.Context, blogID uuid.UUID, revision int) (*models.BlogRevision, error)
+	ListRevisions(ctx context.Context, blogID uuid.UUID, pq *utils.PaginationQuery) (*models.BlogRevisionsList, error)
+	UpdateStatus(ctx context.Context, id uuid.UUID, status string, publishedAt *time.Time) (*models.BlogBase, error)
+	SetHeld(ctx context.Context, id uuid.UUID, held bool) (*models.BlogBase, error)
+	PublishScheduled(ctx context.Context, id uuid.UUID, publishAt time.Time) (bool, error)
+	Delete(ctx context.Context, id uuid.UUID, version int) error
+	List(ctx context.Context, filter *models.BlogFilter, pq *utils.PaginationQuery) (*models.BlogsList, error)
+	Search(ctx context.Context, query *models.BlogSearchQuery, pq *utils.PaginationQuery) (*models.BlogsList, error)
+	ListFeed(ctx context.Context, followerID uuid.UUID, pq *utils.PaginationQuery) (*models.BlogsList, error)
+	ListPublishedByIDs(ctx context.Context, ids []uuid.UUID) ([]*models.BlogBase, error)
 }
@@ -1,7 +1,9 @@
+//go:generate mockgen -source redis_repo.go -destination mock/redis_repo_mock.go -package mock
 package blog
 
 import (
 	"context"
+	"github.com/google/uuid"
 	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
 )
 
@@ -9,4 +11,6 @@ type RedisRepository interface {
 	GetBlogByIDCtx(ctx context.Context, key string) (*models.BlogBase, error)
 	SetBlogCtx(ctx context.Context, key string, seconds int, blog *models.BlogBase) error
 	DeleteBlogCtx(ctx context.Context, key string) error
+	GetBlogIDBySlugCtx(ctx context.Context, key string) (uuid.UUID, error)
+	SetBlogSlugCtx(ctx context.Context, key string, seconds int, blogID uuid.UUID) error
 }
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"github.com/minio/minio-go/v7"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/blog"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	"io"
+)
+
+type blogMinioRepo struct {
+	client *minio.Client
+}
+
+func NewBlogMinioRepository(client *minio.Client) blog.MinioRepository {
+	return &blogMinioRepo{client: client}
+}
+
+// PutObject upload file to Minio, input name is used as object key
+func (r *blogMinioRepo) PutObject(ctx context.Context, input models.UploadInput) (*minio.UploadInfo, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "blogMinioRepo.PutObject")
+	defer span.Finish()
+
+	options := minio.PutObjectOptions{
+		ContentType:  input.ContentType,
+		UserMetadata: map[string]string{"x-amz-acl": "public-read"},
+	}
+
+	uploadInfo, err := r.client.PutObject(ctx, input.BucketName, input.Name, input.File, input.Size, options)
+	if err != nil {
+		return nil, errors.Wrap(err, "blogMinioRepo.FileUpload.PutObject")
+	}
+
+	return &uploadInfo, err
+}
+
+// GetObject download file from Minio
+func (r *blogMinioRepo) GetObject(ctx context.Context, bucket string, fileName string) (*minio.Object, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "blogMinioRepo.GetObject")
+	defer span.Finish()
+
+	object, err := r.client.GetObject(ctx, bucket, fileName, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "blogMinioRepo.FileDownload.GetObject")
+	}
+	return object, nil
+}
+
+// ReadObject download whole file from Minio, it is meant for small files like uploaded images
+func (r *blogMinioRepo) ReadObject(ctx context.Context, bucket string, fileName string) ([]byte, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "blogMinioRepo.ReadObject")
+	defer span.Finish()
+
+	object, err := r.client.GetObject(ctx, bucket, fileName, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "blogMinioRepo.ReadObject.GetObject")
+	}
+	defer object.Close()
+
+	data, err := io.ReadAll(object)
+	if err != nil {
+		return nil, errors.Wrap(err, "blogMinioRepo.ReadObject.ReadAll")
+	}
+	return data, nil
+}
+
+// RemoveObject delete file from Minio
+func (r *blogMinioRepo) RemoveObject(ctx context.Context, bucket string, fileName string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "blogMinioRepo.RemoveObject")
+	defer span.Finish()
+
+	if err := r.client.RemoveObject(ctx, bucket, fileName, minio.RemoveObjectOptions{}); err != nil {
+		return errors.Wrap(err, "blogMinioRepo.RemoveObject")
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	"github.com/opentracing/opentracing-go"
@@ -10,8 +11,13 @@ import (
 	"github.com/scul0405/blog-clean-architecture-rest-api/internal/blog"
 	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
 	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/utils"
+	"strings"
+	"time"
 )
 
+// maxSlugAttempts limit picking slug again when it was taken concurrently
+const maxSlugAttempts = 3
+
 type blogRepo struct {
 	db *sqlx.DB
 }
@@ -20,15 +26,42 @@ func NewBlogRepository(db *sqlx.DB) blog.Repository {
 	return &blogRepo{db: db}
 }
 
+// Create blog with its first revision
 func (r *blogRepo) Create(ctx context.Context, blog *models.Blog) (*models.BlogBase, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "blogRepo.Create")
 	defer span.Finish()
 
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "blogRepo.Create.BeginTxx")
+	}
+	defer tx.Rollback()
+
 	var b models.BlogBase
-	if err := r.db.QueryRowxContext(ctx, createBlogQuery, &blog.AuthorID, &blog.Title, &blog.Content, &blog.ImageURL, &blog.Category).StructScan(&b); err != nil {
+	if err = tx.QueryRowxContext(ctx, createBlogQuery, &blog.AuthorID, &blog.Title, &blog.Content, &blog.ImageURL, &blog.Category, &blog.Status, &blog.ContentFormat, &blog.Held).StructScan(&b); err != nil {
 		return nil, errors.Wrap(err, "blogRepo.Create.StructScan")
 	}
 
+	if err = r.assignSlug(ctx, tx, &b); err != nil {
+		return nil, err
+	}
+
+	if err = r.setTags(ctx, tx, &b, blog.Tags); err != nil {
+		return nil, err
+	}
+
+	if err = r.renderContent(ctx, tx, &b); err != nil {
+		return nil, err
+	}
+
+	if _, err = tx.ExecContext(ctx, createBlogRevisionQuery, b.BlogID, blog.AuthorID); err != nil {
+		return nil, errors.Wrap(err, "blogRepo.Create.ExecContext.revision")
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "blogRepo.Create.Commit")
+	}
+
 	return &b, nil
 }
 
@@ -44,23 +77,264 @@ func (r *blogRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.BlogBase,
 	return &b, nil
 }
 
-func (r *blogRepo) Update(ctx context.Context, blog *models.BlogBase) (*models.BlogBase, error) {
+// Update blog and write its new state as revision of editor
+func (r *blogRepo) Update(ctx context.Context, blog *models.BlogBase, editorID uuid.UUID) (*models.BlogBase, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "blogRepo.Update")
 	defer span.Finish()
 
+	return r.changeWithRevision(ctx, blog.BlogID, editorID, blog.Tags, updateBlogQuery, &blog.Title, &blog.Content, &blog.ImageURL, &blog.Category, &blog.BlogID, &blog.Version, &blog.ContentFormat)
+}
+
+// Restore set blog to state of revision, restored state is written as new revision
+func (r *blogRepo) Restore(ctx context.Context, blogID uuid.UUID, revision int, editorID uuid.UUID) (*models.BlogBase, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "blogRepo.Restore")
+	defer span.Finish()
+
+	return r.changeWithRevision(ctx, blogID, editorID, nil, restoreBlogQuery, blogID, revision)
+}
+
+func (r *blogRepo) GetRevision(ctx context.Context, blogID uuid.UUID, revision int) (*models.BlogRevision, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "blogRepo.GetRevision")
+	defer span.Finish()
+
+	var rev models.BlogRevision
+	if err := r.db.QueryRowxContext(ctx, getBlogRevisionQuery, blogID, revision).StructScan(&rev); err != nil {
+		return nil, errors.Wrap(err, "blogRepo.GetRevision.StructScan")
+	}
+
+	return &rev, nil
+}
+
+// ListRevisions list revisions of blog without content, newest first
+func (r *blogRepo) ListRevisions(ctx context.Context, blogID uuid.UUID, pq *utils.PaginationQuery) (*models.BlogRevisionsList, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "blogRepo.ListRevisions")
+	defer span.Finish()
+
+	var totalCount int
+	if err := r.db.GetContext(ctx, &totalCount, getBlogRevisionsCountQuery, blogID); err != nil {
+		return nil, errors.Wrap(err, "blogRepo.ListRevisions.GetContext.totalCount")
+	}
+
+	var revisions = make([]*models.BlogRevision, 0, pq.GetSize())
+	if totalCount > 0 {
+		if err := r.db.SelectContext(ctx, &revisions, listBlogRevisionsQuery, blogID, pq.GetOffset(), pq.GetLimit()); err != nil {
+			return nil, errors.Wrap(err, "blogRepo.ListRevisions.SelectContext")
+		}
+	}
+
+	return &models.BlogRevisionsList{
+		TotalCount: totalCount,
+		TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+		Page:       pq.GetPage(),
+		Size:       pq.GetSize(),
+		HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+		Revisions:  revisions,
+	}, nil
+}
+
+// changeWithRevision run query which returns changed blog and write revision in same transaction, nil tags are left unchanged.
+// Slug is assigned again only when title changed
+func (r *blogRepo) changeWithRevision(ctx context.Context, blogID uuid.UUID, editorID uuid.UUID, tags models.Tags, query string, args ...interface{}) (*models.BlogBase, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "blogRepo.changeWithRevision.BeginTxx")
+	}
+	defer tx.Rollback()
+
+	var previousTitle string
+	if err = tx.GetContext(ctx, &previousTitle, getBlogTitleForUpdateQuery, blogID); err != nil {
+		return nil, errors.Wrap(err, "blogRepo.changeWithRevision.GetContext.title")
+	}
+
 	var b models.BlogBase
-	if err := r.db.QueryRowxContext(ctx, updateBlogQuery, &blog.Title, &blog.Content, &blog.ImageURL, &blog.Category, &blog.BlogID).StructScan(&b); err != nil {
-		return nil, errors.Wrap(err, "blogRepo.Update.StructScan")
+	if err = tx.QueryRowxContext(ctx, query, args...).StructScan(&b); err != nil {
+		return nil, errors.Wrap(err, "blogRepo.changeWithRevision.StructScan")
+	}
+
+	if b.Title != previousTitle {
+		if err = r.assignSlug(ctx, tx, &b); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = r.setTags(ctx, tx, &b, tags); err != nil {
+		return nil, err
+	}
+
+	if err = r.renderContent(ctx, tx, &b); err != nil {
+		return nil, err
+	}
+
+	if _, err = tx.ExecContext(ctx, createBlogRevisionQuery, b.BlogID, editorID); err != nil {
+		return nil, errors.Wrap(err, "blogRepo.changeWithRevision.ExecContext.revision")
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "blogRepo.changeWithRevision.Commit")
 	}
 
 	return &b, nil
 }
 
-func (r *blogRepo) Delete(ctx context.Context, id uuid.UUID) error {
+// assignSlug give blog slug of its title unless current slug already has same base.
+// Slug taken by other blog gets first free collision suffix, slug taken concurrently is picked again
+func (r *blogRepo) assignSlug(ctx context.Context, tx *sqlx.Tx, b *models.BlogBase) error {
+	base := utils.Slugify(b.Title)
+	if base == "" {
+		base = strings.Split(b.BlogID.String(), "-")[0]
+	}
+	if b.Slug != "" && utils.SlugHasBase(b.Slug, base) {
+		return nil
+	}
+
+	for attempt := 0; attempt < maxSlugAttempts; attempt++ {
+		var taken []string
+		if err := tx.SelectContext(ctx, &taken, listTakenBlogSlugsQuery, base, b.BlogID); err != nil {
+			return errors.Wrap(err, "blogRepo.assignSlug.SelectContext")
+		}
+
+		slug := freeSlug(base, taken)
+		result, err := tx.ExecContext(ctx, createBlogSlugQuery, slug, b.BlogID)
+		if err != nil {
+			return errors.Wrap(err, "blogRepo.assignSlug.ExecContext.slug")
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return errors.Wrap(err, "blogRepo.assignSlug.RowsAffected")
+		}
+		if rowsAffected == 0 {
+			continue
+		}
+
+		if _, err = tx.ExecContext(ctx, updateBlogSlugQuery, slug, b.BlogID); err != nil {
+			return errors.Wrap(err, "blogRepo.assignSlug.ExecContext.blog")
+		}
+		b.Slug = slug
+
+		return nil
+	}
+
+	return errors.Errorf("blogRepo.assignSlug: no free slug for %s after %d attempts", base, maxSlugAttempts)
+}
+
+// setTags replace tags of blog unless tags are nil, missing tags are created. Blog gets its tags as they are stored
+func (r *blogRepo) setTags(ctx context.Context, tx *sqlx.Tx, b *models.BlogBase, tags models.Tags) error {
+	if tags != nil {
+		names := strings.Join(tags, ",")
+		if _, err := tx.ExecContext(ctx, createTagsQuery, names); err != nil {
+			return errors.Wrap(err, "blogRepo.setTags.ExecContext.tags")
+		}
+		if _, err := tx.ExecContext(ctx, deleteBlogTagsQuery, b.BlogID, names); err != nil {
+			return errors.Wrap(err, "blogRepo.setTags.ExecContext.delete")
+		}
+		if _, err := tx.ExecContext(ctx, createBlogTagsQuery, b.BlogID, names); err != nil {
+			return errors.Wrap(err, "blogRepo.setTags.ExecContext.create")
+		}
+	}
+
+	if err := tx.GetContext(ctx, &b.Tags, getBlogTagsQuery, b.BlogID); err != nil {
+		return errors.Wrap(err, "blogRepo.setTags.GetContext")
+	}
+
+	return nil
+}
+
+// renderContent store content of blog rendered in its format next to it
+func (r *blogRepo) renderContent(ctx context.Context, tx *sqlx.Tx, b *models.BlogBase) error {
+	rendered, err := utils.RenderContent(b.Content, b.ContentFormat == models.BlogContentMarkdown)
+	if err != nil {
+		return errors.Wrap(err, "blogRepo.renderContent.RenderContent")
+	}
+
+	if _, err = tx.ExecContext(ctx, updateBlogRenderedQuery, rendered.HTML, rendered.Excerpt, rendered.WordCount, rendered.ReadingTime, b.BlogID); err != nil {
+		return errors.Wrap(err, "blogRepo.renderContent.ExecContext")
+	}
+	b.ContentHTML = &rendered.HTML
+	b.Excerpt = rendered.Excerpt
+	b.WordCount = rendered.WordCount
+	b.ReadingTime = rendered.ReadingTime
+
+	return nil
+}
+
+// freeSlug return first candidate of base which is not taken
+func freeSlug(base string, taken []string) string {
+	takenSet := make(map[string]struct{}, len(taken))
+	for _, slug := range taken {
+		takenSet[slug] = struct{}{}
+	}
+
+	for n := 1; ; n++ {
+		if _, ok := takenSet[utils.SlugCandidate(base, n)]; !ok {
+			return utils.SlugCandidate(base, n)
+		}
+	}
+}
+
+// GetIDBySlug find blog by its current or old slug
+func (r *blogRepo) GetIDBySlug(ctx context.Context, slug string) (uuid.UUID, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "blogRepo.GetIDBySlug")
+	defer span.Finish()
+
+	var blogID uuid.UUID
+	if err := r.db.GetContext(ctx, &blogID, getBlogIDBySlugQuery, slug); err != nil {
+		return uuid.Nil, errors.Wrap(err, "blogRepo.GetIDBySlug.GetContext")
+	}
+
+	return blogID, nil
+}
+
+// UpdateStatus set status of blog and time it was or will be published
+func (r *blogRepo) UpdateStatus(ctx context.Context, id uuid.UUID, status string, publishedAt *time.Time) (*models.BlogBase, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "blogRepo.UpdateStatus")
+	defer span.Finish()
+
+	var b models.BlogBase
+	if err := r.db.QueryRowxContext(ctx, updateBlogStatusQuery, status, publishedAt, id).StructScan(&b); err != nil {
+		return nil, errors.Wrap(err, "blogRepo.UpdateStatus.StructScan")
+	}
+
+	return &b, nil
+}
+
+// SetHeld hold blog for moderation or release it, held blog which was published or scheduled goes back to draft
+func (r *blogRepo) SetHeld(ctx context.Context, id uuid.UUID, held bool) (*models.BlogBase, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "blogRepo.SetHeld")
+	defer span.Finish()
+
+	var b models.BlogBase
+	if err := r.db.QueryRowxContext(ctx, setBlogHeldQuery, held, id).StructScan(&b); err != nil {
+		return nil, errors.Wrap(err, "blogRepo.SetHeld.StructScan")
+	}
+
+	return &b, nil
+}
+
+// PublishScheduled publish blog scheduled at publishAt, it reports false when blog is no longer scheduled for that time
+func (r *blogRepo) PublishScheduled(ctx context.Context, id uuid.UUID, publishAt time.Time) (bool, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "blogRepo.PublishScheduled")
+	defer span.Finish()
+
+	result, err := r.db.ExecContext(ctx, publishScheduledBlogQuery, id, publishAt)
+	if err != nil {
+		return false, errors.Wrap(err, "blogRepo.PublishScheduled.ExecContext")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "blogRepo.PublishScheduled.RowsAffected")
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// Delete blog of version, version 0 deletes any version
+func (r *blogRepo) Delete(ctx context.Context, id uuid.UUID, version int) error {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "blogRepo.Delete")
 	defer span.Finish()
 
-	result, err := r.db.ExecContext(ctx, deleteBlogQuery, id)
+	result, err := r.db.ExecContext(ctx, deleteBlogQuery, id, version)
 	if err != nil {
 		return errors.Wrap(err, "blogRepo.Delete.StructScan")
 	}
@@ -76,12 +350,18 @@ func (r *blogRepo) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
-func (r *blogRepo) List(ctx context.Context, pq *utils.PaginationQuery) (*models.BlogsList, error) {
+// List blogs matching filter, ordered by pagination query sort
+func (r *blogRepo) List(ctx context.Context, filter *models.BlogFilter, pq *utils.PaginationQuery) (*models.BlogsList, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "blogRepo.List")
 	defer span.Finish()
 
+	args := []interface{}{filter.Category, filter.AuthorID, filter.CreatedAfter, filter.CreatedBefore, filter.HasImage, filter.Status, filter.ViewerID, filter.Tag}
+	if pq.IsCursorMode() {
+		return r.listByCursor(ctx, args, pq)
+	}
+
 	var totalCount int
-	if err := r.db.GetContext(ctx, &totalCount, getTotalCountQuery); err != nil {
+	if err := r.db.GetContext(ctx, &totalCount, getTotalCountQuery, args...); err != nil {
 		return nil, errors.Wrap(err, "blogRepo.List.GetContext.totalCount")
 	}
 
@@ -96,9 +376,9 @@ func (r *blogRepo) List(ctx context.Context, pq *utils.PaginationQuery) (*models
 		}, nil
 	}
 
-	// TODO: update order by
 	var blogsList = make([]*models.BlogBase, 0, pq.GetSize())
-	rows, err := r.db.QueryxContext(ctx, listBlogsQuery, pq.GetOffset(), pq.GetLimit())
+	query := fmt.Sprintf(listBlogsQuery, blogOrderBy(pq.GetSort()))
+	rows, err := r.db.QueryxContext(ctx, query, append(args, pq.GetOffset(), pq.GetLimit())...)
 	if err != nil {
 		return nil, errors.Wrap(err, "blogRepo.List.QueryxContext")
 	}
@@ -125,3 +405,150 @@ func (r *blogRepo) List(ctx context.Context, pq *utils.PaginationQuery) (*models
 		Blogs:      blogsList,
 	}, nil
 }
+
+// listByCursor read page of blogs after cursor without counting, newest first
+func (r *blogRepo) listByCursor(ctx context.Context, args []interface{}, pq *utils.PaginationQuery) (*models.BlogsList, error) {
+	var createdAt *time.Time
+	var blogID *uuid.UUID
+	if cursor := pq.GetCursor(); cursor != nil {
+		createdAt, blogID = &cursor.CreatedAt, &cursor.ID
+	}
+
+	operator, direction := utils.CursorKeyset(pq.GetCursor())
+	query := fmt.Sprintf(listBlogsByCursorQuery, operator, direction)
+
+	var blogsList = make([]*models.BlogBase, 0, pq.GetSize()+1)
+	if err := r.db.SelectContext(ctx, &blogsList, query, append(args, createdAt, blogID, pq.GetSize()+1)...); err != nil {
+		return nil, errors.Wrap(err, "blogRepo.listByCursor.SelectContext")
+	}
+
+	blogsList, nextCursor, prevCursor := utils.CursorPage(blogsList, pq, func(b *models.BlogBase) (time.Time, uuid.UUID) {
+		return b.CreatedAt, b.BlogID
+	})
+
+	return &models.BlogsList{
+		Size:       pq.GetSize(),
+		HasMore:    nextCursor != nil,
+		NextCursor: nextCursor,
+		PrevCursor: prevCursor,
+		Blogs:      blogsList,
+	}, nil
+}
+
+// Search blogs by full text query, best ranked first
+func (r *blogRepo) Search(ctx context.Context, query *models.BlogSearchQuery, pq *utils.PaginationQuery) (*models.BlogsList, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "blogRepo.Search")
+	defer span.Finish()
+
+	args := []interface{}{query.Query, query.Category, query.AuthorID, query.CreatedAfter, query.CreatedBefore, query.HasImage, query.Tag}
+
+	var totalCount int
+	if err := r.db.GetContext(ctx, &totalCount, getSearchTotalCountQuery, args...); err != nil {
+		return nil, errors.Wrap(err, "blogRepo.Search.GetContext.totalCount")
+	}
+
+	if totalCount == 0 {
+		return &models.BlogsList{
+			TotalCount: totalCount,
+			TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+			Page:       pq.GetPage(),
+			Size:       pq.GetSize(),
+			HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+			Blogs:      make([]*models.BlogBase, 0),
+		}, nil
+	}
+
+	var blogsList = make([]*models.BlogBase, 0, pq.GetSize())
+	rows, err := r.db.QueryxContext(ctx, searchBlogsQuery, append(args, pq.GetOffset(), pq.GetLimit())...)
+	if err != nil {
+		return nil, errors.Wrap(err, "blogRepo.Search.QueryxContext")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		n := &models.BlogBase{}
+		if err = rows.StructScan(n); err != nil {
+			return nil, errors.Wrap(err, "blogRepo.Search.StructScan")
+		}
+		if n.Snippet != nil {
+			snippet := utils.MarkHeadline(*n.Snippet)
+			n.Snippet = &snippet
+		}
+		blogsList = append(blogsList, n)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "blogRepo.Search.rows.Err")
+	}
+
+	return &models.BlogsList{
+		TotalCount: totalCount,
+		TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+		Page:       pq.GetPage(),
+		Size:       pq.GetSize(),
+		HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+		Blogs:      blogsList,
+	}, nil
+}
+
+// ListFeed read page of published blogs of authors follower follows after cursor, most recently published first
+func (r *blogRepo) ListFeed(ctx context.Context, followerID uuid.UUID, pq *utils.PaginationQuery) (*models.BlogsList, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "blogRepo.ListFeed")
+	defer span.Finish()
+
+	var publishedAt *time.Time
+	var blogID *uuid.UUID
+	if cursor := pq.GetCursor(); cursor != nil {
+		publishedAt, blogID = &cursor.CreatedAt, &cursor.ID
+	}
+
+	operator, direction := utils.CursorKeyset(pq.GetCursor())
+	query := fmt.Sprintf(listFeedQuery, operator, direction)
+
+	var blogsList = make([]*models.BlogBase, 0, pq.GetSize()+1)
+	if err := r.db.SelectContext(ctx, &blogsList, query, followerID, publishedAt, blogID, pq.GetSize()+1); err != nil {
+		return nil, errors.Wrap(err, "blogRepo.ListFeed.SelectContext")
+	}
+
+	blogsList, nextCursor, prevCursor := utils.CursorPage(blogsList, pq, func(b *models.BlogBase) (time.Time, uuid.UUID) {
+		if b.PublishedAt == nil {
+			return b.CreatedAt, b.BlogID
+		}
+		return *b.PublishedAt, b.BlogID
+	})
+
+	return &models.BlogsList{
+		Size:       pq.GetSize(),
+		HasMore:    nextCursor != nil,
+		NextCursor: nextCursor,
+		PrevCursor: prevCursor,
+		Blogs:      blogsList,
+	}, nil
+}
+
+// ListPublishedByIDs read blogs with ids which are still published, in no particular order
+func (r *blogRepo) ListPublishedByIDs(ctx context.Context, ids []uuid.UUID) ([]*models.BlogBase, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "blogRepo.ListPublishedByIDs")
+	defer span.Finish()
+
+	var blogsList = make([]*models.BlogBase, 0, len(ids))
+	if len(ids) == 0 {
+		return blogsList, nil
+	}
+
+	blogIDs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		blogIDs = append(blogIDs, id.String())
+	}
+
+	if err := r.db.SelectContext(ctx, &blogsList, listPublishedBlogsByIDsQuery, strings.Join(blogIDs, ",")); err != nil {
+		return nil, errors.Wrap(err, "blogRepo.ListPublishedByIDs.SelectContext")
+	}
+
+	return blogsList, nil
+}
+
+// blogOrderBy build ORDER BY list from whitelisted sort, blog_id keeps pages stable on ties
+func blogOrderBy(sort []utils.SortField) string {
+	return utils.OrderByClause(sort, blogSortColumns, defaultBlogsOrder) + ", b.blog_id"
+}
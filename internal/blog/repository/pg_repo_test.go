@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"fmt"
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
@@ -9,6 +10,7 @@ import (
 	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/utils"
 	"github.com/stretchr/testify/require"
 	"testing"
+	"time"
 )
 
 func TestBlogRepo_Create(t *testing.T) {
@@ -35,21 +37,42 @@ func TestBlogRepo_Create(t *testing.T) {
 			AuthorID: authorUID,
 			Title:    title,
 			Content:  content,
+			Status:   models.BlogStatusDraft,
+			Tags:     models.Tags{"go", "backend"},
 		}
 
+		mock.ExpectBegin()
 		mock.ExpectQuery(createBlogQuery).
 			WithArgs(blog.AuthorID,
 				blog.Title,
 				blog.Content,
 				blog.ImageURL,
-				blog.Category).
+				blog.Category,
+				blog.Status,
+				blog.ContentFormat,
+				blog.Held).
 			WillReturnRows(rows)
+		mock.ExpectQuery(listTakenBlogSlugsQuery).WithArgs("title", uuid.Nil).
+			WillReturnRows(sqlmock.NewRows([]string{"slug"}).AddRow("title").AddRow("title-3"))
+		mock.ExpectExec(createBlogSlugQuery).WithArgs("title-2", uuid.Nil).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(updateBlogSlugQuery).WithArgs("title-2", uuid.Nil).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(createTagsQuery).WithArgs("go,backend").WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(deleteBlogTagsQuery).WithArgs(uuid.Nil, "go,backend").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(createBlogTagsQuery).WithArgs(uuid.Nil, "go,backend").WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectQuery(getBlogTagsQuery).WithArgs(uuid.Nil).WillReturnRows(sqlmock.NewRows([]string{"tags"}).AddRow("backend,go"))
+		mock.ExpectExec(updateBlogRenderedQuery).WithArgs("<p>content</p>\n", "content", 1, 1, uuid.Nil).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(createBlogRevisionQuery).WithArgs(uuid.Nil, blog.AuthorID).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
 
 		createdBlog, err := blogRepo.Create(context.Background(), blog)
 
 		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
 		require.NotNil(t, createdBlog)
 		require.Equal(t, createdBlog.AuthorID, blog.AuthorID)
+		require.Equal(t, "title-2", createdBlog.Slug)
+		require.Equal(t, models.Tags{"backend", "go"}, createdBlog.Tags)
+		require.Equal(t, "content", createdBlog.Excerpt)
 	})
 }
 
@@ -108,32 +131,99 @@ func TestBlogRepo_Update(t *testing.T) {
 		blogUID := uuid.New()
 		authorUID := uuid.New()
 		title := "update title"
-		content := "content"
+		content := "# Heading\n\nSome *text* <script>alert(1)</script>"
 
-		rows := sqlmock.NewRows([]string{"blog_id", "author_id", "title", "content"}).
-			AddRow(blogUID, authorUID, title, content)
+		rows := sqlmock.NewRows([]string{"blog_id", "author_id", "title", "content", "content_format"}).
+			AddRow(blogUID, authorUID, title, content, models.BlogContentMarkdown)
 
 		blog := &models.BlogBase{
-			BlogID:   blogUID,
-			AuthorID: authorUID,
-			Title:    title,
-			Content:  content,
+			BlogID:        blogUID,
+			AuthorID:      authorUID,
+			Title:         title,
+			Content:       content,
+			ContentFormat: models.BlogContentMarkdown,
 		}
 
+		mock.ExpectBegin()
+		mock.ExpectQuery(getBlogTitleForUpdateQuery).WithArgs(blogUID).WillReturnRows(sqlmock.NewRows([]string{"title"}).AddRow("old title"))
 		mock.ExpectQuery(updateBlogQuery).WithArgs(
 			blog.Title,
 			blog.Content,
 			blog.ImageURL,
 			blog.Category,
-			blog.BlogID).
+			blog.BlogID,
+			blog.Version,
+			blog.ContentFormat).
 			WillReturnRows(rows)
+		mock.ExpectQuery(listTakenBlogSlugsQuery).WithArgs("update-title", blogUID).WillReturnRows(sqlmock.NewRows([]string{"slug"}))
+		mock.ExpectExec(createBlogSlugQuery).WithArgs("update-title", blogUID).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(updateBlogSlugQuery).WithArgs("update-title", blogUID).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(getBlogTagsQuery).WithArgs(blogUID).WillReturnRows(sqlmock.NewRows([]string{"tags"}).AddRow(""))
+		mock.ExpectExec(updateBlogRenderedQuery).WithArgs("<h1>Heading</h1>\n<p>Some <em>text</em> alert(1)</p>\n", "Heading Some text alert(1)", 4, 1, blogUID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(createBlogRevisionQuery).WithArgs(blogUID, authorUID).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
 
-		updatedBlog, err := blogRepo.Update(context.Background(), blog)
+		updatedBlog, err := blogRepo.Update(context.Background(), blog, authorUID)
 
 		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
 		require.NotNil(t, updatedBlog)
 		require.Equal(t, updatedBlog.BlogID, blog.BlogID)
 		require.Equal(t, updatedBlog.Title, blog.Title)
+		require.Equal(t, 4, updatedBlog.WordCount)
+	})
+
+	t.Run("Slug of same title is kept", func(t *testing.T) {
+		blogUID := uuid.New()
+		authorUID := uuid.New()
+
+		rows := sqlmock.NewRows([]string{"blog_id", "author_id", "title", "content", "slug"}).
+			AddRow(blogUID, authorUID, "Update Title!", "content", "update-title-2")
+
+		blog := &models.BlogBase{BlogID: blogUID, Title: "Update Title!"}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(getBlogTitleForUpdateQuery).WithArgs(blogUID).WillReturnRows(sqlmock.NewRows([]string{"title"}).AddRow("Update title"))
+		mock.ExpectQuery(updateBlogQuery).WithArgs(blog.Title, blog.Content, blog.ImageURL, blog.Category, blog.BlogID, blog.Version, blog.ContentFormat).
+			WillReturnRows(rows)
+		mock.ExpectQuery(getBlogTagsQuery).WithArgs(blogUID).WillReturnRows(sqlmock.NewRows([]string{"tags"}).AddRow("go"))
+		mock.ExpectExec(updateBlogRenderedQuery).WithArgs("<p>content</p>\n", "content", 1, 1, blogUID).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(createBlogRevisionQuery).WithArgs(blogUID, authorUID).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		updatedBlog, err := blogRepo.Update(context.Background(), blog, authorUID)
+
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+		require.Equal(t, "update-title-2", updatedBlog.Slug)
+		require.Equal(t, models.Tags{"go"}, updatedBlog.Tags)
+	})
+
+	t.Run("Slug is kept when title is unchanged", func(t *testing.T) {
+		blogUID := uuid.New()
+		authorUID := uuid.New()
+		imageURL := "https://example.com/cover.png"
+
+		rows := sqlmock.NewRows([]string{"blog_id", "author_id", "title", "content", "slug"}).
+			AddRow(blogUID, authorUID, "Old title", "content", "old-title-of-other-form")
+
+		blog := &models.BlogBase{BlogID: blogUID, ImageURL: &imageURL}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(getBlogTitleForUpdateQuery).WithArgs(blogUID).WillReturnRows(sqlmock.NewRows([]string{"title"}).AddRow("Old title"))
+		mock.ExpectQuery(updateBlogQuery).WithArgs(blog.Title, blog.Content, blog.ImageURL, blog.Category, blog.BlogID, blog.Version, blog.ContentFormat).
+			WillReturnRows(rows)
+		mock.ExpectQuery(getBlogTagsQuery).WithArgs(blogUID).WillReturnRows(sqlmock.NewRows([]string{"tags"}).AddRow(""))
+		mock.ExpectExec(updateBlogRenderedQuery).WithArgs("<p>content</p>\n", "content", 1, 1, blogUID).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(createBlogRevisionQuery).WithArgs(blogUID, authorUID).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		updatedBlog, err := blogRepo.Update(context.Background(), blog, authorUID)
+
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+		require.Equal(t, "old-title-of-other-form", updatedBlog.Slug)
 	})
 }
 
@@ -151,9 +241,9 @@ func TestBlogRepo_Delete(t *testing.T) {
 
 	t.Run("Delete", func(t *testing.T) {
 		blogUID := uuid.New()
-		mock.ExpectExec(deleteBlogQuery).WithArgs(blogUID).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(deleteBlogQuery).WithArgs(blogUID, 0).WillReturnResult(sqlmock.NewResult(1, 1))
 
-		err := blogRepo.Delete(context.Background(), blogUID)
+		err := blogRepo.Delete(context.Background(), blogUID, 0)
 
 		require.NoError(t, err)
 	})
@@ -192,16 +282,24 @@ func TestBlogRepo_List(t *testing.T) {
 			rows.AddRow(blog.BlogID, blog.AuthorID, blog.Title, blog.Content)
 		}
 
+		hasImage := true
+		filter := &models.BlogFilter{Category: "golang", HasImage: &hasImage}
+
 		countRows := sqlmock.NewRows([]string{"count"}).AddRow(expectedCount)
-		mock.ExpectQuery(getTotalCountQuery).WillReturnRows(countRows)
+		mock.ExpectQuery(getTotalCountQuery).
+			WithArgs(filter.Category, filter.AuthorID, filter.CreatedAfter, filter.CreatedBefore, filter.HasImage, filter.Status, filter.ViewerID, filter.Tag).
+			WillReturnRows(countRows)
 
 		pq := utils.PaginationQuery{
 			Size: expectedSize,
 			Page: expectedPage,
+			Sort: []utils.SortField{{Field: "created_at", Desc: true}, {Field: "title"}},
 		}
-		mock.ExpectQuery(listBlogsQuery).WithArgs(pq.GetOffset(), pq.GetLimit()).WillReturnRows(rows)
+		mock.ExpectQuery(fmt.Sprintf(listBlogsQuery, "b.created_at DESC, b.title ASC, b.blog_id")).
+			WithArgs(filter.Category, filter.AuthorID, filter.CreatedAfter, filter.CreatedBefore, filter.HasImage, filter.Status, filter.ViewerID, filter.Tag, pq.GetOffset(), pq.GetLimit()).
+			WillReturnRows(rows)
 
-		listBlogs, err := blogRepo.List(context.Background(), &pq)
+		listBlogs, err := blogRepo.List(context.Background(), filter, &pq)
 
 		require.NoError(t, err)
 		require.NotNil(t, listBlogs)
@@ -213,3 +311,164 @@ func TestBlogRepo_List(t *testing.T) {
 		require.NotNil(t, listBlogs.Blogs)
 	})
 }
+
+func TestBlogRepo_Search(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	blogRepo := NewBlogRepository(sqlxDB)
+
+	t.Run("Search", func(t *testing.T) {
+		authorID := uuid.New()
+		query := &models.BlogSearchQuery{
+			Query: "clean architecture",
+			BlogFilter: models.BlogFilter{
+				Category: "golang",
+				AuthorID: &authorID,
+			},
+		}
+		pq := utils.PaginationQuery{
+			Size: 10,
+			Page: 1,
+		}
+
+		countRows := sqlmock.NewRows([]string{"count"}).AddRow(1)
+		mock.ExpectQuery(getSearchTotalCountQuery).
+			WithArgs(query.Query, query.Category, query.AuthorID, query.CreatedAfter, query.CreatedBefore, query.HasImage, query.Tag).
+			WillReturnRows(countRows)
+
+		rows := sqlmock.NewRows([]string{"blog_id", "author_id", "title", "content", "rank", "snippet"}).
+			AddRow(uuid.New(), authorID, "title", "content", 0.6, "\x02clean\x03 \x02architecture\x03 <script>alert(1)</script>")
+		mock.ExpectQuery(searchBlogsQuery).
+			WithArgs(query.Query, query.Category, query.AuthorID, query.CreatedAfter, query.CreatedBefore, query.HasImage, query.Tag, pq.GetOffset(), pq.GetLimit()).
+			WillReturnRows(rows)
+
+		listBlogs, err := blogRepo.Search(context.Background(), query, &pq)
+		require.NoError(t, err)
+		require.Equal(t, 1, listBlogs.TotalCount)
+		require.Len(t, listBlogs.Blogs, 1)
+		require.Equal(t, 0.6, *listBlogs.Blogs[0].Rank)
+		require.Equal(t, "<mark>clean</mark> <mark>architecture</mark> &lt;script&gt;alert(1)&lt;/script&gt;", *listBlogs.Blogs[0].Snippet)
+	})
+}
+
+func TestBlogRepo_ListByCursor(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	blogRepo := NewBlogRepository(sqlxDB)
+	filter := &models.BlogFilter{}
+	now := time.Now().UTC().Truncate(time.Second)
+
+	newRows := func(count int) (*sqlmock.Rows, []uuid.UUID) {
+		rows := sqlmock.NewRows([]string{"blog_id", "title", "created_at"})
+		ids := make([]uuid.UUID, count)
+		for i := range ids {
+			ids[i] = uuid.New()
+			rows.AddRow(ids[i], "title", now.Add(-time.Duration(i)*time.Minute))
+		}
+		return rows, ids
+	}
+
+	t.Run("First page", func(t *testing.T) {
+		pq := utils.PaginationQuery{Size: 2, CursorMode: true}
+		rows, ids := newRows(3)
+
+		mock.ExpectQuery(fmt.Sprintf(listBlogsByCursorQuery, "<", "DESC")).
+			WithArgs(filter.Category, filter.AuthorID, filter.CreatedAfter, filter.CreatedBefore, filter.HasImage, filter.Status, filter.ViewerID, filter.Tag, nil, nil, 3).
+			WillReturnRows(rows)
+
+		listBlogs, err := blogRepo.List(context.Background(), filter, &pq)
+		require.NoError(t, err)
+		require.Len(t, listBlogs.Blogs, 2)
+		require.True(t, listBlogs.HasMore)
+		require.Nil(t, listBlogs.PrevCursor)
+		require.NotNil(t, listBlogs.NextCursor)
+
+		next, err := utils.DecodeCursor(*listBlogs.NextCursor)
+		require.NoError(t, err)
+		require.Equal(t, ids[1], next.ID)
+		require.False(t, next.Prev)
+	})
+
+	t.Run("Prev page", func(t *testing.T) {
+		cursor := &utils.Cursor{CreatedAt: now, ID: uuid.New(), Prev: true}
+		pq := utils.PaginationQuery{Size: 2, CursorMode: true, Cursor: cursor}
+		rows, ids := newRows(2)
+
+		mock.ExpectQuery(fmt.Sprintf(listBlogsByCursorQuery, ">", "ASC")).
+			WithArgs(filter.Category, filter.AuthorID, filter.CreatedAfter, filter.CreatedBefore, filter.HasImage, filter.Status, filter.ViewerID, filter.Tag, &cursor.CreatedAt, &cursor.ID, 3).
+			WillReturnRows(rows)
+
+		listBlogs, err := blogRepo.List(context.Background(), filter, &pq)
+		require.NoError(t, err)
+		require.Len(t, listBlogs.Blogs, 2)
+		require.Equal(t, ids[1], listBlogs.Blogs[0].BlogID)
+		require.Nil(t, listBlogs.PrevCursor)
+		require.NotNil(t, listBlogs.NextCursor)
+	})
+}
+
+func TestBlogRepo_ListFeed(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	blogRepo := NewBlogRepository(sqlxDB)
+	followerUID := uuid.New()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	t.Run("Paged by publish time", func(t *testing.T) {
+		pq := utils.PaginationQuery{Size: 2, CursorMode: true}
+		ids := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+		rows := sqlmock.NewRows([]string{"blog_id", "title", "status", "published_at", "created_at"})
+		for i, id := range ids {
+			rows.AddRow(id, "title", models.BlogStatusPublished, now.Add(-time.Duration(i)*time.Minute), now.Add(-time.Hour))
+		}
+
+		mock.ExpectQuery(fmt.Sprintf(listFeedQuery, "<", "DESC")).
+			WithArgs(followerUID, nil, nil, 3).
+			WillReturnRows(rows)
+
+		listBlogs, err := blogRepo.ListFeed(context.Background(), followerUID, &pq)
+		require.NoError(t, err)
+		require.Len(t, listBlogs.Blogs, 2)
+		require.True(t, listBlogs.HasMore)
+
+		next, err := utils.DecodeCursor(*listBlogs.NextCursor)
+		require.NoError(t, err)
+		require.Equal(t, ids[1], next.ID)
+		require.True(t, now.Add(-time.Minute).Equal(next.CreatedAt))
+	})
+
+	t.Run("Published by ids", func(t *testing.T) {
+		ids := []uuid.UUID{uuid.New(), uuid.New()}
+		rows := sqlmock.NewRows([]string{"blog_id", "title"}).AddRow(ids[1], "title")
+
+		mock.ExpectQuery(listPublishedBlogsByIDsQuery).
+			WithArgs(ids[0].String() + "," + ids[1].String()).
+			WillReturnRows(rows)
+
+		blogs, err := blogRepo.ListPublishedByIDs(context.Background(), ids)
+		require.NoError(t, err)
+		require.Len(t, blogs, 1)
+		require.Equal(t, ids[1], blogs[0].BlogID)
+	})
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"encoding/json"
+	"github.com/google/uuid"
 	"github.com/opentracing/opentracing-go"
 	"github.com/pkg/errors"
 	"github.com/redis/go-redis/v9"
@@ -61,3 +62,31 @@ func (r *blogRedisRepo) DeleteBlogCtx(ctx context.Context, key string) error {
 	}
 	return nil
 }
+
+func (r *blogRedisRepo) GetBlogIDBySlugCtx(ctx context.Context, key string) (uuid.UUID, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "blogRedisRepo.GetBlogIDBySlugCtx")
+	defer span.Finish()
+
+	blogID, err := r.rdb.Get(ctx, key).Result()
+	if err != nil {
+		return uuid.Nil, errors.Wrap(err, "blogRedisRepo.GetBlogIDBySlugCtx.redisClient.Get")
+	}
+
+	blogUID, err := uuid.Parse(blogID)
+	if err != nil {
+		return uuid.Nil, errors.Wrap(err, "blogRedisRepo.GetBlogIDBySlugCtx.uuid.Parse")
+	}
+
+	return blogUID, nil
+}
+
+func (r *blogRedisRepo) SetBlogSlugCtx(ctx context.Context, key string, seconds int, blogID uuid.UUID) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "blogRedisRepo.SetBlogSlugCtx")
+	defer span.Finish()
+
+	if err := r.rdb.Set(ctx, key, blogID.String(), time.Second*time.Duration(seconds)).Err(); err != nil {
+		return errors.Wrap(err, "blogRedisRepo.SetBlogSlugCtx.redisClient.Set")
+	}
+
+	return nil
+}
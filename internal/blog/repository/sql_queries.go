@@ -1,37 +1,213 @@
 package repository
 
 const (
-	createBlogQuery = `INSERT INTO blogs (author_id, title, content, image_url, category, created_at) 
-					VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), now()) 
-					RETURNING *`
+	// blogTagsColumn select comma separated tag names of blog b
+	blogTagsColumn = `(SELECT COALESCE(string_agg(t.name, ',' ORDER BY t.name), '')
+						FROM blog_tags bt JOIN tags t ON t.tag_id = bt.tag_id WHERE bt.blog_id = b.blog_id) as tags`
+
+	// Slug is assigned by assignSlug in same transaction
+	createBlogQuery = `INSERT INTO blogs (author_id, title, content, image_url, category, created_at, status, published_at, content_format, held) 
+					VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), now(), $6, CASE WHEN $6 = 'published' THEN now() END, $7, $8) 
+					RETURNING blog_id, author_id, title, content, content_format, image_url, category, status, held, published_at, version, created_at, updated_at`
 
 	getBlogByIDQuery = `SELECT b.blog_id,
 						   b.title,
 						   b.content,
+						   b.content_format,
+						   b.content_html,
+						   b.excerpt,
+						   b.word_count,
+						   b.reading_time,
 						   b.updated_at,
 						   b.image_url,
 						   b.category,
+						   b.status,
+						   b.held,
+						   b.published_at,
+						   b.version,
+						   b.slug,
+						   ` + blogTagsColumn + `,
 						   CONCAT(u.first_name, ' ', u.last_name) as author,
 						   u.user_id as author_id
 					FROM blogs b
 							 LEFT JOIN users u on u.user_id = b.author_id
 					WHERE blog_id = $1`
 
+	// Version 0 updates any version, other version updates blog only if it was not changed since
 	updateBlogQuery = `UPDATE blogs 
 					SET title = COALESCE(NULLIF($1, ''), title),
 						content = COALESCE(NULLIF($2, ''), content), 
 					    image_url = COALESCE(NULLIF($3, ''), image_url), 
 					    category = COALESCE(NULLIF($4, ''), category), 
-					    updated_at = now() 
-					WHERE blog_id = $5
-					RETURNING *`
+					    content_format = COALESCE(NULLIF($7, ''), content_format),
+					    updated_at = now(),
+					    version = version + 1
+					WHERE blog_id = $5 AND ($6::int = 0 OR version = $6)
+					RETURNING blog_id, author_id, title, content, content_format, image_url, category, status, held, published_at, version, slug, created_at, updated_at`
+
+	updateBlogStatusQuery = `UPDATE blogs b SET status = $1, published_at = $2, updated_at = now(), version = version + 1 WHERE b.blog_id = $3
+					RETURNING b.blog_id, b.author_id, b.title, b.content, b.content_format, b.excerpt, b.word_count, b.reading_time,
+						b.image_url, b.category, b.status, b.held, b.published_at, b.version, b.slug, ` + blogTagsColumn + `, b.created_at, b.updated_at`
+
+	// Held blog which was published or scheduled goes back to draft, released blog keeps its status
+	setBlogHeldQuery = `UPDATE blogs b SET held = $1,
+						status = CASE WHEN $1 AND b.status IN ('published', 'scheduled') THEN 'draft' ELSE b.status END,
+						published_at = CASE WHEN $1 AND b.status IN ('published', 'scheduled') THEN NULL ELSE b.published_at END,
+						updated_at = now(), version = version + 1
+					WHERE b.blog_id = $2
+					RETURNING b.blog_id, b.author_id, b.title, b.content, b.content_format, b.excerpt, b.word_count, b.reading_time,
+						b.image_url, b.category, b.status, b.held, b.published_at, b.version, b.slug, ` + blogTagsColumn + `, b.created_at, b.updated_at`
+
+	// Blog is published only if it is still scheduled for same time and not held, rescheduled or archived blog stays as it is
+	publishScheduledBlogQuery = `UPDATE blogs SET status = 'published', updated_at = now(), version = version + 1
+					WHERE blog_id = $1 AND status = 'scheduled' AND published_at = $2 AND NOT held`
+
+	// Version 0 deletes any version
+	deleteBlogQuery = `DELETE FROM blogs WHERE blog_id = $1 AND ($2::int = 0 OR version = $2)`
+
+	// Slugs of other blogs which collide with base, old slugs of blog itself can be taken again
+	listTakenBlogSlugsQuery = `SELECT slug FROM blog_slugs WHERE (slug = $1 OR slug LIKE $1 || '-%') AND blog_id <> $2`
+
+	// Nothing is inserted when slug belongs to other blog
+	createBlogSlugQuery = `INSERT INTO blog_slugs (slug, blog_id) VALUES ($1, $2)
+					ON CONFLICT (slug) DO UPDATE SET created_at = now() WHERE blog_slugs.blog_id = EXCLUDED.blog_id`
+
+	updateBlogSlugQuery = `UPDATE blogs SET slug = $1 WHERE blog_id = $2`
+
+	// Row is locked until change of blog commits, so title read here is the one change replaces
+	getBlogTitleForUpdateQuery = `SELECT title FROM blogs WHERE blog_id = $1 FOR UPDATE`
+
+	getBlogIDBySlugQuery = `SELECT blog_id FROM blog_slugs WHERE slug = $1`
+
+	// Rendered HTML, excerpt and reading stats are derived from content on every write
+	updateBlogRenderedQuery = `UPDATE blogs SET content_html = $1, excerpt = $2, word_count = $3, reading_time = $4 WHERE blog_id = $5`
+
+	// Tags are comma separated names, names are slugs so they never contain comma
+	createTagsQuery = `INSERT INTO tags (name) SELECT unnest(string_to_array($1::text, ',')) ON CONFLICT (name) DO NOTHING`
+
+	deleteBlogTagsQuery = `DELETE FROM blog_tags bt USING tags t
+					WHERE bt.tag_id = t.tag_id AND bt.blog_id = $1 AND NOT (t.name = ANY (string_to_array($2::text, ',')))`
+
+	createBlogTagsQuery = `INSERT INTO blog_tags (blog_id, tag_id)
+					SELECT $1, t.tag_id FROM tags t WHERE t.name = ANY (string_to_array($2::text, ','))
+					ON CONFLICT DO NOTHING`
+
+	getBlogTagsQuery = `SELECT ` + blogTagsColumn + ` FROM blogs b WHERE b.blog_id = $1`
+
+	// Revision is snapshot of blog row, it is written in transaction which changed row so row lock orders revision numbers
+	createBlogRevisionQuery = `INSERT INTO blog_revisions (blog_id, revision, editor_id, title, content, content_format, image_url, category)
+					SELECT b.blog_id, COALESCE((SELECT MAX(r.revision) FROM blog_revisions r WHERE r.blog_id = b.blog_id), 0) + 1,
+						$2, b.title, b.content, b.content_format, b.image_url, b.category
+					FROM blogs b
+					WHERE b.blog_id = $1`
+
+	// Restore set every field of revision, fields which were empty at revision become empty again.
+	// Category deleted since revision is left empty
+	restoreBlogQuery = `UPDATE blogs b
+					SET title = r.title, content = r.content, content_format = r.content_format, image_url = r.image_url,
+						category = (SELECT c.slug FROM categories c WHERE c.slug = r.category), updated_at = now(),
+						version = b.version + 1
+					FROM blog_revisions r
+					WHERE b.blog_id = $1 AND r.blog_id = $1 AND r.revision = $2
+					RETURNING b.blog_id, b.author_id, b.title, b.content, b.content_format, b.image_url, b.category, b.status, b.held, b.published_at, b.version, b.slug, b.created_at, b.updated_at`
 
-	deleteBlogQuery = `DELETE FROM blogs WHERE blog_id = $1`
+	getBlogRevisionQuery = `SELECT r.blog_id, r.revision, r.editor_id, COALESCE(CONCAT(u.first_name, ' ', u.last_name), '') as editor,
+						r.title, r.content, r.content_format, r.image_url, r.category, r.created_at
+					FROM blog_revisions r
+						LEFT JOIN users u on u.user_id = r.editor_id
+					WHERE r.blog_id = $1 AND r.revision = $2`
 
-	listBlogsQuery = `SELECT b.blog_id, b.title, b.content, b.image_url, b.category, b.updated_at, b.created_at,  CONCAT(u.first_name, ' ', u.last_name) as author, u.user_id as author_id 
+	getBlogRevisionsCountQuery = `SELECT COUNT(*) FROM blog_revisions WHERE blog_id = $1`
+
+	listBlogRevisionsQuery = `SELECT r.blog_id, r.revision, r.editor_id, COALESCE(CONCAT(u.first_name, ' ', u.last_name), '') as editor,
+						r.title, r.content_format, r.image_url, r.category, r.created_at
+					FROM blog_revisions r
+						LEFT JOIN users u on u.user_id = r.editor_id
+					WHERE r.blog_id = $1
+					ORDER BY r.revision DESC OFFSET $2 LIMIT $3`
+
+	// Optional filters are skipped when their argument is empty or NULL
+	listBlogsFilter = `FROM blogs b
+					LEFT JOIN users u on u.user_id = b.author_id
+				WHERE ($1::text = '' OR b.category = $1)
+					AND ($2::uuid IS NULL OR b.author_id = $2)
+					AND ($3::timestamptz IS NULL OR b.created_at >= $3)
+					AND ($4::timestamptz IS NULL OR b.created_at < $4)
+					AND ($5::boolean IS NULL OR (b.image_url IS NOT NULL) = $5)
+					AND ($6::text = '' OR b.status = $6)
+					AND (b.status = 'published' OR ($7::uuid IS NOT NULL AND b.author_id = $7))
+					AND ($8::text = '' OR EXISTS (SELECT 1 FROM blog_tags bt JOIN tags t ON t.tag_id = bt.tag_id
+						WHERE bt.blog_id = b.blog_id AND t.name = $8))`
+
+	// ORDER BY is filled by blogOrderBy, never with raw user input
+	listBlogsQuery = `SELECT b.blog_id, b.title, b.content_format, b.excerpt, b.word_count, b.reading_time, b.image_url, b.category, b.status, b.held, b.published_at, b.version, b.slug, ` + blogTagsColumn + `, b.updated_at, b.created_at,  CONCAT(u.first_name, ' ', u.last_name) as author, u.user_id as author_id 
+				` + listBlogsFilter + `
+				ORDER BY %s OFFSET $9 LIMIT $10`
+
+	getTotalCountQuery = `SELECT COUNT(b.blog_id) ` + listBlogsFilter
+
+	// Keyset operator and directions are filled by utils.CursorKeyset
+	listBlogsByCursorQuery = `SELECT b.blog_id, b.title, b.content_format, b.excerpt, b.word_count, b.reading_time, b.image_url, b.category, b.status, b.held, b.published_at, b.version, b.slug, ` + blogTagsColumn + `, b.updated_at, b.created_at,  CONCAT(u.first_name, ' ', u.last_name) as author, u.user_id as author_id 
+				` + listBlogsFilter + `
+					AND ($9::timestamptz IS NULL OR (b.created_at, b.blog_id) %[1]s ($9, $10::uuid))
+				ORDER BY b.created_at %[2]s, b.blog_id %[2]s LIMIT $11`
+
+	defaultBlogsOrder = `b.created_at, b.updated_at`
+
+	// Feed is paged by publish time, keyset operator and directions are filled by utils.CursorKeyset
+	listFeedQuery = `SELECT b.blog_id, b.title, b.content_format, b.excerpt, b.word_count, b.reading_time, b.image_url, b.category, b.status, b.published_at, b.version, b.slug, ` + blogTagsColumn + `, b.updated_at, b.created_at,  CONCAT(u.first_name, ' ', u.last_name) as author, u.user_id as author_id 
 				FROM blogs b
+					JOIN follows f ON f.followee_id = b.author_id AND f.follower_id = $1
 					LEFT JOIN users u on u.user_id = b.author_id
-				ORDER BY b.created_at, b.updated_at OFFSET $1 LIMIT $2`
+				WHERE b.status = 'published'
+					AND ($2::timestamptz IS NULL OR (b.published_at, b.blog_id) %[1]s ($2, $3::uuid))
+				ORDER BY b.published_at %[2]s, b.blog_id %[2]s LIMIT $4`
 
-	getTotalCountQuery = `SELECT COUNT(blog_id) FROM blogs`
+	// Blog ids are comma separated, blogs which are no longer published are left out
+	listPublishedBlogsByIDsQuery = `SELECT b.blog_id, b.title, b.content_format, b.excerpt, b.word_count, b.reading_time, b.image_url, b.category, b.status, b.published_at, b.version, b.slug, ` + blogTagsColumn + `, b.updated_at, b.created_at,  CONCAT(u.first_name, ' ', u.last_name) as author, u.user_id as author_id 
+				FROM blogs b
+					LEFT JOIN users u on u.user_id = b.author_id
+				WHERE b.blog_id = ANY (string_to_array($1, ',')::uuid[]) AND b.status = 'published'`
+
+	// Comments which are deleted or hidden by moderation are not searched
+	searchCommentsCondition = `c.blog_id = b.blog_id AND c.search_vector @@ query AND c.deleted_at IS NULL
+						AND c.moderation_status NOT IN ('hidden', 'rejected')`
+
+	// Optional filters are skipped when their argument is empty or NULL, only published blogs are searched.
+	// Blog matches by its own text or by text of its comments
+	searchBlogsFilter = `FROM blogs b
+					LEFT JOIN users u on u.user_id = b.author_id
+					CROSS JOIN websearch_to_tsquery('english', $1) query
+				WHERE (b.search_vector @@ query OR EXISTS (SELECT 1 FROM comments c WHERE ` + searchCommentsCondition + `))
+					AND b.status = 'published'
+					AND ($2::text = '' OR b.category = $2)
+					AND ($3::uuid IS NULL OR b.author_id = $3)
+					AND ($4::timestamptz IS NULL OR b.created_at >= $4)
+					AND ($5::timestamptz IS NULL OR b.created_at < $5)
+					AND ($6::boolean IS NULL OR (b.image_url IS NOT NULL) = $6)
+					AND ($7::text = '' OR EXISTS (SELECT 1 FROM blog_tags bt JOIN tags t ON t.tag_id = bt.tag_id
+						WHERE bt.blog_id = b.blog_id AND t.name = $7))`
+
+	// Rank adds best comment match to blog match. Headline is made from text of sanitised HTML, or from best matching comment
+	// when only comments match, and matches are marked by sentinels, utils.MarkHeadline escapes it and marks matches
+	searchBlogsQuery = `SELECT b.blog_id, b.title, b.content_format, b.excerpt, b.word_count, b.reading_time, b.image_url, b.category, b.status, b.published_at, b.version, b.slug, ` + blogTagsColumn + `, b.updated_at, b.created_at, CONCAT(u.first_name, ' ', u.last_name) as author, u.user_id as author_id,
+					ts_rank(b.search_vector, query) + COALESCE((SELECT max(ts_rank(c.search_vector, query)) FROM comments c WHERE ` + searchCommentsCondition + `), 0) as rank,
+					CASE WHEN b.search_vector @@ query
+						THEN ts_headline('english', COALESCE(regexp_replace(b.content_html, '<[^>]*>', ' ', 'g'), b.content), query, ` + searchHeadlineOptions + `)
+						ELSE (SELECT ts_headline('english', c.message, query, ` + searchHeadlineOptions + `) FROM comments c
+							WHERE ` + searchCommentsCondition + ` ORDER BY ts_rank(c.search_vector, query) DESC, c.created_at LIMIT 1)
+					END as snippet
+				` + searchBlogsFilter + `
+				ORDER BY rank DESC, b.created_at DESC OFFSET $8 LIMIT $9`
+
+	searchHeadlineOptions = `'StartSel=' || chr(2) || ', StopSel=' || chr(3) || ', MaxFragments=2, MaxWords=30, MinWords=10'`
+
+	getSearchTotalCountQuery = `SELECT COUNT(b.blog_id) ` + searchBlogsFilter
 )
+
+// blogSortColumns map sort fields of models.BlogSortFields to columns
+var blogSortColumns = map[string]string{
+	"created_at": "b.created_at",
+	"updated_at": "b.updated_at",
+	"title":      "b.title",
+}
@@ -5,7 +5,17 @@ import "github.com/labstack/echo/v4"
 type Handlers interface {
 	Create() echo.HandlerFunc
 	GetByID() echo.HandlerFunc
+	GetBySlug() echo.HandlerFunc
 	Update() echo.HandlerFunc
 	Delete() echo.HandlerFunc
+	ListRevisions() echo.HandlerFunc
+	GetRevision() echo.HandlerFunc
+	DiffRevisions() echo.HandlerFunc
+	RestoreRevision() echo.HandlerFunc
+	Publish() echo.HandlerFunc
+	Archive() echo.HandlerFunc
+	Release() echo.HandlerFunc
 	List() echo.HandlerFunc
+	Search() echo.HandlerFunc
+	UploadCover() echo.HandlerFunc
 }
@@ -0,0 +1,64 @@
+//go:generate mockgen -source distributors.go -destination ../../mock/distributors_mock.go -package mock
+package asynq
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/hibiken/asynq"
+	asynqPkg "github.com/scul0405/blog-clean-architecture-rest-api/pkg/asynq"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/logger"
+)
+
+type BlogTaskDistributor interface {
+	DistributeTaskProcessCover(ctx context.Context, payload *ProcessCoverPayload, opts ...asynq.Option) error
+	DistributeTaskPublishBlog(ctx context.Context, payload *PublishBlogPayload, opts ...asynq.Option) error
+}
+
+type blogTaskDistributor struct {
+	client    *asynq.Client
+	inspector *asynq.Inspector
+	logger    logger.Logger
+}
+
+func NewBlogTaskDistributor(client *asynq.Client, inspector *asynq.Inspector, logger logger.Logger) BlogTaskDistributor {
+	return &blogTaskDistributor{
+		client:    client,
+		inspector: inspector,
+		logger:    logger,
+	}
+}
+
+func (distributor *blogTaskDistributor) DistributeTaskProcessCover(ctx context.Context, payload *ProcessCoverPayload, opts ...asynq.Option) error {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task payload: %w", err)
+	}
+
+	task := asynq.NewTask(TypeProcessCoverTask, jsonPayload)
+	info, err := asynqPkg.EnqueueReplacingArchived(ctx, distributor.client, distributor.inspector, task, opts...)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+
+	distributor.logger.Infof("type=%s, queue=%s, maxRetry=%d enqueued task", info.Type, info.Queue, info.MaxRetry)
+
+	return nil
+}
+
+func (distributor *blogTaskDistributor) DistributeTaskPublishBlog(ctx context.Context, payload *PublishBlogPayload, opts ...asynq.Option) error {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task payload: %w", err)
+	}
+
+	task := asynq.NewTask(TypePublishBlogTask, jsonPayload)
+	info, err := asynqPkg.EnqueueReplacingArchived(ctx, distributor.client, distributor.inspector, task, opts...)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+
+	distributor.logger.Infof("type=%s, payload=%s, queue=%s, maxRetry=%d enqueued task", info.Type, info.Payload, info.Queue, info.MaxRetry)
+
+	return nil
+}
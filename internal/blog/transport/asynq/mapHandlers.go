@@ -0,0 +1,8 @@
+package asynq
+
+import asynqPkg "github.com/scul0405/blog-clean-architecture-rest-api/pkg/asynq"
+
+func MapHandlers(tp *asynqPkg.RedisTaskProcessor, bp BlogProcessor) {
+	tp.RegisterHandler(TypeProcessCoverTask, bp.ProcessTaskProcessCover)
+	tp.RegisterHandler(TypePublishBlogTask, bp.ProcessTaskPublishBlog)
+}
@@ -0,0 +1,23 @@
+package asynq
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	TypeProcessCoverTask = "blog:process_cover"
+	TypePublishBlogTask  = "blog:publish"
+)
+
+type ProcessCoverPayload struct {
+	BlogID     uuid.UUID
+	ImageID    string
+	BucketName string
+	ObjectKey  string
+}
+
+type PublishBlogPayload struct {
+	BlogID    uuid.UUID
+	PublishAt time.Time
+}
@@ -0,0 +1,65 @@
+package asynq
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/hibiken/asynq"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/blog"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	asynqPkg "github.com/scul0405/blog-clean-architecture-rest-api/pkg/asynq"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/logger"
+)
+
+type BlogProcessor interface {
+	ProcessTaskProcessCover(ctx context.Context, t *asynq.Task) error
+	ProcessTaskPublishBlog(ctx context.Context, t *asynq.Task) error
+}
+
+type blogProcessor struct {
+	blogUC blog.UseCase
+	logger logger.Logger
+}
+
+func NewBlogProcessor(blogUC blog.UseCase, logger logger.Logger) BlogProcessor {
+	return &blogProcessor{
+		blogUC: blogUC,
+		logger: logger,
+	}
+}
+
+func (p *blogProcessor) ProcessTaskProcessCover(ctx context.Context, t *asynq.Task) error {
+	var payload ProcessCoverPayload
+	if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+		return fmt.Errorf("json.Unmarshal failed: %v: %w", err, asynq.SkipRetry)
+	}
+
+	err := p.blogUC.ProcessCover(ctx, payload.BlogID, &models.ImageInput{
+		ImageID:    payload.ImageID,
+		BucketName: payload.BucketName,
+		ObjectKey:  payload.ObjectKey,
+	})
+	if err != nil {
+		return err
+	}
+
+	p.logger.Infof("type=%s, blog_id=%s processed cover", t.Type(), payload.BlogID)
+
+	return nil
+}
+
+func (p *blogProcessor) ProcessTaskPublishBlog(ctx context.Context, t *asynq.Task) error {
+	var payload PublishBlogPayload
+	if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+		return fmt.Errorf("json.Unmarshal failed: %v: %w", err, asynq.SkipRetry)
+	}
+
+	err := p.blogUC.ProcessPublish(ctx, payload.BlogID, payload.PublishAt)
+	if err != nil {
+		return asynqPkg.SkipRetryIfPermanent(err)
+	}
+
+	p.logger.Infof("type=%s, blog_id=%s published scheduled blog", t.Type(), payload.BlogID)
+
+	return nil
+}
@@ -11,8 +11,14 @@ import (
 	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/logger"
 	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/utils"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 )
 
+// renderFormatHTML is value of render query param requesting rendered content
+const renderFormatHTML = "html"
+
 type blogHandlers struct {
 	cfg    *config.Config
 	blogUC blog.UseCase
@@ -29,7 +35,7 @@ func NewBlogHandlers(cfg *config.Config, blogUC blog.UseCase, logger logger.Logg
 
 // Create godoc
 // @Summary Create blog
-// @Description create blog, returns blog
+// @Description create blog, returns blog. Blog is draft unless status is published
 // @Tags Blog
 // @Accept json
 // @Produce json
@@ -56,7 +62,7 @@ func (h *blogHandlers) Create() echo.HandlerFunc {
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
-		return c.JSON(http.StatusCreated, createdBlog)
+		return c.JSON(http.StatusCreated, withContentHTML(createdBlog, false))
 	}
 }
 
@@ -67,7 +73,10 @@ func (h *blogHandlers) Create() echo.HandlerFunc {
 // @Accept json
 // @Produce json
 // @Param blog_id path string true "blog_id"
+// @Param render query string false "html returns content rendered to sanitised HTML as content_html" Enums(html)
+// @Param If-None-Match header string false "ETag of cached blog, returns 304 when unchanged"
 // @Success 200 {object} models.BlogBase
+// @Success 304 "not modified"
 // @Failure 400 {object} httpErrors.RestError
 // @Failure 500 {object} httpErrors.RestError
 // @Router /blogs/{blog_id} [get]
@@ -82,13 +91,78 @@ func (h *blogHandlers) GetByID() echo.HandlerFunc {
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
+		renderHTML, err := getRenderHTMLFromCtx(c)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
 		blogByID, err := h.blogUC.GetByID(ctx, blogID)
 		if err != nil {
 			utils.LogResponseError(c, h.logger, err)
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
-		return c.JSON(http.StatusOK, blogByID)
+		variant := blogETagVariant(blogByID, renderHTML)
+		utils.SetCallerCache(c, ctx)
+		utils.SetETag(c, blogByID.Version, variant...)
+		if utils.IfNoneMatch(c, blogByID.Version, variant...) {
+			return c.NoContent(http.StatusNotModified)
+		}
+
+		return c.JSON(http.StatusOK, withContentHTML(blogByID, renderHTML))
+	}
+}
+
+// GetBySlug godoc
+// @Summary Get blog by slug
+// @Description get blog by its current slug, old slug of blog redirects to current one
+// @Tags Blog
+// @Accept json
+// @Produce json
+// @Param slug path string true "slug"
+// @Param render query string false "html returns content rendered to sanitised HTML as content_html" Enums(html)
+// @Param If-None-Match header string false "ETag of cached blog, returns 304 when unchanged"
+// @Success 200 {object} models.BlogBase
+// @Success 301 "moved to current slug"
+// @Success 304 "not modified"
+// @Failure 404 {object} httpErrors.RestError
+// @Failure 500 {object} httpErrors.RestError
+// @Router /blogs/by-slug/{slug} [get]
+func (h *blogHandlers) GetBySlug() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "blogHandlers.GetBySlug")
+		defer span.Finish()
+
+		renderHTML, err := getRenderHTMLFromCtx(c)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		slug := c.Param("slug")
+		blogBySlug, err := h.blogUC.GetBySlug(ctx, slug)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		if blogBySlug.Slug != slug {
+			location := strings.Replace(c.Path(), ":slug", url.PathEscape(blogBySlug.Slug), 1)
+			if c.QueryString() != "" {
+				location += "?" + c.QueryString()
+			}
+			return c.Redirect(http.StatusMovedPermanently, location)
+		}
+
+		variant := blogETagVariant(blogBySlug, renderHTML)
+		utils.SetCallerCache(c, ctx)
+		utils.SetETag(c, blogBySlug.Version, variant...)
+		if utils.IfNoneMatch(c, blogBySlug.Version, variant...) {
+			return c.NoContent(http.StatusNotModified)
+		}
+
+		return c.JSON(http.StatusOK, withContentHTML(blogBySlug, renderHTML))
 	}
 }
 
@@ -100,9 +174,11 @@ func (h *blogHandlers) GetByID() echo.HandlerFunc {
 // @Produce json
 // @Security Bearer
 // @Param blog_id path string true "blog_id"
+// @Param If-Match header string false "ETag of blog being updated, update fails when blog was changed since"
 // @Param request body models.BlogBase true "input data"
 // @Success 200 {object} models.BlogBase
 // @Failure 400 {object} httpErrors.RestError
+// @Failure 412 {object} httpErrors.PreconditionFailedError
 // @Failure 500 {object} httpErrors.RestError
 // @Router /blogs/{blog_id} [patch]
 func (h *blogHandlers) Update() echo.HandlerFunc {
@@ -123,13 +199,24 @@ func (h *blogHandlers) Update() echo.HandlerFunc {
 		}
 		blogReq.BlogID = blogID
 
+		// If-Match takes precedence over version of request body
+		version, err := utils.GetIfMatchVersion(c)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+		if version != 0 {
+			blogReq.Version = version
+		}
+
 		updatedBlog, err := h.blogUC.Update(ctx, blogReq)
 		if err != nil {
 			utils.LogResponseError(c, h.logger, err)
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
-		return c.JSON(http.StatusOK, updatedBlog)
+		utils.SetETag(c, updatedBlog.Version)
+		return c.JSON(http.StatusOK, withContentHTML(updatedBlog, false))
 	}
 }
 
@@ -141,8 +228,10 @@ func (h *blogHandlers) Update() echo.HandlerFunc {
 // @Produce json
 // @Security Bearer
 // @Param blog_id path string true "blog_id"
+// @Param If-Match header string false "ETag of blog being deleted, delete fails when blog was changed since"
 // @Success 200 {string} string "success"
 // @Failure 400 {object} httpErrors.RestError
+// @Failure 412 {object} httpErrors.PreconditionFailedError
 // @Failure 500 {object} httpErrors.RestError
 // @Router /blogs/{blog_id} [delete]
 func (h *blogHandlers) Delete() echo.HandlerFunc {
@@ -156,7 +245,13 @@ func (h *blogHandlers) Delete() echo.HandlerFunc {
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
-		if err = h.blogUC.Delete(ctx, blogID); err != nil {
+		version, err := utils.GetIfMatchVersion(c)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		if err = h.blogUC.Delete(ctx, blogID, version); err != nil {
 			utils.LogResponseError(c, h.logger, err)
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
@@ -165,12 +260,291 @@ func (h *blogHandlers) Delete() echo.HandlerFunc {
 	}
 }
 
+// ListRevisions godoc
+// @Summary List revisions of blog
+// @Description list revisions of blog without content, newest first
+// @Tags Blog
+// @Accept json
+// @Produce json
+// @Param blog_id path string true "blog_id"
+// @Param page query int false "page number" Format(page)
+// @Param size query int false "number of elements per page" Format(size)
+// @Success 200 {object} models.BlogRevisionsList
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 500 {object} httpErrors.RestError
+// @Router /blogs/{blog_id}/revisions [get]
+func (h *blogHandlers) ListRevisions() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "blogHandlers.ListRevisions")
+		defer span.Finish()
+
+		blogID, err := uuid.Parse(c.Param("blog_id"))
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		pq, err := utils.GetPaginationFromCtx(c)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		revisionsList, err := h.blogUC.ListRevisions(ctx, blogID, pq)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, revisionsList)
+	}
+}
+
+// GetRevision godoc
+// @Summary Get revision of blog
+// @Description get revision of blog with content
+// @Tags Blog
+// @Accept json
+// @Produce json
+// @Param blog_id path string true "blog_id"
+// @Param rev path int true "revision number"
+// @Success 200 {object} models.BlogRevision
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 500 {object} httpErrors.RestError
+// @Router /blogs/{blog_id}/revisions/{rev} [get]
+func (h *blogHandlers) GetRevision() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "blogHandlers.GetRevision")
+		defer span.Finish()
+
+		blogID, revision, err := getRevisionFromCtx(c)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		blogRevision, err := h.blogUC.GetRevision(ctx, blogID, revision)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, blogRevision)
+	}
+}
+
+// DiffRevisions godoc
+// @Summary Diff revisions of blog
+// @Description line level diff of title and content from one revision to other
+// @Tags Blog
+// @Accept json
+// @Produce json
+// @Param blog_id path string true "blog_id"
+// @Param from query int true "revision to compare from"
+// @Param to query int true "revision to compare to"
+// @Success 200 {object} models.BlogRevisionDiff
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 413 {object} httpErrors.RestError
+// @Failure 500 {object} httpErrors.RestError
+// @Router /blogs/{blog_id}/revisions/diff [get]
+func (h *blogHandlers) DiffRevisions() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "blogHandlers.DiffRevisions")
+		defer span.Finish()
+
+		blogID, err := uuid.Parse(c.Param("blog_id"))
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		invalid := make([]httpErrors.FieldError, 0)
+		from, err := strconv.Atoi(c.QueryParam("from"))
+		if err != nil || from < 1 {
+			invalid = append(invalid, httpErrors.FieldError{Field: "from", Value: c.QueryParam("from"), Message: "must be revision number"})
+		}
+		to, err := strconv.Atoi(c.QueryParam("to"))
+		if err != nil || to < 1 {
+			invalid = append(invalid, httpErrors.FieldError{Field: "to", Value: c.QueryParam("to"), Message: "must be revision number"})
+		}
+		if len(invalid) > 0 {
+			err = httpErrors.NewValidationError(invalid...)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		diff, err := h.blogUC.DiffRevisions(ctx, blogID, from, to)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, diff)
+	}
+}
+
+// RestoreRevision godoc
+// @Summary Restore revision of blog
+// @Description set blog to state of revision, restored state becomes new revision. Only author can restore
+// @Tags Blog
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param blog_id path string true "blog_id"
+// @Param rev path int true "revision number"
+// @Success 200 {object} models.BlogBase
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 403 {object} httpErrors.RestError
+// @Failure 500 {object} httpErrors.RestError
+// @Router /blogs/{blog_id}/revisions/{rev}/restore [post]
+func (h *blogHandlers) RestoreRevision() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "blogHandlers.RestoreRevision")
+		defer span.Finish()
+
+		blogID, revision, err := getRevisionFromCtx(c)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		restoredBlog, err := h.blogUC.RestoreRevision(ctx, blogID, revision)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, withContentHTML(restoredBlog, false))
+	}
+}
+
+// Publish godoc
+// @Summary Publish blog by id
+// @Description publish blog now, or schedule it when publish_at is in future. Returns blog
+// @Tags Blog
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param blog_id path string true "blog_id"
+// @Param request body models.BlogPublish false "input data"
+// @Success 200 {object} models.BlogBase
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 409 {object} httpErrors.RestError
+// @Failure 500 {object} httpErrors.RestError
+// @Router /blogs/{blog_id}/publish [post]
+func (h *blogHandlers) Publish() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "blogHandlers.Publish")
+		defer span.Finish()
+
+		blogID, err := uuid.Parse(c.Param("blog_id"))
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		publishReq := &models.BlogPublish{}
+		if err = utils.ReadRequest(c, publishReq); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		publishedBlog, err := h.blogUC.Publish(ctx, blogID, publishReq)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, publishedBlog)
+	}
+}
+
+// Archive godoc
+// @Summary Archive blog by id
+// @Description archive blog, archived blog is seen by its author only. Returns blog
+// @Tags Blog
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param blog_id path string true "blog_id"
+// @Success 200 {object} models.BlogBase
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 500 {object} httpErrors.RestError
+// @Router /blogs/{blog_id}/archive [post]
+func (h *blogHandlers) Archive() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "blogHandlers.Archive")
+		defer span.Finish()
+
+		blogID, err := uuid.Parse(c.Param("blog_id"))
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		archivedBlog, err := h.blogUC.Archive(ctx, blogID)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, archivedBlog)
+	}
+}
+
+// Release godoc
+// @Summary Release blog held for moderation by id
+// @Description release blog held by content filter, its author can publish it then. Returns blog
+// @Tags Blog
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param blog_id path string true "blog_id"
+// @Success 200 {object} models.BlogBase
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 403 {object} httpErrors.RestError
+// @Failure 404 {object} httpErrors.RestError
+// @Failure 500 {object} httpErrors.RestError
+// @Router /blogs/{blog_id}/release [post]
+func (h *blogHandlers) Release() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "blogHandlers.Release")
+		defer span.Finish()
+
+		blogID, err := uuid.Parse(c.Param("blog_id"))
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		releasedBlog, err := h.blogUC.Release(ctx, blogID)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, releasedBlog)
+	}
+}
+
 // List godoc
 // @Summary List blogs
-// @Description List blogs, return list of blogs
+// @Description List published blogs, authenticated caller also gets own blogs of other statuses
 // @Tags Blog
 // @Accept json
 // @Produce json
+// @Param category query string false "category slug"
+// @Param tag query string false "tag name"
+// @Param author_id query string false "author id"
+// @Param created_after query string false "RFC3339 time or date, inclusive"
+// @Param created_before query string false "RFC3339 time or date, exclusive"
+// @Param has_image query bool false "only blogs with or without cover image"
+// @Param status query string false "one of draft, scheduled, published, archived"
+// @Param sort query string false "comma separated fields of created_at, updated_at, title, prefix with - to sort descending, e.g. -created_at,title"
+// @Param cursor query string false "next_cursor or prev_cursor of previous response, empty for first page, switches to cursor pagination newest first"
+// @Param limit query int false "number of elements per page in cursor pagination, from 1 to 100"
+// @Param page query int false "page number" Format(page)
+// @Param size query int false "number of elements per page" Format(size)
 // @Success 200 {object} models.BlogsList
 // @Failure 400 {object} httpErrors.RestError
 // @Failure 500 {object} httpErrors.RestError
@@ -180,13 +554,24 @@ func (h *blogHandlers) List() echo.HandlerFunc {
 		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "blogHandlers.List")
 		defer span.Finish()
 
+		filter, err := getBlogFilterFromCtx(c)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
 		pq, err := utils.GetPaginationFromCtx(c)
 		if err != nil {
 			utils.LogResponseError(c, h.logger, err)
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
-		blogsList, err := h.blogUC.List(ctx, pq)
+		if err = pq.SetSort(models.BlogSortFields...); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		blogsList, err := h.blogUC.List(ctx, filter, pq)
 		if err != nil {
 			utils.LogResponseError(c, h.logger, err)
 			return c.JSON(httpErrors.ErrorResponse(err))
@@ -195,3 +580,211 @@ func (h *blogHandlers) List() echo.HandlerFunc {
 		return c.JSON(http.StatusOK, blogsList)
 	}
 }
+
+// Search godoc
+// @Summary Search blogs
+// @Description full text search of blogs and their comments, title matches rank above content matches and comment matches rank lowest,
+// @Description returns list of blogs with rank and snippet
+// @Tags Blog
+// @Accept json
+// @Produce json
+// @Param q query string true "search query, supports quoted phrases, OR and -exclusion"
+// @Param category query string false "category slug"
+// @Param tag query string false "tag name"
+// @Param author_id query string false "author id"
+// @Param created_after query string false "RFC3339 time or date, inclusive"
+// @Param created_before query string false "RFC3339 time or date, exclusive"
+// @Param has_image query bool false "only blogs with or without cover image"
+// @Param page query int false "page number" Format(page)
+// @Param size query int false "number of elements per page" Format(size)
+// @Success 200 {object} models.BlogsList
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 500 {object} httpErrors.RestError
+// @Router /blogs/search [get]
+func (h *blogHandlers) Search() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "blogHandlers.Search")
+		defer span.Finish()
+
+		query := strings.TrimSpace(c.QueryParam("q"))
+		if query == "" {
+			err := httpErrors.NewRestError(http.StatusBadRequest, "Query parameter q is required", nil)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		filter, err := getBlogFilterFromCtx(c)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		pq, err := utils.GetPaginationFromCtx(c)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		blogsList, err := h.blogUC.Search(ctx, &models.BlogSearchQuery{Query: query, BlogFilter: *filter}, pq)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, blogsList)
+	}
+}
+
+// UploadCover godoc
+// @Summary Upload blog cover image
+// @Description upload cover image, only author or editor can upload. Image is processed in background into variants,
+// @Description previous cover is removed once they are stored, returns url of every variant
+// @Tags Blog
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param file formData file true "cover image"
+// @Param blog_id path string true "blog_id"
+// @Param bucket query string true "minio bucket"
+// @Success 202 {object} models.ImageVariants
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 401 {object} httpErrors.RestError
+// @Failure 403 {object} httpErrors.RestError
+// @Failure 413 {object} httpErrors.RestError
+// @Failure 500 {object} httpErrors.RestError
+// @Router /blogs/{blog_id}/cover [post]
+func (h *blogHandlers) UploadCover() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "blogHandlers.UploadCover")
+		defer span.Finish()
+
+		bucket := c.QueryParam("bucket")
+		blogID, err := uuid.Parse(c.Param("blog_id"))
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		image, err := utils.ReadImage(c, "file")
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		binaryImage, err := utils.ReadImageFile(image, utils.MaxImageSize)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		variants, err := h.blogUC.UploadCover(ctx, blogID, &models.ImageInput{
+			BucketName: bucket,
+			Image:      binaryImage,
+		})
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusAccepted, variants)
+	}
+}
+
+// getBlogFilterFromCtx read optional blog filters from query params, every invalid one is reported
+func getBlogFilterFromCtx(c echo.Context) (*models.BlogFilter, error) {
+	filter := &models.BlogFilter{
+		Category: strings.TrimSpace(c.QueryParam("category")),
+		Tag:      utils.Slugify(c.QueryParam("tag")),
+	}
+	invalid := make([]httpErrors.FieldError, 0)
+
+	if authorID := c.QueryParam("author_id"); authorID != "" {
+		authorUID, err := uuid.Parse(authorID)
+		if err != nil {
+			invalid = append(invalid, httpErrors.FieldError{Field: "author_id", Value: authorID, Message: "must be uuid"})
+		} else {
+			filter.AuthorID = &authorUID
+		}
+	}
+
+	var err error
+	if filter.CreatedAfter, err = utils.ParseTimeQuery(c.QueryParam("created_after")); err != nil {
+		invalid = append(invalid, httpErrors.FieldError{Field: "created_after", Value: c.QueryParam("created_after"), Message: "must be RFC3339 time or date"})
+	}
+	if filter.CreatedBefore, err = utils.ParseTimeQuery(c.QueryParam("created_before")); err != nil {
+		invalid = append(invalid, httpErrors.FieldError{Field: "created_before", Value: c.QueryParam("created_before"), Message: "must be RFC3339 time or date"})
+	}
+
+	if hasImage := c.QueryParam("has_image"); hasImage != "" {
+		value, err := strconv.ParseBool(hasImage)
+		if err != nil {
+			invalid = append(invalid, httpErrors.FieldError{Field: "has_image", Value: hasImage, Message: "must be boolean"})
+		} else {
+			filter.HasImage = &value
+		}
+	}
+
+	if status := c.QueryParam("status"); status != "" {
+		if !isBlogStatus(status) {
+			invalid = append(invalid, httpErrors.FieldError{Field: "status", Value: status, Message: "must be one of " + strings.Join(models.BlogStatuses, ", ")})
+		} else {
+			filter.Status = status
+		}
+	}
+
+	if len(invalid) > 0 {
+		return nil, httpErrors.NewValidationError(invalid...)
+	}
+
+	return filter, nil
+}
+
+// getRenderHTMLFromCtx report whether rendered HTML of content is requested
+func getRenderHTMLFromCtx(c echo.Context) (bool, error) {
+	switch render := c.QueryParam("render"); render {
+	case "":
+		return false, nil
+	case renderFormatHTML:
+		return true, nil
+	default:
+		return false, httpErrors.NewValidationError(httpErrors.FieldError{Field: "render", Value: render, Message: "must be " + renderFormatHTML})
+	}
+}
+
+// withContentHTML leave rendered HTML of blog in response only when it was requested
+func withContentHTML(blog *models.BlogBase, renderHTML bool) *models.BlogBase {
+	if !renderHTML {
+		blog.ContentHTML = nil
+	}
+
+	return blog
+}
+
+// blogETagVariant is state response of blog depends on besides its version, reactions change without new version
+func blogETagVariant(blog *models.BlogBase, renderHTML bool) []string {
+	return []string{strconv.FormatBool(renderHTML), utils.ReactionsVariant(blog.Reactions, blog.MyReactions)}
+}
+
+func isBlogStatus(status string) bool {
+	for _, s := range models.BlogStatuses {
+		if s == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+func getRevisionFromCtx(c echo.Context) (uuid.UUID, int, error) {
+	blogID, err := uuid.Parse(c.Param("blog_id"))
+	if err != nil {
+		return uuid.Nil, 0, err
+	}
+
+	revision, err := strconv.Atoi(c.Param("rev"))
+	if err != nil || revision < 1 {
+		return uuid.Nil, 0, httpErrors.NewValidationError(httpErrors.FieldError{Field: "rev", Value: c.Param("rev"), Message: "must be revision number"})
+	}
+
+	return blogID, revision, nil
+}
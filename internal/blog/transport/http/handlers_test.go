@@ -13,6 +13,7 @@ import (
 	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/utils"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
+	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"strings"
@@ -119,6 +120,126 @@ func TestBlogHandlers_GetByID(t *testing.T) {
 	require.Nil(t, err)
 }
 
+func TestBlogHandlers_GetBySlug(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBlogUC := mock.NewMockUseCase(ctrl)
+
+	cfg := &config.Config{
+		Logger: config.LoggerConfig{
+			Development: true,
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	apiLogger.InitLogger()
+	blogHandlers := NewBlogHandlers(cfg, mockBlogUC, apiLogger)
+
+	blogBase := &models.BlogBase{
+		BlogID:  uuid.New(),
+		Title:   "New title of blog",
+		Version: 3,
+		Slug:    "new-title-of-blog",
+	}
+
+	t.Run("Old slug redirects", func(t *testing.T) {
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(httptest.NewRequest(echo.GET, "/api/v1/blogs/by-slug/old-title", nil), rec)
+		c.SetPath("/api/v1/blogs/by-slug/:slug")
+		c.SetParamNames("slug")
+		c.SetParamValues("old-title")
+
+		mockBlogUC.EXPECT().GetBySlug(gomock.Any(), "old-title").Return(blogBase, nil)
+
+		require.NoError(t, blogHandlers.GetBySlug()(c))
+		require.Equal(t, http.StatusMovedPermanently, rec.Code)
+		require.Equal(t, "/api/v1/blogs/by-slug/new-title-of-blog", rec.Header().Get(echo.HeaderLocation))
+	})
+
+	t.Run("Current slug", func(t *testing.T) {
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(httptest.NewRequest(echo.GET, "/api/v1/blogs/by-slug/new-title-of-blog", nil), rec)
+		c.SetPath("/api/v1/blogs/by-slug/:slug")
+		c.SetParamNames("slug")
+		c.SetParamValues("new-title-of-blog")
+
+		mockBlogUC.EXPECT().GetBySlug(gomock.Any(), "new-title-of-blog").Return(blogBase, nil)
+
+		require.NoError(t, blogHandlers.GetBySlug()(c))
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.True(t, strings.HasPrefix(rec.Header().Get(utils.HeaderETag), `"3-`))
+		require.Equal(t, echo.HeaderAuthorization, rec.Header().Get(echo.HeaderVary))
+		require.Empty(t, rec.Header().Get(echo.HeaderCacheControl))
+	})
+
+	t.Run("Reactions change ETag", func(t *testing.T) {
+		etags := make(map[string]bool)
+		for _, myReactions := range [][]string{nil, {"like"}} {
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(echo.GET, "/api/v1/blogs/by-slug/new-title-of-blog", nil)
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", uuid.New().String()))
+			req.Header.Set(utils.HeaderIfNoneMatch, `"3"`)
+			c := e.NewContext(req, rec)
+			c.SetPath("/api/v1/blogs/by-slug/:slug")
+			c.SetParamNames("slug")
+			c.SetParamValues("new-title-of-blog")
+
+			blogCopy := *blogBase
+			blogCopy.Reactions = map[string]int{"like": 1}
+			blogCopy.MyReactions = myReactions
+			mockBlogUC.EXPECT().GetBySlug(gomock.Any(), "new-title-of-blog").Return(&blogCopy, nil)
+
+			require.NoError(t, blogHandlers.GetBySlug()(c))
+			require.Equal(t, http.StatusOK, rec.Code)
+			require.Equal(t, "private", rec.Header().Get(echo.HeaderCacheControl))
+			etags[rec.Header().Get(utils.HeaderETag)] = true
+		}
+		require.Len(t, etags, 2)
+	})
+
+	t.Run("Rendered HTML", func(t *testing.T) {
+		contentHTML := "<p>content</p>\n"
+		rendered := &models.BlogBase{BlogID: blogBase.BlogID, Slug: blogBase.Slug, Content: "content", ContentHTML: &contentHTML}
+
+		etags := make(map[string]bool)
+		for render, hasHTML := range map[string]bool{"": false, "html": true} {
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(echo.GET, "/api/v1/blogs/by-slug/new-title-of-blog?render="+render, nil), rec)
+			c.SetPath("/api/v1/blogs/by-slug/:slug")
+			c.SetParamNames("slug")
+			c.SetParamValues("new-title-of-blog")
+
+			blogCopy := *rendered
+			mockBlogUC.EXPECT().GetBySlug(gomock.Any(), "new-title-of-blog").Return(&blogCopy, nil)
+
+			require.NoError(t, blogHandlers.GetBySlug()(c))
+			require.Equal(t, http.StatusOK, rec.Code)
+			require.Equal(t, hasHTML, strings.Contains(rec.Body.String(), `"content_html"`))
+			etags[rec.Header().Get(utils.HeaderETag)] = true
+		}
+		require.Len(t, etags, 2)
+	})
+
+	t.Run("Invalid render", func(t *testing.T) {
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(httptest.NewRequest(echo.GET, "/api/v1/blogs/by-slug/new-title-of-blog?render=pdf", nil), rec)
+		c.SetPath("/api/v1/blogs/by-slug/:slug")
+		c.SetParamNames("slug")
+		c.SetParamValues("new-title-of-blog")
+
+		require.NoError(t, blogHandlers.GetBySlug()(c))
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
 func TestBlogHandlers_Update(t *testing.T) {
 	t.Parallel()
 
@@ -205,7 +326,7 @@ func TestBlogHandlers_Delete(t *testing.T) {
 	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "blogHandlers.Delete")
 	defer span.Finish()
 
-	mockBlogUC.EXPECT().Delete(ctxWithTrace, gomock.Eq(blogUID)).Return(nil)
+	mockBlogUC.EXPECT().Delete(ctxWithTrace, gomock.Eq(blogUID), 0).Return(nil)
 
 	handlerFunc := blogHandlers.Delete()
 	err := handlerFunc(c)
@@ -268,10 +389,57 @@ func TestBlogHandlers_List(t *testing.T) {
 	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "blogHandlers.List")
 	defer span.Finish()
 
-	mockBlogUC.EXPECT().List(ctxWithTrace, gomock.Eq(pq)).Return(blogList, nil)
+	mockBlogUC.EXPECT().List(ctxWithTrace, gomock.Eq(&models.BlogFilter{}), gomock.Eq(pq)).Return(blogList, nil)
 
 	handlerFunc := blogHandlers.List()
 	err := handlerFunc(c)
 	require.NoError(t, err)
 	require.Nil(t, err)
 }
+
+func TestBlogHandlers_ListInvalidQuery(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBlogUC := mock.NewMockUseCase(ctrl)
+
+	cfg := &config.Config{
+		Logger: config.LoggerConfig{
+			Development: true,
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	apiLogger.InitLogger()
+	blogHandlers := NewBlogHandlers(cfg, mockBlogUC, apiLogger)
+
+	e := echo.New()
+	q := make(url.Values)
+	q.Set("sort", "-created_at,password")
+	q.Set("has_image", "maybe")
+
+	t.Run("invalid sort", func(t *testing.T) {
+		req := httptest.NewRequest(echo.GET, "/api/v1/blogs?sort="+url.QueryEscape(q.Get("sort")), nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := blogHandlers.List()(c)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		require.Contains(t, rec.Body.String(), `"field":"sort"`)
+		require.Contains(t, rec.Body.String(), `"value":"password"`)
+	})
+
+	t.Run("invalid filter", func(t *testing.T) {
+		req := httptest.NewRequest(echo.GET, "/api/v1/blogs?"+q.Encode(), nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := blogHandlers.List()(c)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		require.Contains(t, rec.Body.String(), `"field":"has_image"`)
+	})
+}
@@ -4,12 +4,23 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/scul0405/blog-clean-architecture-rest-api/internal/blog"
 	"github.com/scul0405/blog-clean-architecture-rest-api/internal/middleware"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/rbac"
 )
 
 func MapBlogRoutes(blogGroup *echo.Group, h blog.Handlers, mw *middleware.MiddlewareManager) {
-	blogGroup.POST("", h.Create(), mw.AuthPASETOMiddleware)
-	blogGroup.GET("/:blog_id", h.GetByID())
+	blogGroup.POST("", h.Create(), mw.AuthPASETOMiddleware, mw.RequirePermission(rbac.BlogCreate))
+	blogGroup.GET("/:blog_id", h.GetByID(), mw.OptionalAuthPASETOMiddleware)
+	blogGroup.GET("/by-slug/:slug", h.GetBySlug(), mw.OptionalAuthPASETOMiddleware)
 	blogGroup.PATCH("/:blog_id", h.Update(), mw.AuthPASETOMiddleware)
 	blogGroup.DELETE("/:blog_id", h.Delete(), mw.AuthPASETOMiddleware)
-	blogGroup.GET("", h.List())
+	blogGroup.GET("/:blog_id/revisions", h.ListRevisions(), mw.OptionalAuthPASETOMiddleware)
+	blogGroup.GET("/:blog_id/revisions/diff", h.DiffRevisions(), mw.OptionalAuthPASETOMiddleware)
+	blogGroup.GET("/:blog_id/revisions/:rev", h.GetRevision(), mw.OptionalAuthPASETOMiddleware)
+	blogGroup.POST("/:blog_id/revisions/:rev/restore", h.RestoreRevision(), mw.AuthPASETOMiddleware)
+	blogGroup.POST("/:blog_id/publish", h.Publish(), mw.AuthPASETOMiddleware)
+	blogGroup.POST("/:blog_id/archive", h.Archive(), mw.AuthPASETOMiddleware)
+	blogGroup.POST("/:blog_id/release", h.Release(), mw.AuthPASETOMiddleware, mw.RequirePermission(rbac.BlogModerate))
+	blogGroup.GET("", h.List(), mw.OptionalAuthPASETOMiddleware)
+	blogGroup.GET("/search", h.Search(), mw.OptionalAuthPASETOMiddleware)
+	blogGroup.POST("/:blog_id/cover", h.UploadCover(), mw.AuthPASETOMiddleware)
 }
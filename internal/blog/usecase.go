@@ -6,12 +6,25 @@ import (
 	"github.com/google/uuid"
 	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
 	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/utils"
+	"time"
 )
 
 type UseCase interface {
 	Create(ctx context.Context, blog *models.Blog) (*models.BlogBase, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*models.BlogBase, error)
+	GetBySlug(ctx context.Context, slug string) (*models.BlogBase, error)
 	Update(ctx context.Context, blog *models.BlogBase) (*models.BlogBase, error)
-	Delete(ctx context.Context, id uuid.UUID) error
-	List(ctx context.Context, pq *utils.PaginationQuery) (*models.BlogsList, error)
+	Delete(ctx context.Context, id uuid.UUID, version int) error
+	ListRevisions(ctx context.Context, id uuid.UUID, pq *utils.PaginationQuery) (*models.BlogRevisionsList, error)
+	GetRevision(ctx context.Context, id uuid.UUID, revision int) (*models.BlogRevision, error)
+	DiffRevisions(ctx context.Context, id uuid.UUID, from, to int) (*models.BlogRevisionDiff, error)
+	RestoreRevision(ctx context.Context, id uuid.UUID, revision int) (*models.BlogBase, error)
+	Publish(ctx context.Context, id uuid.UUID, publish *models.BlogPublish) (*models.BlogBase, error)
+	Archive(ctx context.Context, id uuid.UUID) (*models.BlogBase, error)
+	Release(ctx context.Context, id uuid.UUID) (*models.BlogBase, error)
+	ProcessPublish(ctx context.Context, id uuid.UUID, publishAt time.Time) error
+	List(ctx context.Context, filter *models.BlogFilter, pq *utils.PaginationQuery) (*models.BlogsList, error)
+	Search(ctx context.Context, query *models.BlogSearchQuery, pq *utils.PaginationQuery) (*models.BlogsList, error)
+	UploadCover(ctx context.Context, blogID uuid.UUID, image *models.ImageInput) (models.ImageVariants, error)
+	ProcessCover(ctx context.Context, blogID uuid.UUID, image *models.ImageInput) error
 }
@@ -1,34 +1,59 @@
 package usecase
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/minio/minio-go/v7"
 	"github.com/opentracing/opentracing-go"
 	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
 	"github.com/scul0405/blog-clean-architecture-rest-api/config"
 	"github.com/scul0405/blog-clean-architecture-rest-api/internal/blog"
+	blogAsynq "github.com/scul0405/blog-clean-architecture-rest-api/internal/blog/transport/asynq"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/content_filter"
+	feedAsynq "github.com/scul0405/blog-clean-architecture-rest-api/internal/feed/transport/asynq"
 	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/reaction"
+	asynqPkg "github.com/scul0405/blog-clean-architecture-rest-api/pkg/asynq"
 	httpErrors "github.com/scul0405/blog-clean-architecture-rest-api/pkg/http_errors"
 	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/logger"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/rbac"
 	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/utils"
 	"net/http"
+	"strings"
+	"time"
 )
 
 const (
-	basePrefix    = "blog-api"
-	cacheDuration = 3600
+	basePrefix           = "blog-api"
+	cacheDuration        = 3600
+	processImageMaxRetry = 3
+	publishBlogMaxRetry  = 10
+	fanOutBlogMaxRetry   = 5
+	coverKeyPrefix       = "covers"
+	maxTagLength         = 32
 )
 
 type blogUseCase struct {
-	cfg       *config.Config
-	blogRepo  blog.Repository
-	redisRepo blog.RedisRepository
-	logger    logger.Logger
+	cfg           *config.Config
+	blogRepo      blog.Repository
+	redisRepo     blog.RedisRepository
+	minioRepo     blog.MinioRepository
+	reactionRepo  reaction.Repository
+	blogTD        blogAsynq.BlogTaskDistributor
+	feedTD        feedAsynq.FeedTaskDistributor
+	contentFilter content_filter.ContentFilter
+	logger        logger.Logger
 }
 
-func NewBlogUseCase(cfg *config.Config, blogRepo blog.Repository, redisRepo blog.RedisRepository, logger logger.Logger) blog.UseCase {
-	return &blogUseCase{cfg: cfg, blogRepo: blogRepo, redisRepo: redisRepo, logger: logger}
+func NewBlogUseCase(cfg *config.Config, blogRepo blog.Repository, redisRepo blog.RedisRepository, minioRepo blog.MinioRepository,
+	reactionRepo reaction.Repository, blogTD blogAsynq.BlogTaskDistributor, feedTD feedAsynq.FeedTaskDistributor,
+	contentFilter content_filter.ContentFilter, logger logger.Logger) blog.UseCase {
+	return &blogUseCase{cfg: cfg, blogRepo: blogRepo, redisRepo: redisRepo, minioRepo: minioRepo, reactionRepo: reactionRepo,
+		blogTD: blogTD, feedTD: feedTD, contentFilter: contentFilter, logger: logger}
 }
 
 func (u *blogUseCase) Create(ctx context.Context, blog *models.Blog) (*models.BlogBase, error) {
@@ -41,11 +66,42 @@ func (u *blogUseCase) Create(ctx context.Context, blog *models.Blog) (*models.Bl
 	}
 
 	blog.AuthorID = userUID
+	if blog.Status == "" {
+		blog.Status = models.BlogStatusDraft
+	}
+	if blog.ContentFormat == "" {
+		blog.ContentFormat = models.BlogContentPlain
+	}
+	if blog.Tags, err = normalizeTags(blog.Tags); err != nil {
+		return nil, err
+	}
+
+	decision, err := u.contentFilter.Check(ctx, &models.FilterInput{
+		TargetType: models.FilterTargetBlog,
+		AuthorID:   userUID,
+		Texts:      []string{blog.Title, blog.Content},
+	})
+	if err != nil {
+		return nil, err
+	}
+	blog.Title, blog.Content = decision.Texts[0], decision.Texts[1]
+	// Held blog is kept as draft, it cannot be published until moderator releases it
+	if decision.Action == models.FilterHold {
+		blog.Status = models.BlogStatusDraft
+		blog.Held = true
+	}
+
 	createdBlog, err := u.blogRepo.Create(ctx, blog)
 	if err != nil {
 		return nil, err
 	}
 
+	if err = u.contentFilter.Record(ctx, decision, createdBlog.BlogID); err != nil {
+		u.logger.Errorf("blogUC.Create: Record: %v", err)
+	}
+
+	u.fanOut(ctx, createdBlog)
+
 	return createdBlog, nil
 }
 
@@ -54,11 +110,18 @@ func (u *blogUseCase) GetByID(ctx context.Context, id uuid.UUID) (*models.BlogBa
 	defer span.Finish()
 
 	blogCached, err := u.redisRepo.GetBlogByIDCtx(ctx, u.generateBlogKey(id.String()))
-	if err != nil {
+	if err != nil && !errors.Is(err, redis.Nil) {
 		u.logger.Errorf("blogUC.GetByID: GetBlogByIDCtx: %v", err)
 	}
 
-	if blogCached != nil {
+	// Reactions are not cached, they are attached on every read. Blogs cached before content formats existed are read again
+	if blogCached != nil && blogCached.ContentFormat != "" {
+		if !u.canView(ctx, blogCached) {
+			return nil, httpErrors.NewRestError(http.StatusNotFound, httpErrors.NotFound.Error(), nil)
+		}
+		if err = u.attachReactions(ctx, blogCached); err != nil {
+			return nil, err
+		}
 		return blogCached, nil
 	}
 
@@ -67,13 +130,62 @@ func (u *blogUseCase) GetByID(ctx context.Context, id uuid.UUID) (*models.BlogBa
 		return nil, err
 	}
 
+	// Blogs written before rendering existed have no HTML until they are edited, it is rendered for cache
+	if blog.ContentHTML == nil {
+		rendered, err := utils.RenderContent(blog.Content, blog.ContentFormat == models.BlogContentMarkdown)
+		if err != nil {
+			return nil, errors.Wrap(err, "blogUC.GetByID.RenderContent")
+		}
+		blog.ContentHTML = &rendered.HTML
+	}
+
 	if err = u.redisRepo.SetBlogCtx(ctx, u.generateBlogKey(blog.BlogID.String()), cacheDuration, blog); err != nil {
 		u.logger.Errorf("blogUC.GetByID: SetBlogCtx: %v", err)
 	}
 
+	if !u.canView(ctx, blog) {
+		return nil, httpErrors.NewRestError(http.StatusNotFound, httpErrors.NotFound.Error(), nil)
+	}
+
+	if err = u.attachReactions(ctx, blog); err != nil {
+		return nil, err
+	}
+
 	return blog, nil
 }
 
+// GetBySlug get blog by its current or old slug, caller compares slug of blog to redirect old one.
+// Cached blog id may be of blog deleted since, whose slug is free for other blog, then slug is read again
+func (u *blogUseCase) GetBySlug(ctx context.Context, slug string) (*models.BlogBase, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "blogUC.GetBySlug")
+	defer span.Finish()
+
+	blogID, err := u.redisRepo.GetBlogIDBySlugCtx(ctx, u.generateSlugKey(slug))
+	if err == nil {
+		blogBySlug, err := u.GetByID(ctx, blogID)
+		if err == nil || httpErrors.ParseErrors(err).Status() != http.StatusNotFound {
+			return blogBySlug, err
+		}
+
+		if err = u.redisRepo.DeleteBlogCtx(ctx, u.generateSlugKey(slug)); err != nil {
+			u.logger.Errorf("blogUC.GetBySlug: DeleteBlogCtx: %v", err)
+		}
+	} else if !errors.Is(err, redis.Nil) {
+		u.logger.Errorf("blogUC.GetBySlug: GetBlogIDBySlugCtx: %v", err)
+	}
+
+	blogID, err = u.blogRepo.GetIDBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = u.redisRepo.SetBlogSlugCtx(ctx, u.generateSlugKey(slug), cacheDuration, blogID); err != nil {
+		u.logger.Errorf("blogUC.GetBySlug: SetBlogSlugCtx: %v", err)
+	}
+
+	return u.GetByID(ctx, blogID)
+}
+
 func (u *blogUseCase) Update(ctx context.Context, blog *models.BlogBase) (*models.BlogBase, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "blogUC.Update")
 	defer span.Finish()
@@ -83,14 +195,49 @@ func (u *blogUseCase) Update(ctx context.Context, blog *models.BlogBase) (*model
 		return nil, err
 	}
 
-	if err = utils.ValidateIsOwner(ctx, blogByID.AuthorID.String(), u.logger); err != nil {
-		return nil, httpErrors.NewRestError(http.StatusForbidden, "Forbidden", errors.Wrap(err, "blogUC.Update.ValidateIsOwner"))
+	if err = utils.ValidateIsOwnerOrHasPermission(ctx, blogByID.AuthorID.String(), rbac.BlogUpdateAny, u.logger); err != nil {
+		return nil, err
+	}
+
+	if err = utils.ValidateVersion(blogByID.Version, blog.Version); err != nil {
+		return nil, err
 	}
 
-	updatedBlog, err := u.blogRepo.Update(ctx, blog)
+	if blog.Tags, err = normalizeTags(blog.Tags); err != nil {
+		return nil, err
+	}
+
+	editorUID, err := utils.GetUserUIDFromCtx(ctx)
+	if err != nil {
+		return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "blogUC.Update.GetUserUIDFromCtx"))
+	}
+
+	decision, err := u.contentFilter.Check(ctx, &models.FilterInput{
+		TargetType: models.FilterTargetBlog,
+		TargetID:   &blog.BlogID,
+		AuthorID:   blogByID.AuthorID,
+		Texts:      []string{blog.Title, blog.Content},
+	})
 	if err != nil {
 		return nil, err
 	}
+	blog.Title, blog.Content = decision.Texts[0], decision.Texts[1]
+
+	updatedBlog, err := u.blogRepo.Update(ctx, blog, editorUID)
+	if err != nil {
+		return nil, utils.VersionConflict(err, blog.Version)
+	}
+
+	// Held blog goes back to draft, scheduled blog is not published by its task then
+	if decision.Action == models.FilterHold && !updatedBlog.Held {
+		if updatedBlog, err = u.blogRepo.SetHeld(ctx, updatedBlog.BlogID, true); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = u.contentFilter.Record(ctx, decision, updatedBlog.BlogID); err != nil {
+		u.logger.Errorf("blogUC.Update: Record: %v", err)
+	}
 
 	if err = u.redisRepo.DeleteBlogCtx(ctx, u.generateBlogKey(blog.BlogID.String())); err != nil {
 		u.logger.Errorf("blogUC.Update.DeleteBlogCtx: %v", err)
@@ -99,7 +246,7 @@ func (u *blogUseCase) Update(ctx context.Context, blog *models.BlogBase) (*model
 	return updatedBlog, nil
 }
 
-func (u *blogUseCase) Delete(ctx context.Context, id uuid.UUID) error {
+func (u *blogUseCase) Delete(ctx context.Context, id uuid.UUID, version int) error {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "blogUC.Delete")
 	defer span.Finish()
 
@@ -108,28 +255,550 @@ func (u *blogUseCase) Delete(ctx context.Context, id uuid.UUID) error {
 		return err
 	}
 
-	if err = utils.ValidateIsOwner(ctx, blogByID.AuthorID.String(), u.logger); err != nil {
-		return httpErrors.NewRestError(http.StatusForbidden, "Forbidden", errors.Wrap(err, "blogUC.Delete.ValidateIsOwner"))
+	if err = utils.ValidateIsOwnerOrHasPermission(ctx, blogByID.AuthorID.String(), rbac.BlogDeleteAny, u.logger); err != nil {
+		return err
 	}
 
-	if err = u.blogRepo.Delete(ctx, id); err != nil {
+	if err = utils.ValidateVersion(blogByID.Version, version); err != nil {
 		return err
 	}
 
+	if err = u.blogRepo.Delete(ctx, id, version); err != nil {
+		return utils.VersionConflict(err, version)
+	}
+
 	if err = u.redisRepo.DeleteBlogCtx(ctx, u.generateBlogKey(id.String())); err != nil {
 		u.logger.Errorf("blogUC.Delete.DeleteBlogCtx: %v", err)
 	}
 
+	// Old slugs of blog are dropped when they are read and miss blog
+	if err = u.redisRepo.DeleteBlogCtx(ctx, u.generateSlugKey(blogByID.Slug)); err != nil {
+		u.logger.Errorf("blogUC.Delete.DeleteBlogCtx: %v", err)
+	}
+
+	return nil
+}
+
+// ListRevisions list revisions of blog caller can read, newest first
+func (u *blogUseCase) ListRevisions(ctx context.Context, id uuid.UUID, pq *utils.PaginationQuery) (*models.BlogRevisionsList, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "blogUC.ListRevisions")
+	defer span.Finish()
+
+	if _, err := u.getViewable(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return u.blogRepo.ListRevisions(ctx, id, pq)
+}
+
+func (u *blogUseCase) GetRevision(ctx context.Context, id uuid.UUID, revision int) (*models.BlogRevision, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "blogUC.GetRevision")
+	defer span.Finish()
+
+	if _, err := u.getViewable(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return u.blogRepo.GetRevision(ctx, id, revision)
+}
+
+// DiffRevisions compare title and content of two revisions line by line
+func (u *blogUseCase) DiffRevisions(ctx context.Context, id uuid.UUID, from, to int) (*models.BlogRevisionDiff, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "blogUC.DiffRevisions")
+	defer span.Finish()
+
+	if _, err := u.getViewable(ctx, id); err != nil {
+		return nil, err
+	}
+
+	fromRevision, err := u.blogRepo.GetRevision(ctx, id, from)
+	if err != nil {
+		return nil, err
+	}
+
+	toRevision, err := u.blogRepo.GetRevision(ctx, id, to)
+	if err != nil {
+		return nil, err
+	}
+
+	titleDiff, err := utils.DiffLines(fromRevision.Title, toRevision.Title)
+	if err != nil {
+		return nil, err
+	}
+
+	contentDiff, err := utils.DiffLines(fromRevision.Content, toRevision.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.BlogRevisionDiff{
+		BlogID:  id,
+		From:    from,
+		To:      to,
+		Title:   titleDiff,
+		Content: contentDiff,
+	}, nil
+}
+
+// RestoreRevision set blog to state of revision, only author of blog can restore it
+func (u *blogUseCase) RestoreRevision(ctx context.Context, id uuid.UUID, revision int) (*models.BlogBase, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "blogUC.RestoreRevision")
+	defer span.Finish()
+
+	blogByID, err := u.blogRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = utils.ValidateIsOwner(ctx, blogByID.AuthorID.String(), u.logger); err != nil {
+		if errors.Is(err, httpErrors.Forbidden) {
+			return nil, httpErrors.NewForbiddenError(err)
+		}
+		return nil, httpErrors.NewUnauthorizedError(err)
+	}
+
+	restoredBlog, err := u.blogRepo.Restore(ctx, id, revision, blogByID.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = u.redisRepo.DeleteBlogCtx(ctx, u.generateBlogKey(id.String())); err != nil {
+		u.logger.Errorf("blogUC.RestoreRevision.DeleteBlogCtx: %v", err)
+	}
+
+	return restoredBlog, nil
+}
+
+// getViewable read blog from database, blog caller cannot read is not found
+func (u *blogUseCase) getViewable(ctx context.Context, id uuid.UUID) (*models.BlogBase, error) {
+	blogByID, err := u.blogRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !u.canView(ctx, blogByID) {
+		return nil, httpErrors.NewRestError(http.StatusNotFound, httpErrors.NotFound.Error(), nil)
+	}
+
+	return blogByID, nil
+}
+
+// Publish blog now, or schedule it when publish time is in future
+func (u *blogUseCase) Publish(ctx context.Context, id uuid.UUID, publish *models.BlogPublish) (*models.BlogBase, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "blogUC.Publish")
+	defer span.Finish()
+
+	blogByID, err := u.blogRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = utils.ValidateIsOwnerOrHasPermission(ctx, blogByID.AuthorID.String(), rbac.BlogUpdateAny, u.logger); err != nil {
+		return nil, err
+	}
+
+	if blogByID.Held {
+		return nil, httpErrors.NewRestError(http.StatusConflict, "Blog is held for moderation", nil)
+	}
+
+	now := time.Now().UTC()
+	if publish.PublishAt == nil || !publish.PublishAt.After(now) {
+		if blogByID.Status == models.BlogStatusPublished {
+			return blogByID, nil
+		}
+		publishedBlog, err := u.setStatus(ctx, id, models.BlogStatusPublished, &now)
+		if err != nil {
+			return nil, err
+		}
+		u.fanOut(ctx, publishedBlog)
+		return publishedBlog, nil
+	}
+
+	if blogByID.Status == models.BlogStatusPublished {
+		return nil, httpErrors.NewRestError(http.StatusBadRequest, "Blog is already published", nil)
+	}
+
+	// Postgres keeps microseconds, task compares publish time with stored one
+	publishAt := publish.PublishAt.UTC().Truncate(time.Microsecond)
+
+	// Task is enqueued first, task of schedule which is not stored finds blog not scheduled for its time and does nothing
+	err = u.blogTD.DistributeTaskPublishBlog(ctx, &blogAsynq.PublishBlogPayload{
+		BlogID:    id,
+		PublishAt: publishAt,
+	},
+		asynq.ProcessAt(publishAt),
+		asynq.TaskID(fmt.Sprintf("%s:%s:%d", blogAsynq.TypePublishBlogTask, id, publishAt.UnixMicro())),
+		asynq.MaxRetry(publishBlogMaxRetry),
+		asynq.Queue(asynqPkg.QueueCritical),
+	)
+	if err = asynqPkg.IgnoreTaskIDConflict(err); err != nil {
+		return nil, httpErrors.NewInternalServerError(errors.Wrap(err, "blogUC.Publish.DistributeTaskPublishBlog"))
+	}
+
+	return u.setStatus(ctx, id, models.BlogStatusScheduled, &publishAt)
+}
+
+// Archive hide blog from everyone except its author, scheduled publish of archived blog does nothing
+func (u *blogUseCase) Archive(ctx context.Context, id uuid.UUID) (*models.BlogBase, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "blogUC.Archive")
+	defer span.Finish()
+
+	blogByID, err := u.blogRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = utils.ValidateIsOwnerOrHasPermission(ctx, blogByID.AuthorID.String(), rbac.BlogUpdateAny, u.logger); err != nil {
+		return nil, err
+	}
+
+	if blogByID.Status == models.BlogStatusArchived {
+		return blogByID, nil
+	}
+
+	return u.setStatus(ctx, id, models.BlogStatusArchived, blogByID.PublishedAt)
+}
+
+// Release blog held by content filter, it keeps its status and author can publish it then
+func (u *blogUseCase) Release(ctx context.Context, id uuid.UUID) (*models.BlogBase, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "blogUC.Release")
+	defer span.Finish()
+
+	if !rbac.HasPermission(utils.GetRoleFromCtx(ctx), rbac.BlogModerate) {
+		return nil, httpErrors.NewMissingPermissionError(string(rbac.BlogModerate), httpErrors.Forbidden)
+	}
+
+	blogByID, err := u.blogRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !blogByID.Held {
+		return blogByID, nil
+	}
+
+	releasedBlog, err := u.blogRepo.SetHeld(ctx, id, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = u.redisRepo.DeleteBlogCtx(ctx, u.generateBlogKey(id.String())); err != nil {
+		u.logger.Errorf("blogUC.Release.DeleteBlogCtx: %v", err)
+	}
+
+	return releasedBlog, nil
+}
+
+// ProcessPublish publish scheduled blog when its publish time comes
+func (u *blogUseCase) ProcessPublish(ctx context.Context, id uuid.UUID, publishAt time.Time) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "blogUC.ProcessPublish")
+	defer span.Finish()
+
+	published, err := u.blogRepo.PublishScheduled(ctx, id, publishAt)
+	if err != nil {
+		return err
+	}
+
+	if !published {
+		u.logger.Infof("blogUC.ProcessPublish: blog %s is no longer scheduled at %s", id, publishAt)
+		return nil
+	}
+
+	if err = u.redisRepo.DeleteBlogCtx(ctx, u.generateBlogKey(id.String())); err != nil {
+		u.logger.Errorf("blogUC.ProcessPublish.DeleteBlogCtx: %v", err)
+	}
+
+	u.fanOut(ctx, &models.BlogBase{BlogID: id, Status: models.BlogStatusPublished, PublishedAt: &publishAt})
+
 	return nil
 }
 
-func (u *blogUseCase) List(ctx context.Context, pq *utils.PaginationQuery) (*models.BlogsList, error) {
+// fanOut enqueue adding published blog to feeds of followers of its author. When it fails blog is missing
+// from feeds which are kept until they are made again
+func (u *blogUseCase) fanOut(ctx context.Context, blog *models.BlogBase) {
+	if blog.Status != models.BlogStatusPublished || blog.PublishedAt == nil {
+		return
+	}
+
+	err := u.feedTD.DistributeTaskFanOutBlog(ctx, &feedAsynq.FanOutBlogPayload{BlogID: blog.BlogID},
+		asynq.TaskID(fmt.Sprintf("%s:%s:%d", feedAsynq.TypeFanOutBlogTask, blog.BlogID, blog.PublishedAt.UnixMicro())),
+		asynq.MaxRetry(fanOutBlogMaxRetry),
+		asynq.Queue(asynqPkg.QueueDefault),
+	)
+	if err = asynqPkg.IgnoreTaskIDConflict(err); err != nil {
+		u.logger.Errorf("blogUC.fanOut: DistributeTaskFanOutBlog: %v", err)
+	}
+}
+
+func (u *blogUseCase) setStatus(ctx context.Context, id uuid.UUID, status string, publishedAt *time.Time) (*models.BlogBase, error) {
+	updatedBlog, err := u.blogRepo.UpdateStatus(ctx, id, status, publishedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = u.redisRepo.DeleteBlogCtx(ctx, u.generateBlogKey(id.String())); err != nil {
+		u.logger.Errorf("blogUC.setStatus.DeleteBlogCtx: %v", err)
+	}
+
+	return updatedBlog, nil
+}
+
+// canView report whether caller can read blog, blogs which are not published are read by author and editors only.
+// Held blogs are read by moderators too
+func (u *blogUseCase) canView(ctx context.Context, blog *models.BlogBase) bool {
+	return utils.CanViewBlog(ctx, blog.AuthorID, blog.Status == models.BlogStatusPublished, blog.Held)
+}
+
+func (u *blogUseCase) List(ctx context.Context, filter *models.BlogFilter, pq *utils.PaginationQuery) (*models.BlogsList, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "blogUC.List")
 	defer span.Finish()
 
-	return u.blogRepo.List(ctx, pq)
+	filter.ViewerID = nil
+	if userUID, err := utils.GetUserUIDFromCtx(ctx); err == nil {
+		filter.ViewerID = &userUID
+	}
+
+	blogsList, err := u.blogRepo.List(ctx, filter, pq)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = u.attachReactions(ctx, blogsList.Blogs...); err != nil {
+		return nil, err
+	}
+
+	return blogsList, nil
+}
+
+func (u *blogUseCase) Search(ctx context.Context, query *models.BlogSearchQuery, pq *utils.PaginationQuery) (*models.BlogsList, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "blogUC.Search")
+	defer span.Finish()
+
+	blogsList, err := u.blogRepo.Search(ctx, query, pq)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = u.attachReactions(ctx, blogsList.Blogs...); err != nil {
+		return nil, err
+	}
+
+	return blogsList, nil
+}
+
+// attachReactions set reaction counts of blogs and kinds caller reacted with, anonymous caller has no reactions
+func (u *blogUseCase) attachReactions(ctx context.Context, blogs ...*models.BlogBase) error {
+	blogIDs := make([]uuid.UUID, 0, len(blogs))
+	for _, b := range blogs {
+		blogIDs = append(blogIDs, b.BlogID)
+	}
+
+	userUID, _ := utils.GetUserUIDFromCtx(ctx)
+	summaries, err := u.reactionRepo.Summaries(ctx, models.ReactionTargetBlog, blogIDs, userUID)
+	if err != nil {
+		return err
+	}
+
+	for _, b := range blogs {
+		if summary, ok := summaries[b.BlogID]; ok {
+			b.Reactions = summary.Counts
+			b.MyReactions = summary.Mine
+		}
+	}
+
+	return nil
+}
+
+func (u *blogUseCase) UploadCover(ctx context.Context, blogID uuid.UUID, image *models.ImageInput) (models.ImageVariants, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "blogUC.UploadCover")
+	defer span.Finish()
+
+	blogByID, err := u.blogRepo.GetByID(ctx, blogID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = utils.ValidateIsOwnerOrHasPermission(ctx, blogByID.AuthorID.String(), rbac.BlogUpdateAny, u.logger); err != nil {
+		return nil, err
+	}
+
+	image.ImageID = uuid.New().String()
+	prefix := u.generateCoverKeyPrefix(blogID, image.ImageID)
+	contentType := http.DetectContentType(image.Image)
+
+	// Task only carries key of stored upload, image bytes are kept out of redis
+	image.ObjectKey = utils.ImageUploadKey(prefix)
+	if _, err = u.minioRepo.PutObject(ctx, models.UploadInput{
+		File:        bytes.NewReader(image.Image),
+		Name:        image.ObjectKey,
+		Size:        int64(len(image.Image)),
+		ContentType: contentType,
+		BucketName:  image.BucketName,
+	}); err != nil {
+		return nil, httpErrors.NewInternalServerError(errors.Wrap(err, "blogUC.UploadCover.PutObject"))
+	}
+
+	opts := []asynq.Option{
+		asynq.MaxRetry(processImageMaxRetry),
+		asynq.Queue(asynqPkg.QueueDefault),
+	}
+	err = u.blogTD.DistributeTaskProcessCover(ctx, &blogAsynq.ProcessCoverPayload{
+		BlogID:     blogID,
+		ImageID:    image.ImageID,
+		BucketName: image.BucketName,
+		ObjectKey:  image.ObjectKey,
+	}, opts...)
+	if err != nil {
+		u.removeImageUpload(ctx, image.BucketName, image.ObjectKey)
+		return nil, httpErrors.NewInternalServerError(errors.Wrap(err, "blogUC.UploadCover.DistributeTaskProcessCover"))
+	}
+
+	// Keys are deterministic so urls are known before variants are stored
+	variants := make(models.ImageVariants, len(utils.ImageVariants))
+	for _, variant := range utils.ImageVariants {
+		key := utils.ImageVariantKey(prefix, variant.Name, contentType)
+		variants[variant.Name] = u.generateMinioURL(image.BucketName, key)
+	}
+
+	return variants, nil
+}
+
+func (u *blogUseCase) ProcessCover(ctx context.Context, blogID uuid.UUID, image *models.ImageInput) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "blogUC.ProcessCover")
+	defer span.Finish()
+
+	blogByID, err := u.blogRepo.GetByID(ctx, blogID)
+	if err != nil {
+		return errors.Wrap(err, "blogUC.ProcessCover.GetByID")
+	}
+
+	data, err := u.minioRepo.ReadObject(ctx, image.BucketName, image.ObjectKey)
+	if err != nil {
+		if minio.ToErrorResponse(errors.Cause(err)).Code == "NoSuchKey" {
+			return fmt.Errorf("blogUC.ProcessCover.ReadObject: %v: %w", err, asynq.SkipRetry)
+		}
+		return errors.Wrap(err, "blogUC.ProcessCover.ReadObject")
+	}
+
+	variants, contentType, err := utils.ProcessImage(data)
+	if err != nil {
+		u.removeImageUpload(ctx, image.BucketName, image.ObjectKey)
+		return fmt.Errorf("blogUC.ProcessCover.ProcessImage: %v: %w", err, asynq.SkipRetry)
+	}
+
+	prefix := u.generateCoverKeyPrefix(blogID, image.ImageID)
+	for name, data := range variants {
+		_, err = u.minioRepo.PutObject(ctx, models.UploadInput{
+			File:        bytes.NewReader(data),
+			Name:        utils.ImageVariantKey(prefix, name, contentType),
+			Size:        int64(len(data)),
+			ContentType: contentType,
+			BucketName:  image.BucketName,
+		})
+		if err != nil {
+			return errors.Wrap(err, "blogUC.ProcessCover.PutObject")
+		}
+	}
+
+	originalKey := utils.ImageVariantKey(prefix, utils.ImageVariantOriginal, contentType)
+	imageURL := u.generateMinioURL(image.BucketName, originalKey)
+
+	// Task does not know who uploaded cover, revision is written for author
+	if _, err = u.blogRepo.Update(ctx, &models.BlogBase{
+		BlogID:   blogID,
+		ImageURL: &imageURL,
+	}, blogByID.AuthorID); err != nil {
+		// Do not leave objects nobody points to
+		u.removeImageVariants(ctx, image.BucketName, originalKey)
+		return errors.Wrap(err, "blogUC.ProcessCover.Update")
+	}
+
+	// Old cover is removed only after blog points to the new one, free-form urls are not ours to remove
+	if blogByID.ImageURL != nil && *blogByID.ImageURL != imageURL {
+		if bucket, key, ok := u.parseMinioURL(*blogByID.ImageURL); ok {
+			u.removeImageVariants(ctx, bucket, key)
+		}
+	}
+
+	// Upload is kept until now so failed task can be retried
+	u.removeImageUpload(ctx, image.BucketName, image.ObjectKey)
+
+	if err = u.redisRepo.DeleteBlogCtx(ctx, u.generateBlogKey(blogID.String())); err != nil {
+		u.logger.Errorf("blogUC.ProcessCover.DeleteBlogCtx: %v", err)
+	}
+
+	return nil
+}
+
+// normalizeTags make slugs of tag names without duplicates, nil tags stay nil so they are left unchanged
+func normalizeTags(tags models.Tags) (models.Tags, error) {
+	if tags == nil {
+		return nil, nil
+	}
+
+	normalized := make(models.Tags, 0, len(tags))
+	seen := make(map[string]struct{}, len(tags))
+	for _, tag := range tags {
+		name := utils.Slugify(tag)
+		if len(name) > maxTagLength {
+			name = strings.TrimRight(name[:maxTagLength], "-")
+		}
+		if name == "" {
+			return nil, httpErrors.NewValidationError(httpErrors.FieldError{Field: "tags", Value: tag, Message: "must contain letters or digits"})
+		}
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+		normalized = append(normalized, name)
+	}
+
+	return normalized, nil
 }
 
 func (u *blogUseCase) generateBlogKey(blogID string) string {
 	return fmt.Sprintf("%s: %s", basePrefix, blogID)
 }
+
+func (u *blogUseCase) generateSlugKey(slug string) string {
+	return fmt.Sprintf("%s: slug: %s", basePrefix, slug)
+}
+
+func (u *blogUseCase) generateCoverKeyPrefix(blogID uuid.UUID, imageID string) string {
+	return fmt.Sprintf("%s/%s/%s", coverKeyPrefix, blogID.String(), imageID)
+}
+
+// removeImageUpload remove upload once it is not needed, failures only leave garbage so they are logged
+func (u *blogUseCase) removeImageUpload(ctx context.Context, bucket string, key string) {
+	if err := u.minioRepo.RemoveObject(ctx, bucket, key); err != nil {
+		u.logger.Errorf("blogUC.removeImageUpload.RemoveObject: %v", err)
+	}
+}
+
+// removeImageVariants remove every variant stored next to key, failures only leave garbage so they are logged
+func (u *blogUseCase) removeImageVariants(ctx context.Context, bucket string, key string) {
+	for _, variantKey := range utils.ImageVariantKeys(key) {
+		if err := u.minioRepo.RemoveObject(ctx, bucket, variantKey); err != nil {
+			u.logger.Errorf("blogUC.removeImageVariants.RemoveObject: %v", err)
+		}
+	}
+}
+
+func (u *blogUseCase) generateMinioURL(bucket string, key string) string {
+	return fmt.Sprintf("%s/minio/%s/%s", u.cfg.Minio.MinioEndpoint, bucket, key)
+}
+
+// parseMinioURL get bucket and key back from url made by generateMinioURL
+func (u *blogUseCase) parseMinioURL(url string) (string, string, bool) {
+	path, ok := strings.CutPrefix(url, fmt.Sprintf("%s/minio/", u.cfg.Minio.MinioEndpoint))
+	if !ok {
+		return "", "", false
+	}
+
+	bucket, key, ok := strings.Cut(path, "/")
+	if !ok || bucket == "" || key == "" {
+		return "", "", false
+	}
+
+	return bucket, key, true
+}
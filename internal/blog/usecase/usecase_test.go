@@ -2,16 +2,28 @@ package usecase
 
 import (
 	"context"
+	"database/sql"
 	"github.com/google/uuid"
 	"github.com/opentracing/opentracing-go"
+	"github.com/redis/go-redis/v9"
 	"github.com/scul0405/blog-clean-architecture-rest-api/config"
 	"github.com/scul0405/blog-clean-architecture-rest-api/internal/blog/mock"
+	blogAsynq "github.com/scul0405/blog-clean-architecture-rest-api/internal/blog/transport/asynq"
+	filterMock "github.com/scul0405/blog-clean-architecture-rest-api/internal/content_filter/mock"
+	feedMock "github.com/scul0405/blog-clean-architecture-rest-api/internal/feed/mock"
+	feedAsynq "github.com/scul0405/blog-clean-architecture-rest-api/internal/feed/transport/asynq"
 	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	reactionMock "github.com/scul0405/blog-clean-architecture-rest-api/internal/reaction/mock"
+	httpErrors "github.com/scul0405/blog-clean-architecture-rest-api/pkg/http_errors"
 	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/logger"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/rbac"
 	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/utils"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
+	"net/http"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestBlogUseCase_Create(t *testing.T) {
@@ -34,7 +46,10 @@ func TestBlogUseCase_Create(t *testing.T) {
 
 	apiLogger := logger.NewApiLogger(cfg)
 	mockBlogRepo := mock.NewMockRepository(ctrl)
-	blogUC := NewBlogUseCase(cfg, mockBlogRepo, apiLogger)
+	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
+	mockContentFilter := filterMock.NewMockContentFilter(ctrl)
+	allowContent(mockContentFilter)
+	blogUC := NewBlogUseCase(cfg, mockBlogRepo, mockRedisRepo, nil, nil, nil, nil, mockContentFilter, apiLogger)
 
 	userUID := uuid.New()
 
@@ -62,6 +77,47 @@ func TestBlogUseCase_Create(t *testing.T) {
 	require.NotNil(t, createdBlog)
 }
 
+func TestBlogUseCase_CreateTags(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Logger: config.LoggerConfig{
+			Development: true,
+			Encoding:    "json",
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	mockBlogRepo := mock.NewMockRepository(ctrl)
+	mockContentFilter := filterMock.NewMockContentFilter(ctrl)
+	allowContent(mockContentFilter)
+	blogUC := NewBlogUseCase(cfg, mockBlogRepo, nil, nil, nil, nil, nil, mockContentFilter, apiLogger)
+
+	ctx := context.WithValue(context.Background(), "user_id", uuid.New().String())
+
+	t.Run("Tags are normalised", func(t *testing.T) {
+		blog := &models.Blog{Title: "Title long text string", Tags: models.Tags{"Go", "go", "Machine Learning"}}
+
+		mockBlogRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, b *models.Blog) (*models.BlogBase, error) {
+			require.Equal(t, models.Tags{"go", "machine-learning"}, b.Tags)
+			return &models.BlogBase{Tags: b.Tags}, nil
+		})
+
+		createdBlog, err := blogUC.Create(ctx, blog)
+		require.NoError(t, err)
+		require.Len(t, createdBlog.Tags, 2)
+	})
+
+	t.Run("Tag without letters", func(t *testing.T) {
+		_, err := blogUC.Create(ctx, &models.Blog{Title: "Title long text string", Tags: models.Tags{"go", "!!!"}})
+		require.Error(t, err)
+		require.Equal(t, http.StatusBadRequest, httpErrors.ParseErrors(err).Status())
+	})
+}
+
 func TestBlogUseCase_GetByID(t *testing.T) {
 	t.Parallel()
 
@@ -82,7 +138,9 @@ func TestBlogUseCase_GetByID(t *testing.T) {
 
 	apiLogger := logger.NewApiLogger(cfg)
 	mockBlogRepo := mock.NewMockRepository(ctrl)
-	blogUC := NewBlogUseCase(cfg, mockBlogRepo, apiLogger)
+	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
+	mockReactionRepo := reactionMock.NewMockRepository(ctrl)
+	blogUC := NewBlogUseCase(cfg, mockBlogRepo, mockRedisRepo, nil, mockReactionRepo, nil, nil, nil, apiLogger)
 
 	blogUID := uuid.New()
 	userUID := uuid.New()
@@ -92,18 +150,148 @@ func TestBlogUseCase_GetByID(t *testing.T) {
 		AuthorID: userUID,
 		Title:    "Title long text string greater then 20 characters",
 		Content:  "Content long text string greater then 20 characters",
+		Status:   models.BlogStatusPublished,
 	}
 
 	ctx := context.Background()
 	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "blogUC.GetByID")
 	defer span.Finish()
 
+	mockRedisRepo.EXPECT().GetBlogByIDCtx(ctxWithTrace, gomock.Any()).Return(nil, nil)
 	mockBlogRepo.EXPECT().GetByID(ctxWithTrace, gomock.Eq(blogUID)).Return(blogBase, nil)
+	mockRedisRepo.EXPECT().SetBlogCtx(ctxWithTrace, gomock.Any(), gomock.Any(), gomock.Eq(blogBase)).Return(nil)
+	mockReactionRepo.EXPECT().Summaries(ctxWithTrace, models.ReactionTargetBlog, []uuid.UUID{blogUID}, uuid.Nil).
+		Return(map[uuid.UUID]*models.ReactionSummary{blogUID: {Counts: map[string]int{"like": 3, "love": 1}}}, nil)
 
 	getByIDBlog, err := blogUC.GetByID(ctx, blogUID)
 	require.NoError(t, err)
 	require.Nil(t, err)
 	require.NotNil(t, getByIDBlog)
+	require.Equal(t, 3, getByIDBlog.Reactions["like"])
+	require.Empty(t, getByIDBlog.MyReactions)
+}
+
+func TestBlogUseCase_GetBySlug(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Logger: config.LoggerConfig{
+			Development: true,
+			Encoding:    "json",
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	apiLogger.InitLogger()
+	mockBlogRepo := mock.NewMockRepository(ctrl)
+	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
+	mockReactionRepo := reactionMock.NewMockRepository(ctrl)
+	blogUC := NewBlogUseCase(cfg, mockBlogRepo, mockRedisRepo, nil, mockReactionRepo, nil, nil, nil, apiLogger)
+
+	blogUID := uuid.New()
+	blogBase := &models.BlogBase{
+		BlogID:   blogUID,
+		AuthorID: uuid.New(),
+		Title:    "Title long text string greater then 20 characters",
+		Status:   models.BlogStatusPublished,
+		Version:  2,
+		Slug:     "title-long-text-string-greater-then-20-characters",
+		Tags:     models.Tags{"go"},
+
+		ContentFormat: models.BlogContentPlain,
+	}
+
+	t.Run("Old slug is not cached", func(t *testing.T) {
+		mockRedisRepo.EXPECT().GetBlogIDBySlugCtx(gomock.Any(), gomock.Any()).Return(uuid.Nil, redis.Nil)
+		mockBlogRepo.EXPECT().GetIDBySlug(gomock.Any(), "old-title").Return(blogUID, nil)
+		mockRedisRepo.EXPECT().SetBlogSlugCtx(gomock.Any(), gomock.Any(), gomock.Any(), blogUID).Return(nil)
+		mockRedisRepo.EXPECT().GetBlogByIDCtx(gomock.Any(), gomock.Any()).Return(blogBase, nil)
+		mockReactionRepo.EXPECT().Summaries(gomock.Any(), models.ReactionTargetBlog, []uuid.UUID{blogUID}, uuid.Nil).
+			Return(map[uuid.UUID]*models.ReactionSummary{}, nil)
+
+		blogBySlug, err := blogUC.GetBySlug(context.Background(), "old-title")
+		require.NoError(t, err)
+		require.Equal(t, blogBase.Slug, blogBySlug.Slug)
+	})
+
+	t.Run("Cached slug of deleted blog", func(t *testing.T) {
+		deletedUID := uuid.New()
+
+		mockRedisRepo.EXPECT().GetBlogIDBySlugCtx(gomock.Any(), gomock.Any()).Return(deletedUID, nil)
+		mockRedisRepo.EXPECT().GetBlogByIDCtx(gomock.Any(), gomock.Any()).Return(nil, redis.Nil)
+		mockBlogRepo.EXPECT().GetByID(gomock.Any(), gomock.Eq(deletedUID)).Return(nil, sql.ErrNoRows)
+		mockRedisRepo.EXPECT().DeleteBlogCtx(gomock.Any(), gomock.Eq("blog-api: slug: "+blogBase.Slug)).Return(nil)
+		mockBlogRepo.EXPECT().GetIDBySlug(gomock.Any(), blogBase.Slug).Return(blogUID, nil)
+		mockRedisRepo.EXPECT().SetBlogSlugCtx(gomock.Any(), gomock.Any(), gomock.Any(), blogUID).Return(nil)
+		mockRedisRepo.EXPECT().GetBlogByIDCtx(gomock.Any(), gomock.Any()).Return(blogBase, nil)
+		mockReactionRepo.EXPECT().Summaries(gomock.Any(), models.ReactionTargetBlog, []uuid.UUID{blogUID}, uuid.Nil).
+			Return(map[uuid.UUID]*models.ReactionSummary{}, nil)
+
+		blogBySlug, err := blogUC.GetBySlug(context.Background(), blogBase.Slug)
+		require.NoError(t, err)
+		require.Equal(t, blogUID, blogBySlug.BlogID)
+	})
+
+	t.Run("Unknown slug", func(t *testing.T) {
+		mockRedisRepo.EXPECT().GetBlogIDBySlugCtx(gomock.Any(), gomock.Any()).Return(uuid.Nil, redis.Nil)
+		mockBlogRepo.EXPECT().GetIDBySlug(gomock.Any(), "unknown").Return(uuid.Nil, sql.ErrNoRows)
+
+		_, err := blogUC.GetBySlug(context.Background(), "unknown")
+		require.Error(t, err)
+		require.Equal(t, http.StatusNotFound, httpErrors.ParseErrors(err).Status())
+	})
+}
+
+func TestBlogUseCase_CreateHeld(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Logger: config.LoggerConfig{
+			Development: true,
+			Encoding:    "json",
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	mockBlogRepo := mock.NewMockRepository(ctrl)
+	mockContentFilter := filterMock.NewMockContentFilter(ctrl)
+	blogUC := NewBlogUseCase(cfg, mockBlogRepo, nil, nil, nil, nil, nil, mockContentFilter, apiLogger)
+
+	userUID := uuid.New()
+	blogUID := uuid.New()
+
+	blog := &models.Blog{
+		Title:   "Title long text string greater then 20 characters",
+		Content: "Content long text string greater then 20 characters",
+		Status:  models.BlogStatusPublished,
+	}
+
+	decision := &models.FilterDecision{
+		Action: models.FilterHold,
+		Texts:  []string{blog.Title, "Content with [link removed]"},
+	}
+
+	ctx := context.WithValue(context.Background(), "user_id", userUID.String())
+
+	mockContentFilter.EXPECT().Check(gomock.Any(), gomock.Any()).Return(decision, nil)
+	mockBlogRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, blog *models.Blog) (*models.BlogBase, error) {
+			require.Equal(t, models.BlogStatusDraft, blog.Status)
+			require.True(t, blog.Held)
+			require.Equal(t, "Content with [link removed]", blog.Content)
+			return &models.BlogBase{BlogID: blogUID, AuthorID: userUID, Status: blog.Status, Held: blog.Held}, nil
+		})
+	mockContentFilter.EXPECT().Record(gomock.Any(), decision, blogUID).Return(nil)
+
+	createdBlog, err := blogUC.Create(ctx, blog)
+	require.NoError(t, err)
+	require.Equal(t, models.BlogStatusDraft, createdBlog.Status)
 }
 
 func TestBlogUseCase_Update(t *testing.T) {
@@ -126,7 +314,10 @@ func TestBlogUseCase_Update(t *testing.T) {
 
 	apiLogger := logger.NewApiLogger(cfg)
 	mockBlogRepo := mock.NewMockRepository(ctrl)
-	blogUC := NewBlogUseCase(cfg, mockBlogRepo, apiLogger)
+	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
+	mockContentFilter := filterMock.NewMockContentFilter(ctrl)
+	allowContent(mockContentFilter)
+	blogUC := NewBlogUseCase(cfg, mockBlogRepo, mockRedisRepo, nil, nil, nil, nil, mockContentFilter, apiLogger)
 
 	blogUID := uuid.New()
 	userUID := uuid.New()
@@ -143,7 +334,8 @@ func TestBlogUseCase_Update(t *testing.T) {
 	defer span.Finish()
 
 	mockBlogRepo.EXPECT().GetByID(ctxWithTrace, gomock.Eq(blogUID)).Return(blogBase, nil)
-	mockBlogRepo.EXPECT().Update(ctxWithTrace, gomock.Eq(blogBase)).Return(blogBase, nil)
+	mockBlogRepo.EXPECT().Update(ctxWithTrace, gomock.Eq(blogBase), userUID).Return(blogBase, nil)
+	mockRedisRepo.EXPECT().DeleteBlogCtx(ctxWithTrace, gomock.Any()).Return(nil)
 
 	updatedBlog, err := blogUC.Update(ctx, blogBase)
 	require.NoError(t, err)
@@ -151,6 +343,48 @@ func TestBlogUseCase_Update(t *testing.T) {
 	require.NotNil(t, updatedBlog)
 }
 
+func TestBlogUseCase_UpdateStaleVersion(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Logger: config.LoggerConfig{
+			Development: true,
+			Encoding:    "json",
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	mockBlogRepo := mock.NewMockRepository(ctrl)
+	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
+	blogUC := NewBlogUseCase(cfg, mockBlogRepo, mockRedisRepo, nil, nil, nil, nil, nil, apiLogger)
+
+	blogUID := uuid.New()
+	userUID := uuid.New()
+	ctx := context.WithValue(context.Background(), "user_id", userUID.String())
+
+	blogByID := &models.BlogBase{BlogID: blogUID, AuthorID: userUID, Version: 3}
+
+	t.Run("Stale version", func(t *testing.T) {
+		mockBlogRepo.EXPECT().GetByID(gomock.Any(), gomock.Eq(blogUID)).Return(blogByID, nil)
+
+		_, err := blogUC.Update(ctx, &models.BlogBase{BlogID: blogUID, Title: "Title long text string greater then 20 characters", Version: 2})
+		require.Error(t, err)
+		require.Equal(t, http.StatusPreconditionFailed, httpErrors.ParseErrors(err).Status())
+	})
+
+	t.Run("Changed concurrently", func(t *testing.T) {
+		mockBlogRepo.EXPECT().GetByID(gomock.Any(), gomock.Eq(blogUID)).Return(blogByID, nil)
+		mockBlogRepo.EXPECT().Delete(gomock.Any(), gomock.Eq(blogUID), 3).Return(sql.ErrNoRows)
+
+		err := blogUC.Delete(ctx, blogUID, 3)
+		require.Error(t, err)
+		require.Equal(t, http.StatusPreconditionFailed, httpErrors.ParseErrors(err).Status())
+	})
+}
+
 func TestBlogUseCase_Delete(t *testing.T) {
 	t.Parallel()
 
@@ -171,7 +405,8 @@ func TestBlogUseCase_Delete(t *testing.T) {
 
 	apiLogger := logger.NewApiLogger(cfg)
 	mockBlogRepo := mock.NewMockRepository(ctrl)
-	blogUC := NewBlogUseCase(cfg, mockBlogRepo, apiLogger)
+	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
+	blogUC := NewBlogUseCase(cfg, mockBlogRepo, mockRedisRepo, nil, nil, nil, nil, nil, apiLogger)
 
 	blogUID := uuid.New()
 	userUID := uuid.New()
@@ -188,13 +423,271 @@ func TestBlogUseCase_Delete(t *testing.T) {
 	defer span.Finish()
 
 	mockBlogRepo.EXPECT().GetByID(ctxWithTrace, gomock.Eq(blogUID)).Return(blogBase, nil)
-	mockBlogRepo.EXPECT().Delete(ctxWithTrace, gomock.Eq(blogUID)).Return(nil)
+	mockBlogRepo.EXPECT().Delete(ctxWithTrace, gomock.Eq(blogUID), 0).Return(nil)
+	mockRedisRepo.EXPECT().DeleteBlogCtx(ctxWithTrace, gomock.Any()).Return(nil)
+	mockRedisRepo.EXPECT().DeleteBlogCtx(ctxWithTrace, gomock.Eq("blog-api: slug: "+blogBase.Slug)).Return(nil)
 
-	err := blogUC.Delete(ctx, blogUID)
+	err := blogUC.Delete(ctx, blogUID, 0)
 	require.NoError(t, err)
 	require.Nil(t, err)
 }
 
+func TestBlogUseCase_DeleteNotOwner(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Server: config.ServerConfig{
+			SymmetricKey: "secret_token_symmetric_key_12345",
+		},
+		Logger: config.LoggerConfig{
+			Development:       true,
+			DisableCaller:     false,
+			DisableStacktrace: false,
+			Encoding:          "json",
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	apiLogger.InitLogger()
+	mockBlogRepo := mock.NewMockRepository(ctrl)
+	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
+	blogUC := NewBlogUseCase(cfg, mockBlogRepo, mockRedisRepo, nil, nil, nil, nil, nil, apiLogger)
+
+	blogUID := uuid.New()
+
+	blogBase := &models.BlogBase{
+		BlogID:   blogUID,
+		AuthorID: uuid.New(),
+		Title:    "Title long text string greater then 20 characters",
+		Content:  "Content long text string greater then 20 characters",
+	}
+
+	t.Run("Admin", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), "user_id", uuid.New().String())
+		ctx = context.WithValue(ctx, "role", rbac.RoleAdmin)
+
+		mockBlogRepo.EXPECT().GetByID(gomock.Any(), gomock.Eq(blogUID)).Return(blogBase, nil)
+		mockBlogRepo.EXPECT().Delete(gomock.Any(), gomock.Eq(blogUID), 0).Return(nil)
+		mockRedisRepo.EXPECT().DeleteBlogCtx(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+		err := blogUC.Delete(ctx, blogUID, 0)
+		require.NoError(t, err)
+	})
+
+	t.Run("Editor", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), "user_id", uuid.New().String())
+		ctx = context.WithValue(ctx, "role", rbac.RoleEditor)
+
+		mockBlogRepo.EXPECT().GetByID(gomock.Any(), gomock.Eq(blogUID)).Return(blogBase, nil)
+
+		err := blogUC.Delete(ctx, blogUID, 0)
+		require.Error(t, err)
+		require.Equal(t, http.StatusForbidden, httpErrors.ParseErrors(err).Status())
+	})
+}
+
+func TestBlogUseCase_Publish(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Logger: config.LoggerConfig{
+			Development: true,
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	apiLogger.InitLogger()
+	mockBlogRepo := mock.NewMockRepository(ctrl)
+	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
+	mockBlogTD := mock.NewMockBlogTaskDistributor(ctrl)
+	mockFeedTD := feedMock.NewMockFeedTaskDistributor(ctrl)
+	blogUC := NewBlogUseCase(cfg, mockBlogRepo, mockRedisRepo, nil, nil, mockBlogTD, mockFeedTD, nil, apiLogger)
+
+	authorUID := uuid.New()
+	ctx := context.WithValue(context.Background(), "user_id", authorUID.String())
+
+	t.Run("Now", func(t *testing.T) {
+		blogUID := uuid.New()
+		publishedAt := time.Now().UTC()
+		draft := &models.BlogBase{BlogID: blogUID, AuthorID: authorUID, Status: models.BlogStatusDraft}
+
+		mockBlogRepo.EXPECT().GetByID(gomock.Any(), blogUID).Return(draft, nil)
+		mockBlogRepo.EXPECT().UpdateStatus(gomock.Any(), blogUID, models.BlogStatusPublished, gomock.Not(gomock.Nil())).
+			Return(&models.BlogBase{BlogID: blogUID, AuthorID: authorUID, Status: models.BlogStatusPublished, PublishedAt: &publishedAt}, nil)
+		mockRedisRepo.EXPECT().DeleteBlogCtx(gomock.Any(), gomock.Any()).Return(nil)
+		mockFeedTD.EXPECT().DistributeTaskFanOutBlog(gomock.Any(), gomock.Eq(&feedAsynq.FanOutBlogPayload{BlogID: blogUID}), gomock.Any()).Return(nil)
+
+		publishedBlog, err := blogUC.Publish(ctx, blogUID, &models.BlogPublish{})
+		require.NoError(t, err)
+		require.Equal(t, models.BlogStatusPublished, publishedBlog.Status)
+	})
+
+	t.Run("Scheduled", func(t *testing.T) {
+		blogUID := uuid.New()
+		publishAt := time.Now().Add(time.Hour).UTC().Truncate(time.Microsecond)
+		draft := &models.BlogBase{BlogID: blogUID, AuthorID: authorUID, Status: models.BlogStatusDraft}
+
+		mockBlogRepo.EXPECT().GetByID(gomock.Any(), blogUID).Return(draft, nil)
+		mockBlogTD.EXPECT().DistributeTaskPublishBlog(gomock.Any(), gomock.Eq(&blogAsynq.PublishBlogPayload{
+			BlogID:    blogUID,
+			PublishAt: publishAt,
+		}), gomock.Any()).Return(nil)
+		mockBlogRepo.EXPECT().UpdateStatus(gomock.Any(), blogUID, models.BlogStatusScheduled, gomock.Eq(&publishAt)).
+			Return(&models.BlogBase{BlogID: blogUID, AuthorID: authorUID, Status: models.BlogStatusScheduled, PublishedAt: &publishAt}, nil)
+		mockRedisRepo.EXPECT().DeleteBlogCtx(gomock.Any(), gomock.Any()).Return(nil)
+
+		scheduledBlog, err := blogUC.Publish(ctx, blogUID, &models.BlogPublish{PublishAt: &publishAt})
+		require.NoError(t, err)
+		require.Equal(t, models.BlogStatusScheduled, scheduledBlog.Status)
+	})
+
+	t.Run("Held", func(t *testing.T) {
+		blogUID := uuid.New()
+		held := &models.BlogBase{BlogID: blogUID, AuthorID: authorUID, Status: models.BlogStatusDraft, Held: true}
+
+		mockBlogRepo.EXPECT().GetByID(gomock.Any(), blogUID).Return(held, nil)
+
+		_, err := blogUC.Publish(ctx, blogUID, &models.BlogPublish{})
+		require.Error(t, err)
+		require.Equal(t, http.StatusConflict, httpErrors.ParseErrors(err).Status())
+	})
+
+	t.Run("Release", func(t *testing.T) {
+		blogUID := uuid.New()
+		held := &models.BlogBase{BlogID: blogUID, AuthorID: authorUID, Status: models.BlogStatusDraft, Held: true}
+
+		_, err := blogUC.Release(ctx, blogUID)
+		require.Error(t, err)
+		require.Equal(t, http.StatusForbidden, httpErrors.ParseErrors(err).Status())
+
+		moderatorCtx := context.WithValue(context.WithValue(context.Background(), "user_id", uuid.New().String()), "role", rbac.RoleModerator)
+
+		mockBlogRepo.EXPECT().GetByID(gomock.Any(), blogUID).Return(held, nil)
+		mockBlogRepo.EXPECT().SetHeld(gomock.Any(), blogUID, false).
+			Return(&models.BlogBase{BlogID: blogUID, AuthorID: authorUID, Status: models.BlogStatusDraft}, nil)
+		mockRedisRepo.EXPECT().DeleteBlogCtx(gomock.Any(), gomock.Any()).Return(nil)
+
+		releasedBlog, err := blogUC.Release(moderatorCtx, blogUID)
+		require.NoError(t, err)
+		require.False(t, releasedBlog.Held)
+	})
+
+	t.Run("Scheduled time comes", func(t *testing.T) {
+		blogUID := uuid.New()
+		publishAt := time.Now().UTC().Truncate(time.Microsecond)
+
+		mockBlogRepo.EXPECT().PublishScheduled(gomock.Any(), blogUID, publishAt).Return(true, nil)
+		mockRedisRepo.EXPECT().DeleteBlogCtx(gomock.Any(), gomock.Any()).Return(nil)
+		mockFeedTD.EXPECT().DistributeTaskFanOutBlog(gomock.Any(), gomock.Eq(&feedAsynq.FanOutBlogPayload{BlogID: blogUID}), gomock.Any()).Return(nil)
+
+		require.NoError(t, blogUC.ProcessPublish(ctx, blogUID, publishAt))
+	})
+
+	t.Run("Schedule no longer set", func(t *testing.T) {
+		blogUID := uuid.New()
+		publishAt := time.Now().UTC().Truncate(time.Microsecond)
+
+		mockBlogRepo.EXPECT().PublishScheduled(gomock.Any(), blogUID, publishAt).Return(false, nil)
+
+		require.NoError(t, blogUC.ProcessPublish(ctx, blogUID, publishAt))
+	})
+
+	t.Run("Draft is hidden", func(t *testing.T) {
+		blogUID := uuid.New()
+		draft := &models.BlogBase{BlogID: blogUID, AuthorID: authorUID, Status: models.BlogStatusDraft, Version: 1, Slug: "draft", Tags: models.Tags{},
+			ContentFormat: models.BlogContentPlain}
+
+		mockRedisRepo.EXPECT().GetBlogByIDCtx(gomock.Any(), gomock.Any()).Return(draft, nil)
+
+		_, err := blogUC.GetByID(context.Background(), blogUID)
+		require.Error(t, err)
+		require.Equal(t, http.StatusNotFound, httpErrors.ParseErrors(err).Status())
+	})
+}
+
+func TestBlogUseCase_Revisions(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Logger: config.LoggerConfig{
+			Development: true,
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	apiLogger.InitLogger()
+	mockBlogRepo := mock.NewMockRepository(ctrl)
+	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
+	blogUC := NewBlogUseCase(cfg, mockBlogRepo, mockRedisRepo, nil, nil, nil, nil, nil, apiLogger)
+
+	authorUID := uuid.New()
+	blogUID := uuid.New()
+	blogBase := &models.BlogBase{BlogID: blogUID, AuthorID: authorUID, Status: models.BlogStatusPublished}
+
+	t.Run("Diff", func(t *testing.T) {
+		mockBlogRepo.EXPECT().GetByID(gomock.Any(), blogUID).Return(blogBase, nil)
+		mockBlogRepo.EXPECT().GetRevision(gomock.Any(), blogUID, 1).
+			Return(&models.BlogRevision{BlogID: blogUID, Revision: 1, Title: "Title", Content: "first\nsecond"}, nil)
+		mockBlogRepo.EXPECT().GetRevision(gomock.Any(), blogUID, 2).
+			Return(&models.BlogRevision{BlogID: blogUID, Revision: 2, Title: "Title", Content: "first\nchanged"}, nil)
+
+		diff, err := blogUC.DiffRevisions(context.Background(), blogUID, 1, 2)
+		require.NoError(t, err)
+		require.Equal(t, []utils.DiffLine{{Op: utils.DiffEqual, Text: "Title"}}, diff.Title)
+		require.Equal(t, []utils.DiffLine{
+			{Op: utils.DiffEqual, Text: "first"},
+			{Op: utils.DiffDelete, Text: "second"},
+			{Op: utils.DiffInsert, Text: "changed"},
+		}, diff.Content)
+	})
+
+	t.Run("Diff too large", func(t *testing.T) {
+		content := strings.Repeat("line\n", utils.DiffMaxLines+1)
+
+		mockBlogRepo.EXPECT().GetByID(gomock.Any(), blogUID).Return(blogBase, nil)
+		mockBlogRepo.EXPECT().GetRevision(gomock.Any(), blogUID, 1).
+			Return(&models.BlogRevision{BlogID: blogUID, Revision: 1, Title: "Title", Content: content}, nil)
+		mockBlogRepo.EXPECT().GetRevision(gomock.Any(), blogUID, 2).
+			Return(&models.BlogRevision{BlogID: blogUID, Revision: 2, Title: "Title", Content: "first"}, nil)
+
+		_, err := blogUC.DiffRevisions(context.Background(), blogUID, 1, 2)
+		require.Error(t, err)
+		require.Equal(t, http.StatusRequestEntityTooLarge, httpErrors.ParseErrors(err).Status())
+	})
+
+	t.Run("Restore", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), "user_id", authorUID.String())
+
+		mockBlogRepo.EXPECT().GetByID(gomock.Any(), blogUID).Return(blogBase, nil)
+		mockBlogRepo.EXPECT().Restore(gomock.Any(), blogUID, 1, authorUID).Return(blogBase, nil)
+		mockRedisRepo.EXPECT().DeleteBlogCtx(gomock.Any(), gomock.Any()).Return(nil)
+
+		restoredBlog, err := blogUC.RestoreRevision(ctx, blogUID, 1)
+		require.NoError(t, err)
+		require.Equal(t, blogUID, restoredBlog.BlogID)
+	})
+
+	t.Run("Restore by admin", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), "user_id", uuid.New().String())
+		ctx = context.WithValue(ctx, "role", rbac.RoleAdmin)
+
+		mockBlogRepo.EXPECT().GetByID(gomock.Any(), blogUID).Return(blogBase, nil)
+
+		_, err := blogUC.RestoreRevision(ctx, blogUID, 1)
+		require.Error(t, err)
+		require.Equal(t, http.StatusForbidden, httpErrors.ParseErrors(err).Status())
+	})
+}
+
 func TestBlogUseCase_List(t *testing.T) {
 	t.Parallel()
 
@@ -215,7 +708,9 @@ func TestBlogUseCase_List(t *testing.T) {
 
 	apiLogger := logger.NewApiLogger(cfg)
 	mockBlogRepo := mock.NewMockRepository(ctrl)
-	blogUC := NewBlogUseCase(cfg, mockBlogRepo, apiLogger)
+	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
+	mockReactionRepo := reactionMock.NewMockRepository(ctrl)
+	blogUC := NewBlogUseCase(cfg, mockBlogRepo, mockRedisRepo, nil, mockReactionRepo, nil, nil, nil, apiLogger)
 
 	blogUID := uuid.New()
 	userUID := uuid.New()
@@ -243,15 +738,27 @@ func TestBlogUseCase_List(t *testing.T) {
 		OrderBy: "",
 	}
 
+	filter := &models.BlogFilter{Category: "golang"}
+
 	ctx := context.Background()
 	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "blogUC.List")
 	defer span.Finish()
 
-	mockBlogRepo.EXPECT().List(ctxWithTrace, gomock.Eq(pq)).Return(blogsListMock, nil)
+	mockBlogRepo.EXPECT().List(ctxWithTrace, gomock.Eq(filter), gomock.Eq(pq)).Return(blogsListMock, nil)
+	mockReactionRepo.EXPECT().Summaries(ctxWithTrace, models.ReactionTargetBlog, gomock.Len(2), uuid.Nil).
+		Return(map[uuid.UUID]*models.ReactionSummary{}, nil)
 
-	blogsList, err := blogUC.List(ctx, pq)
+	blogsList, err := blogUC.List(ctx, filter, pq)
 	require.NoError(t, err)
 	require.Nil(t, err)
 	require.NotNil(t, blogsList)
 	require.Equal(t, len(blogsList.Blogs), 2)
 }
+
+func allowContent(mockContentFilter *filterMock.MockContentFilter) {
+	mockContentFilter.EXPECT().Check(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, input *models.FilterInput) (*models.FilterDecision, error) {
+			return &models.FilterDecision{Action: models.FilterAllow, Texts: input.Texts}, nil
+		}).AnyTimes()
+	mockContentFilter.EXPECT().Record(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+}
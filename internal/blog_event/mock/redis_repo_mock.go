@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: redis_repo.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRedisRepository is a mock of RedisRepository interface.
+type MockRedisRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRedisRepositoryMockRecorder
+}
+
+// MockRedisRepositoryMockRecorder is the mock recorder for MockRedisRepository.
+type MockRedisRepositoryMockRecorder struct {
+	mock *MockRedisRepository
+}
+
+// NewMockRedisRepository creates a new mock instance.
+func NewMockRedisRepository(ctrl *gomock.Controller) *MockRedisRepository {
+	mock := &MockRedisRepository{ctrl: ctrl}
+	mock.recorder = &MockRedisRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRedisRepository) EXPECT() *MockRedisRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AppendCtx mocks base method.
+func (m *MockRedisRepository) AppendCtx(ctx context.Context, key string, maxLen int64, seconds int, event *models.BlogEvent) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AppendCtx", ctx, key, maxLen, seconds, event)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AppendCtx indicates an expected call of AppendCtx.
+func (mr *MockRedisRepositoryMockRecorder) AppendCtx(ctx, key, maxLen, seconds, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendCtx", reflect.TypeOf((*MockRedisRepository)(nil).AppendCtx), ctx, key, maxLen, seconds, event)
+}
+
+// PublishCtx mocks base method.
+func (m *MockRedisRepository) PublishCtx(ctx context.Context, channel string, event *models.BlogEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishCtx", ctx, channel, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishCtx indicates an expected call of PublishCtx.
+func (mr *MockRedisRepositoryMockRecorder) PublishCtx(ctx, channel, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishCtx", reflect.TypeOf((*MockRedisRepository)(nil).PublishCtx), ctx, channel, event)
+}
+
+// RangeCtx mocks base method.
+func (m *MockRedisRepository) RangeCtx(ctx context.Context, key, fromID string, count int64) ([]*models.BlogEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RangeCtx", ctx, key, fromID, count)
+	ret0, _ := ret[0].([]*models.BlogEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RangeCtx indicates an expected call of RangeCtx.
+func (mr *MockRedisRepositoryMockRecorder) RangeCtx(ctx, key, fromID, count interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RangeCtx", reflect.TypeOf((*MockRedisRepository)(nil).RangeCtx), ctx, key, fromID, count)
+}
+
+// SubscribeCtx mocks base method.
+func (m *MockRedisRepository) SubscribeCtx(ctx context.Context, pattern string, handle func(*models.BlogEvent)) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeCtx", ctx, pattern, handle)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SubscribeCtx indicates an expected call of SubscribeCtx.
+func (mr *MockRedisRepositoryMockRecorder) SubscribeCtx(ctx, pattern, handle interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeCtx", reflect.TypeOf((*MockRedisRepository)(nil).SubscribeCtx), ctx, pattern, handle)
+}
@@ -0,0 +1,14 @@
+//go:generate mockgen -source redis_repo.go -destination mock/redis_repo_mock.go -package mock
+package blog_event
+
+import (
+	"context"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+)
+
+type RedisRepository interface {
+	AppendCtx(ctx context.Context, key string, maxLen int64, seconds int, event *models.BlogEvent) (string, error)
+	RangeCtx(ctx context.Context, key string, fromID string, count int64) ([]*models.BlogEvent, error)
+	PublishCtx(ctx context.Context, channel string, event *models.BlogEvent) error
+	SubscribeCtx(ctx context.Context, pattern string, handle func(event *models.BlogEvent)) error
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/blog_event"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	"time"
+)
+
+const eventField = "event"
+
+type blogEventRedisRepo struct {
+	rdb *redis.Client
+}
+
+func NewBlogEventRedisRepository(rdb *redis.Client) blog_event.RedisRepository {
+	return &blogEventRedisRepo{rdb: rdb}
+}
+
+// AppendCtx add event to stream trimmed to about maxLen events and return id stream gave it, stream expires seconds after last event
+func (r *blogEventRedisRepo) AppendCtx(ctx context.Context, key string, maxLen int64, seconds int, event *models.BlogEvent) (string, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "blogEventRedisRepo.AppendCtx")
+	defer span.Finish()
+
+	eventBytes, err := json.Marshal(event)
+	if err != nil {
+		return "", errors.Wrap(err, "blogEventRedisRepo.AppendCtx.json.Marshal")
+	}
+
+	id, err := r.rdb.XAdd(ctx, &redis.XAddArgs{
+		Stream: key,
+		MaxLen: maxLen,
+		Approx: true,
+		Values: map[string]interface{}{eventField: eventBytes},
+	}).Result()
+	if err != nil {
+		return "", errors.Wrap(err, "blogEventRedisRepo.AppendCtx.redisClient.XAdd")
+	}
+
+	if err = r.rdb.Expire(ctx, key, time.Second*time.Duration(seconds)).Err(); err != nil {
+		return "", errors.Wrap(err, "blogEventRedisRepo.AppendCtx.redisClient.Expire")
+	}
+
+	return id, nil
+}
+
+// RangeCtx read at most count events of stream starting with fromID, event with fromID is included
+func (r *blogEventRedisRepo) RangeCtx(ctx context.Context, key string, fromID string, count int64) ([]*models.BlogEvent, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "blogEventRedisRepo.RangeCtx")
+	defer span.Finish()
+
+	messages, err := r.rdb.XRangeN(ctx, key, fromID, "+", count).Result()
+	if err != nil {
+		return nil, errors.Wrap(err, "blogEventRedisRepo.RangeCtx.redisClient.XRangeN")
+	}
+
+	events := make([]*models.BlogEvent, 0, len(messages))
+	for _, message := range messages {
+		value, ok := message.Values[eventField].(string)
+		if !ok {
+			continue
+		}
+
+		event := &models.BlogEvent{}
+		if err = json.Unmarshal([]byte(value), event); err != nil {
+			continue
+		}
+		event.ID = message.ID
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+func (r *blogEventRedisRepo) PublishCtx(ctx context.Context, channel string, event *models.BlogEvent) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "blogEventRedisRepo.PublishCtx")
+	defer span.Finish()
+
+	eventBytes, err := json.Marshal(event)
+	if err != nil {
+		return errors.Wrap(err, "blogEventRedisRepo.PublishCtx.json.Marshal")
+	}
+
+	if err = r.rdb.Publish(ctx, channel, eventBytes).Err(); err != nil {
+		return errors.Wrap(err, "blogEventRedisRepo.PublishCtx.redisClient.Publish")
+	}
+
+	return nil
+}
+
+// SubscribeCtx pass events of channels matching pattern to handle until ctx is done, client reconnects on its own when connection is lost
+func (r *blogEventRedisRepo) SubscribeCtx(ctx context.Context, pattern string, handle func(event *models.BlogEvent)) error {
+	pubSub := r.rdb.PSubscribe(ctx, pattern)
+	defer pubSub.Close()
+
+	// Subscription is confirmed first so events published after it starts are not lost
+	if _, err := pubSub.Receive(ctx); err != nil {
+		return errors.Wrap(err, "blogEventRedisRepo.SubscribeCtx.pubSub.Receive")
+	}
+
+	messages := pubSub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case message, ok := <-messages:
+			if !ok {
+				return nil
+			}
+
+			event := &models.BlogEvent{}
+			if err := json.Unmarshal([]byte(message.Payload), event); err != nil {
+				continue
+			}
+			handle(event)
+		}
+	}
+}
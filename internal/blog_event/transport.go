@@ -0,0 +1,7 @@
+package blog_event
+
+import "github.com/labstack/echo/v4"
+
+type Handlers interface {
+	Stream() echo.HandlerFunc
+}
@@ -0,0 +1,132 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/opentracing/opentracing-go"
+	"github.com/scul0405/blog-clean-architecture-rest-api/config"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/blog_event"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	httpErrors "github.com/scul0405/blog-clean-architecture-rest-api/pkg/http_errors"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/logger"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/utils"
+	"net/http"
+	"time"
+)
+
+const (
+	headerLastEventID        = "Last-Event-ID"
+	defaultHeartbeatInterval = 15
+	// retryMillis is how long client waits before it reconnects
+	retryMillis = 3000
+)
+
+type blogEventHandlers struct {
+	cfg         *config.Config
+	blogEventUC blog_event.UseCase
+	logger      logger.Logger
+}
+
+func NewBlogEventHandlers(cfg *config.Config, blogEventUC blog_event.UseCase, logger logger.Logger) blog_event.Handlers {
+	return &blogEventHandlers{cfg: cfg, blogEventUC: blogEventUC, logger: logger}
+}
+
+// Stream godoc
+// @Summary Stream events of blog
+// @Description stream comment created, updated, deleted and likes events of blog as server-sent events.
+// @Description Client which reconnects with Last-Event-ID gets events it missed, or reset event when they are no longer kept
+// @Tags Blog
+// @Produce text/event-stream
+// @Param blog_id path string true "blog_id"
+// @Param Last-Event-ID header string false "id of last event client got"
+// @Success 200 {string} string "event stream"
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 404 {object} httpErrors.RestError
+// @Failure 500 {object} httpErrors.RestError
+// @Router /blogs/{blog_id}/events [get]
+func (h *blogEventHandlers) Stream() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "blogEventHandlers.Stream")
+		defer span.Finish()
+
+		blogUID, err := uuid.Parse(c.Param("blog_id"))
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(httpErrors.NewBadRequestError(err)))
+		}
+
+		events, err := h.blogEventUC.Subscribe(ctx, blogUID, c.Request().Header.Get(headerLastEventID))
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		// Stream outlives write timeout of server
+		rc := http.NewResponseController(c.Response().Writer)
+		if err = rc.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+			h.logger.Errorf("blogEventHandlers.Stream: SetWriteDeadline: %v", err)
+		}
+
+		res := c.Response()
+		res.Header().Set(echo.HeaderContentType, "text/event-stream")
+		res.Header().Set("Cache-Control", "no-cache")
+		res.Header().Set("Connection", "keep-alive")
+		// nginx passes events as they are written instead of buffering them
+		res.Header().Set("X-Accel-Buffering", "no")
+		res.WriteHeader(http.StatusOK)
+
+		if _, err = fmt.Fprintf(res, "retry: %d\n\n", retryMillis); err != nil {
+			return nil
+		}
+		res.Flush()
+
+		heartbeat := time.NewTicker(h.heartbeatInterval())
+		defer heartbeat.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-heartbeat.C:
+				if _, err = fmt.Fprint(res, ": heartbeat\n\n"); err != nil {
+					return nil
+				}
+			case event, ok := <-events:
+				if !ok {
+					return nil
+				}
+				if err = writeEvent(res, event); err != nil {
+					return nil
+				}
+				heartbeat.Reset(h.heartbeatInterval())
+			}
+			res.Flush()
+		}
+	}
+}
+
+func (h *blogEventHandlers) heartbeatInterval() time.Duration {
+	if h.cfg.BlogEvents.HeartbeatInterval > 0 {
+		return time.Duration(h.cfg.BlogEvents.HeartbeatInterval) * time.Second
+	}
+	return defaultHeartbeatInterval * time.Second
+}
+
+// writeEvent write event in server-sent events format, event without id keeps last id of client
+func writeEvent(res *echo.Response, event *models.BlogEvent) error {
+	if event.ID != "" {
+		if _, err := fmt.Fprintf(res, "id: %s\n", event.ID); err != nil {
+			return err
+		}
+	}
+
+	data := event.Data
+	if len(data) == 0 {
+		data = []byte("{}")
+	}
+	_, err := fmt.Fprintf(res, "event: %s\ndata: %s\n\n", event.Type, data)
+
+	return err
+}
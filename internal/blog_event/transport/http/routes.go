@@ -0,0 +1,11 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/blog_event"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/middleware"
+)
+
+func MapBlogEventRoutes(blogGroup *echo.Group, h blog_event.Handlers, mw *middleware.MiddlewareManager) {
+	blogGroup.GET("/:blog_id/events", h.Stream(), mw.OptionalAuthPASETOMiddleware)
+}
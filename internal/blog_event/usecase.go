@@ -0,0 +1,14 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase_mock.go -package mock
+package blog_event
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+)
+
+type UseCase interface {
+	Publish(ctx context.Context, blogID uuid.UUID, eventType string, data interface{}) error
+	Subscribe(ctx context.Context, blogID uuid.UUID, lastEventID string) (<-chan *models.BlogEvent, error)
+	Run(ctx context.Context) error
+}
@@ -0,0 +1,265 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"github.com/scul0405/blog-clean-architecture-rest-api/config"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/blog"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/blog_event"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/logger"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	basePrefix         = "blog-events-api"
+	defaultBacklogSize = 100
+	defaultBacklogTTL  = 3600
+	// subscriberBuffer is how many events subscriber can fall behind before it is dropped
+	subscriberBuffer = 64
+	resubscribeDelay = time.Second
+)
+
+type blogEventUseCase struct {
+	cfg       *config.Config
+	blogUC    blog.UseCase
+	redisRepo blog_event.RedisRepository
+	logger    logger.Logger
+
+	mu          sync.Mutex
+	subscribers map[uuid.UUID]map[chan *models.BlogEvent]struct{}
+}
+
+func NewBlogEventUseCase(cfg *config.Config, blogUC blog.UseCase, redisRepo blog_event.RedisRepository, logger logger.Logger) blog_event.UseCase {
+	return &blogEventUseCase{cfg: cfg, blogUC: blogUC, redisRepo: redisRepo, logger: logger,
+		subscribers: make(map[uuid.UUID]map[chan *models.BlogEvent]struct{})}
+}
+
+// Publish add event to backlog of blog and broadcast it to every instance
+func (u *blogEventUseCase) Publish(ctx context.Context, blogID uuid.UUID, eventType string, data interface{}) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "blogEventUC.Publish")
+	defer span.Finish()
+
+	dataBytes, err := json.Marshal(data)
+	if err != nil {
+		return errors.Wrap(err, "blogEventUC.Publish.json.Marshal")
+	}
+
+	event := &models.BlogEvent{BlogID: blogID, Type: eventType, Data: dataBytes}
+	event.ID, err = u.redisRepo.AppendCtx(ctx, u.generateStreamKey(blogID), u.backlogSize(), u.backlogTTL(), event)
+	if err != nil {
+		return err
+	}
+
+	return u.redisRepo.PublishCtx(ctx, u.generateChannel(blogID), event)
+}
+
+// Subscribe stream events of blog caller can view until ctx is done. Events after lastEventID are sent first,
+// reset is sent instead when they are no longer in backlog. Stream is closed when subscriber falls behind
+func (u *blogEventUseCase) Subscribe(ctx context.Context, blogID uuid.UUID, lastEventID string) (<-chan *models.BlogEvent, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "blogEventUC.Subscribe")
+	defer span.Finish()
+
+	if _, err := u.blogUC.GetByID(ctx, blogID); err != nil {
+		return nil, err
+	}
+
+	// Subscriber is added before backlog is read so no event is lost between them, duplicates are skipped by id
+	live := u.subscribe(blogID)
+
+	backlog, err := u.backlog(ctx, blogID, lastEventID)
+	if err != nil {
+		u.unsubscribe(blogID, live)
+		return nil, err
+	}
+
+	events := make(chan *models.BlogEvent)
+	go func() {
+		defer close(events)
+		defer u.unsubscribe(blogID, live)
+
+		lastID, _ := parseEventID(lastEventID)
+		for _, event := range backlog {
+			if id, ok := parseEventID(event.ID); ok {
+				lastID = id
+			}
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-live:
+				if !ok {
+					return
+				}
+				id, _ := parseEventID(event.ID)
+				if !id.after(lastID) {
+					continue
+				}
+				lastID = id
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+// Run receive events of every blog from redis and pass them to subscribers of this instance until ctx is done,
+// subscription which fails is made again
+func (u *blogEventUseCase) Run(ctx context.Context) error {
+	for {
+		err := u.redisRepo.SubscribeCtx(ctx, u.generateChannel("*"), u.dispatch)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		u.logger.Errorf("blogEventUC.Run: SubscribeCtx: %v", err)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(resubscribeDelay):
+		}
+	}
+}
+
+// dispatch pass event to subscribers of its blog, subscriber which is full is dropped so it can not slow down others
+func (u *blogEventUseCase) dispatch(event *models.BlogEvent) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	for subscriber := range u.subscribers[event.BlogID] {
+		select {
+		case subscriber <- event:
+		default:
+			u.logger.Warnf("blogEventUC.dispatch: subscriber of blog %s fell behind and is dropped", event.BlogID)
+			u.removeSubscriber(event.BlogID, subscriber)
+		}
+	}
+}
+
+func (u *blogEventUseCase) subscribe(blogID uuid.UUID) chan *models.BlogEvent {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	subscriber := make(chan *models.BlogEvent, subscriberBuffer)
+	if u.subscribers[blogID] == nil {
+		u.subscribers[blogID] = make(map[chan *models.BlogEvent]struct{})
+	}
+	u.subscribers[blogID][subscriber] = struct{}{}
+
+	return subscriber
+}
+
+func (u *blogEventUseCase) unsubscribe(blogID uuid.UUID, subscriber chan *models.BlogEvent) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.removeSubscriber(blogID, subscriber)
+}
+
+// removeSubscriber close subscriber once, caller holds lock
+func (u *blogEventUseCase) removeSubscriber(blogID uuid.UUID, subscriber chan *models.BlogEvent) {
+	if _, ok := u.subscribers[blogID][subscriber]; !ok {
+		return
+	}
+
+	delete(u.subscribers[blogID], subscriber)
+	if len(u.subscribers[blogID]) == 0 {
+		delete(u.subscribers, blogID)
+	}
+	close(subscriber)
+}
+
+// backlog read events after lastEventID, it is reset when event with lastEventID was trimmed or expired
+func (u *blogEventUseCase) backlog(ctx context.Context, blogID uuid.UUID, lastEventID string) ([]*models.BlogEvent, error) {
+	if lastEventID == "" {
+		return nil, nil
+	}
+
+	reset := &models.BlogEvent{BlogID: blogID, Type: models.BlogEventReset}
+	if _, ok := parseEventID(lastEventID); !ok {
+		return []*models.BlogEvent{reset}, nil
+	}
+
+	events, err := u.redisRepo.RangeCtx(ctx, u.generateStreamKey(blogID), lastEventID, u.backlogSize()+1)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(events) == 0 || events[0].ID != lastEventID {
+		if len(events) > 0 {
+			reset.ID = events[len(events)-1].ID
+		}
+		return []*models.BlogEvent{reset}, nil
+	}
+
+	return events[1:], nil
+}
+
+func (u *blogEventUseCase) backlogSize() int64 {
+	if u.cfg.BlogEvents.BacklogSize > 0 {
+		return int64(u.cfg.BlogEvents.BacklogSize)
+	}
+	return defaultBacklogSize
+}
+
+func (u *blogEventUseCase) backlogTTL() int {
+	if u.cfg.BlogEvents.BacklogTTL > 0 {
+		return u.cfg.BlogEvents.BacklogTTL
+	}
+	return defaultBacklogTTL
+}
+
+func (u *blogEventUseCase) generateStreamKey(blogID uuid.UUID) string {
+	return fmt.Sprintf("%s: stream: %s", basePrefix, blogID)
+}
+
+func (u *blogEventUseCase) generateChannel(blogID interface{}) string {
+	return fmt.Sprintf("%s: channel: %v", basePrefix, blogID)
+}
+
+// eventID is id redis stream gives event, milliseconds and sequence number
+type eventID struct {
+	ms  uint64
+	seq uint64
+}
+
+func parseEventID(id string) (eventID, bool) {
+	msPart, seqPart, found := strings.Cut(id, "-")
+	if !found {
+		return eventID{}, false
+	}
+
+	ms, err := strconv.ParseUint(msPart, 10, 64)
+	if err != nil {
+		return eventID{}, false
+	}
+	seq, err := strconv.ParseUint(seqPart, 10, 64)
+	if err != nil {
+		return eventID{}, false
+	}
+
+	return eventID{ms: ms, seq: seq}, true
+}
+
+func (id eventID) after(other eventID) bool {
+	return id.ms > other.ms || (id.ms == other.ms && id.seq > other.seq)
+}
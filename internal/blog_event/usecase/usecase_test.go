@@ -0,0 +1,151 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/scul0405/blog-clean-architecture-rest-api/config"
+	blogMock "github.com/scul0405/blog-clean-architecture-rest-api/internal/blog/mock"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/blog_event/mock"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	httpErrors "github.com/scul0405/blog-clean-architecture-rest-api/pkg/http_errors"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/logger"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"testing"
+)
+
+func TestBlogEventUseCase_Publish(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Logger: config.LoggerConfig{
+			Development: true,
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
+	blogEventUC := NewBlogEventUseCase(cfg, nil, mockRedisRepo, apiLogger)
+
+	blogUID := uuid.New()
+	commentUID := uuid.New()
+
+	mockRedisRepo.EXPECT().AppendCtx(gomock.Any(), "blog-events-api: stream: "+blogUID.String(), int64(defaultBacklogSize), defaultBacklogTTL, gomock.Any()).
+		Return("1700000000000-0", nil)
+	mockRedisRepo.EXPECT().PublishCtx(gomock.Any(), "blog-events-api: channel: "+blogUID.String(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, channel string, event *models.BlogEvent) error {
+			require.Equal(t, "1700000000000-0", event.ID)
+			require.Equal(t, models.BlogEventCommentDeleted, event.Type)
+			require.JSONEq(t, `{"comment_id":"`+commentUID.String()+`"}`, string(event.Data))
+			return nil
+		})
+
+	err := blogEventUC.Publish(context.Background(), blogUID, models.BlogEventCommentDeleted, &models.CommentDeletedEvent{CommentID: commentUID})
+	require.NoError(t, err)
+}
+
+func TestBlogEventUseCase_Subscribe(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Logger: config.LoggerConfig{
+			Development: true,
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	apiLogger.InitLogger()
+	mockBlogUC := blogMock.NewMockUseCase(ctrl)
+	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
+	blogEventUC := NewBlogEventUseCase(cfg, mockBlogUC, mockRedisRepo, apiLogger).(*blogEventUseCase)
+
+	newEvent := func(blogID uuid.UUID, id string) *models.BlogEvent {
+		return &models.BlogEvent{ID: id, BlogID: blogID, Type: models.BlogEventCommentLikes, Data: json.RawMessage(`{}`)}
+	}
+
+	t.Run("Resume from backlog", func(t *testing.T) {
+		blogUID := uuid.New()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		mockBlogUC.EXPECT().GetByID(gomock.Any(), blogUID).Return(&models.BlogBase{BlogID: blogUID}, nil)
+		mockRedisRepo.EXPECT().RangeCtx(gomock.Any(), gomock.Any(), "100-0", gomock.Any()).
+			Return([]*models.BlogEvent{newEvent(blogUID, "100-0"), newEvent(blogUID, "100-1"), newEvent(blogUID, "101-0")}, nil)
+
+		events, err := blogEventUC.Subscribe(ctx, blogUID, "100-0")
+		require.NoError(t, err)
+
+		// Event which is also in backlog is sent once
+		blogEventUC.dispatch(newEvent(blogUID, "101-0"))
+		blogEventUC.dispatch(newEvent(uuid.New(), "102-0"))
+		blogEventUC.dispatch(newEvent(blogUID, "103-0"))
+
+		require.Equal(t, "100-1", (<-events).ID)
+		require.Equal(t, "101-0", (<-events).ID)
+		require.Equal(t, "103-0", (<-events).ID)
+
+		cancel()
+		for range events {
+		}
+		require.Empty(t, blogEventUC.subscribers)
+	})
+
+	t.Run("Reset when backlog was trimmed", func(t *testing.T) {
+		blogUID := uuid.New()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		mockBlogUC.EXPECT().GetByID(gomock.Any(), blogUID).Return(&models.BlogBase{BlogID: blogUID}, nil)
+		mockRedisRepo.EXPECT().RangeCtx(gomock.Any(), gomock.Any(), "100-0", gomock.Any()).
+			Return([]*models.BlogEvent{newEvent(blogUID, "200-0"), newEvent(blogUID, "201-0")}, nil)
+
+		events, err := blogEventUC.Subscribe(ctx, blogUID, "100-0")
+		require.NoError(t, err)
+
+		reset := <-events
+		require.Equal(t, models.BlogEventReset, reset.Type)
+		require.Equal(t, "201-0", reset.ID)
+	})
+
+	t.Run("Slow subscriber is dropped", func(t *testing.T) {
+		blogUID := uuid.New()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		mockBlogUC.EXPECT().GetByID(gomock.Any(), blogUID).Return(&models.BlogBase{BlogID: blogUID}, nil)
+
+		events, err := blogEventUC.Subscribe(ctx, blogUID, "")
+		require.NoError(t, err)
+
+		// One event waits to be sent and buffer fills up behind it
+		for i := 0; i <= subscriberBuffer+1; i++ {
+			blogEventUC.dispatch(newEvent(blogUID, fmt.Sprintf("300-%d", i)))
+		}
+
+		// Stream ends once buffered events are read
+		count := 0
+		for range events {
+			count++
+		}
+		require.Less(t, count, subscriberBuffer+2)
+	})
+
+	t.Run("Blog is not visible", func(t *testing.T) {
+		blogUID := uuid.New()
+
+		mockBlogUC.EXPECT().GetByID(gomock.Any(), blogUID).Return(nil, httpErrors.NewRestError(http.StatusNotFound, httpErrors.NotFound.Error(), nil))
+
+		events, err := blogEventUC.Subscribe(context.Background(), blogUID, "")
+		require.Nil(t, events)
+		require.Error(t, err)
+	})
+}
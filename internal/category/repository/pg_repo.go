@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/category"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/utils"
+)
+
+type categoryRepo struct {
+	db *sqlx.DB
+}
+
+func NewCategoryRepository(db *sqlx.DB) category.Repository {
+	return &categoryRepo{db: db}
+}
+
+func (r *categoryRepo) Create(ctx context.Context, category *models.Category) (*models.Category, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "categoryRepo.Create")
+	defer span.Finish()
+
+	var c models.Category
+	if err := r.db.QueryRowxContext(ctx, createCategoryQuery, category.Slug, category.Name, category.Description).StructScan(&c); err != nil {
+		return nil, errors.Wrap(err, "categoryRepo.Create.StructScan")
+	}
+
+	return &c, nil
+}
+
+func (r *categoryRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.Category, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "categoryRepo.GetByID")
+	defer span.Finish()
+
+	var c models.Category
+	if err := r.db.QueryRowxContext(ctx, getCategoryByIDQuery, id).StructScan(&c); err != nil {
+		return nil, errors.Wrap(err, "categoryRepo.GetByID.StructScan")
+	}
+
+	return &c, nil
+}
+
+// Update name and description of category, slug never changes
+func (r *categoryRepo) Update(ctx context.Context, category *models.CategoryBase) (*models.Category, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "categoryRepo.Update")
+	defer span.Finish()
+
+	var c models.Category
+	if err := r.db.QueryRowxContext(ctx, updateCategoryQuery, category.Name, category.Description, category.CategoryID).StructScan(&c); err != nil {
+		return nil, errors.Wrap(err, "categoryRepo.Update.StructScan")
+	}
+
+	return &c, nil
+}
+
+// Delete category, its blogs are left without category
+func (r *categoryRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "categoryRepo.Delete")
+	defer span.Finish()
+
+	result, err := r.db.ExecContext(ctx, deleteCategoryQuery, id)
+	if err != nil {
+		return errors.Wrap(err, "categoryRepo.Delete.ExecContext")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "categoryRepo.Delete.RowsAffected")
+	}
+	if rowsAffected == 0 {
+		return errors.Wrap(sql.ErrNoRows, "categoryRepo.Delete.rowsAffected")
+	}
+
+	return nil
+}
+
+// List categories ordered by name
+func (r *categoryRepo) List(ctx context.Context, pq *utils.PaginationQuery) (*models.CategoriesList, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "categoryRepo.List")
+	defer span.Finish()
+
+	var totalCount int
+	if err := r.db.GetContext(ctx, &totalCount, getCategoriesTotalCountQuery); err != nil {
+		return nil, errors.Wrap(err, "categoryRepo.List.GetContext.totalCount")
+	}
+
+	var categories = make([]*models.Category, 0, pq.GetSize())
+	if totalCount > 0 {
+		if err := r.db.SelectContext(ctx, &categories, listCategoriesQuery, pq.GetOffset(), pq.GetLimit()); err != nil {
+			return nil, errors.Wrap(err, "categoryRepo.List.SelectContext")
+		}
+	}
+
+	return &models.CategoriesList{
+		TotalCount: totalCount,
+		TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+		Page:       pq.GetPage(),
+		Size:       pq.GetSize(),
+		HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+		Categories: categories,
+	}, nil
+}
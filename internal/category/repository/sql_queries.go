@@ -0,0 +1,29 @@
+package repository
+
+const (
+	// categoryBlogsCountColumn count published blogs of category c
+	categoryBlogsCountColumn = `(SELECT COUNT(*) FROM blogs b WHERE b.category = c.slug AND b.status = 'published') as blogs_count`
+
+	createCategoryQuery = `INSERT INTO categories (slug, name, description) VALUES ($1, $2, NULLIF($3, ''))
+					RETURNING category_id, slug, name, description, created_at, updated_at`
+
+	getCategoryByIDQuery = `SELECT c.category_id, c.slug, c.name, c.description, c.created_at, c.updated_at, ` + categoryBlogsCountColumn + `
+					FROM categories c
+					WHERE c.category_id = $1`
+
+	// Slug is never updated, NULL description is left unchanged and empty one is removed
+	updateCategoryQuery = `UPDATE categories c
+					SET name = COALESCE(NULLIF($1, ''), name),
+						description = CASE WHEN $2::text IS NULL THEN description ELSE NULLIF($2, '') END,
+						updated_at = now()
+					WHERE c.category_id = $3
+					RETURNING c.category_id, c.slug, c.name, c.description, c.created_at, c.updated_at, ` + categoryBlogsCountColumn
+
+	deleteCategoryQuery = `DELETE FROM categories WHERE category_id = $1`
+
+	getCategoriesTotalCountQuery = `SELECT COUNT(*) FROM categories`
+
+	listCategoriesQuery = `SELECT c.category_id, c.slug, c.name, c.description, c.created_at, c.updated_at, ` + categoryBlogsCountColumn + `
+					FROM categories c
+					ORDER BY c.name, c.category_id OFFSET $1 LIMIT $2`
+)
@@ -0,0 +1,11 @@
+package category
+
+import "github.com/labstack/echo/v4"
+
+type Handlers interface {
+	Create() echo.HandlerFunc
+	GetByID() echo.HandlerFunc
+	Update() echo.HandlerFunc
+	Delete() echo.HandlerFunc
+	List() echo.HandlerFunc
+}
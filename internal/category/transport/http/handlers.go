@@ -0,0 +1,199 @@
+package http
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/opentracing/opentracing-go"
+	"github.com/scul0405/blog-clean-architecture-rest-api/config"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/category"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	httpErrors "github.com/scul0405/blog-clean-architecture-rest-api/pkg/http_errors"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/logger"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/utils"
+	"net/http"
+)
+
+type categoryHandlers struct {
+	cfg        *config.Config
+	categoryUC category.UseCase
+	logger     logger.Logger
+}
+
+func NewCategoryHandlers(cfg *config.Config, categoryUC category.UseCase, logger logger.Logger) category.Handlers {
+	return &categoryHandlers{cfg: cfg, categoryUC: categoryUC, logger: logger}
+}
+
+// Create godoc
+// @Summary Create category
+// @Description create category, slug is made of name when it is not given and never changes. Admin only
+// @Tags Category
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body models.Category true "input data"
+// @Success 201 {object} models.Category
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 403 {object} httpErrors.RestError
+// @Failure 500 {object} httpErrors.RestError
+// @Router /categories [post]
+func (h *categoryHandlers) Create() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "categoryHandlers.Create")
+		defer span.Finish()
+
+		categoryReq := &models.Category{}
+		if err := utils.ReadRequest(c, categoryReq); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		createdCategory, err := h.categoryUC.Create(ctx, categoryReq)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusCreated, createdCategory)
+	}
+}
+
+// GetByID godoc
+// @Summary Get category by id
+// @Description get category with number of published blogs
+// @Tags Category
+// @Accept json
+// @Produce json
+// @Param category_id path string true "category_id"
+// @Success 200 {object} models.Category
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 404 {object} httpErrors.RestError
+// @Failure 500 {object} httpErrors.RestError
+// @Router /categories/{category_id} [get]
+func (h *categoryHandlers) GetByID() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "categoryHandlers.GetByID")
+		defer span.Finish()
+
+		categoryID, err := uuid.Parse(c.Param("category_id"))
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		categoryByID, err := h.categoryUC.GetByID(ctx, categoryID)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, categoryByID)
+	}
+}
+
+// Update godoc
+// @Summary Update category
+// @Description update name and description of category, empty description removes it. Admin only
+// @Tags Category
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param category_id path string true "category_id"
+// @Param request body models.CategoryBase true "input data"
+// @Success 200 {object} models.Category
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 403 {object} httpErrors.RestError
+// @Failure 500 {object} httpErrors.RestError
+// @Router /categories/{category_id} [patch]
+func (h *categoryHandlers) Update() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "categoryHandlers.Update")
+		defer span.Finish()
+
+		categoryID, err := uuid.Parse(c.Param("category_id"))
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		categoryReq := &models.CategoryBase{}
+		if err := utils.ReadRequest(c, categoryReq); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+		categoryReq.CategoryID = categoryID
+
+		updatedCategory, err := h.categoryUC.Update(ctx, categoryReq)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, updatedCategory)
+	}
+}
+
+// Delete godoc
+// @Summary Delete category
+// @Description delete category, its blogs are left without category. Admin only
+// @Tags Category
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param category_id path string true "category_id"
+// @Success 200 {string} string "success"
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 403 {object} httpErrors.RestError
+// @Failure 500 {object} httpErrors.RestError
+// @Router /categories/{category_id} [delete]
+func (h *categoryHandlers) Delete() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "categoryHandlers.Delete")
+		defer span.Finish()
+
+		categoryID, err := uuid.Parse(c.Param("category_id"))
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		if err = h.categoryUC.Delete(ctx, categoryID); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.NoContent(http.StatusOK)
+	}
+}
+
+// List godoc
+// @Summary List categories
+// @Description list categories ordered by name with number of published blogs
+// @Tags Category
+// @Accept json
+// @Produce json
+// @Param page query int false "page number" Format(page)
+// @Param size query int false "number of elements per page" Format(size)
+// @Success 200 {object} models.CategoriesList
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 500 {object} httpErrors.RestError
+// @Router /categories [get]
+func (h *categoryHandlers) List() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "categoryHandlers.List")
+		defer span.Finish()
+
+		pq, err := utils.GetPaginationFromCtx(c)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		categoriesList, err := h.categoryUC.List(ctx, pq)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, categoriesList)
+	}
+}
@@ -0,0 +1,16 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/category"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/middleware"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/rbac"
+)
+
+func MapCategoryRoutes(categoryGroup *echo.Group, h category.Handlers, mw *middleware.MiddlewareManager) {
+	categoryGroup.POST("", h.Create(), mw.AuthPASETOMiddleware, mw.RequirePermission(rbac.CategoryManage))
+	categoryGroup.GET("", h.List())
+	categoryGroup.GET("/:category_id", h.GetByID())
+	categoryGroup.PATCH("/:category_id", h.Update(), mw.AuthPASETOMiddleware, mw.RequirePermission(rbac.CategoryManage))
+	categoryGroup.DELETE("/:category_id", h.Delete(), mw.AuthPASETOMiddleware, mw.RequirePermission(rbac.CategoryManage))
+}
@@ -0,0 +1,17 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase_mock.go -package mock
+package category
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/utils"
+)
+
+type UseCase interface {
+	Create(ctx context.Context, category *models.Category) (*models.Category, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Category, error)
+	Update(ctx context.Context, category *models.CategoryBase) (*models.Category, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	List(ctx context.Context, pq *utils.PaginationQuery) (*models.CategoriesList, error)
+}
@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/opentracing/opentracing-go"
+	"github.com/scul0405/blog-clean-architecture-rest-api/config"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/category"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	httpErrors "github.com/scul0405/blog-clean-architecture-rest-api/pkg/http_errors"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/logger"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/utils"
+	"strings"
+)
+
+const maxSlugLength = 64
+
+type categoryUseCase struct {
+	cfg          *config.Config
+	categoryRepo category.Repository
+	logger       logger.Logger
+}
+
+func NewCategoryUseCase(cfg *config.Config, categoryRepo category.Repository, logger logger.Logger) category.UseCase {
+	return &categoryUseCase{cfg: cfg, categoryRepo: categoryRepo, logger: logger}
+}
+
+// Create category, slug is made of name when it is not given
+func (u *categoryUseCase) Create(ctx context.Context, category *models.Category) (*models.Category, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "categoryUC.Create")
+	defer span.Finish()
+
+	if category.Slug == "" {
+		category.Slug = utils.Slugify(category.Name)
+		if len(category.Slug) > maxSlugLength {
+			category.Slug = strings.TrimRight(category.Slug[:maxSlugLength], "-")
+		}
+		if category.Slug == "" {
+			return nil, httpErrors.NewValidationError(httpErrors.FieldError{Field: "name", Value: category.Name, Message: "must contain letters or digits when slug is not given"})
+		}
+	} else if utils.Slugify(category.Slug) != category.Slug {
+		return nil, httpErrors.NewValidationError(httpErrors.FieldError{Field: "slug", Value: category.Slug, Message: "must be lower case letters and digits separated by hyphens"})
+	}
+
+	return u.categoryRepo.Create(ctx, category)
+}
+
+func (u *categoryUseCase) GetByID(ctx context.Context, id uuid.UUID) (*models.Category, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "categoryUC.GetByID")
+	defer span.Finish()
+
+	return u.categoryRepo.GetByID(ctx, id)
+}
+
+func (u *categoryUseCase) Update(ctx context.Context, category *models.CategoryBase) (*models.Category, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "categoryUC.Update")
+	defer span.Finish()
+
+	return u.categoryRepo.Update(ctx, category)
+}
+
+// Delete category, blogs of category are left without it once their cache expires
+func (u *categoryUseCase) Delete(ctx context.Context, id uuid.UUID) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "categoryUC.Delete")
+	defer span.Finish()
+
+	return u.categoryRepo.Delete(ctx, id)
+}
+
+func (u *categoryUseCase) List(ctx context.Context, pq *utils.PaginationQuery) (*models.CategoriesList, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "categoryUC.List")
+	defer span.Finish()
+
+	return u.categoryRepo.List(ctx, pq)
+}
@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"context"
+	"github.com/scul0405/blog-clean-architecture-rest-api/config"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/category/mock"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	httpErrors "github.com/scul0405/blog-clean-architecture-rest-api/pkg/http_errors"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/logger"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"testing"
+)
+
+func TestCategoryUseCase_Create(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Logger: config.LoggerConfig{
+			Development: true,
+			Encoding:    "json",
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	mockCategoryRepo := mock.NewMockRepository(ctrl)
+	categoryUC := NewCategoryUseCase(cfg, mockCategoryRepo, apiLogger)
+
+	t.Run("Slug is made of name", func(t *testing.T) {
+		category := &models.Category{Name: "Back End & APIs"}
+
+		mockCategoryRepo.EXPECT().Create(gomock.Any(), gomock.Eq(category)).Return(category, nil)
+
+		createdCategory, err := categoryUC.Create(context.Background(), category)
+		require.NoError(t, err)
+		require.Equal(t, "back-end-apis", createdCategory.Slug)
+	})
+
+	t.Run("Given slug is kept", func(t *testing.T) {
+		category := &models.Category{Name: "Back End", Slug: "backend"}
+
+		mockCategoryRepo.EXPECT().Create(gomock.Any(), gomock.Eq(category)).Return(category, nil)
+
+		createdCategory, err := categoryUC.Create(context.Background(), category)
+		require.NoError(t, err)
+		require.Equal(t, "backend", createdCategory.Slug)
+	})
+
+	t.Run("Invalid slug", func(t *testing.T) {
+		_, err := categoryUC.Create(context.Background(), &models.Category{Name: "Back End", Slug: "Back End"})
+		require.Error(t, err)
+		require.Equal(t, http.StatusBadRequest, httpErrors.ParseErrors(err).Status())
+	})
+}
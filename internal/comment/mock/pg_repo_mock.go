@@ -0,0 +1,201 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pg_repo.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	models "github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	utils "github.com/scul0405/blog-clean-architecture-rest-api/pkg/utils"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRepository) Create(ctx context.Context, comment *models.Comment) (*models.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, comment)
+	ret0, _ := ret[0].(*models.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRepositoryMockRecorder) Create(ctx, comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepository)(nil).Create), ctx, comment)
+}
+
+// Delete mocks base method.
+func (m *MockRepository) Delete(ctx context.Context, id uuid.UUID, version int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRepositoryMockRecorder) Delete(ctx, id, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), ctx, id, version)
+}
+
+// GetBlogAccess mocks base method.
+func (m *MockRepository) GetBlogAccess(ctx context.Context, blogID uuid.UUID) (*models.BlogAccess, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlogAccess", ctx, blogID)
+	ret0, _ := ret[0].(*models.BlogAccess)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlogAccess indicates an expected call of GetBlogAccess.
+func (mr *MockRepositoryMockRecorder) GetBlogAccess(ctx, blogID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlogAccess", reflect.TypeOf((*MockRepository)(nil).GetBlogAccess), ctx, blogID)
+}
+
+// GetByID mocks base method.
+func (m *MockRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.CommentBase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.CommentBase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockRepository)(nil).GetByID), ctx, id)
+}
+
+// IsBanned mocks base method.
+func (m *MockRepository) IsBanned(ctx context.Context, userID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsBanned", ctx, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsBanned indicates an expected call of IsBanned.
+func (mr *MockRepositoryMockRecorder) IsBanned(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsBanned", reflect.TypeOf((*MockRepository)(nil).IsBanned), ctx, userID)
+}
+
+// List mocks base method.
+func (m *MockRepository) List(ctx context.Context, filter *models.CommentFilter, pq *utils.PaginationQuery) (*models.CommentsList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter, pq)
+	ret0, _ := ret[0].(*models.CommentsList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockRepositoryMockRecorder) List(ctx, filter, pq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRepository)(nil).List), ctx, filter, pq)
+}
+
+// ListDescendants mocks base method.
+func (m *MockRepository) ListDescendants(ctx context.Context, rootIDs []uuid.UUID, visibility models.CommentVisibility) ([]*models.CommentBase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDescendants", ctx, rootIDs, visibility)
+	ret0, _ := ret[0].([]*models.CommentBase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDescendants indicates an expected call of ListDescendants.
+func (mr *MockRepositoryMockRecorder) ListDescendants(ctx, rootIDs, visibility interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDescendants", reflect.TypeOf((*MockRepository)(nil).ListDescendants), ctx, rootIDs, visibility)
+}
+
+// Moderate mocks base method.
+func (m *MockRepository) Moderate(ctx context.Context, moderation *models.CommentModeration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Moderate", ctx, moderation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Moderate indicates an expected call of Moderate.
+func (mr *MockRepositoryMockRecorder) Moderate(ctx, moderation interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Moderate", reflect.TypeOf((*MockRepository)(nil).Moderate), ctx, moderation)
+}
+
+// ModerationQueue mocks base method.
+func (m *MockRepository) ModerationQueue(ctx context.Context, pq *utils.PaginationQuery) (*models.ModerationQueue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModerationQueue", ctx, pq)
+	ret0, _ := ret[0].(*models.ModerationQueue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ModerationQueue indicates an expected call of ModerationQueue.
+func (mr *MockRepositoryMockRecorder) ModerationQueue(ctx, pq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModerationQueue", reflect.TypeOf((*MockRepository)(nil).ModerationQueue), ctx, pq)
+}
+
+// Report mocks base method.
+func (m *MockRepository) Report(ctx context.Context, report *models.CommentReport, hideThreshold int) (*models.CommentReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Report", ctx, report, hideThreshold)
+	ret0, _ := ret[0].(*models.CommentReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Report indicates an expected call of Report.
+func (mr *MockRepositoryMockRecorder) Report(ctx, report, hideThreshold interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Report", reflect.TypeOf((*MockRepository)(nil).Report), ctx, report, hideThreshold)
+}
+
+// Update mocks base method.
+func (m *MockRepository) Update(ctx context.Context, comment *models.CommentBase) (*models.CommentBase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, comment)
+	ret0, _ := ret[0].(*models.CommentBase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockRepositoryMockRecorder) Update(ctx, comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRepository)(nil).Update), ctx, comment)
+}
@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: redis_repo.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRedisRepository is a mock of RedisRepository interface.
+type MockRedisRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRedisRepositoryMockRecorder
+}
+
+// MockRedisRepositoryMockRecorder is the mock recorder for MockRedisRepository.
+type MockRedisRepositoryMockRecorder struct {
+	mock *MockRedisRepository
+}
+
+// NewMockRedisRepository creates a new mock instance.
+func NewMockRedisRepository(ctrl *gomock.Controller) *MockRedisRepository {
+	mock := &MockRedisRepository{ctrl: ctrl}
+	mock.recorder = &MockRedisRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRedisRepository) EXPECT() *MockRedisRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetLikesDeltaCtx mocks base method.
+func (m *MockRedisRepository) GetLikesDeltaCtx(ctx context.Context, key string, commentID uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLikesDeltaCtx", ctx, key, commentID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLikesDeltaCtx indicates an expected call of GetLikesDeltaCtx.
+func (mr *MockRedisRepositoryMockRecorder) GetLikesDeltaCtx(ctx, key, commentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLikesDeltaCtx", reflect.TypeOf((*MockRedisRepository)(nil).GetLikesDeltaCtx), ctx, key, commentID)
+}
+
+// IncrLikeRateCtx mocks base method.
+func (m *MockRedisRepository) IncrLikeRateCtx(ctx context.Context, key string, seconds int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrLikeRateCtx", ctx, key, seconds)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IncrLikeRateCtx indicates an expected call of IncrLikeRateCtx.
+func (mr *MockRedisRepositoryMockRecorder) IncrLikeRateCtx(ctx, key, seconds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrLikeRateCtx", reflect.TypeOf((*MockRedisRepository)(nil).IncrLikeRateCtx), ctx, key, seconds)
+}
+
+// IncrLikesDeltaCtx mocks base method.
+func (m *MockRedisRepository) IncrLikesDeltaCtx(ctx context.Context, key string, commentID uuid.UUID, delta int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrLikesDeltaCtx", ctx, key, commentID, delta)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrLikesDeltaCtx indicates an expected call of IncrLikesDeltaCtx.
+func (mr *MockRedisRepositoryMockRecorder) IncrLikesDeltaCtx(ctx, key, commentID, delta interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrLikesDeltaCtx", reflect.TypeOf((*MockRedisRepository)(nil).IncrLikesDeltaCtx), ctx, key, commentID, delta)
+}
+
+// PopLikesDeltasCtx mocks base method.
+func (m *MockRedisRepository) PopLikesDeltasCtx(ctx context.Context, key string) (map[uuid.UUID]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PopLikesDeltasCtx", ctx, key)
+	ret0, _ := ret[0].(map[uuid.UUID]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PopLikesDeltasCtx indicates an expected call of PopLikesDeltasCtx.
+func (mr *MockRedisRepositoryMockRecorder) PopLikesDeltasCtx(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PopLikesDeltasCtx", reflect.TypeOf((*MockRedisRepository)(nil).PopLikesDeltasCtx), ctx, key)
+}
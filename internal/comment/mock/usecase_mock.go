@@ -0,0 +1,230 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	models "github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	utils "github.com/scul0405/blog-clean-architecture-rest-api/pkg/utils"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUseCase is a mock of UseCase interface.
+type MockUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockUseCaseMockRecorder
+}
+
+// MockUseCaseMockRecorder is the mock recorder for MockUseCase.
+type MockUseCaseMockRecorder struct {
+	mock *MockUseCase
+}
+
+// NewMockUseCase creates a new mock instance.
+func NewMockUseCase(ctrl *gomock.Controller) *MockUseCase {
+	mock := &MockUseCase{ctrl: ctrl}
+	mock.recorder = &MockUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUseCase) EXPECT() *MockUseCaseMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockUseCase) Create(ctx context.Context, comment *models.Comment) (*models.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, comment)
+	ret0, _ := ret[0].(*models.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockUseCaseMockRecorder) Create(ctx, comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUseCase)(nil).Create), ctx, comment)
+}
+
+// Delete mocks base method.
+func (m *MockUseCase) Delete(ctx context.Context, id uuid.UUID, version int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockUseCaseMockRecorder) Delete(ctx, id, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUseCase)(nil).Delete), ctx, id, version)
+}
+
+// Dislike mocks base method.
+func (m *MockUseCase) Dislike(ctx context.Context, userComment *models.UserComments) (*models.CommentLikeState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Dislike", ctx, userComment)
+	ret0, _ := ret[0].(*models.CommentLikeState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Dislike indicates an expected call of Dislike.
+func (mr *MockUseCaseMockRecorder) Dislike(ctx, userComment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Dislike", reflect.TypeOf((*MockUseCase)(nil).Dislike), ctx, userComment)
+}
+
+// FlushLikes mocks base method.
+func (m *MockUseCase) FlushLikes(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FlushLikes", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FlushLikes indicates an expected call of FlushLikes.
+func (mr *MockUseCaseMockRecorder) FlushLikes(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FlushLikes", reflect.TypeOf((*MockUseCase)(nil).FlushLikes), ctx)
+}
+
+// GetByID mocks base method.
+func (m *MockUseCase) GetByID(ctx context.Context, id uuid.UUID) (*models.CommentBase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.CommentBase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockUseCaseMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockUseCase)(nil).GetByID), ctx, id)
+}
+
+// Like mocks base method.
+func (m *MockUseCase) Like(ctx context.Context, userComment *models.UserComments) (*models.CommentLikeState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Like", ctx, userComment)
+	ret0, _ := ret[0].(*models.CommentLikeState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Like indicates an expected call of Like.
+func (mr *MockUseCaseMockRecorder) Like(ctx, userComment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Like", reflect.TypeOf((*MockUseCase)(nil).Like), ctx, userComment)
+}
+
+// List mocks base method.
+func (m *MockUseCase) List(ctx context.Context, blogID uuid.UUID, mode string, pq *utils.PaginationQuery) (*models.CommentsList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, blogID, mode, pq)
+	ret0, _ := ret[0].(*models.CommentsList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockUseCaseMockRecorder) List(ctx, blogID, mode, pq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockUseCase)(nil).List), ctx, blogID, mode, pq)
+}
+
+// ListReplies mocks base method.
+func (m *MockUseCase) ListReplies(ctx context.Context, commentID uuid.UUID, pq *utils.PaginationQuery) (*models.CommentsList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReplies", ctx, commentID, pq)
+	ret0, _ := ret[0].(*models.CommentsList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReplies indicates an expected call of ListReplies.
+func (mr *MockUseCaseMockRecorder) ListReplies(ctx, commentID, pq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReplies", reflect.TypeOf((*MockUseCase)(nil).ListReplies), ctx, commentID, pq)
+}
+
+// Moderate mocks base method.
+func (m *MockUseCase) Moderate(ctx context.Context, commentID uuid.UUID, action string) (*models.CommentBase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Moderate", ctx, commentID, action)
+	ret0, _ := ret[0].(*models.CommentBase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Moderate indicates an expected call of Moderate.
+func (mr *MockUseCaseMockRecorder) Moderate(ctx, commentID, action interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Moderate", reflect.TypeOf((*MockUseCase)(nil).Moderate), ctx, commentID, action)
+}
+
+// ModerationQueue mocks base method.
+func (m *MockUseCase) ModerationQueue(ctx context.Context, pq *utils.PaginationQuery) (*models.ModerationQueue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModerationQueue", ctx, pq)
+	ret0, _ := ret[0].(*models.ModerationQueue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ModerationQueue indicates an expected call of ModerationQueue.
+func (mr *MockUseCaseMockRecorder) ModerationQueue(ctx, pq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModerationQueue", reflect.TypeOf((*MockUseCase)(nil).ModerationQueue), ctx, pq)
+}
+
+// ReconcileLikes mocks base method.
+func (m *MockUseCase) ReconcileLikes(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileLikes", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReconcileLikes indicates an expected call of ReconcileLikes.
+func (mr *MockUseCaseMockRecorder) ReconcileLikes(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileLikes", reflect.TypeOf((*MockUseCase)(nil).ReconcileLikes), ctx)
+}
+
+// Report mocks base method.
+func (m *MockUseCase) Report(ctx context.Context, report *models.CommentReport) (*models.CommentReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Report", ctx, report)
+	ret0, _ := ret[0].(*models.CommentReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Report indicates an expected call of Report.
+func (mr *MockUseCaseMockRecorder) Report(ctx, report interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Report", reflect.TypeOf((*MockUseCase)(nil).Report), ctx, report)
+}
+
+// Update mocks base method.
+func (m *MockUseCase) Update(ctx context.Context, comment *models.CommentBase) (*models.CommentBase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, comment)
+	ret0, _ := ret[0].(*models.CommentBase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockUseCaseMockRecorder) Update(ctx, comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUseCase)(nil).Update), ctx, comment)
+}
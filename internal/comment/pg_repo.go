@@ -12,6 +12,12 @@ type Repository interface {
 	Create(ctx context.Context, comment *models.Comment) (*models.Comment, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*models.CommentBase, error)
 	Update(ctx context.Context, comment *models.CommentBase) (*models.CommentBase, error)
-	Delete(ctx context.Context, id uuid.UUID) error
-	List(ctx context.Context, blogID uuid.UUID, pq *utils.PaginationQuery) (*models.CommentsList, error)
+	Delete(ctx context.Context, id uuid.UUID, version int) error
+	List(ctx context.Context, filter *models.CommentFilter, pq *utils.PaginationQuery) (*models.CommentsList, error)
+	ListDescendants(ctx context.Context, rootIDs []uuid.UUID, visibility models.CommentVisibility) ([]*models.CommentBase, error)
+	Report(ctx context.Context, report *models.CommentReport, hideThreshold int) (*models.CommentReport, error)
+	Moderate(ctx context.Context, moderation *models.CommentModeration) error
+	IsBanned(ctx context.Context, userID uuid.UUID) (bool, error)
+	GetBlogAccess(ctx context.Context, blogID uuid.UUID) (*models.BlogAccess, error)
+	ModerationQueue(ctx context.Context, pq *utils.PaginationQuery) (*models.ModerationQueue, error)
 }
@@ -0,0 +1,14 @@
+//go:generate mockgen -source redis_repo.go -destination mock/redis_repo_mock.go -package mock
+package comment
+
+import (
+	"context"
+	"github.com/google/uuid"
+)
+
+type RedisRepository interface {
+	IncrLikeRateCtx(ctx context.Context, key string, seconds int) (int64, error)
+	IncrLikesDeltaCtx(ctx context.Context, key string, commentID uuid.UUID, delta int64) error
+	GetLikesDeltaCtx(ctx context.Context, key string, commentID uuid.UUID) (int64, error)
+	PopLikesDeltasCtx(ctx context.Context, key string) (map[uuid.UUID]int64, error)
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	"github.com/opentracing/opentracing-go"
@@ -10,6 +11,8 @@ import (
 	"github.com/scul0405/blog-clean-architecture-rest-api/internal/comment"
 	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
 	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/utils"
+	"strings"
+	"time"
 )
 
 type commentRepo struct {
@@ -25,7 +28,7 @@ func (r *commentRepo) Create(ctx context.Context, comment *models.Comment) (*mod
 	defer span.Finish()
 
 	var c models.Comment
-	if err := r.db.QueryRowxContext(ctx, createCommentQuery, &comment.AuthorID, &comment.BlogID, &comment.Message).StructScan(&c); err != nil {
+	if err := r.db.QueryRowxContext(ctx, createCommentQuery, &comment.AuthorID, &comment.BlogID, &comment.Message, comment.ParentID, comment.Depth, comment.Hidden).StructScan(&c); err != nil {
 		return nil, errors.Wrap(err, "commentRepo.Create.StructScan")
 	}
 
@@ -49,23 +52,37 @@ func (r *commentRepo) Update(ctx context.Context, comment *models.CommentBase) (
 	defer span.Finish()
 
 	var c models.CommentBase
-	if err := r.db.QueryRowxContext(ctx, updateCommentQuery, &comment.Message, &comment.CommentID).StructScan(&c); err != nil {
+	if err := r.db.QueryRowxContext(ctx, updateCommentQuery, &comment.Message, &comment.CommentID, &comment.Version, &comment.Hidden).StructScan(&c); err != nil {
 		return nil, errors.Wrap(err, "commentRepo.Update.StructScan")
 	}
 
 	return &c, nil
 }
 
-func (r *commentRepo) Delete(ctx context.Context, id uuid.UUID) error {
+// Delete comment of version, comment with replies is kept as tombstone
+func (r *commentRepo) Delete(ctx context.Context, id uuid.UUID, version int) error {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "commentRepo.Delete")
 	defer span.Finish()
 
-	result, err := r.db.ExecContext(ctx, deleteCommentQuery, id)
+	result, err := r.db.ExecContext(ctx, tombstoneCommentQuery, id, version)
 	if err != nil {
-		return errors.Wrap(err, "commentRepo.Delete.StructScan")
+		return errors.Wrap(err, "commentRepo.Delete.ExecContext.tombstone")
 	}
 
 	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "commentRepo.Delete.RowsAffected.tombstone")
+	}
+	if rowsAffected > 0 {
+		return nil
+	}
+
+	result, err = r.db.ExecContext(ctx, deleteCommentQuery, id, version)
+	if err != nil {
+		return errors.Wrap(err, "commentRepo.Delete.ExecContext")
+	}
+
+	rowsAffected, err = result.RowsAffected()
 	if err != nil {
 		return errors.Wrap(err, "commentRepo.Delete.RowsAffected")
 	}
@@ -76,12 +93,18 @@ func (r *commentRepo) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
-func (r *commentRepo) List(ctx context.Context, blogID uuid.UUID, pq *utils.PaginationQuery) (*models.CommentsList, error) {
+// List comments matching filter, ordered by pagination query sort
+func (r *commentRepo) List(ctx context.Context, filter *models.CommentFilter, pq *utils.PaginationQuery) (*models.CommentsList, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "commentRepo.List")
 	defer span.Finish()
 
+	args := []interface{}{filter.BlogID, filter.ParentID, filter.RootsOnly, filter.ShowHidden, filter.ViewerID}
+	if pq.IsCursorMode() {
+		return r.listByCursor(ctx, args, pq)
+	}
+
 	var totalCount int
-	if err := r.db.GetContext(ctx, &totalCount, getTotalCountByBlogIDQuery, blogID); err != nil {
+	if err := r.db.GetContext(ctx, &totalCount, getTotalCountByBlogIDQuery, args...); err != nil {
 		return nil, errors.Wrap(err, "commentRepo.List.GetContext.totalCount")
 	}
 
@@ -97,7 +120,8 @@ func (r *commentRepo) List(ctx context.Context, blogID uuid.UUID, pq *utils.Pagi
 	}
 
 	var commentsList = make([]*models.CommentBase, 0, pq.GetSize())
-	rows, err := r.db.QueryxContext(ctx, listCommentsByBlogIDQuery, blogID, pq.GetOffset(), pq.GetLimit())
+	query := fmt.Sprintf(listCommentsByBlogIDQuery, commentOrderBy(pq.GetSort()))
+	rows, err := r.db.QueryxContext(ctx, query, append(args, pq.GetOffset(), pq.GetLimit())...)
 	if err != nil {
 		return nil, errors.Wrap(err, "commentRepo.List.QueryxContext")
 	}
@@ -124,3 +148,185 @@ func (r *commentRepo) List(ctx context.Context, blogID uuid.UUID, pq *utils.Pagi
 		Comments:   commentsList,
 	}, nil
 }
+
+// listByCursor read page of comments after cursor without counting, newest first
+func (r *commentRepo) listByCursor(ctx context.Context, args []interface{}, pq *utils.PaginationQuery) (*models.CommentsList, error) {
+	var createdAt *time.Time
+	var commentID *uuid.UUID
+	if cursor := pq.GetCursor(); cursor != nil {
+		createdAt, commentID = &cursor.CreatedAt, &cursor.ID
+	}
+
+	operator, direction := utils.CursorKeyset(pq.GetCursor())
+	query := fmt.Sprintf(listCommentsByCursorQuery, operator, direction)
+
+	var commentsList = make([]*models.CommentBase, 0, pq.GetSize()+1)
+	if err := r.db.SelectContext(ctx, &commentsList, query, append(args, createdAt, commentID, pq.GetSize()+1)...); err != nil {
+		return nil, errors.Wrap(err, "commentRepo.listByCursor.SelectContext")
+	}
+
+	commentsList, nextCursor, prevCursor := utils.CursorPage(commentsList, pq, func(c *models.CommentBase) (time.Time, uuid.UUID) {
+		return c.CreatedAt, c.CommentID
+	})
+
+	return &models.CommentsList{
+		Size:       pq.GetSize(),
+		HasMore:    nextCursor != nil,
+		NextCursor: nextCursor,
+		PrevCursor: prevCursor,
+		Comments:   commentsList,
+	}, nil
+}
+
+// ListDescendants list every reply below root comments visible by visibility, parents come before their replies
+func (r *commentRepo) ListDescendants(ctx context.Context, rootIDs []uuid.UUID, visibility models.CommentVisibility) ([]*models.CommentBase, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "commentRepo.ListDescendants")
+	defer span.Finish()
+
+	descendants := make([]*models.CommentBase, 0)
+	if len(rootIDs) == 0 {
+		return descendants, nil
+	}
+
+	ids := make([]string, 0, len(rootIDs))
+	for _, id := range rootIDs {
+		ids = append(ids, id.String())
+	}
+
+	if err := r.db.SelectContext(ctx, &descendants, listDescendantsQuery, strings.Join(ids, ","), visibility.ShowHidden, visibility.ViewerID); err != nil {
+		return nil, errors.Wrap(err, "commentRepo.ListDescendants.SelectContext")
+	}
+
+	return descendants, nil
+}
+
+// Report comment and hide it once it has threshold pending reports
+func (r *commentRepo) Report(ctx context.Context, report *models.CommentReport, hideThreshold int) (*models.CommentReport, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "commentRepo.Report")
+	defer span.Finish()
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "commentRepo.Report.BeginTxx")
+	}
+	defer tx.Rollback()
+
+	var cr models.CommentReport
+	if err = tx.QueryRowxContext(ctx, createCommentReportQuery, report.CommentID, report.ReporterID, report.Reason, report.Details).StructScan(&cr); err != nil {
+		return nil, errors.Wrap(err, "commentRepo.Report.StructScan")
+	}
+
+	if _, err = tx.ExecContext(ctx, hideReportedCommentQuery, report.CommentID, hideThreshold); err != nil {
+		return nil, errors.Wrap(err, "commentRepo.Report.ExecContext.hide")
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "commentRepo.Report.Commit")
+	}
+
+	return &cr, nil
+}
+
+// Moderate set moderation status of comment and resolve its pending reports, ban also bans author of comment
+func (r *commentRepo) Moderate(ctx context.Context, moderation *models.CommentModeration) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "commentRepo.Moderate")
+	defer span.Finish()
+
+	status := models.CommentRejected
+	if moderation.Action == models.ModerationApprove {
+		status = models.CommentApproved
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "commentRepo.Moderate.BeginTxx")
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, updateCommentModerationQuery, moderation.CommentID, status)
+	if err != nil {
+		return errors.Wrap(err, "commentRepo.Moderate.ExecContext")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "commentRepo.Moderate.RowsAffected")
+	}
+	if rowsAffected == 0 {
+		return errors.Wrap(sql.ErrNoRows, "commentRepo.Moderate.rowsAffected")
+	}
+
+	if _, err = tx.ExecContext(ctx, resolveCommentReportsQuery, moderation.CommentID, moderation.ModeratorID); err != nil {
+		return errors.Wrap(err, "commentRepo.Moderate.ExecContext.reports")
+	}
+
+	if moderation.Action == models.ModerationBan {
+		if _, err = tx.ExecContext(ctx, banCommentAuthorQuery, moderation.CommentID); err != nil {
+			return errors.Wrap(err, "commentRepo.Moderate.ExecContext.ban")
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.Wrap(err, "commentRepo.Moderate.Commit")
+	}
+
+	return nil
+}
+
+// IsBanned report whether user is banned from commenting
+func (r *commentRepo) IsBanned(ctx context.Context, userID uuid.UUID) (bool, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "commentRepo.IsBanned")
+	defer span.Finish()
+
+	var banned bool
+	if err := r.db.GetContext(ctx, &banned, isCommentsBannedQuery, userID); err != nil {
+		return false, errors.Wrap(err, "commentRepo.IsBanned.GetContext")
+	}
+
+	return banned, nil
+}
+
+// GetBlogAccess get what deciding whether caller can view comments of blog needs
+func (r *commentRepo) GetBlogAccess(ctx context.Context, blogID uuid.UUID) (*models.BlogAccess, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "commentRepo.GetBlogAccess")
+	defer span.Finish()
+
+	var blog models.BlogAccess
+	if err := r.db.GetContext(ctx, &blog, getBlogAccessQuery, blogID); err != nil {
+		return nil, errors.Wrap(err, "commentRepo.GetBlogAccess.GetContext")
+	}
+
+	return &blog, nil
+}
+
+// ModerationQueue list hidden comments and comments with pending reports, hidden comments first
+func (r *commentRepo) ModerationQueue(ctx context.Context, pq *utils.PaginationQuery) (*models.ModerationQueue, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "commentRepo.ModerationQueue")
+	defer span.Finish()
+
+	var totalCount int
+	if err := r.db.GetContext(ctx, &totalCount, getModerationQueueCountQuery); err != nil {
+		return nil, errors.Wrap(err, "commentRepo.ModerationQueue.GetContext.totalCount")
+	}
+
+	var items = make([]*models.ModerationItem, 0, pq.GetSize())
+	if totalCount > 0 {
+		if err := r.db.SelectContext(ctx, &items, listModerationQueueQuery, pq.GetOffset(), pq.GetLimit()); err != nil {
+			return nil, errors.Wrap(err, "commentRepo.ModerationQueue.SelectContext")
+		}
+	}
+
+	return &models.ModerationQueue{
+		TotalCount: totalCount,
+		TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+		Page:       pq.GetPage(),
+		Size:       pq.GetSize(),
+		HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+		Comments:   items,
+	}, nil
+}
+
+// commentOrderBy build ORDER BY list from whitelisted sort, comment_id keeps pages stable on ties
+func commentOrderBy(sort []utils.SortField) string {
+	return utils.OrderByClause(sort, commentSortColumns, defaultCommentsOrder) + ", c.comment_id"
+}
@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCommentRepo_Report(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	commentRepo := NewCommentRepository(sqlxDB)
+
+	t.Run("Report", func(t *testing.T) {
+		report := &models.CommentReport{CommentID: uuid.New(), ReporterID: uuid.New(), Reason: "spam"}
+
+		rows := sqlmock.NewRows([]string{"report_id", "comment_id", "reporter_id", "reason", "details", "status"}).
+			AddRow(uuid.New(), report.CommentID, report.ReporterID, report.Reason, "", models.CommentReportPending)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(createCommentReportQuery).WithArgs(report.CommentID, report.ReporterID, report.Reason, report.Details).WillReturnRows(rows)
+		mock.ExpectExec(hideReportedCommentQuery).WithArgs(report.CommentID, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		createdReport, err := commentRepo.Report(context.Background(), report, 3)
+
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+		require.Equal(t, models.CommentReportPending, createdReport.Status)
+	})
+}
+
+func TestCommentRepo_Moderate(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	commentRepo := NewCommentRepository(sqlxDB)
+
+	t.Run("Ban", func(t *testing.T) {
+		moderation := &models.CommentModeration{CommentID: uuid.New(), ModeratorID: uuid.New(), Action: models.ModerationBan}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(updateCommentModerationQuery).WithArgs(moderation.CommentID, models.CommentRejected).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(resolveCommentReportsQuery).WithArgs(moderation.CommentID, moderation.ModeratorID).WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectExec(banCommentAuthorQuery).WithArgs(moderation.CommentID).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		require.NoError(t, commentRepo.Moderate(context.Background(), moderation))
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Approve", func(t *testing.T) {
+		moderation := &models.CommentModeration{CommentID: uuid.New(), ModeratorID: uuid.New(), Action: models.ModerationApprove}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(updateCommentModerationQuery).WithArgs(moderation.CommentID, models.CommentApproved).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(resolveCommentReportsQuery).WithArgs(moderation.CommentID, moderation.ModeratorID).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		require.NoError(t, commentRepo.Moderate(context.Background(), moderation))
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Missing comment", func(t *testing.T) {
+		moderation := &models.CommentModeration{CommentID: uuid.New(), ModeratorID: uuid.New(), Action: models.ModerationReject}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(updateCommentModerationQuery).WithArgs(moderation.CommentID, models.CommentRejected).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		require.Error(t, commentRepo.Moderate(context.Background(), moderation))
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestCommentRepo_Delete(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	commentRepo := NewCommentRepository(sqlxDB)
+
+	t.Run("Tombstone", func(t *testing.T) {
+		commentUID := uuid.New()
+
+		mock.ExpectExec(tombstoneCommentQuery).WithArgs(commentUID, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		require.NoError(t, commentRepo.Delete(context.Background(), commentUID, 2))
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Hard delete", func(t *testing.T) {
+		commentUID := uuid.New()
+
+		mock.ExpectExec(tombstoneCommentQuery).WithArgs(commentUID, 2).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(deleteCommentQuery).WithArgs(commentUID, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		require.NoError(t, commentRepo.Delete(context.Background(), commentUID, 2))
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Stale version", func(t *testing.T) {
+		commentUID := uuid.New()
+
+		mock.ExpectExec(tombstoneCommentQuery).WithArgs(commentUID, 1).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(deleteCommentQuery).WithArgs(commentUID, 1).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := commentRepo.Delete(context.Background(), commentUID, 1)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestCommentRepo_ListDescendants(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	commentRepo := NewCommentRepository(sqlxDB)
+
+	t.Run("Descendants", func(t *testing.T) {
+		rootIDs := []uuid.UUID{uuid.New(), uuid.New()}
+		replyUID := uuid.New()
+		viewerUID := uuid.New()
+
+		rows := sqlmock.NewRows([]string{"comment_id", "blog_id", "parent_id", "depth", "message", "deleted"}).
+			AddRow(replyUID, uuid.New(), rootIDs[0], 1, "reply to first comment", false)
+
+		mock.ExpectQuery(listDescendantsQuery).WithArgs(rootIDs[0].String()+","+rootIDs[1].String(), false, &viewerUID).WillReturnRows(rows)
+
+		descendants, err := commentRepo.ListDescendants(context.Background(), rootIDs, models.CommentVisibility{ViewerID: &viewerUID})
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+		require.Len(t, descendants, 1)
+		require.Equal(t, replyUID, descendants[0].CommentID)
+		require.Equal(t, rootIDs[0], *descendants[0].ParentID)
+	})
+
+	t.Run("No roots", func(t *testing.T) {
+		descendants, err := commentRepo.ListDescendants(context.Background(), nil, models.CommentVisibility{})
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+		require.Empty(t, descendants)
+	})
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/comment"
+	"strconv"
+	"time"
+)
+
+type commentRedisRepo struct {
+	rdb *redis.Client
+}
+
+func NewCommentRedisRepository(rdb *redis.Client) comment.RedisRepository {
+	return &commentRedisRepo{rdb: rdb}
+}
+
+// IncrLikeRateCtx count like changes in window of seconds which starts with first change
+func (r *commentRedisRepo) IncrLikeRateCtx(ctx context.Context, key string, seconds int) (int64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "commentRedisRepo.IncrLikeRateCtx")
+	defer span.Finish()
+
+	count, err := r.rdb.Incr(ctx, key).Result()
+	if err != nil {
+		return 0, errors.Wrap(err, "commentRedisRepo.IncrLikeRateCtx.redisClient.Incr")
+	}
+
+	if count == 1 {
+		if err = r.rdb.Expire(ctx, key, time.Second*time.Duration(seconds)).Err(); err != nil {
+			return 0, errors.Wrap(err, "commentRedisRepo.IncrLikeRateCtx.redisClient.Expire")
+		}
+	}
+
+	return count, nil
+}
+
+// IncrLikesDeltaCtx buffer like change of comment in hash
+func (r *commentRedisRepo) IncrLikesDeltaCtx(ctx context.Context, key string, commentID uuid.UUID, delta int64) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "commentRedisRepo.IncrLikesDeltaCtx")
+	defer span.Finish()
+
+	if err := r.rdb.HIncrBy(ctx, key, commentID.String(), delta).Err(); err != nil {
+		return errors.Wrap(err, "commentRedisRepo.IncrLikesDeltaCtx.redisClient.HIncrBy")
+	}
+
+	return nil
+}
+
+// GetLikesDeltaCtx read buffered like change of comment, it is 0 when nothing is buffered
+func (r *commentRedisRepo) GetLikesDeltaCtx(ctx context.Context, key string, commentID uuid.UUID) (int64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "commentRedisRepo.GetLikesDeltaCtx")
+	defer span.Finish()
+
+	delta, err := r.rdb.HGet(ctx, key, commentID.String()).Int64()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return 0, errors.Wrap(err, "commentRedisRepo.GetLikesDeltaCtx.redisClient.HGet")
+	}
+
+	return delta, nil
+}
+
+// PopLikesDeltasCtx read and remove buffered like changes at once
+func (r *commentRedisRepo) PopLikesDeltasCtx(ctx context.Context, key string) (map[uuid.UUID]int64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "commentRedisRepo.PopLikesDeltasCtx")
+	defer span.Finish()
+
+	pipe := r.rdb.TxPipeline()
+	getAll := pipe.HGetAll(ctx, key)
+	pipe.Del(ctx, key)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, errors.Wrap(err, "commentRedisRepo.PopLikesDeltasCtx.redisClient.Exec")
+	}
+
+	deltas := make(map[uuid.UUID]int64, len(getAll.Val()))
+	for field, value := range getAll.Val() {
+		commentID, err := uuid.Parse(field)
+		if err != nil {
+			continue
+		}
+		delta, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			continue
+		}
+		deltas[commentID] = delta
+	}
+
+	return deltas, nil
+}
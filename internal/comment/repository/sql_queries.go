@@ -1,24 +1,126 @@
 package repository
 
 const (
-	createCommentQuery = `INSERT INTO comments (author_id, blog_id, message) VALUES ($1, $2, $3) RETURNING *`
+	// Comment held by content filter is created hidden
+	createCommentQuery = `INSERT INTO comments (author_id, blog_id, message, parent_id, depth, moderation_status)
+						VALUES ($1, $2, $3, $4, $5, CASE WHEN $6 THEN 'hidden' ELSE 'visible' END)
+						RETURNING comment_id, author_id, blog_id, message, parent_id, depth, version,
+							moderation_status IN ('hidden', 'rejected') as hidden, created_at, updated_at`
 
-	updateCommentQuery = `UPDATE comments SET message = $1, updated_at = CURRENT_TIMESTAMP WHERE comment_id = $2 RETURNING *`
+	// Version 0 updates and deletes any version, other version changes comment only if it was not changed since.
+	// Comment held by content filter is hidden again even if it was approved, approval of other comment covers
+	// the message it was given for only, so edited message can be hidden by reports again
+	updateCommentQuery = `UPDATE comments SET message = $1, updated_at = CURRENT_TIMESTAMP, version = version + 1,
+							moderation_status = CASE WHEN $4 THEN 'hidden'
+								WHEN moderation_status = 'approved' AND message <> $1 THEN 'visible'
+								ELSE moderation_status END
+						WHERE comment_id = $2 AND deleted_at IS NULL AND ($3::int = 0 OR version = $3)
+						RETURNING comment_id, author_id, blog_id, message, parent_id, depth, version,
+							moderation_status IN ('hidden', 'rejected') as hidden, created_at, updated_at`
 
-	deleteCommentQuery = `DELETE FROM comments WHERE comment_id = $1`
+	// Comment with replies becomes tombstone so thread below it stays, other comments are deleted
+	tombstoneCommentQuery = `UPDATE comments SET deleted_at = now(), version = version + 1
+						WHERE comment_id = $1 AND deleted_at IS NULL AND ($2::int = 0 OR version = $2) AND EXISTS (SELECT 1 FROM comments r WHERE r.parent_id = $1)`
 
-	getCommentByIDQuery = `SELECT concat(u.first_name, ' ', u.last_name) as author, u.avatar as avatar_url, c.message, count(uc.comment_id) as likes, c.updated_at, c.author_id, c.comment_id, c.blog_id	
-						FROM comments c
-        				LEFT JOIN users u on c.author_id = u.user_id LEFT JOIN user_comments uc on c.comment_id = uc.comment_id 
-						WHERE c.comment_id = $1
-						GROUP BY u.first_name, u.last_name, u.avatar, c.message, c.updated_at, c.author_id, c.comment_id, c.blog_id`
+	deleteCommentQuery = `DELETE FROM comments WHERE comment_id = $1 AND ($2::int = 0 OR version = $2) AND NOT EXISTS (SELECT 1 FROM comments r WHERE r.parent_id = $1)`
 
-	getTotalCountByBlogIDQuery = `SELECT COUNT(comment_id) FROM comments WHERE blog_id = $1`
+	// Tombstones keep their place in thread but hide author and message
+	commentColumns = `CASE WHEN c.deleted_at IS NULL THEN concat(u.first_name, ' ', u.last_name) ELSE '' END as author,
+						CASE WHEN c.deleted_at IS NULL THEN u.avatar END as avatar_url,
+						CASE WHEN c.deleted_at IS NULL THEN c.message ELSE '' END as message,
+						c.likes_count as likes, c.version, c.created_at, c.updated_at, c.author_id, c.comment_id, c.blog_id, c.parent_id, c.depth,
+						c.deleted_at IS NOT NULL as deleted,
+						c.moderation_status IN ('hidden', 'rejected') as hidden,
+						(SELECT count(r.comment_id) FROM comments r WHERE r.parent_id = c.comment_id) as replies_count`
 
-	listCommentsByBlogIDQuery = `SELECT concat(u.first_name, ' ', u.last_name) as author, u.avatar as avatar_url, c.message, count(uc.comment_id) as likes, c.updated_at, c.author_id, c.comment_id, c.blog_id
-							FROM comments c
-        					LEFT JOIN users u on c.author_id = u.user_id LEFT JOIN user_comments uc on c.comment_id = uc.comment_id 
-        					WHERE c.blog_id = $1 
-        					GROUP BY u.first_name, u.last_name, u.avatar, c.message, c.updated_at, c.author_id, c.comment_id, c.blog_id
-							ORDER BY updated_at OFFSET $2 LIMIT $3`
+	commentJoins = `FROM comments c
+        				LEFT JOIN users u on c.author_id = u.user_id`
+
+	getCommentByIDQuery = `SELECT ` + commentColumns + `
+						` + commentJoins + `
+						WHERE c.comment_id = $1`
+
+	// Optional filters are skipped when their argument is NULL or false
+	commentsFilter = `WHERE c.blog_id = $1
+							AND ($2::uuid IS NULL OR c.parent_id = $2)
+							AND (NOT $3::boolean OR c.parent_id IS NULL)
+							AND (c.moderation_status NOT IN ('hidden', 'rejected') OR $4::boolean OR c.author_id = $5::uuid)`
+
+	getTotalCountByBlogIDQuery = `SELECT COUNT(c.comment_id) FROM comments c ` + commentsFilter
+
+	// ORDER BY is filled by commentOrderBy, never with raw user input
+	listCommentsByBlogIDQuery = `SELECT ` + commentColumns + `
+							` + commentJoins + `
+        					` + commentsFilter + `
+							ORDER BY %s OFFSET $6 LIMIT $7`
+
+	defaultCommentsOrder = `c.updated_at`
+
+	// Keyset operator and directions are filled by utils.CursorKeyset
+	listCommentsByCursorQuery = `SELECT ` + commentColumns + `
+							` + commentJoins + `
+        					` + commentsFilter + `
+								AND ($6::timestamptz IS NULL OR (c.created_at, c.comment_id) %[1]s ($6, $7::uuid))
+							ORDER BY c.created_at %[2]s, c.comment_id %[2]s LIMIT $8`
+
+	// Root ids are comma separated, replies are ordered by depth so every parent comes before its replies.
+	// Replies of hidden comment are hidden with it
+	listDescendantsQuery = `WITH RECURSIVE thread AS (
+								SELECT comment_id FROM comments WHERE parent_id = ANY (string_to_array($1, ',')::uuid[])
+									AND (moderation_status NOT IN ('hidden', 'rejected') OR $2::boolean OR author_id = $3::uuid)
+								UNION ALL
+								SELECT r.comment_id FROM comments r JOIN thread t ON r.parent_id = t.comment_id
+									WHERE (r.moderation_status NOT IN ('hidden', 'rejected') OR $2::boolean OR r.author_id = $3::uuid)
+							)
+							SELECT ` + commentColumns + `
+							` + commentJoins + `
+								JOIN thread t ON t.comment_id = c.comment_id
+							ORDER BY c.depth, c.created_at, c.comment_id`
 )
+
+const (
+	// Reporting again replaces reason of pending report and reopens resolved one
+	createCommentReportQuery = `INSERT INTO comment_reports (comment_id, reporter_id, reason, details) VALUES ($1, $2, $3, $4)
+						ON CONFLICT (comment_id, reporter_id) DO UPDATE
+							SET reason = EXCLUDED.reason, details = EXCLUDED.details, status = 'pending',
+								resolved_by = NULL, resolved_at = NULL, created_at = now()
+						RETURNING report_id, comment_id, reporter_id, reason, details, status, created_at`
+
+	// Comment is hidden once it has threshold pending reports, comment approved by moderator stays visible
+	hideReportedCommentQuery = `UPDATE comments SET moderation_status = 'hidden'
+						WHERE comment_id = $1 AND moderation_status = 'visible'
+							AND (SELECT count(*) FROM comment_reports r WHERE r.comment_id = $1 AND r.status = 'pending') >= $2`
+
+	updateCommentModerationQuery = `UPDATE comments SET moderation_status = $2 WHERE comment_id = $1`
+
+	resolveCommentReportsQuery = `UPDATE comment_reports SET status = 'resolved', resolved_by = $2, resolved_at = now()
+						WHERE comment_id = $1 AND status = 'pending'`
+
+	banCommentAuthorQuery = `UPDATE users SET comments_banned_at = now()
+						WHERE user_id = (SELECT author_id FROM comments WHERE comment_id = $1) AND comments_banned_at IS NULL`
+
+	getBlogAccessQuery = `SELECT blog_id, author_id, status, held FROM blogs WHERE blog_id = $1`
+
+	isCommentsBannedQuery = `SELECT comments_banned_at IS NOT NULL FROM users WHERE user_id = $1`
+
+	// Queue has hidden comments and comments with pending reports
+	getModerationQueueCountQuery = `SELECT COUNT(c.comment_id) FROM comments c
+							WHERE c.moderation_status = 'hidden'
+								OR EXISTS (SELECT 1 FROM comment_reports r WHERE r.comment_id = c.comment_id AND r.status = 'pending')`
+
+	listModerationQueueQuery = `SELECT ` + commentColumns + `, c.moderation_status,
+							count(r.report_id) as reports_count, string_agg(DISTINCT r.reason, ',') as reasons, max(r.created_at) as last_reported_at
+							` + commentJoins + `
+							LEFT JOIN comment_reports r ON r.comment_id = c.comment_id AND r.status = 'pending'
+							WHERE c.moderation_status = 'hidden' OR r.report_id IS NOT NULL
+							GROUP BY c.comment_id, u.user_id
+							ORDER BY c.moderation_status = 'hidden' DESC, reports_count DESC, c.created_at, c.comment_id
+							OFFSET $1 LIMIT $2`
+)
+
+// commentSortColumns map sort fields of models.CommentSortFields to columns
+var commentSortColumns = map[string]string{
+	"created_at": "c.created_at",
+	"updated_at": "c.updated_at",
+	"likes":      "c.likes_count",
+}
@@ -8,6 +8,12 @@ type Handlers interface {
 	Update() echo.HandlerFunc
 	Delete() echo.HandlerFunc
 	List() echo.HandlerFunc
+	ListReplies() echo.HandlerFunc
 	Like() echo.HandlerFunc
 	Dislike() echo.HandlerFunc
+	Report() echo.HandlerFunc
+	Approve() echo.HandlerFunc
+	Reject() echo.HandlerFunc
+	Ban() echo.HandlerFunc
+	ModerationQueue() echo.HandlerFunc
 }
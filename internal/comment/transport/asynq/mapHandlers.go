@@ -1,8 +1,43 @@
 package asynq
 
-import asynqPkg "github.com/scul0405/blog-clean-architecture-rest-api/pkg/asynq"
+import (
+	"fmt"
+	"github.com/hibiken/asynq"
+	"github.com/scul0405/blog-clean-architecture-rest-api/config"
+	asynqPkg "github.com/scul0405/blog-clean-architecture-rest-api/pkg/asynq"
+	"time"
+)
+
+const (
+	defaultLikesFlushInterval     = 5
+	defaultLikesReconcileInterval = 3600
+)
 
 func MapHandlers(tp *asynqPkg.RedisTaskProcessor, cp CommentProcessor) {
 	tp.RegisterHandler(TypeLikeCommentTask, cp.ProcessTaskLikeComment)
 	tp.RegisterHandler(TypeDislikeCommentTask, cp.ProcessTaskDislikeComment)
+	tp.RegisterHandler(TypeFlushLikesTask, cp.ProcessTaskFlushLikes)
+	tp.RegisterHandler(TypeReconcileLikesTask, cp.ProcessTaskReconcileLikes)
+}
+
+// MapPeriodicTasks schedule flushing of buffered likes and recounting of likes
+func MapPeriodicTasks(ts *asynqPkg.RedisTaskScheduler, cfg *config.Config) error {
+	flushInterval := cfg.Comment.LikesFlushInterval
+	if flushInterval <= 0 {
+		flushInterval = defaultLikesFlushInterval
+	}
+	reconcileInterval := cfg.Comment.LikesReconcileInterval
+	if reconcileInterval <= 0 {
+		reconcileInterval = defaultLikesReconcileInterval
+	}
+
+	// Unique stops runs piling up when worker is slower than schedule
+	err := ts.Register(fmt.Sprintf("@every %ds", flushInterval), asynq.NewTask(TypeFlushLikesTask, nil),
+		asynq.Queue(asynqPkg.QueueCritical), asynq.MaxRetry(0), asynq.Unique(time.Duration(flushInterval)*time.Second))
+	if err != nil {
+		return err
+	}
+
+	return ts.Register(fmt.Sprintf("@every %ds", reconcileInterval), asynq.NewTask(TypeReconcileLikesTask, nil),
+		asynq.Queue(asynqPkg.QueueDefault), asynq.MaxRetry(0), asynq.Unique(time.Duration(reconcileInterval)*time.Second))
 }
@@ -7,6 +7,8 @@ import (
 const (
 	TypeLikeCommentTask    = "comment:like"
 	TypeDislikeCommentTask = "comment:dislike"
+	TypeFlushLikesTask     = "comment:flush_likes"
+	TypeReconcileLikesTask = "comment:reconcile_likes"
 )
 
 type LikeCommentPayload struct {
@@ -7,12 +7,16 @@ import (
 	"github.com/hibiken/asynq"
 	"github.com/scul0405/blog-clean-architecture-rest-api/internal/comment"
 	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	asynqPkg "github.com/scul0405/blog-clean-architecture-rest-api/pkg/asynq"
 	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/logger"
 )
 
+// Like and dislike tasks are no longer enqueued, their processors drain tasks which were queued before likes became synchronous
 type CommentProcessor interface {
 	ProcessTaskLikeComment(ctx context.Context, t *asynq.Task) error
 	ProcessTaskDislikeComment(ctx context.Context, t *asynq.Task) error
+	ProcessTaskFlushLikes(ctx context.Context, t *asynq.Task) error
+	ProcessTaskReconcileLikes(ctx context.Context, t *asynq.Task) error
 }
 
 type commentProcessor struct {
@@ -38,12 +42,9 @@ func (p *commentProcessor) ProcessTaskLikeComment(ctx context.Context, t *asynq.
 		UserID:    payload.UserUID,
 	}
 
-	err := p.commentUC.Like(ctx, userComment)
-	if err != nil {
-		return err
-	}
+	_, err := p.commentUC.Like(ctx, userComment)
 
-	return nil
+	return asynqPkg.SkipRetryIfPermanent(err)
 }
 
 func (p *commentProcessor) ProcessTaskDislikeComment(ctx context.Context, t *asynq.Task) error {
@@ -57,10 +58,15 @@ func (p *commentProcessor) ProcessTaskDislikeComment(ctx context.Context, t *asy
 		UserID:    payload.UserUID,
 	}
 
-	err := p.commentUC.Dislike(ctx, userComment)
-	if err != nil {
-		return err
-	}
+	_, err := p.commentUC.Dislike(ctx, userComment)
+
+	return asynqPkg.SkipRetryIfPermanent(err)
+}
+
+func (p *commentProcessor) ProcessTaskFlushLikes(ctx context.Context, t *asynq.Task) error {
+	return p.commentUC.FlushLikes(ctx)
+}
 
-	return nil
+func (p *commentProcessor) ProcessTaskReconcileLikes(ctx context.Context, t *asynq.Task) error {
+	return p.commentUC.ReconcileLikes(ctx)
 }
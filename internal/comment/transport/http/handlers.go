@@ -1,41 +1,38 @@
 package http
 
 import (
+	"context"
 	"github.com/google/uuid"
-	"github.com/hibiken/asynq"
 	"github.com/labstack/echo/v4"
 	"github.com/opentracing/opentracing-go"
 	"github.com/scul0405/blog-clean-architecture-rest-api/config"
 	"github.com/scul0405/blog-clean-architecture-rest-api/internal/comment"
-	commentAsynq "github.com/scul0405/blog-clean-architecture-rest-api/internal/comment/transport/asynq"
 	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
-	asynqPkg "github.com/scul0405/blog-clean-architecture-rest-api/pkg/asynq"
 	httpErrors "github.com/scul0405/blog-clean-architecture-rest-api/pkg/http_errors"
 	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/logger"
 	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/utils"
 	"net/http"
-	"time"
+	"strconv"
 )
 
 type commentHandlers struct {
 	cfg       *config.Config
-	commentTD commentAsynq.CommentTaskDistributor
 	commentUC comment.UseCase
 	logger    logger.Logger
 }
 
-func NewCommentHandlers(cfg *config.Config, commentUC comment.UseCase, commentTD commentAsynq.CommentTaskDistributor, logger logger.Logger) comment.Handlers {
+func NewCommentHandlers(cfg *config.Config, commentUC comment.UseCase, logger logger.Logger) comment.Handlers {
 	return &commentHandlers{
 		cfg:       cfg,
 		commentUC: commentUC,
-		commentTD: commentTD,
 		logger:    logger,
 	}
 }
 
 // Create godoc
 // @Summary Create comment
-// @Description create comment, returns comment
+// @Description create comment, returns comment. Comment with parent_id is reply, its blog_id can be omitted
+// @Description and replies are limited to configured depth
 // @Tags Comment
 // @Accept json
 // @Produce json
@@ -73,7 +70,9 @@ func (h *commentHandlers) Create() echo.HandlerFunc {
 // @Accept json
 // @Produce json
 // @Param comment_id path string true "comment_id"
+// @Param If-None-Match header string false "ETag of cached comment, returns 304 when unchanged"
 // @Success 200 {object} models.CommentBase
+// @Success 304 "not modified"
 // @Failure 400 {object} httpErrors.RestError
 // @Failure 500 {object} httpErrors.RestError
 // @Router /comments/{comment_id} [get]
@@ -94,6 +93,13 @@ func (h *commentHandlers) GetByID() echo.HandlerFunc {
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
+		variant := commentETagVariant(commentByID)
+		utils.SetCallerCache(c, ctx)
+		utils.SetETag(c, commentByID.Version, variant...)
+		if utils.IfNoneMatch(c, commentByID.Version, variant...) {
+			return c.NoContent(http.StatusNotModified)
+		}
+
 		return c.JSON(http.StatusOK, commentByID)
 	}
 }
@@ -106,9 +112,11 @@ func (h *commentHandlers) GetByID() echo.HandlerFunc {
 // @Produce json
 // @Security Bearer
 // @Param comment_id path string true "comment_id"
+// @Param If-Match header string false "ETag of comment being updated, update fails when comment was changed since"
 // @Param request body models.CommentBase true "input data"
 // @Success 200 {object} models.CommentBase
 // @Failure 400 {object} httpErrors.RestError
+// @Failure 412 {object} httpErrors.PreconditionFailedError
 // @Failure 500 {object} httpErrors.RestError
 // @Router /comments/{comment_id} [patch]
 func (h *commentHandlers) Update() echo.HandlerFunc {
@@ -129,26 +137,40 @@ func (h *commentHandlers) Update() echo.HandlerFunc {
 		}
 		commentReq.CommentID = commentUID
 
+		// If-Match takes precedence over version of request body
+		version, err := utils.GetIfMatchVersion(c)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+		if version != 0 {
+			commentReq.Version = version
+		}
+
 		updatedComment, err := h.commentUC.Update(ctx, commentReq)
 		if err != nil {
 			utils.LogResponseError(c, h.logger, err)
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
+		utils.SetETag(c, updatedComment.Version)
 		return c.JSON(http.StatusOK, updatedComment)
 	}
 }
 
 // Delete godoc
 // @Summary Delete comment by id
-// @Description Delete comment by comment_id
+// @Description Delete comment by comment_id, comment with replies is kept as tombstone without author and message, tombstone can not be deleted again
 // @Tags Comment
 // @Accept json
 // @Produce json
 // @Security Bearer
 // @Param comment_id path string true "comment_id"
+// @Param If-Match header string false "ETag of comment being deleted, delete fails when comment was changed since"
 // @Success 200 {string} string "success"
 // @Failure 400 {object} httpErrors.RestError
+// @Failure 404 {object} httpErrors.RestError
+// @Failure 412 {object} httpErrors.PreconditionFailedError
 // @Failure 500 {object} httpErrors.RestError
 // @Router /comments/{comment_id} [delete]
 func (h *commentHandlers) Delete() echo.HandlerFunc {
@@ -162,7 +184,13 @@ func (h *commentHandlers) Delete() echo.HandlerFunc {
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
-		if err = h.commentUC.Delete(ctx, commentUID); err != nil {
+		version, err := utils.GetIfMatchVersion(c)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		if err = h.commentUC.Delete(ctx, commentUID, version); err != nil {
 			utils.LogResponseError(c, h.logger, err)
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
@@ -173,11 +201,18 @@ func (h *commentHandlers) Delete() echo.HandlerFunc {
 
 // List godoc
 // @Summary List comments by blog_id
-// @Description List comments by blog_id, return list of comments
+// @Description List comments by blog_id, return list of comments. Flat mode lists every comment with parent_id of replies,
+// @Description tree mode pages comments which are not replies and nests every reply below them
 // @Tags Comment
 // @Accept json
 // @Produce json
 // @Param blog_id query string true "blog id"
+// @Param mode query string false "flat (default) or tree"
+// @Param sort query string false "comma separated fields of created_at, updated_at, likes, prefix with - to sort descending, e.g. -likes"
+// @Param cursor query string false "next_cursor or prev_cursor of previous response, empty for first page, switches to cursor pagination newest first"
+// @Param limit query int false "number of elements per page in cursor pagination, from 1 to 100"
+// @Param page query int false "page number" Format(page)
+// @Param size query int false "number of elements per page" Format(size)
 // @Success 200 {object} models.CommentsList
 // @Failure 400 {object} httpErrors.RestError
 // @Failure 500 {object} httpErrors.RestError
@@ -193,13 +228,25 @@ func (h *commentHandlers) List() echo.HandlerFunc {
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
+		mode := c.QueryParam("mode")
+		if mode != "" && mode != models.CommentsListFlat && mode != models.CommentsListTree {
+			err = httpErrors.NewValidationError(httpErrors.FieldError{Field: "mode", Value: mode, Message: "must be flat or tree"})
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
 		pq, err := utils.GetPaginationFromCtx(c)
 		if err != nil {
 			utils.LogResponseError(c, h.logger, err)
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
-		commentsList, err := h.commentUC.List(ctx, blogUID, pq)
+		if err = pq.SetSort(models.CommentSortFields...); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		commentsList, err := h.commentUC.List(ctx, blogUID, mode, pq)
 		if err != nil {
 			utils.LogResponseError(c, h.logger, err)
 			return c.JSON(httpErrors.ErrorResponse(err))
@@ -209,21 +256,95 @@ func (h *commentHandlers) List() echo.HandlerFunc {
 	}
 }
 
+// ListReplies godoc
+// @Summary List replies of comment
+// @Description List direct replies of comment, return list of comments
+// @Tags Comment
+// @Accept json
+// @Produce json
+// @Param comment_id path string true "comment_id"
+// @Param sort query string false "comma separated fields of created_at, updated_at, likes, prefix with - to sort descending, e.g. -likes"
+// @Param cursor query string false "next_cursor or prev_cursor of previous response, empty for first page, switches to cursor pagination newest first"
+// @Param limit query int false "number of elements per page in cursor pagination, from 1 to 100"
+// @Param page query int false "page number" Format(page)
+// @Param size query int false "number of elements per page" Format(size)
+// @Success 200 {object} models.CommentsList
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 404 {object} httpErrors.RestError
+// @Failure 500 {object} httpErrors.RestError
+// @Router /comments/{comment_id}/replies [get]
+func (h *commentHandlers) ListReplies() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "commentHandlers.ListReplies")
+		defer span.Finish()
+
+		commentUID, err := uuid.Parse(c.Param("comment_id"))
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		pq, err := utils.GetPaginationFromCtx(c)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		if err = pq.SetSort(models.CommentSortFields...); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		repliesList, err := h.commentUC.ListReplies(ctx, commentUID, pq)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, repliesList)
+	}
+}
+
 // Like godoc
 // @Summary Like comment by id
-// @Description like comment, returns comment
+// @Description like comment, returns likes of comment and whether caller likes it. Liking twice changes nothing
 // @Tags Comment
 // @Accept json
 // @Produce json
 // @Security Bearer
 // @Param comment_id path string true "comment_id"
-// @Success 200 {object} models.CommentBase
+// @Success 200 {object} models.CommentLikeState
 // @Failure 400 {object} httpErrors.RestError
+// @Failure 404 {object} httpErrors.RestError
 // @Failure 500 {object} httpErrors.RestError
 // @Router /comments/{comment_id}/like [patch]
 func (h *commentHandlers) Like() echo.HandlerFunc {
+	return h.changeLike("commentHandlers.Like", h.commentUC.Like)
+}
+
+// Dislike godoc
+// @Summary Dislike comment by id
+// @Description remove like of comment, returns likes of comment and whether caller likes it. Unliking twice changes nothing
+// @Tags Comment
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param comment_id path string true "comment_id"
+// @Success 200 {object} models.CommentLikeState
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 404 {object} httpErrors.RestError
+// @Failure 500 {object} httpErrors.RestError
+// @Router /comments/{comment_id}/dislike [patch]
+func (h *commentHandlers) Dislike() echo.HandlerFunc {
+	return h.changeLike("commentHandlers.Dislike", h.commentUC.Dislike)
+}
+
+func (h *commentHandlers) changeLike(
+	operationName string,
+	change func(ctx context.Context, userComment *models.UserComments) (*models.CommentLikeState, error),
+) echo.HandlerFunc {
 	return func(c echo.Context) error {
-		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "commentHandlers.Like")
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), operationName)
 		defer span.Finish()
 
 		userUID, err := utils.GetUserUIDFromCtx(ctx)
@@ -232,34 +353,68 @@ func (h *commentHandlers) Like() echo.HandlerFunc {
 		}
 
 		commentUID, err := uuid.Parse(c.Param("comment_id"))
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(httpErrors.NewBadRequestError(err)))
+		}
+
+		likeState, err := change(ctx, &models.UserComments{UserID: userUID, CommentID: commentUID})
 		if err != nil {
 			utils.LogResponseError(c, h.logger, err)
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
-		opts := []asynq.Option{
-			asynq.MaxRetry(10),
-			asynq.ProcessIn(5 * time.Second),
-			asynq.Queue(asynqPkg.QueueCritical),
+		return c.JSON(http.StatusOK, likeState)
+	}
+}
+
+// Report godoc
+// @Summary Report comment by id
+// @Description report comment of other user with reason, returns report. Reporting again replaces report of caller
+// @Description and comment is hidden once it has configured number of pending reports
+// @Tags Comment
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param comment_id path string true "comment_id"
+// @Param request body models.CommentReport true "reason is one of spam, abuse, harassment, off_topic, other"
+// @Success 201 {object} models.CommentReport
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 404 {object} httpErrors.RestError
+// @Failure 500 {object} httpErrors.RestError
+// @Router /comments/{comment_id}/report [post]
+func (h *commentHandlers) Report() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "commentHandlers.Report")
+		defer span.Finish()
+
+		commentUID, err := uuid.Parse(c.Param("comment_id"))
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(httpErrors.NewBadRequestError(err)))
 		}
 
-		payload := &commentAsynq.LikeCommentPayload{
-			UserUID:   userUID,
-			CommentID: commentUID,
+		report := &models.CommentReport{}
+		if err = utils.ReadRequest(c, report); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
 		}
+		report.CommentID = commentUID
 
-		err = h.commentTD.DistributeTaskLikeComment(ctx, payload, opts...)
+		createdReport, err := h.commentUC.Report(ctx, report)
 		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
-		return c.NoContent(http.StatusOK)
+		return c.JSON(http.StatusCreated, createdReport)
 	}
 }
 
-// Dislike godoc
-// @Summary Dislike comment by id
-// @Description dislike comment, returns comment
+// Approve godoc
+// @Summary Approve comment by id
+// @Description approve comment, it is shown again and reports do not hide it again until it is edited. Pending reports are resolved,
+// @Description comment which was hidden is announced to blog watchers and notified like new comment, returns comment
 // @Tags Comment
 // @Accept json
 // @Produce json
@@ -267,40 +422,119 @@ func (h *commentHandlers) Like() echo.HandlerFunc {
 // @Param comment_id path string true "comment_id"
 // @Success 200 {object} models.CommentBase
 // @Failure 400 {object} httpErrors.RestError
+// @Failure 403 {object} httpErrors.RestError
+// @Failure 404 {object} httpErrors.RestError
 // @Failure 500 {object} httpErrors.RestError
-// @Router /comments/{comment_id}/dislike [patch]
-func (h *commentHandlers) Dislike() echo.HandlerFunc {
+// @Router /comments/{comment_id}/approve [post]
+func (h *commentHandlers) Approve() echo.HandlerFunc {
+	return h.moderate("commentHandlers.Approve", models.ModerationApprove)
+}
+
+// Reject godoc
+// @Summary Reject comment by id
+// @Description reject comment, it is hidden for everyone except its author and moderators. Pending reports are resolved, returns comment
+// @Tags Comment
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param comment_id path string true "comment_id"
+// @Success 200 {object} models.CommentBase
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 403 {object} httpErrors.RestError
+// @Failure 404 {object} httpErrors.RestError
+// @Failure 500 {object} httpErrors.RestError
+// @Router /comments/{comment_id}/reject [post]
+func (h *commentHandlers) Reject() echo.HandlerFunc {
+	return h.moderate("commentHandlers.Reject", models.ModerationReject)
+}
+
+// Ban godoc
+// @Summary Reject comment by id and ban its author
+// @Description reject comment and ban its author from commenting. Pending reports are resolved, returns comment
+// @Tags Comment
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param comment_id path string true "comment_id"
+// @Success 200 {object} models.CommentBase
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 403 {object} httpErrors.RestError
+// @Failure 404 {object} httpErrors.RestError
+// @Failure 500 {object} httpErrors.RestError
+// @Router /comments/{comment_id}/ban [post]
+func (h *commentHandlers) Ban() echo.HandlerFunc {
+	return h.moderate("commentHandlers.Ban", models.ModerationBan)
+}
+
+func (h *commentHandlers) moderate(operationName string, action string) echo.HandlerFunc {
 	return func(c echo.Context) error {
-		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "commentHandlers.Dislike")
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), operationName)
 		defer span.Finish()
 
-		userUID, err := utils.GetUserUIDFromCtx(ctx)
+		commentUID, err := uuid.Parse(c.Param("comment_id"))
 		if err != nil {
-			return c.JSON(httpErrors.ErrorResponse(httpErrors.NewUnauthorizedError(err)))
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(httpErrors.NewBadRequestError(err)))
 		}
 
-		commentUID, err := uuid.Parse(c.Param("comment_id"))
+		moderatedComment, err := h.commentUC.Moderate(ctx, commentUID, action)
 		if err != nil {
 			utils.LogResponseError(c, h.logger, err)
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
-		opts := []asynq.Option{
-			asynq.MaxRetry(10),
-			asynq.ProcessIn(5 * time.Second),
-			asynq.Queue(asynqPkg.QueueCritical),
-		}
+		return c.JSON(http.StatusOK, moderatedComment)
+	}
+}
 
-		payload := &commentAsynq.DislikeCommentPayload{
-			UserUID:   userUID,
-			CommentID: commentUID,
+// ModerationQueue godoc
+// @Summary List comments waiting for moderation
+// @Description list comments with pending reports, hidden comments first and then most reported
+// @Tags Comment
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param page query int false "page number" Format(page)
+// @Param size query int false "number of elements per page" Format(size)
+// @Success 200 {object} models.ModerationQueue
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 403 {object} httpErrors.RestError
+// @Failure 500 {object} httpErrors.RestError
+// @Router /comments/moderation [get]
+func (h *commentHandlers) ModerationQueue() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "commentHandlers.ModerationQueue")
+		defer span.Finish()
+
+		pq, err := utils.GetPaginationFromCtx(c)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
-		err = h.commentTD.DistributeTaskDislikeComment(ctx, payload, opts...)
+		queue, err := h.commentUC.ModerationQueue(ctx, pq)
 		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
-		return c.NoContent(http.StatusOK)
+		return c.JSON(http.StatusOK, queue)
+	}
+}
+
+// commentETagVariant is state response of comment depends on besides its version, likes, replies and reactions
+// change without new version
+func commentETagVariant(comment *models.CommentBase) []string {
+	likedByMe := ""
+	if comment.LikedByMe != nil {
+		likedByMe = strconv.FormatBool(*comment.LikedByMe)
+	}
+
+	return []string{
+		strconv.FormatInt(comment.Likes, 10),
+		strconv.Itoa(comment.RepliesCount),
+		strconv.FormatBool(comment.Hidden),
+		likedByMe,
+		utils.ReactionsVariant(comment.Reactions, comment.MyReactions),
 	}
 }
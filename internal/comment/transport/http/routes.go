@@ -4,14 +4,21 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/scul0405/blog-clean-architecture-rest-api/internal/comment"
 	"github.com/scul0405/blog-clean-architecture-rest-api/internal/middleware"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/rbac"
 )
 
 func MapCommentRoutes(commentGroup *echo.Group, h comment.Handlers, mw *middleware.MiddlewareManager) {
-	commentGroup.POST("", h.Create(), mw.AuthPASETOMiddleware)
-	commentGroup.GET("/:comment_id", h.GetByID())
+	commentGroup.POST("", h.Create(), mw.AuthPASETOMiddleware, mw.RequirePermission(rbac.CommentCreate))
+	commentGroup.GET("/:comment_id", h.GetByID(), mw.OptionalAuthPASETOMiddleware)
 	commentGroup.PATCH("/:comment_id", h.Update(), mw.AuthPASETOMiddleware)
 	commentGroup.DELETE("/:comment_id", h.Delete(), mw.AuthPASETOMiddleware)
-	commentGroup.GET("", h.List())
+	commentGroup.GET("", h.List(), mw.OptionalAuthPASETOMiddleware)
+	commentGroup.GET("/:comment_id/replies", h.ListReplies(), mw.OptionalAuthPASETOMiddleware)
 	commentGroup.PATCH("/:comment_id/like", h.Like(), mw.AuthPASETOMiddleware)
 	commentGroup.PATCH("/:comment_id/dislike", h.Dislike(), mw.AuthPASETOMiddleware)
+	commentGroup.POST("/:comment_id/report", h.Report(), mw.AuthPASETOMiddleware)
+	commentGroup.GET("/moderation", h.ModerationQueue(), mw.AuthPASETOMiddleware, mw.RequirePermission(rbac.CommentModerate))
+	commentGroup.POST("/:comment_id/approve", h.Approve(), mw.AuthPASETOMiddleware, mw.RequirePermission(rbac.CommentModerate))
+	commentGroup.POST("/:comment_id/reject", h.Reject(), mw.AuthPASETOMiddleware, mw.RequirePermission(rbac.CommentModerate))
+	commentGroup.POST("/:comment_id/ban", h.Ban(), mw.AuthPASETOMiddleware, mw.RequirePermission(rbac.CommentModerate))
 }
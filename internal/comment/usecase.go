@@ -12,8 +12,14 @@ type UseCase interface {
 	Create(ctx context.Context, comment *models.Comment) (*models.Comment, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*models.CommentBase, error)
 	Update(ctx context.Context, comment *models.CommentBase) (*models.CommentBase, error)
-	Delete(ctx context.Context, id uuid.UUID) error
-	List(ctx context.Context, blogID uuid.UUID, pq *utils.PaginationQuery) (*models.CommentsList, error)
-	Like(ctx context.Context, userComment *models.UserComments) error
-	Dislike(ctx context.Context, userComment *models.UserComments) error
+	Delete(ctx context.Context, id uuid.UUID, version int) error
+	List(ctx context.Context, blogID uuid.UUID, mode string, pq *utils.PaginationQuery) (*models.CommentsList, error)
+	ListReplies(ctx context.Context, commentID uuid.UUID, pq *utils.PaginationQuery) (*models.CommentsList, error)
+	Like(ctx context.Context, userComment *models.UserComments) (*models.CommentLikeState, error)
+	Dislike(ctx context.Context, userComment *models.UserComments) (*models.CommentLikeState, error)
+	Report(ctx context.Context, report *models.CommentReport) (*models.CommentReport, error)
+	Moderate(ctx context.Context, commentID uuid.UUID, action string) (*models.CommentBase, error)
+	ModerationQueue(ctx context.Context, pq *utils.PaginationQuery) (*models.ModerationQueue, error)
+	FlushLikes(ctx context.Context) error
+	ReconcileLikes(ctx context.Context) error
 }
@@ -2,33 +2,61 @@ package usecase
 
 import (
 	"context"
-	"database/sql"
+	"fmt"
 	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
 	"github.com/opentracing/opentracing-go"
 	"github.com/pkg/errors"
 	"github.com/scul0405/blog-clean-architecture-rest-api/config"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/blog_event"
 	"github.com/scul0405/blog-clean-architecture-rest-api/internal/comment"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/content_filter"
 	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	notificationAsynq "github.com/scul0405/blog-clean-architecture-rest-api/internal/notification/transport/asynq"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/reaction"
 	"github.com/scul0405/blog-clean-architecture-rest-api/internal/user_comment"
+	asynqPkg "github.com/scul0405/blog-clean-architecture-rest-api/pkg/asynq"
 	httpErrors "github.com/scul0405/blog-clean-architecture-rest-api/pkg/http_errors"
 	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/logger"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/rbac"
 	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/utils"
 	"net/http"
 )
 
+const (
+	defaultMaxReplyDepth     = 5
+	defaultHotLikesPerMinute = 30
+	basePrefix               = "comment-api"
+	likesDeltasKey           = basePrefix + ": likes-deltas"
+	likeRateWindow           = 60
+	defaultReportThreshold   = 3
+	notifyMaxRetry           = 5
+)
+
 type commentUseCase struct {
 	cfg             *config.Config
 	commentRepo     comment.Repository
+	redisRepo       comment.RedisRepository
 	userCommentRepo user_comment.Repository
+	reactionRepo    reaction.Repository
+	contentFilter   content_filter.ContentFilter
+	notificationTD  notificationAsynq.NotificationTaskDistributor
+	blogEventUC     blog_event.UseCase
 	logger          logger.Logger
 }
 
 func NewCommentUseCase(
 	cfg *config.Config,
 	commentRepo comment.Repository,
+	redisRepo comment.RedisRepository,
 	userCommentRepo user_comment.Repository,
+	reactionRepo reaction.Repository,
+	contentFilter content_filter.ContentFilter,
+	notificationTD notificationAsynq.NotificationTaskDistributor,
+	blogEventUC blog_event.UseCase,
 	logger logger.Logger) comment.UseCase {
-	return &commentUseCase{cfg: cfg, commentRepo: commentRepo, userCommentRepo: userCommentRepo, logger: logger}
+	return &commentUseCase{cfg: cfg, commentRepo: commentRepo, redisRepo: redisRepo, userCommentRepo: userCommentRepo, reactionRepo: reactionRepo,
+		contentFilter: contentFilter, notificationTD: notificationTD, blogEventUC: blogEventUC, logger: logger}
 }
 
 func (u *commentUseCase) Create(ctx context.Context, comment *models.Comment) (*models.Comment, error) {
@@ -40,13 +68,55 @@ func (u *commentUseCase) Create(ctx context.Context, comment *models.Comment) (*
 		return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "commentUC.Create.GetUserUIDFromCtx"))
 	}
 
+	if err = u.checkNotBanned(ctx, userUID); err != nil {
+		return nil, err
+	}
+
 	comment.AuthorID = userUID
-	createdBlog, err := u.commentRepo.Create(ctx, comment)
+	comment.Depth = 0
+	if comment.ParentID != nil {
+		if err = u.setReplyParent(ctx, comment); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = u.checkBlogVisible(ctx, comment.BlogID); err != nil {
+		return nil, err
+	}
+
+	decision, err := u.contentFilter.Check(ctx, &models.FilterInput{
+		TargetType: models.FilterTargetComment,
+		AuthorID:   userUID,
+		Texts:      []string{comment.Message},
+	})
+	if err != nil {
+		return nil, err
+	}
+	comment.Message = decision.Texts[0]
+	comment.Hidden = decision.Action == models.FilterHold
+
+	createdComment, err := u.commentRepo.Create(ctx, comment)
 	if err != nil {
 		return nil, err
 	}
 
-	return createdBlog, nil
+	if err = u.contentFilter.Record(ctx, decision, createdComment.CommentID); err != nil {
+		u.logger.Errorf("commentUC.Create: Record: %v", err)
+	}
+
+	// Comment held by content filter is not announced
+	if !createdComment.Hidden {
+		u.publish(ctx, createdComment.BlogID, models.BlogEventCommentCreated, createdComment)
+		u.notify(ctx, &notificationAsynq.NotifyPayload{
+			Type:      models.EventCommentCreated,
+			ActorID:   userUID,
+			BlogID:    createdComment.BlogID,
+			CommentID: createdComment.CommentID,
+			ParentID:  createdComment.ParentID,
+		})
+	}
+
+	return createdComment, nil
 }
 
 func (u *commentUseCase) GetByID(ctx context.Context, id uuid.UUID) (*models.CommentBase, error) {
@@ -58,6 +128,17 @@ func (u *commentUseCase) GetByID(ctx context.Context, id uuid.UUID) (*models.Com
 		return nil, err
 	}
 
+	if !u.canView(ctx, comment) {
+		return nil, httpErrors.NewRestError(http.StatusNotFound, httpErrors.NotFound.Error(), nil)
+	}
+	if err = u.checkBlogVisible(ctx, comment.BlogID); err != nil {
+		return nil, err
+	}
+
+	if err = u.attachReactions(ctx, comment); err != nil {
+		return nil, err
+	}
+
 	return comment, nil
 }
 
@@ -70,19 +151,60 @@ func (u *commentUseCase) Update(ctx context.Context, comment *models.CommentBase
 		return nil, err
 	}
 
-	if err := utils.ValidateIsOwner(ctx, commentByID.AuthorID.String(), u.logger); err != nil {
-		return nil, httpErrors.NewRestError(http.StatusForbidden, "Forbidden", errors.Wrap(err, "commentUC.Update.ValidateIsOwner"))
+	if commentByID.Deleted {
+		return nil, httpErrors.NewRestError(http.StatusNotFound, "Comment is deleted", nil)
+	}
+
+	if err = utils.ValidateIsOwnerOrHasPermission(ctx, commentByID.AuthorID.String(), rbac.CommentUpdateAny, u.logger); err != nil {
+		return nil, err
+	}
+
+	editorUID, err := utils.GetUserUIDFromCtx(ctx)
+	if err != nil {
+		return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "commentUC.Update.GetUserUIDFromCtx"))
+	}
+
+	// Banned user can not get around ban by editing comments written before it
+	if err = u.checkNotBanned(ctx, editorUID); err != nil {
+		return nil, err
+	}
+
+	if err = utils.ValidateVersion(commentByID.Version, comment.Version); err != nil {
+		return nil, err
 	}
 
-	updatedBlog, err := u.commentRepo.Update(ctx, comment)
+	decision, err := u.contentFilter.Check(ctx, &models.FilterInput{
+		TargetType: models.FilterTargetComment,
+		TargetID:   &comment.CommentID,
+		AuthorID:   commentByID.AuthorID,
+		Texts:      []string{comment.Message},
+	})
 	if err != nil {
 		return nil, err
 	}
+	comment.Message = decision.Texts[0]
+	comment.Hidden = decision.Action == models.FilterHold
 
-	return updatedBlog, nil
+	updatedComment, err := u.commentRepo.Update(ctx, comment)
+	if err != nil {
+		return nil, utils.VersionConflict(err, comment.Version)
+	}
+
+	if err = u.contentFilter.Record(ctx, decision, updatedComment.CommentID); err != nil {
+		u.logger.Errorf("commentUC.Update: Record: %v", err)
+	}
+
+	// Hidden comment disappears for clients watching blog
+	if updatedComment.Hidden {
+		u.publish(ctx, updatedComment.BlogID, models.BlogEventCommentDeleted, &models.CommentDeletedEvent{CommentID: updatedComment.CommentID})
+	} else {
+		u.publish(ctx, updatedComment.BlogID, models.BlogEventCommentUpdated, updatedComment)
+	}
+
+	return updatedComment, nil
 }
 
-func (u *commentUseCase) Delete(ctx context.Context, id uuid.UUID) error {
+func (u *commentUseCase) Delete(ctx context.Context, id uuid.UUID, version int) error {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "commentUC.Delete")
 	defer span.Finish()
 
@@ -91,50 +213,501 @@ func (u *commentUseCase) Delete(ctx context.Context, id uuid.UUID) error {
 		return err
 	}
 
-	if err := utils.ValidateIsOwner(ctx, commentByID.AuthorID.String(), u.logger); err != nil {
-		return httpErrors.NewRestError(http.StatusForbidden, "Forbidden", errors.Wrap(err, "commentUC.Delete.ValidateIsOwner"))
+	if commentByID.Deleted {
+		return httpErrors.NewRestError(http.StatusNotFound, "Comment is deleted", nil)
+	}
+
+	if err = utils.ValidateIsOwnerOrHasPermission(ctx, commentByID.AuthorID.String(), rbac.CommentDeleteAny, u.logger); err != nil {
+		return err
+	}
+
+	if err = utils.ValidateVersion(commentByID.Version, version); err != nil {
+		return err
+	}
+
+	if err = u.commentRepo.Delete(ctx, id, version); err != nil {
+		return utils.VersionConflict(err, version)
 	}
 
-	return u.commentRepo.Delete(ctx, id)
+	u.publish(ctx, commentByID.BlogID, models.BlogEventCommentDeleted, &models.CommentDeletedEvent{CommentID: id})
+
+	return nil
 }
 
-func (u *commentUseCase) List(ctx context.Context, blogID uuid.UUID, pq *utils.PaginationQuery) (*models.CommentsList, error) {
+// List comments of blog, tree mode pages comments which are not replies and nests every reply below them
+func (u *commentUseCase) List(ctx context.Context, blogID uuid.UUID, mode string, pq *utils.PaginationQuery) (*models.CommentsList, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "commentUC.List")
 	defer span.Finish()
 
-	return u.commentRepo.List(ctx, blogID, pq)
+	if err := u.checkBlogVisible(ctx, blogID); err != nil {
+		return nil, err
+	}
+
+	visibility := u.visibility(ctx)
+	if mode != models.CommentsListTree {
+		commentsList, err := u.commentRepo.List(ctx, &models.CommentFilter{BlogID: blogID, CommentVisibility: visibility}, pq)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = u.attachReactions(ctx, commentsList.Comments...); err != nil {
+			return nil, err
+		}
+
+		return commentsList, nil
+	}
+
+	commentsList, err := u.commentRepo.List(ctx, &models.CommentFilter{BlogID: blogID, RootsOnly: true, CommentVisibility: visibility}, pq)
+	if err != nil {
+		return nil, err
+	}
+
+	rootIDs := make([]uuid.UUID, 0, len(commentsList.Comments))
+	for _, c := range commentsList.Comments {
+		rootIDs = append(rootIDs, c.CommentID)
+	}
+
+	descendants, err := u.commentRepo.ListDescendants(ctx, rootIDs, visibility)
+	if err != nil {
+		return nil, err
+	}
+	if err = u.attachReactions(ctx, append(descendants, commentsList.Comments...)...); err != nil {
+		return nil, err
+	}
+	nestReplies(commentsList.Comments, descendants)
+
+	return commentsList, nil
+}
+
+// ListReplies list direct replies of comment
+func (u *commentUseCase) ListReplies(ctx context.Context, commentID uuid.UUID, pq *utils.PaginationQuery) (*models.CommentsList, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "commentUC.ListReplies")
+	defer span.Finish()
+
+	parent, err := u.commentRepo.GetByID(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !u.canView(ctx, parent) {
+		return nil, httpErrors.NewRestError(http.StatusNotFound, httpErrors.NotFound.Error(), nil)
+	}
+	if err = u.checkBlogVisible(ctx, parent.BlogID); err != nil {
+		return nil, err
+	}
+
+	repliesList, err := u.commentRepo.List(ctx, &models.CommentFilter{BlogID: parent.BlogID, ParentID: &parent.CommentID, CommentVisibility: u.visibility(ctx)}, pq)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = u.attachReactions(ctx, repliesList.Comments...); err != nil {
+		return nil, err
+	}
+
+	return repliesList, nil
 }
 
-func (u *commentUseCase) Like(ctx context.Context, userComment *models.UserComments) error {
+// Like is idempotent, liking comment which caller already likes changes nothing
+func (u *commentUseCase) Like(ctx context.Context, userComment *models.UserComments) (*models.CommentLikeState, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "commentUC.Like")
 	defer span.Finish()
 
-	err := u.userCommentRepo.GetByID(ctx, userComment)
-	if !errors.Is(err, sql.ErrNoRows) {
-		return err
+	return u.changeLike(ctx, userComment, true)
+}
+
+// Dislike is idempotent, unliking comment which caller does not like changes nothing
+func (u *commentUseCase) Dislike(ctx context.Context, userComment *models.UserComments) (*models.CommentLikeState, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "commentUC.Dislike")
+	defer span.Finish()
+
+	return u.changeLike(ctx, userComment, false)
+}
+
+// Report comment of other user, comment is hidden once it has configured number of pending reports
+func (u *commentUseCase) Report(ctx context.Context, report *models.CommentReport) (*models.CommentReport, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "commentUC.Report")
+	defer span.Finish()
+
+	userUID, err := utils.GetUserUIDFromCtx(ctx)
+	if err != nil {
+		return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "commentUC.Report.GetUserUIDFromCtx"))
+	}
+
+	commentByID, err := u.commentRepo.GetByID(ctx, report.CommentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if commentByID.Deleted {
+		return nil, httpErrors.NewRestError(http.StatusNotFound, "Comment is deleted", nil)
+	}
+	if !u.canView(ctx, commentByID) {
+		return nil, httpErrors.NewRestError(http.StatusNotFound, httpErrors.NotFound.Error(), nil)
+	}
+	if err = u.checkBlogVisible(ctx, commentByID.BlogID); err != nil {
+		return nil, err
+	}
+	if commentByID.AuthorID == userUID {
+		return nil, httpErrors.NewRestError(http.StatusBadRequest, "Cannot report own comment", nil)
+	}
+
+	threshold := u.cfg.Comment.ReportHideThreshold
+	if threshold <= 0 {
+		threshold = defaultReportThreshold
+	}
+
+	report.ReporterID = userUID
+	return u.commentRepo.Report(ctx, report, threshold)
+}
+
+// Moderate approve, reject or ban author of comment, every pending report of comment is resolved.
+// Hidden comment released by approval is announced like comment which was never hidden
+func (u *commentUseCase) Moderate(ctx context.Context, commentID uuid.UUID, action string) (*models.CommentBase, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "commentUC.Moderate")
+	defer span.Finish()
+
+	userUID, err := utils.GetUserUIDFromCtx(ctx)
+	if err != nil {
+		return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "commentUC.Moderate.GetUserUIDFromCtx"))
 	}
 
-	err = u.userCommentRepo.Create(ctx, userComment)
+	if !rbac.HasPermission(utils.GetRoleFromCtx(ctx), rbac.CommentModerate) {
+		return nil, httpErrors.NewMissingPermissionError(string(rbac.CommentModerate), httpErrors.Forbidden)
+	}
+
+	commentByID, err := u.commentRepo.GetByID(ctx, commentID)
 	if err != nil {
+		return nil, err
+	}
+
+	if err = u.commentRepo.Moderate(ctx, &models.CommentModeration{CommentID: commentID, ModeratorID: userUID, Action: action}); err != nil {
+		return nil, err
+	}
+
+	moderatedComment, err := u.commentRepo.GetByID(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Event is pushed before reactions of moderator are attached
+	if action == models.ModerationApprove && commentByID.Hidden && !moderatedComment.Deleted {
+		u.publish(ctx, moderatedComment.BlogID, models.BlogEventCommentCreated, moderatedComment)
+		u.notify(ctx, &notificationAsynq.NotifyPayload{
+			Type:      models.EventCommentCreated,
+			ActorID:   moderatedComment.AuthorID,
+			BlogID:    moderatedComment.BlogID,
+			CommentID: moderatedComment.CommentID,
+			ParentID:  moderatedComment.ParentID,
+		})
+	}
+
+	if err = u.attachReactions(ctx, moderatedComment); err != nil {
+		return nil, err
+	}
+
+	return moderatedComment, nil
+}
+
+// ModerationQueue list comments waiting for review
+func (u *commentUseCase) ModerationQueue(ctx context.Context, pq *utils.PaginationQuery) (*models.ModerationQueue, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "commentUC.ModerationQueue")
+	defer span.Finish()
+
+	return u.commentRepo.ModerationQueue(ctx, pq)
+}
+
+// FlushLikes recount likes_count of hot comments with buffered like changes. Likes are stored before their change is
+// buffered, so recount made after changes are taken includes them, and change buffered meanwhile only recounts again
+func (u *commentUseCase) FlushLikes(ctx context.Context) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "commentUC.FlushLikes")
+	defer span.Finish()
+
+	deltas, err := u.redisRepo.PopLikesDeltasCtx(ctx, likesDeltasKey)
+	if err != nil {
+		return err
+	}
+	if len(deltas) == 0 {
+		return nil
+	}
+
+	commentIDs := make([]uuid.UUID, 0, len(deltas))
+	for commentID := range deltas {
+		commentIDs = append(commentIDs, commentID)
+	}
+
+	if err = u.userCommentRepo.RecountLikes(ctx, commentIDs); err != nil {
+		// put deltas back so next flush recounts their comments
+		for commentID, delta := range deltas {
+			if redisErr := u.redisRepo.IncrLikesDeltaCtx(ctx, likesDeltasKey, commentID, delta); redisErr != nil {
+				u.logger.Errorf("commentUC.FlushLikes: IncrLikesDeltaCtx: %v", redisErr)
+			}
+		}
 		return err
 	}
 
 	return nil
 }
 
-func (u *commentUseCase) Dislike(ctx context.Context, userComment *models.UserComments) error {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "commentUC.Dislike")
+// ReconcileLikes recount likes_count of every comment from its likes, changes still buffered only recount their comments again
+func (u *commentUseCase) ReconcileLikes(ctx context.Context) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "commentUC.ReconcileLikes")
 	defer span.Finish()
 
-	err := u.userCommentRepo.GetByID(ctx, userComment)
-	if errors.Is(err, sql.ErrNoRows) || err != nil {
-		return nil
+	drifted, err := u.userCommentRepo.ReconcileLikesCounts(ctx)
+	if err != nil {
+		return err
+	}
+
+	if drifted > 0 {
+		u.logger.Warnf("commentUC.ReconcileLikes: corrected likes_count of %d comments", drifted)
+	}
+
+	return nil
+}
+
+// changeLike set like of caller to liked and return likes of comment including buffered changes
+func (u *commentUseCase) changeLike(ctx context.Context, userComment *models.UserComments, liked bool) (*models.CommentLikeState, error) {
+	commentByID, err := u.commentRepo.GetByID(ctx, userComment.CommentID)
+	if err != nil {
+		return nil, err
 	}
 
-	err = u.userCommentRepo.Delete(ctx, userComment)
+	if commentByID.Deleted && liked {
+		return nil, httpErrors.NewRestError(http.StatusNotFound, "Comment is deleted", nil)
+	}
+	if !u.canView(ctx, commentByID) {
+		return nil, httpErrors.NewRestError(http.StatusNotFound, httpErrors.NotFound.Error(), nil)
+	}
+	if err = u.checkBlogVisible(ctx, commentByID.BlogID); err != nil {
+		return nil, err
+	}
+
+	hot := u.isHot(ctx, userComment.CommentID)
+
+	var changed bool
+	var delta int64
+	if liked {
+		changed, err = u.userCommentRepo.Create(ctx, userComment, hot)
+		delta = 1
+	} else {
+		changed, err = u.userCommentRepo.Delete(ctx, userComment, hot)
+		delta = -1
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if changed && hot {
+		u.bufferLikesDelta(ctx, userComment.CommentID, delta)
+	}
+	if changed && liked {
+		u.notify(ctx, &notificationAsynq.NotifyPayload{
+			Type:      models.EventCommentLiked,
+			ActorID:   userComment.UserID,
+			BlogID:    commentByID.BlogID,
+			CommentID: userComment.CommentID,
+		})
+	}
+
+	likes, err := u.userCommentRepo.GetLikesCount(ctx, userComment.CommentID)
+	if err != nil {
+		return nil, err
+	}
+
+	buffered, err := u.redisRepo.GetLikesDeltaCtx(ctx, likesDeltasKey, userComment.CommentID)
+	if err != nil {
+		u.logger.Errorf("commentUC.changeLike: GetLikesDeltaCtx: %v", err)
+	}
+	if likes += buffered; likes < 0 {
+		likes = 0
+	}
+
+	if changed {
+		u.publish(ctx, commentByID.BlogID, models.BlogEventCommentLikes, &models.CommentLikesEvent{CommentID: userComment.CommentID, Likes: likes})
+	}
+
+	return &models.CommentLikeState{CommentID: userComment.CommentID, Likes: likes, LikedByMe: liked}, nil
+}
+
+// isHot count like change of comment, comment is hot when it gets more changes a minute than configured
+func (u *commentUseCase) isHot(ctx context.Context, commentID uuid.UUID) bool {
+	hotLikes := u.cfg.Comment.HotLikesPerMinute
+	if hotLikes <= 0 {
+		hotLikes = defaultHotLikesPerMinute
+	}
+
+	count, err := u.redisRepo.IncrLikeRateCtx(ctx, u.generateLikeRateKey(commentID), likeRateWindow)
+	if err != nil {
+		u.logger.Errorf("commentUC.isHot: IncrLikeRateCtx: %v", err)
+		return false
+	}
+
+	return count > int64(hotLikes)
+}
+
+// bufferLikesDelta keep like change until next flush, comment is recounted at once when redis fails
+func (u *commentUseCase) bufferLikesDelta(ctx context.Context, commentID uuid.UUID, delta int64) {
+	err := u.redisRepo.IncrLikesDeltaCtx(ctx, likesDeltasKey, commentID, delta)
+	if err == nil {
+		return
+	}
+	u.logger.Errorf("commentUC.bufferLikesDelta: IncrLikesDeltaCtx: %v", err)
+
+	if err = u.userCommentRepo.RecountLikes(ctx, []uuid.UUID{commentID}); err != nil {
+		u.logger.Errorf("commentUC.bufferLikesDelta: RecountLikes: %v", err)
+	}
+}
+
+// publish push event to clients watching blog, change is kept when event can not be pushed
+func (u *commentUseCase) publish(ctx context.Context, blogID uuid.UUID, eventType string, data interface{}) {
+	if err := u.blogEventUC.Publish(ctx, blogID, eventType, data); err != nil {
+		u.logger.Errorf("commentUC.publish: Publish: %v", err)
+	}
+}
+
+// notify enqueue event for notifications, comment or like is kept when event can not be enqueued
+func (u *commentUseCase) notify(ctx context.Context, payload *notificationAsynq.NotifyPayload) {
+	err := u.notificationTD.DistributeTaskNotify(ctx, payload, asynq.MaxRetry(notifyMaxRetry), asynq.Queue(asynqPkg.QueueDefault))
+	if err != nil {
+		u.logger.Errorf("commentUC.notify: DistributeTaskNotify: %v", err)
+	}
+}
+
+func (u *commentUseCase) generateLikeRateKey(commentID uuid.UUID) string {
+	return fmt.Sprintf("%s: like-rate: %s", basePrefix, commentID)
+}
+
+// setReplyParent put reply into blog and depth of its parent, replies deeper than max reply depth are rejected
+func (u *commentUseCase) setReplyParent(ctx context.Context, comment *models.Comment) error {
+	parent, err := u.commentRepo.GetByID(ctx, *comment.ParentID)
 	if err != nil {
 		return err
 	}
 
+	if parent.Deleted {
+		return httpErrors.NewRestError(http.StatusBadRequest, "Cannot reply to deleted comment", nil)
+	}
+	if parent.Hidden {
+		return httpErrors.NewRestError(http.StatusBadRequest, "Cannot reply to hidden comment", nil)
+	}
+	if comment.BlogID != uuid.Nil && comment.BlogID != parent.BlogID {
+		return httpErrors.NewRestError(http.StatusBadRequest, "Reply must belong to blog of parent comment", nil)
+	}
+
+	maxDepth := u.cfg.Comment.MaxReplyDepth
+	if maxDepth <= 0 {
+		maxDepth = defaultMaxReplyDepth
+	}
+	if parent.Depth+1 > maxDepth {
+		return httpErrors.NewRestError(http.StatusBadRequest, fmt.Sprintf("Replies can be at most %d levels deep", maxDepth), nil)
+	}
+
+	comment.BlogID = parent.BlogID
+	comment.Depth = parent.Depth + 1
+
 	return nil
 }
+
+// nestReplies attach descendants to their parents, descendants must list every parent before its replies
+func nestReplies(roots []*models.CommentBase, descendants []*models.CommentBase) {
+	byID := make(map[uuid.UUID]*models.CommentBase, len(roots)+len(descendants))
+	for _, c := range roots {
+		byID[c.CommentID] = c
+	}
+
+	for _, c := range descendants {
+		byID[c.CommentID] = c
+		if c.ParentID == nil {
+			continue
+		}
+		if parent, ok := byID[*c.ParentID]; ok {
+			parent.Replies = append(parent.Replies, c)
+		}
+	}
+}
+
+// visibility of hidden comments to caller, moderators see every hidden comment and authors see their own
+func (u *commentUseCase) visibility(ctx context.Context) models.CommentVisibility {
+	visibility := models.CommentVisibility{ShowHidden: rbac.HasPermission(utils.GetRoleFromCtx(ctx), rbac.CommentModerate)}
+	if userUID, err := utils.GetUserUIDFromCtx(ctx); err == nil {
+		visibility.ViewerID = &userUID
+	}
+
+	return visibility
+}
+
+// checkNotBanned forbid writing comments to user who is banned from commenting
+func (u *commentUseCase) checkNotBanned(ctx context.Context, userUID uuid.UUID) error {
+	banned, err := u.commentRepo.IsBanned(ctx, userUID)
+	if err != nil {
+		return err
+	}
+	if banned {
+		return httpErrors.NewRestError(http.StatusForbidden, "User is banned from commenting", nil)
+	}
+
+	return nil
+}
+
+// checkBlogVisible return not found unless caller can view blog, comments of blogs which are not published stay with them
+func (u *commentUseCase) checkBlogVisible(ctx context.Context, blogID uuid.UUID) error {
+	blog, err := u.commentRepo.GetBlogAccess(ctx, blogID)
+	if err != nil {
+		return err
+	}
+
+	if !utils.CanViewBlog(ctx, blog.AuthorID, blog.Status == models.BlogStatusPublished, blog.Held) {
+		return httpErrors.NewRestError(http.StatusNotFound, httpErrors.NotFound.Error(), nil)
+	}
+
+	return nil
+}
+
+func (u *commentUseCase) canView(ctx context.Context, comment *models.CommentBase) bool {
+	if !comment.Hidden {
+		return true
+	}
+
+	visibility := u.visibility(ctx)
+	return visibility.ShowHidden || (visibility.ViewerID != nil && *visibility.ViewerID == comment.AuthorID)
+}
+
+// attachReactions set reaction counts of comments and kinds caller reacted with, anonymous caller has no reactions
+func (u *commentUseCase) attachReactions(ctx context.Context, comments ...*models.CommentBase) error {
+	commentIDs := make([]uuid.UUID, 0, len(comments))
+	for _, c := range comments {
+		commentIDs = append(commentIDs, c.CommentID)
+	}
+
+	userUID, err := utils.GetUserUIDFromCtx(ctx)
+	authenticated := err == nil
+	summaries, err := u.reactionRepo.Summaries(ctx, models.ReactionTargetComment, commentIDs, userUID)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range comments {
+		summary, ok := summaries[c.CommentID]
+		if ok {
+			c.Reactions = summary.Counts
+			c.MyReactions = summary.Mine
+		}
+		if authenticated {
+			likedByMe := ok && containsKind(summary.Mine, models.ReactionLike)
+			c.LikedByMe = &likedByMe
+		}
+	}
+
+	return nil
+}
+
+func containsKind(kinds []string, kind string) bool {
+	for _, k := range kinds {
+		if k == kind {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,548 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/scul0405/blog-clean-architecture-rest-api/config"
+	blogEventMock "github.com/scul0405/blog-clean-architecture-rest-api/internal/blog_event/mock"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/comment/mock"
+	filterMock "github.com/scul0405/blog-clean-architecture-rest-api/internal/content_filter/mock"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	notificationMock "github.com/scul0405/blog-clean-architecture-rest-api/internal/notification/mock"
+	notificationAsynq "github.com/scul0405/blog-clean-architecture-rest-api/internal/notification/transport/asynq"
+	reactionMock "github.com/scul0405/blog-clean-architecture-rest-api/internal/reaction/mock"
+	userCommentMock "github.com/scul0405/blog-clean-architecture-rest-api/internal/user_comment/mock"
+	httpErrors "github.com/scul0405/blog-clean-architecture-rest-api/pkg/http_errors"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/logger"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/rbac"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/utils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"testing"
+)
+
+func TestCommentUseCase_FlushLikes(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Logger: config.LoggerConfig{
+			Development: true,
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	apiLogger.InitLogger()
+	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
+	mockUserCommentRepo := userCommentMock.NewMockRepository(ctrl)
+	commentUC := NewCommentUseCase(cfg, nil, mockRedisRepo, mockUserCommentRepo, nil, nil, nil, nil, apiLogger)
+
+	commentUID := uuid.New()
+
+	t.Run("Recount", func(t *testing.T) {
+		mockRedisRepo.EXPECT().PopLikesDeltasCtx(gomock.Any(), likesDeltasKey).Return(map[uuid.UUID]int64{commentUID: 3}, nil)
+		mockUserCommentRepo.EXPECT().RecountLikes(gomock.Any(), []uuid.UUID{commentUID}).Return(nil)
+
+		require.NoError(t, commentUC.FlushLikes(context.Background()))
+	})
+
+	t.Run("Nothing buffered", func(t *testing.T) {
+		mockRedisRepo.EXPECT().PopLikesDeltasCtx(gomock.Any(), likesDeltasKey).Return(map[uuid.UUID]int64{}, nil)
+
+		require.NoError(t, commentUC.FlushLikes(context.Background()))
+	})
+
+	t.Run("Recount fails", func(t *testing.T) {
+		mockRedisRepo.EXPECT().PopLikesDeltasCtx(gomock.Any(), likesDeltasKey).Return(map[uuid.UUID]int64{commentUID: 3}, nil)
+		mockUserCommentRepo.EXPECT().RecountLikes(gomock.Any(), []uuid.UUID{commentUID}).Return(errors.New("db is down"))
+		mockRedisRepo.EXPECT().IncrLikesDeltaCtx(gomock.Any(), likesDeltasKey, commentUID, int64(3)).Return(nil)
+
+		require.Error(t, commentUC.FlushLikes(context.Background()))
+	})
+
+	t.Run("Reconcile does not take buffered changes", func(t *testing.T) {
+		mockUserCommentRepo.EXPECT().ReconcileLikesCounts(gomock.Any()).Return(int64(1), nil)
+
+		require.NoError(t, commentUC.ReconcileLikes(context.Background()))
+	})
+}
+
+func TestCommentUseCase_CreateReply(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Comment: config.CommentConfig{
+			MaxReplyDepth: 2,
+		},
+		Logger: config.LoggerConfig{
+			Development: true,
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	apiLogger.InitLogger()
+	mockCommentRepo := mock.NewMockRepository(ctrl)
+	mockFilter := filterMock.NewMockContentFilter(ctrl)
+	mockNotificationTD := notificationMock.NewMockNotificationTaskDistributor(ctrl)
+	mockBlogEventUC := blogEventMock.NewMockUseCase(ctrl)
+	commentUC := NewCommentUseCase(cfg, mockCommentRepo, nil, nil, nil, mockFilter, mockNotificationTD, mockBlogEventUC, apiLogger)
+
+	userUID := uuid.New()
+	blogUID := uuid.New()
+	ctx := context.WithValue(context.Background(), "user_id", userUID.String())
+
+	t.Run("Reply at max depth", func(t *testing.T) {
+		parent := &models.CommentBase{CommentID: uuid.New(), BlogID: blogUID, Depth: 1}
+		reply := &models.Comment{ParentID: &parent.CommentID, Message: "reply to parent comment"}
+
+		mockCommentRepo.EXPECT().IsBanned(gomock.Any(), gomock.Eq(userUID)).Return(false, nil)
+		mockCommentRepo.EXPECT().GetByID(gomock.Any(), gomock.Eq(parent.CommentID)).Return(parent, nil)
+		mockCommentRepo.EXPECT().GetBlogAccess(gomock.Any(), gomock.Eq(blogUID)).Return(&models.BlogAccess{BlogID: blogUID, Status: models.BlogStatusPublished}, nil)
+		mockFilter.EXPECT().Check(gomock.Any(), gomock.Any()).Return(&models.FilterDecision{Action: models.FilterAllow, Texts: []string{reply.Message}}, nil)
+		mockCommentRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, c *models.Comment) (*models.Comment, error) {
+				require.Equal(t, blogUID, c.BlogID)
+				require.Equal(t, 2, c.Depth)
+				return c, nil
+			})
+		mockFilter.EXPECT().Record(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		mockBlogEventUC.EXPECT().Publish(gomock.Any(), gomock.Eq(blogUID), gomock.Eq(models.BlogEventCommentCreated), gomock.Any()).Return(nil)
+		mockNotificationTD.EXPECT().DistributeTaskNotify(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+		createdComment, err := commentUC.Create(ctx, reply)
+		require.NoError(t, err)
+		require.Equal(t, 2, createdComment.Depth)
+	})
+
+	t.Run("Reply too deep", func(t *testing.T) {
+		parent := &models.CommentBase{CommentID: uuid.New(), BlogID: blogUID, Depth: 2}
+
+		mockCommentRepo.EXPECT().IsBanned(gomock.Any(), gomock.Eq(userUID)).Return(false, nil)
+		mockCommentRepo.EXPECT().GetByID(gomock.Any(), gomock.Eq(parent.CommentID)).Return(parent, nil)
+
+		createdComment, err := commentUC.Create(ctx, &models.Comment{ParentID: &parent.CommentID, Message: "reply to parent comment"})
+		require.Nil(t, createdComment)
+		require.Equal(t, http.StatusBadRequest, httpErrors.ParseErrors(err).Status())
+	})
+
+	t.Run("Parent of other blog", func(t *testing.T) {
+		parent := &models.CommentBase{CommentID: uuid.New(), BlogID: blogUID}
+
+		mockCommentRepo.EXPECT().IsBanned(gomock.Any(), gomock.Eq(userUID)).Return(false, nil)
+		mockCommentRepo.EXPECT().GetByID(gomock.Any(), gomock.Eq(parent.CommentID)).Return(parent, nil)
+
+		createdComment, err := commentUC.Create(ctx, &models.Comment{BlogID: uuid.New(), ParentID: &parent.CommentID, Message: "reply to parent comment"})
+		require.Nil(t, createdComment)
+		require.Equal(t, http.StatusBadRequest, httpErrors.ParseErrors(err).Status())
+	})
+
+	t.Run("Deleted parent", func(t *testing.T) {
+		parent := &models.CommentBase{CommentID: uuid.New(), BlogID: blogUID, Deleted: true}
+
+		mockCommentRepo.EXPECT().IsBanned(gomock.Any(), gomock.Eq(userUID)).Return(false, nil)
+		mockCommentRepo.EXPECT().GetByID(gomock.Any(), gomock.Eq(parent.CommentID)).Return(parent, nil)
+
+		createdComment, err := commentUC.Create(ctx, &models.Comment{ParentID: &parent.CommentID, Message: "reply to parent comment"})
+		require.Nil(t, createdComment)
+		require.Equal(t, http.StatusBadRequest, httpErrors.ParseErrors(err).Status())
+	})
+}
+
+func TestCommentUseCase_CreateReplyDefaultDepth(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Logger: config.LoggerConfig{
+			Development: true,
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	apiLogger.InitLogger()
+	mockCommentRepo := mock.NewMockRepository(ctrl)
+	commentUC := NewCommentUseCase(cfg, mockCommentRepo, nil, nil, nil, nil, nil, nil, apiLogger)
+
+	userUID := uuid.New()
+	ctx := context.WithValue(context.Background(), "user_id", userUID.String())
+	parent := &models.CommentBase{CommentID: uuid.New(), BlogID: uuid.New(), Depth: defaultMaxReplyDepth}
+
+	mockCommentRepo.EXPECT().IsBanned(gomock.Any(), gomock.Eq(userUID)).Return(false, nil)
+	mockCommentRepo.EXPECT().GetByID(gomock.Any(), gomock.Eq(parent.CommentID)).Return(parent, nil)
+
+	createdComment, err := commentUC.Create(ctx, &models.Comment{ParentID: &parent.CommentID, Message: "reply to parent comment"})
+	require.Nil(t, createdComment)
+	require.Equal(t, http.StatusBadRequest, httpErrors.ParseErrors(err).Status())
+}
+
+func TestCommentUseCase_Delete(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Logger: config.LoggerConfig{
+			Development: true,
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	apiLogger.InitLogger()
+	mockCommentRepo := mock.NewMockRepository(ctrl)
+	mockBlogEventUC := blogEventMock.NewMockUseCase(ctrl)
+	commentUC := NewCommentUseCase(cfg, mockCommentRepo, nil, nil, nil, nil, nil, mockBlogEventUC, apiLogger)
+
+	userUID := uuid.New()
+	ctx := context.WithValue(context.Background(), "user_id", userUID.String())
+
+	t.Run("Owner", func(t *testing.T) {
+		commentByID := &models.CommentBase{CommentID: uuid.New(), AuthorID: userUID, BlogID: uuid.New(), Version: 2}
+
+		mockCommentRepo.EXPECT().GetByID(gomock.Any(), gomock.Eq(commentByID.CommentID)).Return(commentByID, nil)
+		mockCommentRepo.EXPECT().Delete(gomock.Any(), gomock.Eq(commentByID.CommentID), gomock.Eq(2)).Return(nil)
+		mockBlogEventUC.EXPECT().Publish(gomock.Any(), gomock.Eq(commentByID.BlogID), gomock.Eq(models.BlogEventCommentDeleted), gomock.Any()).Return(nil)
+
+		require.NoError(t, commentUC.Delete(ctx, commentByID.CommentID, 2))
+	})
+
+	t.Run("Tombstone", func(t *testing.T) {
+		commentByID := &models.CommentBase{CommentID: uuid.New(), AuthorID: userUID, Version: 3, Deleted: true}
+
+		mockCommentRepo.EXPECT().GetByID(gomock.Any(), gomock.Eq(commentByID.CommentID)).Return(commentByID, nil)
+
+		err := commentUC.Delete(ctx, commentByID.CommentID, 3)
+		require.Equal(t, http.StatusNotFound, httpErrors.ParseErrors(err).Status())
+	})
+
+	t.Run("Changed meanwhile", func(t *testing.T) {
+		commentByID := &models.CommentBase{CommentID: uuid.New(), AuthorID: userUID, Version: 2}
+
+		mockCommentRepo.EXPECT().GetByID(gomock.Any(), gomock.Eq(commentByID.CommentID)).Return(commentByID, nil)
+		mockCommentRepo.EXPECT().Delete(gomock.Any(), gomock.Eq(commentByID.CommentID), gomock.Eq(2)).Return(sql.ErrNoRows)
+
+		err := commentUC.Delete(ctx, commentByID.CommentID, 2)
+		require.Equal(t, http.StatusPreconditionFailed, httpErrors.ParseErrors(err).Status())
+	})
+
+	t.Run("NotOwner", func(t *testing.T) {
+		commentByID := &models.CommentBase{CommentID: uuid.New(), AuthorID: uuid.New(), Version: 2}
+
+		mockCommentRepo.EXPECT().GetByID(gomock.Any(), gomock.Eq(commentByID.CommentID)).Return(commentByID, nil)
+
+		err := commentUC.Delete(ctx, commentByID.CommentID, 2)
+		require.Equal(t, http.StatusForbidden, httpErrors.ParseErrors(err).Status())
+	})
+
+	t.Run("Moderator", func(t *testing.T) {
+		commentByID := &models.CommentBase{CommentID: uuid.New(), AuthorID: uuid.New(), BlogID: uuid.New(), Version: 2}
+		moderatorCtx := context.WithValue(ctx, "role", rbac.RoleModerator)
+
+		mockCommentRepo.EXPECT().GetByID(gomock.Any(), gomock.Eq(commentByID.CommentID)).Return(commentByID, nil)
+		mockCommentRepo.EXPECT().Delete(gomock.Any(), gomock.Eq(commentByID.CommentID), gomock.Eq(0)).Return(nil)
+		mockBlogEventUC.EXPECT().Publish(gomock.Any(), gomock.Eq(commentByID.BlogID), gomock.Eq(models.BlogEventCommentDeleted), gomock.Any()).Return(nil)
+
+		require.NoError(t, commentUC.Delete(moderatorCtx, commentByID.CommentID, 0))
+	})
+}
+
+func TestCommentUseCase_ListTree(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Logger: config.LoggerConfig{
+			Development: true,
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	apiLogger.InitLogger()
+	mockCommentRepo := mock.NewMockRepository(ctrl)
+	mockReactionRepo := reactionMock.NewMockRepository(ctrl)
+	commentUC := NewCommentUseCase(cfg, mockCommentRepo, nil, nil, mockReactionRepo, nil, nil, nil, apiLogger)
+
+	blogUID := uuid.New()
+	first := &models.CommentBase{CommentID: uuid.New(), BlogID: blogUID}
+	second := &models.CommentBase{CommentID: uuid.New(), BlogID: blogUID}
+	firstReply := &models.CommentBase{CommentID: uuid.New(), BlogID: blogUID, ParentID: &first.CommentID, Depth: 1}
+	nestedReply := &models.CommentBase{CommentID: uuid.New(), BlogID: blogUID, ParentID: &firstReply.CommentID, Depth: 2}
+	secondReply := &models.CommentBase{CommentID: uuid.New(), BlogID: blogUID, ParentID: &second.CommentID, Depth: 1}
+	lastReply := &models.CommentBase{CommentID: uuid.New(), BlogID: blogUID, ParentID: &first.CommentID, Depth: 1}
+
+	pq := &utils.PaginationQuery{Size: 10, Page: 1}
+	mockCommentRepo.EXPECT().GetBlogAccess(gomock.Any(), gomock.Eq(blogUID)).Return(&models.BlogAccess{BlogID: blogUID, Status: models.BlogStatusPublished}, nil)
+	mockCommentRepo.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Eq(pq)).DoAndReturn(
+		func(_ context.Context, filter *models.CommentFilter, _ *utils.PaginationQuery) (*models.CommentsList, error) {
+			require.True(t, filter.RootsOnly)
+			return &models.CommentsList{Comments: []*models.CommentBase{first, second}}, nil
+		})
+	mockCommentRepo.EXPECT().ListDescendants(gomock.Any(), gomock.Eq([]uuid.UUID{first.CommentID, second.CommentID}), gomock.Any()).
+		Return([]*models.CommentBase{firstReply, nestedReply, secondReply, lastReply}, nil)
+	mockReactionRepo.EXPECT().Summaries(gomock.Any(), gomock.Eq(models.ReactionTargetComment), gomock.Any(), gomock.Any()).
+		Return(map[uuid.UUID]*models.ReactionSummary{}, nil)
+
+	commentsList, err := commentUC.List(context.Background(), blogUID, models.CommentsListTree, pq)
+	require.NoError(t, err)
+	require.Equal(t, []*models.CommentBase{first, second}, commentsList.Comments)
+	require.Equal(t, []*models.CommentBase{firstReply, lastReply}, first.Replies)
+	require.Equal(t, []*models.CommentBase{nestedReply}, firstReply.Replies)
+	require.Equal(t, []*models.CommentBase{secondReply}, second.Replies)
+	require.Empty(t, lastReply.Replies)
+}
+
+func TestNestReplies(t *testing.T) {
+	t.Parallel()
+
+	root := &models.CommentBase{CommentID: uuid.New()}
+	missingParent := uuid.New()
+	reply := &models.CommentBase{CommentID: uuid.New(), ParentID: &root.CommentID}
+	orphan := &models.CommentBase{CommentID: uuid.New(), ParentID: &missingParent}
+
+	nestReplies([]*models.CommentBase{root}, []*models.CommentBase{reply, orphan})
+
+	require.Equal(t, []*models.CommentBase{reply}, root.Replies)
+	require.Empty(t, orphan.Replies)
+}
+
+func TestCommentUseCase_Moderate(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Logger: config.LoggerConfig{
+			Development: true,
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	apiLogger.InitLogger()
+	mockCommentRepo := mock.NewMockRepository(ctrl)
+	mockReactionRepo := reactionMock.NewMockRepository(ctrl)
+	mockNotificationTD := notificationMock.NewMockNotificationTaskDistributor(ctrl)
+	mockBlogEventUC := blogEventMock.NewMockUseCase(ctrl)
+	commentUC := NewCommentUseCase(cfg, mockCommentRepo, nil, nil, mockReactionRepo, nil, mockNotificationTD, mockBlogEventUC, apiLogger)
+
+	ctx := context.WithValue(context.Background(), "user_id", uuid.New().String())
+	ctx = context.WithValue(ctx, "role", rbac.RoleModerator)
+
+	t.Run("Approve hidden comment", func(t *testing.T) {
+		heldComment := &models.CommentBase{CommentID: uuid.New(), AuthorID: uuid.New(), BlogID: uuid.New(), Hidden: true}
+		approvedComment := *heldComment
+		approvedComment.Hidden = false
+
+		mockCommentRepo.EXPECT().GetByID(gomock.Any(), gomock.Eq(heldComment.CommentID)).Return(heldComment, nil)
+		mockCommentRepo.EXPECT().Moderate(gomock.Any(), gomock.Any()).Return(nil)
+		mockCommentRepo.EXPECT().GetByID(gomock.Any(), gomock.Eq(heldComment.CommentID)).Return(&approvedComment, nil)
+		mockBlogEventUC.EXPECT().Publish(gomock.Any(), gomock.Eq(heldComment.BlogID), gomock.Eq(models.BlogEventCommentCreated), gomock.Any()).Return(nil)
+		mockNotificationTD.EXPECT().DistributeTaskNotify(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, payload *notificationAsynq.NotifyPayload, _ ...asynq.Option) error {
+				require.Equal(t, models.EventCommentCreated, payload.Type)
+				require.Equal(t, heldComment.AuthorID, payload.ActorID)
+				require.Equal(t, heldComment.CommentID, payload.CommentID)
+				return nil
+			})
+		mockReactionRepo.EXPECT().Summaries(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(map[uuid.UUID]*models.ReactionSummary{}, nil)
+
+		moderatedComment, err := commentUC.Moderate(ctx, heldComment.CommentID, models.ModerationApprove)
+		require.NoError(t, err)
+		require.False(t, moderatedComment.Hidden)
+	})
+
+	t.Run("Approve visible comment", func(t *testing.T) {
+		visibleComment := &models.CommentBase{CommentID: uuid.New(), AuthorID: uuid.New(), BlogID: uuid.New()}
+
+		mockCommentRepo.EXPECT().GetByID(gomock.Any(), gomock.Eq(visibleComment.CommentID)).Return(visibleComment, nil).Times(2)
+		mockCommentRepo.EXPECT().Moderate(gomock.Any(), gomock.Any()).Return(nil)
+		mockReactionRepo.EXPECT().Summaries(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(map[uuid.UUID]*models.ReactionSummary{}, nil)
+
+		_, err := commentUC.Moderate(ctx, visibleComment.CommentID, models.ModerationApprove)
+		require.NoError(t, err)
+	})
+
+	t.Run("Reject hidden comment", func(t *testing.T) {
+		heldComment := &models.CommentBase{CommentID: uuid.New(), AuthorID: uuid.New(), BlogID: uuid.New(), Hidden: true}
+
+		mockCommentRepo.EXPECT().GetByID(gomock.Any(), gomock.Eq(heldComment.CommentID)).Return(heldComment, nil).Times(2)
+		mockCommentRepo.EXPECT().Moderate(gomock.Any(), gomock.Any()).Return(nil)
+		mockReactionRepo.EXPECT().Summaries(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(map[uuid.UUID]*models.ReactionSummary{}, nil)
+
+		_, err := commentUC.Moderate(ctx, heldComment.CommentID, models.ModerationReject)
+		require.NoError(t, err)
+	})
+}
+
+func TestCommentUseCase_UnpublishedBlog(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Logger: config.LoggerConfig{
+			Development: true,
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	apiLogger.InitLogger()
+	mockCommentRepo := mock.NewMockRepository(ctrl)
+	mockReactionRepo := reactionMock.NewMockRepository(ctrl)
+	commentUC := NewCommentUseCase(cfg, mockCommentRepo, nil, nil, mockReactionRepo, nil, nil, nil, apiLogger)
+
+	authorUID := uuid.New()
+	userUID := uuid.New()
+	ctx := context.WithValue(context.Background(), "user_id", userUID.String())
+	draft := &models.BlogAccess{BlogID: uuid.New(), AuthorID: authorUID, Status: models.BlogStatusDraft}
+	scheduled := &models.BlogAccess{BlogID: uuid.New(), AuthorID: authorUID, Status: models.BlogStatusScheduled}
+	pq := &utils.PaginationQuery{Size: 10, Page: 1}
+
+	t.Run("Create on draft", func(t *testing.T) {
+		mockCommentRepo.EXPECT().IsBanned(gomock.Any(), gomock.Eq(userUID)).Return(false, nil)
+		mockCommentRepo.EXPECT().GetBlogAccess(gomock.Any(), gomock.Eq(draft.BlogID)).Return(draft, nil)
+
+		createdComment, err := commentUC.Create(ctx, &models.Comment{BlogID: draft.BlogID, Message: "comment on draft blog"})
+		require.Nil(t, createdComment)
+		require.Equal(t, http.StatusNotFound, httpErrors.ParseErrors(err).Status())
+	})
+
+	t.Run("List of scheduled", func(t *testing.T) {
+		mockCommentRepo.EXPECT().GetBlogAccess(gomock.Any(), gomock.Eq(scheduled.BlogID)).Return(scheduled, nil)
+
+		commentsList, err := commentUC.List(ctx, scheduled.BlogID, "", pq)
+		require.Nil(t, commentsList)
+		require.Equal(t, http.StatusNotFound, httpErrors.ParseErrors(err).Status())
+	})
+
+	t.Run("Anonymous list of draft", func(t *testing.T) {
+		mockCommentRepo.EXPECT().GetBlogAccess(gomock.Any(), gomock.Eq(draft.BlogID)).Return(draft, nil)
+
+		commentsList, err := commentUC.List(context.Background(), draft.BlogID, "", pq)
+		require.Nil(t, commentsList)
+		require.Equal(t, http.StatusNotFound, httpErrors.ParseErrors(err).Status())
+	})
+
+	t.Run("Author lists scheduled", func(t *testing.T) {
+		authorCtx := context.WithValue(context.Background(), "user_id", authorUID.String())
+
+		mockCommentRepo.EXPECT().GetBlogAccess(gomock.Any(), gomock.Eq(scheduled.BlogID)).Return(scheduled, nil)
+		mockCommentRepo.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Eq(pq)).Return(&models.CommentsList{Comments: []*models.CommentBase{}}, nil)
+		mockReactionRepo.EXPECT().Summaries(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(map[uuid.UUID]*models.ReactionSummary{}, nil)
+
+		commentsList, err := commentUC.List(authorCtx, scheduled.BlogID, "", pq)
+		require.NoError(t, err)
+		require.NotNil(t, commentsList)
+	})
+
+	t.Run("Admin lists draft", func(t *testing.T) {
+		adminCtx := context.WithValue(ctx, "role", rbac.RoleAdmin)
+
+		mockCommentRepo.EXPECT().GetBlogAccess(gomock.Any(), gomock.Eq(draft.BlogID)).Return(draft, nil)
+		mockCommentRepo.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Eq(pq)).Return(&models.CommentsList{Comments: []*models.CommentBase{}}, nil)
+		mockReactionRepo.EXPECT().Summaries(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(map[uuid.UUID]*models.ReactionSummary{}, nil)
+
+		_, err := commentUC.List(adminCtx, draft.BlogID, "", pq)
+		require.NoError(t, err)
+	})
+
+	t.Run("Like on draft", func(t *testing.T) {
+		commentByID := &models.CommentBase{CommentID: uuid.New(), AuthorID: authorUID, BlogID: draft.BlogID}
+
+		mockCommentRepo.EXPECT().GetByID(gomock.Any(), gomock.Eq(commentByID.CommentID)).Return(commentByID, nil)
+		mockCommentRepo.EXPECT().GetBlogAccess(gomock.Any(), gomock.Eq(draft.BlogID)).Return(draft, nil)
+
+		likeState, err := commentUC.Like(ctx, &models.UserComments{UserID: userUID, CommentID: commentByID.CommentID})
+		require.Nil(t, likeState)
+		require.Equal(t, http.StatusNotFound, httpErrors.ParseErrors(err).Status())
+	})
+
+	t.Run("Report on scheduled", func(t *testing.T) {
+		commentByID := &models.CommentBase{CommentID: uuid.New(), AuthorID: authorUID, BlogID: scheduled.BlogID}
+
+		mockCommentRepo.EXPECT().GetByID(gomock.Any(), gomock.Eq(commentByID.CommentID)).Return(commentByID, nil)
+		mockCommentRepo.EXPECT().GetBlogAccess(gomock.Any(), gomock.Eq(scheduled.BlogID)).Return(scheduled, nil)
+
+		report, err := commentUC.Report(ctx, &models.CommentReport{CommentID: commentByID.CommentID, Reason: "spam"})
+		require.Nil(t, report)
+		require.Equal(t, http.StatusNotFound, httpErrors.ParseErrors(err).Status())
+	})
+
+	t.Run("Replies on held", func(t *testing.T) {
+		held := &models.BlogAccess{BlogID: uuid.New(), AuthorID: authorUID, Status: models.BlogStatusDraft, Held: true}
+		parent := &models.CommentBase{CommentID: uuid.New(), AuthorID: authorUID, BlogID: held.BlogID}
+
+		mockCommentRepo.EXPECT().GetByID(gomock.Any(), gomock.Eq(parent.CommentID)).Return(parent, nil)
+		mockCommentRepo.EXPECT().GetBlogAccess(gomock.Any(), gomock.Eq(held.BlogID)).Return(held, nil)
+
+		repliesList, err := commentUC.ListReplies(ctx, parent.CommentID, pq)
+		require.Nil(t, repliesList)
+		require.Equal(t, http.StatusNotFound, httpErrors.ParseErrors(err).Status())
+	})
+}
+
+func TestCommentUseCase_UpdateBanned(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Logger: config.LoggerConfig{
+			Development: true,
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	apiLogger.InitLogger()
+	mockCommentRepo := mock.NewMockRepository(ctrl)
+	mockFilter := filterMock.NewMockContentFilter(ctrl)
+	mockBlogEventUC := blogEventMock.NewMockUseCase(ctrl)
+	commentUC := NewCommentUseCase(cfg, mockCommentRepo, nil, nil, nil, mockFilter, nil, mockBlogEventUC, apiLogger)
+
+	authorUID := uuid.New()
+	ctx := context.WithValue(context.Background(), "user_id", authorUID.String())
+
+	t.Run("Banned author", func(t *testing.T) {
+		commentByID := &models.CommentBase{CommentID: uuid.New(), AuthorID: authorUID, BlogID: uuid.New()}
+
+		mockCommentRepo.EXPECT().GetByID(gomock.Any(), gomock.Eq(commentByID.CommentID)).Return(commentByID, nil)
+		mockCommentRepo.EXPECT().IsBanned(gomock.Any(), gomock.Eq(authorUID)).Return(true, nil)
+
+		updatedComment, err := commentUC.Update(ctx, &models.CommentBase{CommentID: commentByID.CommentID, Message: "edited after ban"})
+		require.Nil(t, updatedComment)
+		require.Equal(t, http.StatusForbidden, httpErrors.ParseErrors(err).Status())
+	})
+
+	t.Run("Author who is not banned", func(t *testing.T) {
+		commentByID := &models.CommentBase{CommentID: uuid.New(), AuthorID: authorUID, BlogID: uuid.New(), Version: 1}
+		comment := &models.CommentBase{CommentID: commentByID.CommentID, Message: "edited message"}
+
+		mockCommentRepo.EXPECT().GetByID(gomock.Any(), gomock.Eq(commentByID.CommentID)).Return(commentByID, nil)
+		mockCommentRepo.EXPECT().IsBanned(gomock.Any(), gomock.Eq(authorUID)).Return(false, nil)
+		mockFilter.EXPECT().Check(gomock.Any(), gomock.Any()).Return(&models.FilterDecision{Action: models.FilterAllow, Texts: []string{comment.Message}}, nil)
+		mockCommentRepo.EXPECT().Update(gomock.Any(), gomock.Eq(comment)).Return(&models.CommentBase{
+			CommentID: comment.CommentID, AuthorID: authorUID, BlogID: commentByID.BlogID, Message: comment.Message, Version: 2,
+		}, nil)
+		mockFilter.EXPECT().Record(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		mockBlogEventUC.EXPECT().Publish(gomock.Any(), gomock.Eq(commentByID.BlogID), gomock.Eq(models.BlogEventCommentUpdated), gomock.Any()).Return(nil)
+
+		updatedComment, err := commentUC.Update(ctx, comment)
+		require.NoError(t, err)
+		require.Equal(t, 2, updatedComment.Version)
+	})
+}
@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: redis_repo.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRedisRepository is a mock of RedisRepository interface.
+type MockRedisRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRedisRepositoryMockRecorder
+}
+
+// MockRedisRepositoryMockRecorder is the mock recorder for MockRedisRepository.
+type MockRedisRepositoryMockRecorder struct {
+	mock *MockRedisRepository
+}
+
+// NewMockRedisRepository creates a new mock instance.
+func NewMockRedisRepository(ctrl *gomock.Controller) *MockRedisRepository {
+	mock := &MockRedisRepository{ctrl: ctrl}
+	mock.recorder = &MockRedisRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRedisRepository) EXPECT() *MockRedisRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetFingerprintTargetCtx mocks base method.
+func (m *MockRedisRepository) GetFingerprintTargetCtx(ctx context.Context, key string) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFingerprintTargetCtx", ctx, key)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFingerprintTargetCtx indicates an expected call of GetFingerprintTargetCtx.
+func (mr *MockRedisRepositoryMockRecorder) GetFingerprintTargetCtx(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFingerprintTargetCtx", reflect.TypeOf((*MockRedisRepository)(nil).GetFingerprintTargetCtx), ctx, key)
+}
+
+// SetFingerprintTargetCtx mocks base method.
+func (m *MockRedisRepository) SetFingerprintTargetCtx(ctx context.Context, key string, seconds int, targetID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetFingerprintTargetCtx", ctx, key, seconds, targetID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetFingerprintTargetCtx indicates an expected call of SetFingerprintTargetCtx.
+func (mr *MockRedisRepositoryMockRecorder) SetFingerprintTargetCtx(ctx, key, seconds, targetID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFingerprintTargetCtx", reflect.TypeOf((*MockRedisRepository)(nil).SetFingerprintTargetCtx), ctx, key, seconds, targetID)
+}
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	models "github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockContentFilter is a mock of ContentFilter interface.
+type MockContentFilter struct {
+	ctrl     *gomock.Controller
+	recorder *MockContentFilterMockRecorder
+}
+
+// MockContentFilterMockRecorder is the mock recorder for MockContentFilter.
+type MockContentFilterMockRecorder struct {
+	mock *MockContentFilter
+}
+
+// NewMockContentFilter creates a new mock instance.
+func NewMockContentFilter(ctrl *gomock.Controller) *MockContentFilter {
+	mock := &MockContentFilter{ctrl: ctrl}
+	mock.recorder = &MockContentFilterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockContentFilter) EXPECT() *MockContentFilterMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockContentFilter) Check(ctx context.Context, input *models.FilterInput) (*models.FilterDecision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", ctx, input)
+	ret0, _ := ret[0].(*models.FilterDecision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockContentFilterMockRecorder) Check(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockContentFilter)(nil).Check), ctx, input)
+}
+
+// Record mocks base method.
+func (m *MockContentFilter) Record(ctx context.Context, decision *models.FilterDecision, targetID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", ctx, decision, targetID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockContentFilterMockRecorder) Record(ctx, decision, targetID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockContentFilter)(nil).Record), ctx, decision, targetID)
+}
@@ -0,0 +1,11 @@
+//go:generate mockgen -source pg_repo.go -destination mock/pg_repo_mock.go -package mock
+package content_filter
+
+import (
+	"context"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+)
+
+type Repository interface {
+	CreateDecision(ctx context.Context, decision *models.FilterDecision) error
+}
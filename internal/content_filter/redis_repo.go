@@ -0,0 +1,12 @@
+//go:generate mockgen -source redis_repo.go -destination mock/redis_repo_mock.go -package mock
+package content_filter
+
+import (
+	"context"
+	"github.com/google/uuid"
+)
+
+type RedisRepository interface {
+	GetFingerprintTargetCtx(ctx context.Context, key string) (uuid.UUID, error)
+	SetFingerprintTargetCtx(ctx context.Context, key string, seconds int, targetID uuid.UUID) error
+}
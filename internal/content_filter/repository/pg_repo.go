@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/jmoiron/sqlx"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/content_filter"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+)
+
+type contentFilterRepo struct {
+	db *sqlx.DB
+}
+
+func NewContentFilterRepository(db *sqlx.DB) content_filter.Repository {
+	return &contentFilterRepo{db: db}
+}
+
+// CreateDecision write decision to audit log, matched rules are stored as json
+func (r *contentFilterRepo) CreateDecision(ctx context.Context, decision *models.FilterDecision) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "contentFilterRepo.CreateDecision")
+	defer span.Finish()
+
+	matches, err := json.Marshal(decision.Matches)
+	if err != nil {
+		return errors.Wrap(err, "contentFilterRepo.CreateDecision.json.Marshal")
+	}
+
+	if err = r.db.QueryRowxContext(ctx, createDecisionQuery, decision.TargetType, decision.TargetID, decision.AuthorID, decision.Action, string(matches)).
+		Scan(&decision.DecisionID, &decision.CreatedAt); err != nil {
+		return errors.Wrap(err, "contentFilterRepo.CreateDecision.Scan")
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/content_filter"
+	"time"
+)
+
+type contentFilterRedisRepo struct {
+	rdb *redis.Client
+}
+
+func NewContentFilterRedisRepository(rdb *redis.Client) content_filter.RedisRepository {
+	return &contentFilterRedisRepo{rdb: rdb}
+}
+
+func (r *contentFilterRedisRepo) GetFingerprintTargetCtx(ctx context.Context, key string) (uuid.UUID, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "contentFilterRedisRepo.GetFingerprintTargetCtx")
+	defer span.Finish()
+
+	targetID, err := r.rdb.Get(ctx, key).Result()
+	if err != nil {
+		return uuid.Nil, errors.Wrap(err, "contentFilterRedisRepo.GetFingerprintTargetCtx.redisClient.Get")
+	}
+
+	targetUID, err := uuid.Parse(targetID)
+	if err != nil {
+		return uuid.Nil, errors.Wrap(err, "contentFilterRedisRepo.GetFingerprintTargetCtx.uuid.Parse")
+	}
+
+	return targetUID, nil
+}
+
+func (r *contentFilterRedisRepo) SetFingerprintTargetCtx(ctx context.Context, key string, seconds int, targetID uuid.UUID) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "contentFilterRedisRepo.SetFingerprintTargetCtx")
+	defer span.Finish()
+
+	if err := r.rdb.Set(ctx, key, targetID.String(), time.Second*time.Duration(seconds)).Err(); err != nil {
+		return errors.Wrap(err, "contentFilterRedisRepo.SetFingerprintTargetCtx.redisClient.Set")
+	}
+
+	return nil
+}
@@ -0,0 +1,6 @@
+package repository
+
+const (
+	createDecisionQuery = `INSERT INTO content_filter_decisions (target_type, target_id, author_id, action, matches)
+						VALUES ($1, $2, $3, $4, $5) RETURNING decision_id, created_at`
+)
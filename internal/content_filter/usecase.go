@@ -0,0 +1,16 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase_mock.go -package mock
+package content_filter
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+)
+
+// ContentFilter checks content of comments and blogs before it is stored
+type ContentFilter interface {
+	// Check content against rules, rejected content is recorded and returned as error
+	Check(ctx context.Context, input *models.FilterInput) (*models.FilterDecision, error)
+	// Record decision of content stored as target
+	Record(ctx context.Context, decision *models.FilterDecision, targetID uuid.UUID) error
+}
@@ -0,0 +1,236 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/content_filter"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// linkPattern matches links with scheme and bare links starting with www
+var linkPattern = regexp.MustCompile(`(?i)\b(?:https?://|www\.)[^\s<>()"']+`)
+
+const removedLink = "[link removed]"
+
+// rule detects one kind of unwanted content
+type rule interface {
+	name() string
+	// match return reason input matched rule for, empty reason is no match
+	match(ctx context.Context, input *models.FilterInput) (string, error)
+	// mask replace matched parts of text, ok is false for rule which can not mask
+	mask(text string) (masked string, ok bool)
+}
+
+// wordListRule matches banned words as whole words ignoring case
+type wordListRule struct {
+	words map[string]struct{}
+}
+
+func newWordListRule(words []string) *wordListRule {
+	r := &wordListRule{words: make(map[string]struct{}, len(words))}
+	for _, word := range words {
+		if word = strings.ToLower(strings.TrimSpace(word)); word != "" {
+			r.words[word] = struct{}{}
+		}
+	}
+	return r
+}
+
+func (r *wordListRule) name() string {
+	return "banned_words"
+}
+
+func (r *wordListRule) match(_ context.Context, input *models.FilterInput) (string, error) {
+	count := 0
+	for _, text := range input.Texts {
+		count += len(r.bannedSpans(text))
+	}
+	if count == 0 {
+		return "", nil
+	}
+
+	return fmt.Sprintf("contains %d banned words", count), nil
+}
+
+func (r *wordListRule) mask(text string) (string, bool) {
+	spans := r.bannedSpans(text)
+	if len(spans) == 0 {
+		return text, true
+	}
+
+	var sb strings.Builder
+	last := 0
+	for _, span := range spans {
+		sb.WriteString(text[last:span[0]])
+		sb.WriteString(strings.Repeat("*", utf8.RuneCountInString(text[span[0]:span[1]])))
+		last = span[1]
+	}
+	sb.WriteString(text[last:])
+
+	return sb.String(), true
+}
+
+// bannedSpans return byte offsets of banned words of text
+func (r *wordListRule) bannedSpans(text string) [][2]int {
+	spans := make([][2]int, 0)
+	if len(r.words) == 0 {
+		return spans
+	}
+
+	start := -1
+	for i, c := range text + " " {
+		isWordChar := unicode.IsLetter(c) || unicode.IsDigit(c)
+		if isWordChar && start < 0 {
+			start = i
+		}
+		if !isWordChar && start >= 0 {
+			if _, banned := r.words[strings.ToLower(text[start:i])]; banned {
+				spans = append(spans, [2]int{start, i})
+			}
+			start = -1
+		}
+	}
+
+	return spans
+}
+
+// linksRule matches content with more links than allowed
+type linksRule struct {
+	max int
+}
+
+func (r *linksRule) name() string {
+	return "links"
+}
+
+func (r *linksRule) match(_ context.Context, input *models.FilterInput) (string, error) {
+	count := 0
+	for _, text := range input.Texts {
+		count += len(linkPattern.FindAllStringIndex(text, -1))
+	}
+	if count <= r.max {
+		return "", nil
+	}
+
+	return fmt.Sprintf("contains %d links, at most %d are allowed", count, r.max), nil
+}
+
+func (r *linksRule) mask(text string) (string, bool) {
+	return linkPattern.ReplaceAllString(text, removedLink), true
+}
+
+// repeatedCharsRule matches runs of one character longer than allowed, whitespace is not counted
+type repeatedCharsRule struct {
+	max int
+}
+
+func (r *repeatedCharsRule) name() string {
+	return "repeated_chars"
+}
+
+func (r *repeatedCharsRule) match(_ context.Context, input *models.FilterInput) (string, error) {
+	for _, text := range input.Texts {
+		if r.longestRun(text) > r.max {
+			return fmt.Sprintf("repeats character more than %d times", r.max), nil
+		}
+	}
+
+	return "", nil
+}
+
+func (r *repeatedCharsRule) mask(text string) (string, bool) {
+	var sb strings.Builder
+	var prev rune
+	run := 0
+	for _, c := range text {
+		if c == prev {
+			run++
+		} else {
+			prev, run = c, 1
+		}
+		if run <= r.max || unicode.IsSpace(c) {
+			sb.WriteRune(c)
+		}
+	}
+
+	return sb.String(), true
+}
+
+func (r *repeatedCharsRule) longestRun(text string) int {
+	var prev rune
+	run, longest := 0, 0
+	for _, c := range text {
+		if c == prev {
+			run++
+		} else {
+			prev, run = c, 1
+		}
+		if run > longest && !unicode.IsSpace(c) {
+			longest = run
+		}
+	}
+
+	return longest
+}
+
+// duplicateRule matches texts author posted recently as other comment or blog
+type duplicateRule struct {
+	redisRepo content_filter.RedisRepository
+}
+
+func (r *duplicateRule) name() string {
+	return "duplicate"
+}
+
+func (r *duplicateRule) match(ctx context.Context, input *models.FilterInput) (string, error) {
+	fp := fingerprint(input)
+	if fp == "" {
+		return "", nil
+	}
+
+	targetID, err := r.redisRepo.GetFingerprintTargetCtx(ctx, generateFingerprintKey(input.AuthorID.String(), fp))
+	if errors.Is(err, redis.Nil) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if input.TargetID != nil && *input.TargetID == targetID {
+		return "", nil
+	}
+
+	return "repeats content posted recently", nil
+}
+
+func (r *duplicateRule) mask(text string) (string, bool) {
+	return text, false
+}
+
+// fingerprint identify texts of target type ignoring case and spacing, blank texts have no fingerprint
+func fingerprint(input *models.FilterInput) string {
+	if strings.TrimSpace(strings.Join(input.Texts, "")) == "" {
+		return ""
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(input.TargetType))
+	for _, text := range input.Texts {
+		hash.Write([]byte{0})
+		hash.Write([]byte(strings.Join(strings.Fields(strings.ToLower(text)), " ")))
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func generateFingerprintKey(authorID string, fingerprint string) string {
+	return fmt.Sprintf("%s: fingerprint: %s: %s", basePrefix, authorID, fingerprint)
+}
@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/opentracing/opentracing-go"
+	"github.com/scul0405/blog-clean-architecture-rest-api/config"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/content_filter"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	httpErrors "github.com/scul0405/blog-clean-architecture-rest-api/pkg/http_errors"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/logger"
+	"net/http"
+)
+
+const (
+	basePrefix             = "content-filter-api"
+	defaultDuplicateWindow = 600
+)
+
+// actionRanks order actions from weakest to strongest
+var actionRanks = map[string]int{
+	models.FilterAllow:  0,
+	models.FilterMask:   1,
+	models.FilterHold:   2,
+	models.FilterReject: 3,
+}
+
+type configuredRule struct {
+	rule
+	action string
+}
+
+type contentFilter struct {
+	cfg       *config.Config
+	repo      content_filter.Repository
+	redisRepo content_filter.RedisRepository
+	rules     []configuredRule
+	logger    logger.Logger
+}
+
+// NewContentFilter build content filter of rules configured in cfg
+func NewContentFilter(cfg *config.Config, repo content_filter.Repository, redisRepo content_filter.RedisRepository, logger logger.Logger) content_filter.ContentFilter {
+	f := &contentFilter{cfg: cfg, repo: repo, redisRepo: redisRepo, logger: logger}
+
+	filterCfg := cfg.ContentFilter
+	f.addRule(newWordListRule(filterCfg.BannedWords), filterCfg.BannedWordsAction)
+	f.addRule(&linksRule{max: filterCfg.MaxLinks}, filterCfg.LinksAction)
+	f.addRule(&repeatedCharsRule{max: filterCfg.MaxRepeatedChars}, filterCfg.RepeatedCharsAction)
+	f.addRule(&duplicateRule{redisRepo: redisRepo}, filterCfg.DuplicateAction)
+
+	return f
+}
+
+// Check run every rule on input, decision takes strongest action of matched rules and masks texts of rules with mask action.
+// Rule which fails is skipped and listed as failed match, so content is never rejected because filter is unavailable
+func (f *contentFilter) Check(ctx context.Context, input *models.FilterInput) (*models.FilterDecision, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "contentFilter.Check")
+	defer span.Finish()
+
+	decision := &models.FilterDecision{
+		TargetType:  input.TargetType,
+		TargetID:    input.TargetID,
+		AuthorID:    input.AuthorID,
+		Action:      models.FilterAllow,
+		Matches:     make([]models.FilterMatch, 0),
+		Texts:       append([]string(nil), input.Texts...),
+		Fingerprint: fingerprint(input),
+	}
+
+	for _, r := range f.rules {
+		reason, err := r.match(ctx, input)
+		if err != nil {
+			f.logger.Errorf("contentFilter.Check: %s: %v", r.name(), err)
+			decision.Matches = append(decision.Matches, models.FilterMatch{Rule: r.name(), Action: r.action, Reason: err.Error(), Failed: true})
+			continue
+		}
+		if reason == "" {
+			continue
+		}
+
+		decision.Matches = append(decision.Matches, models.FilterMatch{Rule: r.name(), Action: r.action, Reason: reason})
+		if actionRanks[r.action] > actionRanks[decision.Action] {
+			decision.Action = r.action
+		}
+		if r.action == models.FilterMask {
+			for i, text := range decision.Texts {
+				decision.Texts[i], _ = r.mask(text)
+			}
+		}
+	}
+
+	if decision.Action != models.FilterReject {
+		return decision, nil
+	}
+
+	if err := f.repo.CreateDecision(ctx, decision); err != nil {
+		f.logger.Errorf("contentFilter.Check: CreateDecision: %v", err)
+	}
+
+	reasons := make([]string, 0, len(decision.Matches))
+	for _, m := range decision.Matches {
+		if m.Action == models.FilterReject && !m.Failed {
+			reasons = append(reasons, m.Reason)
+		}
+	}
+
+	return nil, httpErrors.NewRestError(http.StatusBadRequest, "Content rejected by filter", reasons)
+}
+
+// Record remember texts of target for duplicate detection and write decision to audit log, allowed content is audited too
+// so skipped checks show up
+func (f *contentFilter) Record(ctx context.Context, decision *models.FilterDecision, targetID uuid.UUID) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "contentFilter.Record")
+	defer span.Finish()
+
+	if f.cfg.ContentFilter.DuplicateAction != "" && decision.Fingerprint != "" {
+		key := generateFingerprintKey(decision.AuthorID.String(), decision.Fingerprint)
+		if err := f.redisRepo.SetFingerprintTargetCtx(ctx, key, f.duplicateWindow(), targetID); err != nil {
+			f.logger.Errorf("contentFilter.Record: SetFingerprintTargetCtx: %v", err)
+		}
+	}
+
+	decision.TargetID = &targetID
+	return f.repo.CreateDecision(ctx, decision)
+}
+
+// addRule enable rule with known action, rule which can not mask holds content instead
+func (f *contentFilter) addRule(r rule, action string) {
+	switch action {
+	case "":
+		return
+	case models.FilterMask:
+		if _, ok := r.mask(""); !ok {
+			action = models.FilterHold
+		}
+	case models.FilterHold, models.FilterReject:
+	default:
+		f.logger.Warnf("contentFilter: unknown action %s of rule %s, rule is disabled", action, r.name())
+		return
+	}
+
+	f.rules = append(f.rules, configuredRule{rule: r, action: action})
+}
+
+func (f *contentFilter) duplicateWindow() int {
+	if f.cfg.ContentFilter.DuplicateWindow > 0 {
+		return f.cfg.ContentFilter.DuplicateWindow
+	}
+	return defaultDuplicateWindow
+}
@@ -0,0 +1,179 @@
+package usecase
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+	"github.com/scul0405/blog-clean-architecture-rest-api/config"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/content_filter/mock"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	httpErrors "github.com/scul0405/blog-clean-architecture-rest-api/pkg/http_errors"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/logger"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"testing"
+)
+
+func TestContentFilter_Check(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Logger: config.LoggerConfig{
+			Development: true,
+		},
+		ContentFilter: config.ContentFilterConfig{
+			BannedWords:         []string{"spam"},
+			BannedWordsAction:   models.FilterMask,
+			MaxLinks:            1,
+			LinksAction:         models.FilterHold,
+			MaxRepeatedChars:    5,
+			RepeatedCharsAction: models.FilterReject,
+			DuplicateAction:     models.FilterMask,
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	apiLogger.InitLogger()
+	mockRepo := mock.NewMockRepository(ctrl)
+	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
+	filter := NewContentFilter(cfg, mockRepo, mockRedisRepo, apiLogger)
+
+	authorUID := uuid.New()
+	ctx := context.Background()
+
+	t.Run("Allow", func(t *testing.T) {
+		mockRedisRepo.EXPECT().GetFingerprintTargetCtx(gomock.Any(), gomock.Any()).Return(uuid.Nil, redis.Nil)
+
+		decision, err := filter.Check(ctx, &models.FilterInput{AuthorID: authorUID, Texts: []string{"Nice post, thanks"}})
+		require.NoError(t, err)
+		require.Equal(t, models.FilterAllow, decision.Action)
+		require.Empty(t, decision.Matches)
+		require.Equal(t, []string{"Nice post, thanks"}, decision.Texts)
+	})
+
+	t.Run("Mask banned words", func(t *testing.T) {
+		mockRedisRepo.EXPECT().GetFingerprintTargetCtx(gomock.Any(), gomock.Any()).Return(uuid.Nil, redis.Nil)
+
+		input := &models.FilterInput{AuthorID: authorUID, Texts: []string{"Buy SPAM now, spammer"}}
+		decision, err := filter.Check(ctx, input)
+		require.NoError(t, err)
+		require.Equal(t, models.FilterMask, decision.Action)
+		require.Equal(t, []string{"Buy **** now, spammer"}, decision.Texts)
+		require.Equal(t, []string{"Buy SPAM now, spammer"}, input.Texts)
+	})
+
+	t.Run("Hold links and duplicate", func(t *testing.T) {
+		mockRedisRepo.EXPECT().GetFingerprintTargetCtx(gomock.Any(), gomock.Any()).Return(uuid.New(), nil)
+
+		decision, err := filter.Check(ctx, &models.FilterInput{
+			AuthorID: authorUID,
+			Texts:    []string{"See https://a.example and www.b.example"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, models.FilterHold, decision.Action)
+		require.Len(t, decision.Matches, 2)
+	})
+
+	t.Run("Same target is not duplicate", func(t *testing.T) {
+		targetUID := uuid.New()
+		mockRedisRepo.EXPECT().GetFingerprintTargetCtx(gomock.Any(), gomock.Any()).Return(targetUID, nil)
+
+		decision, err := filter.Check(ctx, &models.FilterInput{AuthorID: authorUID, TargetID: &targetUID, Texts: []string{"Edited"}})
+		require.NoError(t, err)
+		require.Equal(t, models.FilterAllow, decision.Action)
+	})
+
+	t.Run("Redis error is skipped", func(t *testing.T) {
+		mockRedisRepo.EXPECT().GetFingerprintTargetCtx(gomock.Any(), gomock.Any()).Return(uuid.Nil, errors.New("connection refused"))
+
+		decision, err := filter.Check(ctx, &models.FilterInput{AuthorID: authorUID, Texts: []string{"Hello"}})
+		require.NoError(t, err)
+		require.Equal(t, models.FilterAllow, decision.Action)
+		require.Len(t, decision.Matches, 1)
+		require.True(t, decision.Matches[0].Failed)
+		require.Contains(t, decision.Matches[0].Reason, "connection refused")
+	})
+
+	t.Run("Reject", func(t *testing.T) {
+		mockRedisRepo.EXPECT().GetFingerprintTargetCtx(gomock.Any(), gomock.Any()).Return(uuid.Nil, redis.Nil)
+		mockRepo.EXPECT().CreateDecision(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, decision *models.FilterDecision) error {
+				require.Equal(t, models.FilterReject, decision.Action)
+				require.Nil(t, decision.TargetID)
+				return nil
+			})
+
+		decision, err := filter.Check(ctx, &models.FilterInput{AuthorID: authorUID, Texts: []string{"Wooooooooow"}})
+		require.Nil(t, decision)
+		require.Error(t, err)
+		var restErr httpErrors.RestErr
+		require.True(t, errors.As(err, &restErr))
+		require.Equal(t, http.StatusBadRequest, restErr.Status())
+	})
+}
+
+func TestContentFilter_Record(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Logger: config.LoggerConfig{
+			Development: true,
+		},
+		ContentFilter: config.ContentFilterConfig{
+			DuplicateAction: models.FilterHold,
+			DuplicateWindow: 60,
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	apiLogger.InitLogger()
+	mockRepo := mock.NewMockRepository(ctrl)
+	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
+	filter := NewContentFilter(cfg, mockRepo, mockRedisRepo, apiLogger)
+
+	ctx := context.Background()
+	targetUID := uuid.New()
+
+	t.Run("Allow is audited", func(t *testing.T) {
+		decision := &models.FilterDecision{AuthorID: uuid.New(), Action: models.FilterAllow, Fingerprint: "abc"}
+		mockRedisRepo.EXPECT().SetFingerprintTargetCtx(gomock.Any(), gomock.Any(), 60, targetUID).Return(nil)
+		mockRepo.EXPECT().CreateDecision(gomock.Any(), decision).Return(nil)
+
+		err := filter.Record(ctx, decision, targetUID)
+		require.NoError(t, err)
+		require.Equal(t, targetUID, *decision.TargetID)
+	})
+
+	t.Run("Failed check is audited", func(t *testing.T) {
+		decision := &models.FilterDecision{AuthorID: uuid.New(), Action: models.FilterAllow, Matches: []models.FilterMatch{
+			{Rule: "duplicate", Action: models.FilterHold, Reason: "connection refused", Failed: true},
+		}}
+		mockRepo.EXPECT().CreateDecision(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, recorded *models.FilterDecision) error {
+				require.Equal(t, models.FilterAllow, recorded.Action)
+				require.Len(t, recorded.Matches, 1)
+				require.True(t, recorded.Matches[0].Failed)
+				return nil
+			})
+
+		err := filter.Record(ctx, decision, targetUID)
+		require.NoError(t, err)
+	})
+
+	t.Run("Hold is audited", func(t *testing.T) {
+		decision := &models.FilterDecision{AuthorID: uuid.New(), Action: models.FilterHold}
+		mockRepo.EXPECT().CreateDecision(gomock.Any(), decision).Return(nil)
+
+		err := filter.Record(ctx, decision, targetUID)
+		require.NoError(t, err)
+		require.Equal(t, targetUID, *decision.TargetID)
+	})
+}
@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: distributors.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	asynq "github.com/hibiken/asynq"
+	asynq0 "github.com/scul0405/blog-clean-architecture-rest-api/internal/feed/transport/asynq"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFeedTaskDistributor is a mock of FeedTaskDistributor interface.
+type MockFeedTaskDistributor struct {
+	ctrl     *gomock.Controller
+	recorder *MockFeedTaskDistributorMockRecorder
+}
+
+// MockFeedTaskDistributorMockRecorder is the mock recorder for MockFeedTaskDistributor.
+type MockFeedTaskDistributorMockRecorder struct {
+	mock *MockFeedTaskDistributor
+}
+
+// NewMockFeedTaskDistributor creates a new mock instance.
+func NewMockFeedTaskDistributor(ctrl *gomock.Controller) *MockFeedTaskDistributor {
+	mock := &MockFeedTaskDistributor{ctrl: ctrl}
+	mock.recorder = &MockFeedTaskDistributorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFeedTaskDistributor) EXPECT() *MockFeedTaskDistributorMockRecorder {
+	return m.recorder
+}
+
+// DistributeTaskFanOutBlog mocks base method.
+func (m *MockFeedTaskDistributor) DistributeTaskFanOutBlog(ctx context.Context, payload *asynq0.FanOutBlogPayload, opts ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, payload}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskFanOutBlog", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskFanOutBlog indicates an expected call of DistributeTaskFanOutBlog.
+func (mr *MockFeedTaskDistributorMockRecorder) DistributeTaskFanOutBlog(ctx, payload interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, payload}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskFanOutBlog", reflect.TypeOf((*MockFeedTaskDistributor)(nil).DistributeTaskFanOutBlog), varargs...)
+}
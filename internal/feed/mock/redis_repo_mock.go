@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: redis_repo.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	utils "github.com/scul0405/blog-clean-architecture-rest-api/pkg/utils"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRedisRepository is a mock of RedisRepository interface.
+type MockRedisRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRedisRepositoryMockRecorder
+}
+
+// MockRedisRepositoryMockRecorder is the mock recorder for MockRedisRepository.
+type MockRedisRepositoryMockRecorder struct {
+	mock *MockRedisRepository
+}
+
+// NewMockRedisRepository creates a new mock instance.
+func NewMockRedisRepository(ctrl *gomock.Controller) *MockRedisRepository {
+	mock := &MockRedisRepository{ctrl: ctrl}
+	mock.recorder = &MockRedisRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRedisRepository) EXPECT() *MockRedisRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddCtx mocks base method.
+func (m *MockRedisRepository) AddCtx(ctx context.Context, keys []string, maxLen int64, entry *models.FeedEntry) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddCtx", ctx, keys, maxLen, entry)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddCtx indicates an expected call of AddCtx.
+func (mr *MockRedisRepositoryMockRecorder) AddCtx(ctx, keys, maxLen, entry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCtx", reflect.TypeOf((*MockRedisRepository)(nil).AddCtx), ctx, keys, maxLen, entry)
+}
+
+// DeleteCtx mocks base method.
+func (m *MockRedisRepository) DeleteCtx(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCtx", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCtx indicates an expected call of DeleteCtx.
+func (mr *MockRedisRepositoryMockRecorder) DeleteCtx(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCtx", reflect.TypeOf((*MockRedisRepository)(nil).DeleteCtx), ctx, key)
+}
+
+// RangeCtx mocks base method.
+func (m *MockRedisRepository) RangeCtx(ctx context.Context, key string, cursor *utils.Cursor, count int64) ([]*models.FeedEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RangeCtx", ctx, key, cursor, count)
+	ret0, _ := ret[0].([]*models.FeedEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RangeCtx indicates an expected call of RangeCtx.
+func (mr *MockRedisRepositoryMockRecorder) RangeCtx(ctx, key, cursor, count interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RangeCtx", reflect.TypeOf((*MockRedisRepository)(nil).RangeCtx), ctx, key, cursor, count)
+}
+
+// SetCtx mocks base method.
+func (m *MockRedisRepository) SetCtx(ctx context.Context, key string, seconds int, entries []*models.FeedEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCtx", ctx, key, seconds, entries)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetCtx indicates an expected call of SetCtx.
+func (mr *MockRedisRepositoryMockRecorder) SetCtx(ctx, key, seconds, entries interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCtx", reflect.TypeOf((*MockRedisRepository)(nil).SetCtx), ctx, key, seconds, entries)
+}
+
+// TouchCtx mocks base method.
+func (m *MockRedisRepository) TouchCtx(ctx context.Context, key string, seconds int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchCtx", ctx, key, seconds)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TouchCtx indicates an expected call of TouchCtx.
+func (mr *MockRedisRepositoryMockRecorder) TouchCtx(ctx, key, seconds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchCtx", reflect.TypeOf((*MockRedisRepository)(nil).TouchCtx), ctx, key, seconds)
+}
@@ -0,0 +1,16 @@
+//go:generate mockgen -source redis_repo.go -destination mock/redis_repo_mock.go -package mock
+package feed
+
+import (
+	"context"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/utils"
+)
+
+type RedisRepository interface {
+	TouchCtx(ctx context.Context, key string, seconds int) (int64, error)
+	RangeCtx(ctx context.Context, key string, cursor *utils.Cursor, count int64) ([]*models.FeedEntry, error)
+	SetCtx(ctx context.Context, key string, seconds int, entries []*models.FeedEntry) error
+	AddCtx(ctx context.Context, keys []string, maxLen int64, entry *models.FeedEntry) (int64, error)
+	DeleteCtx(ctx context.Context, key string) error
+}
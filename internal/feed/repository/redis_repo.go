@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/feed"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/utils"
+	"strconv"
+	"time"
+)
+
+// addToFeedsScript add entry to feeds which exist and trim them to newest maxLen entries.
+// Feed which expired meanwhile is not made again, it would miss older entries
+var addToFeedsScript = redis.NewScript(`
+local added = 0
+for _, key in ipairs(KEYS) do
+	if redis.call('EXISTS', key) == 1 then
+		redis.call('ZADD', key, ARGV[1], ARGV[2])
+		redis.call('ZREMRANGEBYRANK', key, 0, -tonumber(ARGV[3]) - 1)
+		added = added + 1
+	end
+end
+return added
+`)
+
+type feedRedisRepo struct {
+	rdb *redis.Client
+}
+
+func NewFeedRedisRepository(rdb *redis.Client) feed.RedisRepository {
+	return &feedRedisRepo{rdb: rdb}
+}
+
+// TouchCtx make feed expire seconds from now and return how many entries it has, feed which does not exist has none
+func (r *feedRedisRepo) TouchCtx(ctx context.Context, key string, seconds int) (int64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "feedRedisRepo.TouchCtx")
+	defer span.Finish()
+
+	var size *redis.IntCmd
+	_, err := r.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		size = pipe.ZCard(ctx, key)
+		pipe.Expire(ctx, key, time.Second*time.Duration(seconds))
+		return nil
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "feedRedisRepo.TouchCtx.redisClient.TxPipelined")
+	}
+
+	return size.Val(), nil
+}
+
+// RangeCtx read at most count entries after cursor, newest first. Entries of same time are ordered by blog id descending
+func (r *feedRedisRepo) RangeCtx(ctx context.Context, key string, cursor *utils.Cursor, count int64) ([]*models.FeedEntry, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "feedRedisRepo.RangeCtx")
+	defer span.Finish()
+
+	max := "+inf"
+	if cursor != nil {
+		max = strconv.FormatInt(cursor.CreatedAt.UnixMicro(), 10)
+	}
+
+	entries := make([]*models.FeedEntry, 0, count)
+	// Entries of cursor time which are not after cursor are skipped, so reading goes on until page is full
+	for offset := int64(0); int64(len(entries)) < count; {
+		members, err := r.rdb.ZRevRangeByScoreWithScores(ctx, key, &redis.ZRangeBy{
+			Min:    "-inf",
+			Max:    max,
+			Offset: offset,
+			Count:  count,
+		}).Result()
+		if err != nil {
+			return nil, errors.Wrap(err, "feedRedisRepo.RangeCtx.redisClient.ZRevRangeByScoreWithScores")
+		}
+
+		for _, member := range members {
+			entry, ok := toFeedEntry(member)
+			if !ok || (cursor != nil && !isAfterCursor(entry, cursor)) {
+				continue
+			}
+			if entries = append(entries, entry); int64(len(entries)) == count {
+				break
+			}
+		}
+
+		if int64(len(members)) < count {
+			break
+		}
+		offset += int64(len(members))
+	}
+
+	return entries, nil
+}
+
+// SetCtx replace feed with entries, feed expires seconds from now
+func (r *feedRedisRepo) SetCtx(ctx context.Context, key string, seconds int, entries []*models.FeedEntry) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "feedRedisRepo.SetCtx")
+	defer span.Finish()
+
+	members := make([]redis.Z, 0, len(entries))
+	for _, entry := range entries {
+		members = append(members, redis.Z{Score: feedScore(entry), Member: entry.BlogID.String()})
+	}
+
+	_, err := r.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, key)
+		if len(members) > 0 {
+			pipe.ZAdd(ctx, key, members...)
+			pipe.Expire(ctx, key, time.Second*time.Duration(seconds))
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "feedRedisRepo.SetCtx.redisClient.TxPipelined")
+	}
+
+	return nil
+}
+
+// AddCtx add entry to those of feeds which exist and return how many feeds it was added to
+func (r *feedRedisRepo) AddCtx(ctx context.Context, keys []string, maxLen int64, entry *models.FeedEntry) (int64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "feedRedisRepo.AddCtx")
+	defer span.Finish()
+
+	if len(keys) == 0 {
+		return 0, nil
+	}
+
+	added, err := addToFeedsScript.Run(ctx, r.rdb, keys, feedScore(entry), entry.BlogID.String(), maxLen).Int64()
+	if err != nil {
+		return 0, errors.Wrap(err, "feedRedisRepo.AddCtx.addToFeedsScript.Run")
+	}
+
+	return added, nil
+}
+
+func (r *feedRedisRepo) DeleteCtx(ctx context.Context, key string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "feedRedisRepo.DeleteCtx")
+	defer span.Finish()
+
+	if err := r.rdb.Del(ctx, key).Err(); err != nil {
+		return errors.Wrap(err, "feedRedisRepo.DeleteCtx.redisClient.Del")
+	}
+
+	return nil
+}
+
+// feedScore is publish time in microseconds, it is kept exactly by float score and matches precision of postgres
+func feedScore(entry *models.FeedEntry) float64 {
+	return float64(entry.PublishedAt.UnixMicro())
+}
+
+func toFeedEntry(member redis.Z) (*models.FeedEntry, bool) {
+	value, ok := member.Member.(string)
+	if !ok {
+		return nil, false
+	}
+
+	blogID, err := uuid.Parse(value)
+	if err != nil {
+		return nil, false
+	}
+
+	return &models.FeedEntry{BlogID: blogID, PublishedAt: time.UnixMicro(int64(member.Score)).UTC()}, true
+}
+
+// isAfterCursor report whether entry comes after cursor going newest first
+func isAfterCursor(entry *models.FeedEntry, cursor *utils.Cursor) bool {
+	entryTime, cursorTime := entry.PublishedAt.UnixMicro(), cursor.CreatedAt.UnixMicro()
+	return entryTime < cursorTime || (entryTime == cursorTime && entry.BlogID.String() < cursor.ID.String())
+}
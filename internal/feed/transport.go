@@ -0,0 +1,7 @@
+package feed
+
+import "github.com/labstack/echo/v4"
+
+type Handlers interface {
+	Feed() echo.HandlerFunc
+}
@@ -0,0 +1,46 @@
+//go:generate mockgen -source distributors.go -destination ../../mock/distributors_mock.go -package mock
+package asynq
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/hibiken/asynq"
+	asynqPkg "github.com/scul0405/blog-clean-architecture-rest-api/pkg/asynq"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/logger"
+)
+
+type FeedTaskDistributor interface {
+	DistributeTaskFanOutBlog(ctx context.Context, payload *FanOutBlogPayload, opts ...asynq.Option) error
+}
+
+type feedTaskDistributor struct {
+	client    *asynq.Client
+	inspector *asynq.Inspector
+	logger    logger.Logger
+}
+
+func NewFeedTaskDistributor(client *asynq.Client, inspector *asynq.Inspector, logger logger.Logger) FeedTaskDistributor {
+	return &feedTaskDistributor{
+		client:    client,
+		inspector: inspector,
+		logger:    logger,
+	}
+}
+
+func (distributor *feedTaskDistributor) DistributeTaskFanOutBlog(ctx context.Context, payload *FanOutBlogPayload, opts ...asynq.Option) error {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task payload: %w", err)
+	}
+
+	task := asynq.NewTask(TypeFanOutBlogTask, jsonPayload)
+	info, err := asynqPkg.EnqueueReplacingArchived(ctx, distributor.client, distributor.inspector, task, opts...)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+
+	distributor.logger.Infof("type=%s, payload=%s, queue=%s, maxRetry=%d enqueued task", info.Type, info.Payload, info.Queue, info.MaxRetry)
+
+	return nil
+}
@@ -0,0 +1,13 @@
+package asynq
+
+import (
+	"github.com/google/uuid"
+)
+
+const (
+	TypeFanOutBlogTask = "feed:fan_out_blog"
+)
+
+type FanOutBlogPayload struct {
+	BlogID uuid.UUID
+}
@@ -0,0 +1,42 @@
+package asynq
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/hibiken/asynq"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/feed"
+	asynqPkg "github.com/scul0405/blog-clean-architecture-rest-api/pkg/asynq"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/logger"
+)
+
+type FeedProcessor interface {
+	ProcessTaskFanOutBlog(ctx context.Context, t *asynq.Task) error
+}
+
+type feedProcessor struct {
+	feedUC feed.UseCase
+	logger logger.Logger
+}
+
+func NewFeedProcessor(feedUC feed.UseCase, logger logger.Logger) FeedProcessor {
+	return &feedProcessor{
+		feedUC: feedUC,
+		logger: logger,
+	}
+}
+
+func (p *feedProcessor) ProcessTaskFanOutBlog(ctx context.Context, t *asynq.Task) error {
+	var payload FanOutBlogPayload
+	if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+		return fmt.Errorf("json.Unmarshal failed: %v: %w", err, asynq.SkipRetry)
+	}
+
+	if err := p.feedUC.FanOut(ctx, payload.BlogID); err != nil {
+		return asynqPkg.SkipRetryIfPermanent(err)
+	}
+
+	p.logger.Infof("type=%s, blog_id=%s fanned out to feeds", t.Type(), payload.BlogID)
+
+	return nil
+}
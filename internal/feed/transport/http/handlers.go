@@ -0,0 +1,57 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/opentracing/opentracing-go"
+	"github.com/scul0405/blog-clean-architecture-rest-api/config"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/feed"
+	httpErrors "github.com/scul0405/blog-clean-architecture-rest-api/pkg/http_errors"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/logger"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/utils"
+	"net/http"
+)
+
+type feedHandlers struct {
+	cfg    *config.Config
+	feedUC feed.UseCase
+	logger logger.Logger
+}
+
+func NewFeedHandlers(cfg *config.Config, feedUC feed.UseCase, logger logger.Logger) feed.Handlers {
+	return &feedHandlers{cfg: cfg, feedUC: feedUC, logger: logger}
+}
+
+// Feed godoc
+// @Summary Home feed
+// @Description list recent published blogs of authors caller follows, most recently published first
+// @Tags Feed
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param cursor query string false "next_cursor or prev_cursor of previous response, empty for first page"
+// @Param limit query int false "number of elements per page, from 1 to 100"
+// @Success 200 {object} models.BlogsList
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 401 {object} httpErrors.RestError
+// @Failure 500 {object} httpErrors.RestError
+// @Router /feed [get]
+func (h *feedHandlers) Feed() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "feedHandlers.Feed")
+		defer span.Finish()
+
+		pq, err := utils.GetCursorPaginationFromCtx(c)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		blogsList, err := h.feedUC.Feed(ctx, pq)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, blogsList)
+	}
+}
@@ -0,0 +1,11 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/feed"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/middleware"
+)
+
+func MapFeedRoutes(feedGroup *echo.Group, h feed.Handlers, mw *middleware.MiddlewareManager) {
+	feedGroup.GET("", h.Feed(), mw.AuthPASETOMiddleware)
+}
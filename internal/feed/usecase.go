@@ -0,0 +1,15 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase_mock.go -package mock
+package feed
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/utils"
+)
+
+type UseCase interface {
+	Feed(ctx context.Context, pq *utils.PaginationQuery) (*models.BlogsList, error)
+	FanOut(ctx context.Context, blogID uuid.UUID) error
+	Reset(ctx context.Context, userID uuid.UUID) error
+}
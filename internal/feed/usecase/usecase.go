@@ -0,0 +1,201 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"github.com/scul0405/blog-clean-architecture-rest-api/config"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/blog"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/feed"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/follow"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	httpErrors "github.com/scul0405/blog-clean-architecture-rest-api/pkg/http_errors"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/logger"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/utils"
+	"time"
+)
+
+const (
+	basePrefix       = "feed-api"
+	defaultFeedSize  = 500
+	defaultActiveTTL = 604800
+	// fanOutBatchSize is how many followers are read and updated at once
+	fanOutBatchSize = 500
+)
+
+type feedUseCase struct {
+	cfg        *config.Config
+	blogRepo   blog.Repository
+	followRepo follow.Repository
+	redisRepo  feed.RedisRepository
+	logger     logger.Logger
+}
+
+func NewFeedUseCase(cfg *config.Config, blogRepo blog.Repository, followRepo follow.Repository, redisRepo feed.RedisRepository, logger logger.Logger) feed.UseCase {
+	return &feedUseCase{cfg: cfg, blogRepo: blogRepo, followRepo: followRepo, redisRepo: redisRepo, logger: logger}
+}
+
+// Feed read page of recent published blogs of authors caller follows. Reading feed keeps caller active, feed of
+// active user is kept in redis by fan out of published blogs and is made from database when user is not active
+func (u *feedUseCase) Feed(ctx context.Context, pq *utils.PaginationQuery) (*models.BlogsList, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "feedUC.Feed")
+	defer span.Finish()
+
+	userUID, err := utils.GetUserUIDFromCtx(ctx)
+	if err != nil {
+		return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "feedUC.Feed.GetUserUIDFromCtx"))
+	}
+
+	key := u.generateFeedKey(userUID)
+	size, err := u.redisRepo.TouchCtx(ctx, key, u.activeTTL())
+	if err != nil {
+		u.logger.Errorf("feedUC.Feed: TouchCtx: %v", err)
+		return u.blogRepo.ListFeed(ctx, userUID, pq)
+	}
+
+	if size == 0 {
+		if err = u.rebuild(ctx, userUID, key); err != nil {
+			u.logger.Errorf("feedUC.Feed: rebuild: %v", err)
+		}
+		return u.blogRepo.ListFeed(ctx, userUID, pq)
+	}
+
+	// Feed keeps newest blogs only and is read going forward, other pages are read from database
+	if pq.GetCursor() != nil && pq.GetCursor().Prev {
+		return u.blogRepo.ListFeed(ctx, userUID, pq)
+	}
+
+	entries, err := u.redisRepo.RangeCtx(ctx, key, pq.GetCursor(), int64(pq.GetSize()+1))
+	if err != nil {
+		u.logger.Errorf("feedUC.Feed: RangeCtx: %v", err)
+		return u.blogRepo.ListFeed(ctx, userUID, pq)
+	}
+
+	if len(entries) <= pq.GetSize() && size >= int64(u.feedSize()) {
+		return u.blogRepo.ListFeed(ctx, userUID, pq)
+	}
+
+	return u.page(ctx, entries, pq)
+}
+
+// FanOut add published blog to feeds of active followers of its author, blog which is not published is skipped
+func (u *feedUseCase) FanOut(ctx context.Context, blogID uuid.UUID) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "feedUC.FanOut")
+	defer span.Finish()
+
+	blogByID, err := u.blogRepo.GetByID(ctx, blogID)
+	if err != nil {
+		return err
+	}
+
+	if blogByID.Status != models.BlogStatusPublished || blogByID.PublishedAt == nil {
+		u.logger.Infof("feedUC.FanOut: blog %s is no longer published", blogID)
+		return nil
+	}
+
+	entry := &models.FeedEntry{BlogID: blogByID.BlogID, PublishedAt: *blogByID.PublishedAt}
+	afterID := uuid.Nil
+	for {
+		followerIDs, err := u.followRepo.ListFollowerIDs(ctx, blogByID.AuthorID, afterID, fanOutBatchSize)
+		if err != nil {
+			return err
+		}
+
+		keys := make([]string, 0, len(followerIDs))
+		for _, followerID := range followerIDs {
+			keys = append(keys, u.generateFeedKey(followerID))
+		}
+
+		// Adding entry again changes nothing, so retried task does not duplicate it
+		if _, err = u.redisRepo.AddCtx(ctx, keys, int64(u.feedSize()), entry); err != nil {
+			return err
+		}
+
+		if len(followerIDs) < fanOutBatchSize {
+			return nil
+		}
+		afterID = followerIDs[len(followerIDs)-1]
+	}
+}
+
+// Reset drop feed of user, it is made again from database when user reads it
+func (u *feedUseCase) Reset(ctx context.Context, userID uuid.UUID) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "feedUC.Reset")
+	defer span.Finish()
+
+	return u.redisRepo.DeleteCtx(ctx, u.generateFeedKey(userID))
+}
+
+// rebuild make feed of user from newest blogs of authors user follows
+func (u *feedUseCase) rebuild(ctx context.Context, userID uuid.UUID, key string) error {
+	blogsList, err := u.blogRepo.ListFeed(ctx, userID, &utils.PaginationQuery{CursorMode: true, Size: u.feedSize()})
+	if err != nil {
+		return err
+	}
+
+	entries := make([]*models.FeedEntry, 0, len(blogsList.Blogs))
+	for _, b := range blogsList.Blogs {
+		if b.PublishedAt != nil {
+			entries = append(entries, &models.FeedEntry{BlogID: b.BlogID, PublishedAt: *b.PublishedAt})
+		}
+	}
+
+	return u.redisRepo.SetCtx(ctx, key, u.activeTTL(), entries)
+}
+
+// page read blogs of entries in feed order, blogs which are no longer published are left out
+func (u *feedUseCase) page(ctx context.Context, entries []*models.FeedEntry, pq *utils.PaginationQuery) (*models.BlogsList, error) {
+	entries, nextCursor, prevCursor := utils.CursorPage(entries, pq, func(e *models.FeedEntry) (time.Time, uuid.UUID) {
+		return e.PublishedAt, e.BlogID
+	})
+
+	blogIDs := make([]uuid.UUID, 0, len(entries))
+	for _, entry := range entries {
+		blogIDs = append(blogIDs, entry.BlogID)
+	}
+
+	blogs, err := u.blogRepo.ListPublishedByIDs(ctx, blogIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	blogsByID := make(map[uuid.UUID]*models.BlogBase, len(blogs))
+	for _, b := range blogs {
+		blogsByID[b.BlogID] = b
+	}
+
+	blogsList := make([]*models.BlogBase, 0, len(entries))
+	for _, entry := range entries {
+		if b, ok := blogsByID[entry.BlogID]; ok {
+			blogsList = append(blogsList, b)
+		}
+	}
+
+	return &models.BlogsList{
+		Size:       pq.GetSize(),
+		HasMore:    nextCursor != nil,
+		NextCursor: nextCursor,
+		PrevCursor: prevCursor,
+		Blogs:      blogsList,
+	}, nil
+}
+
+func (u *feedUseCase) feedSize() int {
+	if u.cfg.Feed.Size > 0 {
+		return u.cfg.Feed.Size
+	}
+	return defaultFeedSize
+}
+
+func (u *feedUseCase) activeTTL() int {
+	if u.cfg.Feed.ActiveTTL > 0 {
+		return u.cfg.Feed.ActiveTTL
+	}
+	return defaultActiveTTL
+}
+
+func (u *feedUseCase) generateFeedKey(userID uuid.UUID) string {
+	return fmt.Sprintf("%s: %s", basePrefix, userID)
+}
@@ -0,0 +1,171 @@
+package usecase
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/scul0405/blog-clean-architecture-rest-api/config"
+	blogMock "github.com/scul0405/blog-clean-architecture-rest-api/internal/blog/mock"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/feed/mock"
+	followMock "github.com/scul0405/blog-clean-architecture-rest-api/internal/follow/mock"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/logger"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/utils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+func TestFeedUseCase_Feed(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Logger: config.LoggerConfig{
+			Development: true,
+		},
+		Feed: config.FeedConfig{
+			Size:      5,
+			ActiveTTL: 60,
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	apiLogger.InitLogger()
+	mockBlogRepo := blogMock.NewMockRepository(ctrl)
+	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
+	feedUC := NewFeedUseCase(cfg, mockBlogRepo, nil, mockRedisRepo, apiLogger)
+
+	userUID := uuid.New()
+	ctx := context.WithValue(context.Background(), "user_id", userUID.String())
+	key := "feed-api: " + userUID.String()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	t.Run("Inactive user gets feed made", func(t *testing.T) {
+		pq := &utils.PaginationQuery{CursorMode: true, Size: 2}
+		blogUID := uuid.New()
+		recent := &models.BlogsList{Blogs: []*models.BlogBase{{BlogID: blogUID, PublishedAt: &now}}}
+
+		mockRedisRepo.EXPECT().TouchCtx(gomock.Any(), key, 60).Return(int64(0), nil)
+		mockBlogRepo.EXPECT().ListFeed(gomock.Any(), userUID, gomock.Eq(&utils.PaginationQuery{CursorMode: true, Size: 5})).Return(recent, nil)
+		mockRedisRepo.EXPECT().SetCtx(gomock.Any(), key, 60, gomock.Eq([]*models.FeedEntry{{BlogID: blogUID, PublishedAt: now}})).Return(nil)
+		mockBlogRepo.EXPECT().ListFeed(gomock.Any(), userUID, pq).Return(recent, nil)
+
+		blogsList, err := feedUC.Feed(ctx, pq)
+		require.NoError(t, err)
+		require.Equal(t, recent, blogsList)
+	})
+
+	t.Run("Active user reads feed from redis", func(t *testing.T) {
+		pq := &utils.PaginationQuery{CursorMode: true, Size: 2}
+		entries := []*models.FeedEntry{
+			{BlogID: uuid.New(), PublishedAt: now},
+			{BlogID: uuid.New(), PublishedAt: now.Add(-time.Minute)},
+			{BlogID: uuid.New(), PublishedAt: now.Add(-time.Hour)},
+		}
+
+		mockRedisRepo.EXPECT().TouchCtx(gomock.Any(), key, 60).Return(int64(3), nil)
+		mockRedisRepo.EXPECT().RangeCtx(gomock.Any(), key, nil, int64(3)).Return(entries, nil)
+		// Second blog is no longer published
+		mockBlogRepo.EXPECT().ListPublishedByIDs(gomock.Any(), []uuid.UUID{entries[0].BlogID, entries[1].BlogID}).
+			Return([]*models.BlogBase{{BlogID: entries[0].BlogID}}, nil)
+
+		blogsList, err := feedUC.Feed(ctx, pq)
+		require.NoError(t, err)
+		require.Len(t, blogsList.Blogs, 1)
+		require.Equal(t, entries[0].BlogID, blogsList.Blogs[0].BlogID)
+		require.True(t, blogsList.HasMore)
+		require.Nil(t, blogsList.PrevCursor)
+
+		cursor, err := utils.DecodeCursor(*blogsList.NextCursor)
+		require.NoError(t, err)
+		require.Equal(t, entries[1].BlogID, cursor.ID)
+		require.True(t, entries[1].PublishedAt.Equal(cursor.CreatedAt))
+	})
+
+	t.Run("Page past kept blogs is read from database", func(t *testing.T) {
+		pq := &utils.PaginationQuery{CursorMode: true, Size: 2, Cursor: &utils.Cursor{CreatedAt: now, ID: uuid.New()}}
+		older := &models.BlogsList{Blogs: []*models.BlogBase{{BlogID: uuid.New()}}}
+
+		mockRedisRepo.EXPECT().TouchCtx(gomock.Any(), key, 60).Return(int64(5), nil)
+		mockRedisRepo.EXPECT().RangeCtx(gomock.Any(), key, pq.Cursor, int64(3)).Return([]*models.FeedEntry{{BlogID: uuid.New(), PublishedAt: now}}, nil)
+		mockBlogRepo.EXPECT().ListFeed(gomock.Any(), userUID, pq).Return(older, nil)
+
+		blogsList, err := feedUC.Feed(ctx, pq)
+		require.NoError(t, err)
+		require.Equal(t, older, blogsList)
+	})
+
+	t.Run("Prev page is read from database", func(t *testing.T) {
+		pq := &utils.PaginationQuery{CursorMode: true, Size: 2, Cursor: &utils.Cursor{CreatedAt: now, ID: uuid.New(), Prev: true}}
+		newer := &models.BlogsList{Blogs: []*models.BlogBase{{BlogID: uuid.New()}}}
+
+		mockRedisRepo.EXPECT().TouchCtx(gomock.Any(), key, 60).Return(int64(3), nil)
+		mockBlogRepo.EXPECT().ListFeed(gomock.Any(), userUID, pq).Return(newer, nil)
+
+		blogsList, err := feedUC.Feed(ctx, pq)
+		require.NoError(t, err)
+		require.Equal(t, newer, blogsList)
+	})
+
+	t.Run("Redis error", func(t *testing.T) {
+		pq := &utils.PaginationQuery{CursorMode: true, Size: 2}
+		recent := &models.BlogsList{Blogs: []*models.BlogBase{}}
+
+		mockRedisRepo.EXPECT().TouchCtx(gomock.Any(), key, 60).Return(int64(0), errors.New("redis is down"))
+		mockBlogRepo.EXPECT().ListFeed(gomock.Any(), userUID, pq).Return(recent, nil)
+
+		blogsList, err := feedUC.Feed(ctx, pq)
+		require.NoError(t, err)
+		require.Equal(t, recent, blogsList)
+	})
+}
+
+func TestFeedUseCase_FanOut(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Logger: config.LoggerConfig{
+			Development: true,
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	apiLogger.InitLogger()
+	mockBlogRepo := blogMock.NewMockRepository(ctrl)
+	mockFollowRepo := followMock.NewMockRepository(ctrl)
+	mockRedisRepo := mock.NewMockRedisRepository(ctrl)
+	feedUC := NewFeedUseCase(cfg, mockBlogRepo, mockFollowRepo, mockRedisRepo, apiLogger)
+
+	ctx := context.Background()
+	publishedAt := time.Now().UTC()
+
+	t.Run("Published blog is added to feeds of followers", func(t *testing.T) {
+		blog := &models.BlogBase{BlogID: uuid.New(), AuthorID: uuid.New(), Status: models.BlogStatusPublished, PublishedAt: &publishedAt}
+		followerUIDs := []uuid.UUID{uuid.New(), uuid.New()}
+
+		mockBlogRepo.EXPECT().GetByID(gomock.Any(), blog.BlogID).Return(blog, nil)
+		mockFollowRepo.EXPECT().ListFollowerIDs(gomock.Any(), blog.AuthorID, uuid.Nil, fanOutBatchSize).Return(followerUIDs, nil)
+		mockRedisRepo.EXPECT().AddCtx(gomock.Any(),
+			[]string{"feed-api: " + followerUIDs[0].String(), "feed-api: " + followerUIDs[1].String()},
+			int64(defaultFeedSize),
+			gomock.Eq(&models.FeedEntry{BlogID: blog.BlogID, PublishedAt: publishedAt}),
+		).Return(int64(1), nil)
+
+		require.NoError(t, feedUC.FanOut(ctx, blog.BlogID))
+	})
+
+	t.Run("Blog which is not published is skipped", func(t *testing.T) {
+		blog := &models.BlogBase{BlogID: uuid.New(), AuthorID: uuid.New(), Status: models.BlogStatusArchived, PublishedAt: &publishedAt}
+
+		mockBlogRepo.EXPECT().GetByID(gomock.Any(), blog.BlogID).Return(blog, nil)
+
+		require.NoError(t, feedUC.FanOut(ctx, blog.BlogID))
+	})
+}
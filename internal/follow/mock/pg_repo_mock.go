@@ -0,0 +1,143 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pg_repo.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	models "github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	utils "github.com/scul0405/blog-clean-architecture-rest-api/pkg/utils"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRepository) Create(ctx context.Context, followerID, followeeID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, followerID, followeeID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRepositoryMockRecorder) Create(ctx, followerID, followeeID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepository)(nil).Create), ctx, followerID, followeeID)
+}
+
+// Delete mocks base method.
+func (m *MockRepository) Delete(ctx context.Context, followerID, followeeID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, followerID, followeeID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRepositoryMockRecorder) Delete(ctx, followerID, followeeID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), ctx, followerID, followeeID)
+}
+
+// GetCounts mocks base method.
+func (m *MockRepository) GetCounts(ctx context.Context, userID uuid.UUID) (*models.FollowCounts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCounts", ctx, userID)
+	ret0, _ := ret[0].(*models.FollowCounts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCounts indicates an expected call of GetCounts.
+func (mr *MockRepositoryMockRecorder) GetCounts(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCounts", reflect.TypeOf((*MockRepository)(nil).GetCounts), ctx, userID)
+}
+
+// ListFollowerIDs mocks base method.
+func (m *MockRepository) ListFollowerIDs(ctx context.Context, userID, afterID uuid.UUID, limit int) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFollowerIDs", ctx, userID, afterID, limit)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFollowerIDs indicates an expected call of ListFollowerIDs.
+func (mr *MockRepositoryMockRecorder) ListFollowerIDs(ctx, userID, afterID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFollowerIDs", reflect.TypeOf((*MockRepository)(nil).ListFollowerIDs), ctx, userID, afterID, limit)
+}
+
+// ListFollowers mocks base method.
+func (m *MockRepository) ListFollowers(ctx context.Context, userID uuid.UUID, pq *utils.PaginationQuery) (*models.FollowsList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFollowers", ctx, userID, pq)
+	ret0, _ := ret[0].(*models.FollowsList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFollowers indicates an expected call of ListFollowers.
+func (mr *MockRepositoryMockRecorder) ListFollowers(ctx, userID, pq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFollowers", reflect.TypeOf((*MockRepository)(nil).ListFollowers), ctx, userID, pq)
+}
+
+// ListFollowing mocks base method.
+func (m *MockRepository) ListFollowing(ctx context.Context, userID uuid.UUID, pq *utils.PaginationQuery) (*models.FollowsList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFollowing", ctx, userID, pq)
+	ret0, _ := ret[0].(*models.FollowsList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFollowing indicates an expected call of ListFollowing.
+func (mr *MockRepositoryMockRecorder) ListFollowing(ctx, userID, pq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFollowing", reflect.TypeOf((*MockRepository)(nil).ListFollowing), ctx, userID, pq)
+}
+
+// UserExists mocks base method.
+func (m *MockRepository) UserExists(ctx context.Context, userID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserExists", ctx, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserExists indicates an expected call of UserExists.
+func (mr *MockRepositoryMockRecorder) UserExists(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserExists", reflect.TypeOf((*MockRepository)(nil).UserExists), ctx, userID)
+}
@@ -0,0 +1,98 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	models "github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	utils "github.com/scul0405/blog-clean-architecture-rest-api/pkg/utils"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUseCase is a mock of UseCase interface.
+type MockUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockUseCaseMockRecorder
+}
+
+// MockUseCaseMockRecorder is the mock recorder for MockUseCase.
+type MockUseCaseMockRecorder struct {
+	mock *MockUseCase
+}
+
+// NewMockUseCase creates a new mock instance.
+func NewMockUseCase(ctrl *gomock.Controller) *MockUseCase {
+	mock := &MockUseCase{ctrl: ctrl}
+	mock.recorder = &MockUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUseCase) EXPECT() *MockUseCaseMockRecorder {
+	return m.recorder
+}
+
+// Follow mocks base method.
+func (m *MockUseCase) Follow(ctx context.Context, userID uuid.UUID) (*models.FollowState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Follow", ctx, userID)
+	ret0, _ := ret[0].(*models.FollowState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Follow indicates an expected call of Follow.
+func (mr *MockUseCaseMockRecorder) Follow(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Follow", reflect.TypeOf((*MockUseCase)(nil).Follow), ctx, userID)
+}
+
+// ListFollowers mocks base method.
+func (m *MockUseCase) ListFollowers(ctx context.Context, userID uuid.UUID, pq *utils.PaginationQuery) (*models.FollowsList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFollowers", ctx, userID, pq)
+	ret0, _ := ret[0].(*models.FollowsList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFollowers indicates an expected call of ListFollowers.
+func (mr *MockUseCaseMockRecorder) ListFollowers(ctx, userID, pq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFollowers", reflect.TypeOf((*MockUseCase)(nil).ListFollowers), ctx, userID, pq)
+}
+
+// ListFollowing mocks base method.
+func (m *MockUseCase) ListFollowing(ctx context.Context, userID uuid.UUID, pq *utils.PaginationQuery) (*models.FollowsList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFollowing", ctx, userID, pq)
+	ret0, _ := ret[0].(*models.FollowsList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFollowing indicates an expected call of ListFollowing.
+func (mr *MockUseCaseMockRecorder) ListFollowing(ctx, userID, pq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFollowing", reflect.TypeOf((*MockUseCase)(nil).ListFollowing), ctx, userID, pq)
+}
+
+// Unfollow mocks base method.
+func (m *MockUseCase) Unfollow(ctx context.Context, userID uuid.UUID) (*models.FollowState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unfollow", ctx, userID)
+	ret0, _ := ret[0].(*models.FollowState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Unfollow indicates an expected call of Unfollow.
+func (mr *MockUseCaseMockRecorder) Unfollow(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unfollow", reflect.TypeOf((*MockUseCase)(nil).Unfollow), ctx, userID)
+}
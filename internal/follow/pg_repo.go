@@ -0,0 +1,19 @@
+//go:generate mockgen -source pg_repo.go -destination mock/pg_repo_mock.go -package mock
+package follow
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/utils"
+)
+
+type Repository interface {
+	Create(ctx context.Context, followerID uuid.UUID, followeeID uuid.UUID) (bool, error)
+	Delete(ctx context.Context, followerID uuid.UUID, followeeID uuid.UUID) (bool, error)
+	UserExists(ctx context.Context, userID uuid.UUID) (bool, error)
+	GetCounts(ctx context.Context, userID uuid.UUID) (*models.FollowCounts, error)
+	ListFollowers(ctx context.Context, userID uuid.UUID, pq *utils.PaginationQuery) (*models.FollowsList, error)
+	ListFollowing(ctx context.Context, userID uuid.UUID, pq *utils.PaginationQuery) (*models.FollowsList, error)
+	ListFollowerIDs(ctx context.Context, userID uuid.UUID, afterID uuid.UUID, limit int) ([]uuid.UUID, error)
+}
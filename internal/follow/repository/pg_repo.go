@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/follow"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/utils"
+)
+
+type followRepo struct {
+	db *sqlx.DB
+}
+
+func NewFollowRepository(db *sqlx.DB) follow.Repository {
+	return &followRepo{db: db}
+}
+
+// Create follow of followee by follower, report whether it was made now
+func (r *followRepo) Create(ctx context.Context, followerID uuid.UUID, followeeID uuid.UUID) (bool, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "followRepo.Create")
+	defer span.Finish()
+
+	result, err := r.db.ExecContext(ctx, createFollowQuery, followerID, followeeID)
+	if err != nil {
+		return false, errors.Wrap(err, "followRepo.Create.ExecContext")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "followRepo.Create.RowsAffected")
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// Delete follow of followee by follower, report whether there was one
+func (r *followRepo) Delete(ctx context.Context, followerID uuid.UUID, followeeID uuid.UUID) (bool, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "followRepo.Delete")
+	defer span.Finish()
+
+	result, err := r.db.ExecContext(ctx, deleteFollowQuery, followerID, followeeID)
+	if err != nil {
+		return false, errors.Wrap(err, "followRepo.Delete.ExecContext")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "followRepo.Delete.RowsAffected")
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func (r *followRepo) UserExists(ctx context.Context, userID uuid.UUID) (bool, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "followRepo.UserExists")
+	defer span.Finish()
+
+	var exists bool
+	if err := r.db.GetContext(ctx, &exists, userExistsQuery, userID); err != nil {
+		return false, errors.Wrap(err, "followRepo.UserExists.GetContext")
+	}
+
+	return exists, nil
+}
+
+func (r *followRepo) GetCounts(ctx context.Context, userID uuid.UUID) (*models.FollowCounts, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "followRepo.GetCounts")
+	defer span.Finish()
+
+	var counts models.FollowCounts
+	if err := r.db.GetContext(ctx, &counts, getFollowCountsQuery, userID); err != nil {
+		return nil, errors.Wrap(err, "followRepo.GetCounts.GetContext")
+	}
+
+	return &counts, nil
+}
+
+// ListFollowers list users who follow user, most recently followed first
+func (r *followRepo) ListFollowers(ctx context.Context, userID uuid.UUID, pq *utils.PaginationQuery) (*models.FollowsList, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "followRepo.ListFollowers")
+	defer span.Finish()
+
+	return r.list(ctx, getFollowersCountQuery, listFollowersQuery, userID, pq)
+}
+
+// ListFollowing list users user follows, most recently followed first
+func (r *followRepo) ListFollowing(ctx context.Context, userID uuid.UUID, pq *utils.PaginationQuery) (*models.FollowsList, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "followRepo.ListFollowing")
+	defer span.Finish()
+
+	return r.list(ctx, getFollowingCountQuery, listFollowingQuery, userID, pq)
+}
+
+func (r *followRepo) list(ctx context.Context, countQuery string, listQuery string, userID uuid.UUID, pq *utils.PaginationQuery) (*models.FollowsList, error) {
+	var totalCount int
+	if err := r.db.GetContext(ctx, &totalCount, countQuery, userID); err != nil {
+		return nil, errors.Wrap(err, "followRepo.list.GetContext.totalCount")
+	}
+
+	var users = make([]*models.FollowUser, 0, pq.GetSize())
+	if totalCount > 0 {
+		if err := r.db.SelectContext(ctx, &users, listQuery, userID, pq.GetOffset(), pq.GetLimit()); err != nil {
+			return nil, errors.Wrap(err, "followRepo.list.SelectContext")
+		}
+	}
+
+	return &models.FollowsList{
+		TotalCount: totalCount,
+		TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+		Page:       pq.GetPage(),
+		Size:       pq.GetSize(),
+		HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+		Users:      users,
+	}, nil
+}
+
+// ListFollowerIDs read batch of at most limit followers of user with id after afterID, uuid.Nil starts from first
+func (r *followRepo) ListFollowerIDs(ctx context.Context, userID uuid.UUID, afterID uuid.UUID, limit int) ([]uuid.UUID, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "followRepo.ListFollowerIDs")
+	defer span.Finish()
+
+	var followerIDs = make([]uuid.UUID, 0, limit)
+	if err := r.db.SelectContext(ctx, &followerIDs, listFollowerIDsQuery, userID, afterID, limit); err != nil {
+		return nil, errors.Wrap(err, "followRepo.ListFollowerIDs.SelectContext")
+	}
+
+	return followerIDs, nil
+}
@@ -0,0 +1,37 @@
+package repository
+
+const (
+	createFollowQuery = `INSERT INTO follows (follower_id, followee_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+
+	deleteFollowQuery = `DELETE FROM follows WHERE follower_id = $1 AND followee_id = $2`
+
+	userExistsQuery = `SELECT EXISTS (SELECT 1 FROM users WHERE user_id = $1 AND deleted_at IS NULL)`
+
+	// Deleted users are not counted nor listed
+	getFollowCountsQuery = `SELECT
+					(SELECT COUNT(*) FROM follows f JOIN users u ON u.user_id = f.follower_id
+						WHERE f.followee_id = $1 AND u.deleted_at IS NULL) as followers_count,
+					(SELECT COUNT(*) FROM follows f JOIN users u ON u.user_id = f.followee_id
+						WHERE f.follower_id = $1 AND u.deleted_at IS NULL) as following_count`
+
+	getFollowersCountQuery = `SELECT COUNT(*) FROM follows f JOIN users u ON u.user_id = f.follower_id
+					WHERE f.followee_id = $1 AND u.deleted_at IS NULL`
+
+	listFollowersQuery = `SELECT u.user_id, u.first_name, u.last_name, u.avatar, f.created_at as followed_at
+					FROM follows f JOIN users u ON u.user_id = f.follower_id
+					WHERE f.followee_id = $1 AND u.deleted_at IS NULL
+					ORDER BY f.created_at DESC, f.follower_id OFFSET $2 LIMIT $3`
+
+	getFollowingCountQuery = `SELECT COUNT(*) FROM follows f JOIN users u ON u.user_id = f.followee_id
+					WHERE f.follower_id = $1 AND u.deleted_at IS NULL`
+
+	listFollowingQuery = `SELECT u.user_id, u.first_name, u.last_name, u.avatar, f.created_at as followed_at
+					FROM follows f JOIN users u ON u.user_id = f.followee_id
+					WHERE f.follower_id = $1 AND u.deleted_at IS NULL
+					ORDER BY f.created_at DESC, f.followee_id OFFSET $2 LIMIT $3`
+
+	// Followers are read in batches ordered by id, batch starts after afterID
+	listFollowerIDsQuery = `SELECT follower_id FROM follows
+					WHERE followee_id = $1 AND follower_id > $2
+					ORDER BY follower_id LIMIT $3`
+)
@@ -0,0 +1,10 @@
+package follow
+
+import "github.com/labstack/echo/v4"
+
+type Handlers interface {
+	Follow() echo.HandlerFunc
+	Unfollow() echo.HandlerFunc
+	ListFollowers() echo.HandlerFunc
+	ListFollowing() echo.HandlerFunc
+}
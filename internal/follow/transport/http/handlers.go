@@ -0,0 +1,144 @@
+package http
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/opentracing/opentracing-go"
+	"github.com/scul0405/blog-clean-architecture-rest-api/config"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/follow"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	httpErrors "github.com/scul0405/blog-clean-architecture-rest-api/pkg/http_errors"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/logger"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/utils"
+	"net/http"
+)
+
+type followHandlers struct {
+	cfg      *config.Config
+	followUC follow.UseCase
+	logger   logger.Logger
+}
+
+func NewFollowHandlers(cfg *config.Config, followUC follow.UseCase, logger logger.Logger) follow.Handlers {
+	return &followHandlers{cfg: cfg, followUC: followUC, logger: logger}
+}
+
+// Follow godoc
+// @Summary Follow user
+// @Description follow user, published blogs of user appear in feed of caller. Following user again has no effect
+// @Tags Follow
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "user id"
+// @Success 200 {object} models.FollowState
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 401 {object} httpErrors.RestError
+// @Failure 404 {object} httpErrors.RestError
+// @Failure 500 {object} httpErrors.RestError
+// @Router /users/{id}/follow [post]
+func (h *followHandlers) Follow() echo.HandlerFunc {
+	return h.changeFollow("followHandlers.Follow", h.followUC.Follow)
+}
+
+// Unfollow godoc
+// @Summary Unfollow user
+// @Description unfollow user, unfollowing user caller does not follow has no effect
+// @Tags Follow
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "user id"
+// @Success 200 {object} models.FollowState
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 401 {object} httpErrors.RestError
+// @Failure 500 {object} httpErrors.RestError
+// @Router /users/{id}/follow [delete]
+func (h *followHandlers) Unfollow() echo.HandlerFunc {
+	return h.changeFollow("followHandlers.Unfollow", h.followUC.Unfollow)
+}
+
+// ListFollowers godoc
+// @Summary List followers
+// @Description list users who follow user, most recently followed first
+// @Tags Follow
+// @Accept json
+// @Produce json
+// @Param id path string true "user id"
+// @Param page query int false "page number" Format(page)
+// @Param size query int false "number of elements per page" Format(size)
+// @Success 200 {object} models.FollowsList
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 404 {object} httpErrors.RestError
+// @Failure 500 {object} httpErrors.RestError
+// @Router /users/{id}/followers [get]
+func (h *followHandlers) ListFollowers() echo.HandlerFunc {
+	return h.list("followHandlers.ListFollowers", h.followUC.ListFollowers)
+}
+
+// ListFollowing godoc
+// @Summary List following
+// @Description list users user follows, most recently followed first
+// @Tags Follow
+// @Accept json
+// @Produce json
+// @Param id path string true "user id"
+// @Param page query int false "page number" Format(page)
+// @Param size query int false "number of elements per page" Format(size)
+// @Success 200 {object} models.FollowsList
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 404 {object} httpErrors.RestError
+// @Failure 500 {object} httpErrors.RestError
+// @Router /users/{id}/following [get]
+func (h *followHandlers) ListFollowing() echo.HandlerFunc {
+	return h.list("followHandlers.ListFollowing", h.followUC.ListFollowing)
+}
+
+func (h *followHandlers) changeFollow(operation string, change func(ctx context.Context, userID uuid.UUID) (*models.FollowState, error)) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), operation)
+		defer span.Finish()
+
+		userUID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(httpErrors.NewBadRequestError(err)))
+		}
+
+		state, err := change(ctx, userUID)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, state)
+	}
+}
+
+func (h *followHandlers) list(operation string, list func(ctx context.Context, userID uuid.UUID, pq *utils.PaginationQuery) (*models.FollowsList, error)) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), operation)
+		defer span.Finish()
+
+		userUID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(httpErrors.NewBadRequestError(err)))
+		}
+
+		pq, err := utils.GetPaginationFromCtx(c)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		followsList, err := list(ctx, userUID, pq)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, followsList)
+	}
+}
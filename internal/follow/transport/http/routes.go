@@ -0,0 +1,14 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/follow"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/middleware"
+)
+
+func MapFollowRoutes(userGroup *echo.Group, h follow.Handlers, mw *middleware.MiddlewareManager) {
+	userGroup.POST("/:id/follow", h.Follow(), mw.AuthPASETOMiddleware)
+	userGroup.DELETE("/:id/follow", h.Unfollow(), mw.AuthPASETOMiddleware)
+	userGroup.GET("/:id/followers", h.ListFollowers())
+	userGroup.GET("/:id/following", h.ListFollowing())
+}
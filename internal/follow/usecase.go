@@ -0,0 +1,16 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase_mock.go -package mock
+package follow
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/utils"
+)
+
+type UseCase interface {
+	Follow(ctx context.Context, userID uuid.UUID) (*models.FollowState, error)
+	Unfollow(ctx context.Context, userID uuid.UUID) (*models.FollowState, error)
+	ListFollowers(ctx context.Context, userID uuid.UUID, pq *utils.PaginationQuery) (*models.FollowsList, error)
+	ListFollowing(ctx context.Context, userID uuid.UUID, pq *utils.PaginationQuery) (*models.FollowsList, error)
+}
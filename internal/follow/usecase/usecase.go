@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"github.com/scul0405/blog-clean-architecture-rest-api/config"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/feed"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/follow"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	httpErrors "github.com/scul0405/blog-clean-architecture-rest-api/pkg/http_errors"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/logger"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/utils"
+	"net/http"
+)
+
+type followUseCase struct {
+	cfg        *config.Config
+	followRepo follow.Repository
+	feedUC     feed.UseCase
+	logger     logger.Logger
+}
+
+func NewFollowUseCase(cfg *config.Config, followRepo follow.Repository, feedUC feed.UseCase, logger logger.Logger) follow.UseCase {
+	return &followUseCase{cfg: cfg, followRepo: followRepo, feedUC: feedUC, logger: logger}
+}
+
+// Follow user by caller, following user again changes nothing
+func (u *followUseCase) Follow(ctx context.Context, userID uuid.UUID) (*models.FollowState, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "followUC.Follow")
+	defer span.Finish()
+
+	callerUID, err := utils.GetUserUIDFromCtx(ctx)
+	if err != nil {
+		return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "followUC.Follow.GetUserUIDFromCtx"))
+	}
+
+	if callerUID == userID {
+		return nil, httpErrors.NewRestError(http.StatusBadRequest, "Can not follow yourself", nil)
+	}
+
+	if err = u.checkUserExists(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	created, err := u.followRepo.Create(ctx, callerUID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if created {
+		u.resetFeed(ctx, callerUID)
+	}
+
+	return u.state(ctx, userID, true)
+}
+
+// Unfollow user by caller, unfollowing user caller does not follow changes nothing
+func (u *followUseCase) Unfollow(ctx context.Context, userID uuid.UUID) (*models.FollowState, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "followUC.Unfollow")
+	defer span.Finish()
+
+	callerUID, err := utils.GetUserUIDFromCtx(ctx)
+	if err != nil {
+		return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "followUC.Unfollow.GetUserUIDFromCtx"))
+	}
+
+	deleted, err := u.followRepo.Delete(ctx, callerUID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if deleted {
+		u.resetFeed(ctx, callerUID)
+	}
+
+	return u.state(ctx, userID, false)
+}
+
+func (u *followUseCase) ListFollowers(ctx context.Context, userID uuid.UUID, pq *utils.PaginationQuery) (*models.FollowsList, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "followUC.ListFollowers")
+	defer span.Finish()
+
+	if err := u.checkUserExists(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	return u.followRepo.ListFollowers(ctx, userID, pq)
+}
+
+func (u *followUseCase) ListFollowing(ctx context.Context, userID uuid.UUID, pq *utils.PaginationQuery) (*models.FollowsList, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "followUC.ListFollowing")
+	defer span.Finish()
+
+	if err := u.checkUserExists(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	return u.followRepo.ListFollowing(ctx, userID, pq)
+}
+
+func (u *followUseCase) checkUserExists(ctx context.Context, userID uuid.UUID) error {
+	exists, err := u.followRepo.UserExists(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return httpErrors.NewRestError(http.StatusNotFound, httpErrors.NotFound.Error(), nil)
+	}
+
+	return nil
+}
+
+func (u *followUseCase) state(ctx context.Context, userID uuid.UUID, following bool) (*models.FollowState, error) {
+	counts, err := u.followRepo.GetCounts(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.FollowState{UserID: userID, Following: following, FollowCounts: *counts}, nil
+}
+
+// resetFeed drop feed of follower, it no longer matches authors follower follows
+func (u *followUseCase) resetFeed(ctx context.Context, followerID uuid.UUID) {
+	if err := u.feedUC.Reset(ctx, followerID); err != nil {
+		u.logger.Errorf("followUC.resetFeed: Reset: %v", err)
+	}
+}
@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/scul0405/blog-clean-architecture-rest-api/config"
+	feedMock "github.com/scul0405/blog-clean-architecture-rest-api/internal/feed/mock"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/follow/mock"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	httpErrors "github.com/scul0405/blog-clean-architecture-rest-api/pkg/http_errors"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/logger"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"testing"
+)
+
+func TestFollowUseCase_Follow(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Logger: config.LoggerConfig{
+			Development: true,
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	mockFollowRepo := mock.NewMockRepository(ctrl)
+	mockFeedUC := feedMock.NewMockUseCase(ctrl)
+	followUC := NewFollowUseCase(cfg, mockFollowRepo, mockFeedUC, apiLogger)
+
+	callerUID := uuid.New()
+	ctx := context.WithValue(context.Background(), "user_id", callerUID.String())
+
+	t.Run("Follow resets feed of caller", func(t *testing.T) {
+		userUID := uuid.New()
+
+		mockFollowRepo.EXPECT().UserExists(gomock.Any(), userUID).Return(true, nil)
+		mockFollowRepo.EXPECT().Create(gomock.Any(), callerUID, userUID).Return(true, nil)
+		mockFeedUC.EXPECT().Reset(gomock.Any(), callerUID).Return(nil)
+		mockFollowRepo.EXPECT().GetCounts(gomock.Any(), userUID).Return(&models.FollowCounts{FollowersCount: 1}, nil)
+
+		state, err := followUC.Follow(ctx, userUID)
+		require.NoError(t, err)
+		require.True(t, state.Following)
+		require.Equal(t, userUID, state.UserID)
+		require.Equal(t, 1, state.FollowersCount)
+	})
+
+	t.Run("Follow again keeps feed", func(t *testing.T) {
+		userUID := uuid.New()
+
+		mockFollowRepo.EXPECT().UserExists(gomock.Any(), userUID).Return(true, nil)
+		mockFollowRepo.EXPECT().Create(gomock.Any(), callerUID, userUID).Return(false, nil)
+		mockFollowRepo.EXPECT().GetCounts(gomock.Any(), userUID).Return(&models.FollowCounts{FollowersCount: 1}, nil)
+
+		state, err := followUC.Follow(ctx, userUID)
+		require.NoError(t, err)
+		require.True(t, state.Following)
+	})
+
+	t.Run("Follow yourself", func(t *testing.T) {
+		_, err := followUC.Follow(ctx, callerUID)
+		require.Error(t, err)
+		require.Equal(t, http.StatusBadRequest, httpErrors.ParseErrors(err).Status())
+	})
+
+	t.Run("Follow missing user", func(t *testing.T) {
+		userUID := uuid.New()
+
+		mockFollowRepo.EXPECT().UserExists(gomock.Any(), userUID).Return(false, nil)
+
+		_, err := followUC.Follow(ctx, userUID)
+		require.Error(t, err)
+		require.Equal(t, http.StatusNotFound, httpErrors.ParseErrors(err).Status())
+	})
+
+	t.Run("Follow without login", func(t *testing.T) {
+		_, err := followUC.Follow(context.Background(), uuid.New())
+		require.Error(t, err)
+		require.Equal(t, http.StatusUnauthorized, httpErrors.ParseErrors(err).Status())
+	})
+
+	t.Run("Unfollow resets feed of caller", func(t *testing.T) {
+		userUID := uuid.New()
+
+		mockFollowRepo.EXPECT().Delete(gomock.Any(), callerUID, userUID).Return(true, nil)
+		mockFeedUC.EXPECT().Reset(gomock.Any(), callerUID).Return(nil)
+		mockFollowRepo.EXPECT().GetCounts(gomock.Any(), userUID).Return(&models.FollowCounts{}, nil)
+
+		state, err := followUC.Unfollow(ctx, userUID)
+		require.NoError(t, err)
+		require.False(t, state.Following)
+		require.Equal(t, 0, state.FollowersCount)
+	})
+}
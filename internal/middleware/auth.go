@@ -10,6 +10,17 @@ import (
 	"strings"
 )
 
+// OptionalAuthPASETOMiddleware authenticate request only when it has token, e.g. for public routes showing caller's own state
+func (mw *MiddlewareManager) OptionalAuthPASETOMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if c.Request().Header.Get("Authorization") == "" {
+			return next(c)
+		}
+
+		return mw.AuthPASETOMiddleware(next)(c)
+	}
+}
+
 func (mw *MiddlewareManager) AuthPASETOMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(c echo.Context) error {
 		bearerHeader := c.Request().Header.Get("Authorization")
@@ -31,9 +42,20 @@ func (mw *MiddlewareManager) AuthPASETOMiddleware(next echo.HandlerFunc) echo.Ha
 			return c.JSON(http.StatusUnauthorized, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
 		}
 
+		if err = mw.authUC.ValidateAccessToken(c.Request().Context(), payload); err != nil {
+			mw.logger.Error("auth middleware", zap.String("validateAccessToken", err.Error()))
+			return c.JSON(http.StatusUnauthorized, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+		}
+
 		c.Set("user_id", payload.ID)
+		c.Set("session_id", payload.SessionID)
+		c.Set("token_id", payload.TokenID)
+		c.Set("role", payload.Role)
 
 		ctx := context.WithValue(c.Request().Context(), "user_id", payload.ID)
+		ctx = context.WithValue(ctx, "session_id", payload.SessionID)
+		ctx = context.WithValue(ctx, "token_id", payload.TokenID)
+		ctx = context.WithValue(ctx, "role", payload.Role)
 		c.SetRequest(c.Request().WithContext(ctx))
 
 		return next(c)
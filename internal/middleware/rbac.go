@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"github.com/labstack/echo/v4"
+	httpErrors "github.com/scul0405/blog-clean-architecture-rest-api/pkg/http_errors"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/rbac"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/utils"
+	"go.uber.org/zap"
+)
+
+// RequireRole allow request only for users with one of roles, must be used after AuthPASETOMiddleware
+func (mw *MiddlewareManager) RequireRole(roles ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			role := utils.GetRoleFromCtx(c.Request().Context())
+			if !rbac.HasRole(role, roles...) {
+				mw.logger.Error("rbac middleware", zap.String("role", role), zap.Strings("requiredRoles", roles))
+				return c.JSON(httpErrors.ErrorResponse(httpErrors.NewMissingRoleError(roles, httpErrors.Forbidden)))
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// RequirePermission allow request only for users whose role grants permission, must be used after AuthPASETOMiddleware
+func (mw *MiddlewareManager) RequirePermission(permission rbac.Permission) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			role := utils.GetRoleFromCtx(c.Request().Context())
+			if !rbac.HasPermission(role, permission) {
+				mw.logger.Error("rbac middleware", zap.String("role", role), zap.String("permission", string(permission)))
+				return c.JSON(httpErrors.ErrorResponse(httpErrors.NewMissingPermissionError(string(permission), httpErrors.Forbidden)))
+			}
+
+			return next(c)
+		}
+	}
+}
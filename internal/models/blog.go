@@ -8,35 +8,120 @@ import (
 
 // Blog base model
 type Blog struct {
-	BlogID    uuid.UUID `json:"blog_id" db:"blog_id" validate:"omitempty,uuid"`
-	AuthorID  uuid.UUID `json:"author_id,omitempty" db:"author_id"`
-	Title     string    `json:"title" db:"title" validate:"required,gte=10"`
-	Content   string    `json:"content" db:"content" validate:"required,gte=20"`
-	ImageURL  *string   `json:"image_url,omitempty" db:"image_url" validate:"omitempty,lte=512,url"`
-	Category  *string   `json:"category,omitempty" db:"category" validate:"omitempty,lte=10"`
+	BlogID   uuid.UUID `json:"blog_id" db:"blog_id" validate:"omitempty,uuid"`
+	AuthorID uuid.UUID `json:"author_id,omitempty" db:"author_id"`
+	Title    string    `json:"title" db:"title" validate:"required,gte=10"`
+	Content  string    `json:"content" db:"content" validate:"required,gte=20"`
+	// ContentFormat of new blog is plain or markdown, plain is default
+	ContentFormat string  `json:"content_format,omitempty" db:"content_format" validate:"omitempty,oneof=plain markdown"`
+	ImageURL      *string `json:"image_url,omitempty" db:"image_url" validate:"omitempty,lte=512,url"`
+	// Category is slug of one of categories, tags are normalised to lower case slugs
+	Category *string `json:"category,omitempty" db:"category" validate:"omitempty,lte=64"`
+	Tags     Tags    `json:"tags,omitempty" db:"tags" validate:"omitempty,max=10,dive,required,lte=32"`
+	// Status of new blog is draft or published, draft is default
+	Status      string     `json:"status,omitempty" db:"status" validate:"omitempty,oneof=draft published"`
+	PublishedAt *time.Time `json:"published_at,omitempty" db:"published_at" swaggerignore:"true"`
+	// Held is set by content filter, held blog is kept as draft
+	Held      bool      `json:"-" db:"held"`
 	CreatedAt time.Time `json:"created_at,omitempty" db:"created_at"`
 	UpdatedAt time.Time `json:"updated_at,omitempty" db:"updated_at"`
 }
 
-// BlogsList contains list of blogs
+const (
+	BlogStatusDraft     = "draft"
+	BlogStatusScheduled = "scheduled"
+	BlogStatusPublished = "published"
+	BlogStatusArchived  = "archived"
+)
+
+const (
+	BlogContentPlain    = "plain"
+	BlogContentMarkdown = "markdown"
+)
+
+// BlogStatuses are statuses blogs list can be filtered by
+var BlogStatuses = []string{BlogStatusDraft, BlogStatusScheduled, BlogStatusPublished, BlogStatusArchived}
+
+// BlogAccess is what deciding whether caller can view blog needs, comments and reactions follow their blog
+type BlogAccess struct {
+	BlogID   uuid.UUID `db:"blog_id"`
+	AuthorID uuid.UUID `db:"author_id"`
+	Status   string    `db:"status"`
+	Held     bool      `db:"held"`
+}
+
+// BlogPublish publish blog now or schedule it when PublishAt is in future
+type BlogPublish struct {
+	PublishAt *time.Time `json:"publish_at,omitempty"`
+}
+
+// BlogsList contains list of blogs, in cursor mode counts and page are not computed and cursors are set instead
 type BlogsList struct {
 	TotalCount int         `json:"total_count"`
 	TotalPages int         `json:"total_pages"`
 	Page       int         `json:"page"`
 	Size       int         `json:"size"`
 	HasMore    bool        `json:"has_more"`
+	NextCursor *string     `json:"next_cursor,omitempty"`
+	PrevCursor *string     `json:"prev_cursor,omitempty"`
 	Blogs      []*BlogBase `json:"blogs"`
 }
 
 // BlogBase contains data when update and response to client
 type BlogBase struct {
-	BlogID    uuid.UUID `json:"blog_id" db:"blog_id"`
-	AuthorID  uuid.UUID `json:"author_id,omitempty" db:"author_id"`
-	Title     string    `json:"title" db:"title" validate:"omitempty,gte=10"`
-	Content   string    `json:"content" db:"content" validate:"omitempty,gte=20"`
-	ImageURL  *string   `json:"image_url,omitempty" db:"image_url" validate:"omitempty,lte=512,url"`
-	Category  *string   `json:"category,omitempty" db:"category" validate:"omitempty,lte=10"`
-	Author    string    `json:"author" db:"author"`
+	BlogID   uuid.UUID `json:"blog_id" db:"blog_id"`
+	AuthorID uuid.UUID `json:"author_id,omitempty" db:"author_id"`
+	Title    string    `json:"title" db:"title" validate:"omitempty,gte=10"`
+	Content  string    `json:"content,omitempty" db:"content" validate:"omitempty,gte=20"`
+	// ContentHTML is content rendered to sanitised HTML, it is returned when HTML is requested only.
+	// Lists return excerpt, word count and reading time in minutes instead of content
+	ContentFormat string  `json:"content_format" db:"content_format" validate:"omitempty,oneof=plain markdown"`
+	ContentHTML   *string `json:"content_html,omitempty" db:"content_html" validate:"-"`
+	Excerpt       string  `json:"excerpt" db:"excerpt" validate:"-"`
+	WordCount     int     `json:"word_count" db:"word_count" validate:"-"`
+	ReadingTime   int     `json:"reading_time" db:"reading_time" validate:"-"`
+	ImageURL      *string `json:"image_url,omitempty" db:"image_url" validate:"omitempty,lte=512,url"`
+	Category      *string `json:"category,omitempty" db:"category" validate:"omitempty,lte=64"`
+	Tags          Tags    `json:"tags" db:"tags" validate:"omitempty,max=10,dive,required,lte=32"`
+	Author        string  `json:"author" db:"author"`
+	// Status is changed by publish and archive only, PublishedAt is time blog was or is scheduled to be published.
+	// Held blog waits for moderator, it is not published until moderator releases it
+	Status      string     `json:"status" db:"status" validate:"-"`
+	Held        bool       `json:"held" db:"held" validate:"-"`
+	PublishedAt *time.Time `json:"published_at,omitempty" db:"published_at" validate:"-"`
+	// Version grows with every change, ETag of blog starts with it. Update and delete of version other than 0 fail if blog changed
+	Version int `json:"version" db:"version" validate:"-"`
+	// Slug is made of title, it changes with title and old slugs redirect to current one
+	Slug      string    `json:"slug" db:"slug" validate:"-"`
 	CreatedAt time.Time `json:"created_at,omitempty" db:"created_at"`
 	UpdatedAt time.Time `json:"updated_at,omitempty" db:"updated_at"`
+	// Rank and Snippet are set by search only, snippet is escaped text of content, or of best matching comment when only
+	// comments match, with matches wrapped in <mark>
+	Rank    *float64 `json:"rank,omitempty" db:"rank" validate:"-"`
+	Snippet *string  `json:"snippet,omitempty" db:"snippet" validate:"-"`
+	// Reactions count reactions per kind, MyReactions are kinds caller reacted with
+	Reactions   map[string]int `json:"reactions,omitempty" db:"-" validate:"-"`
+	MyReactions []string       `json:"my_reactions,omitempty" db:"-" validate:"-"`
+}
+
+// BlogFilter contains optional filters of blogs list, zero value matches every published blog.
+// Blogs which are not published are listed for their author only, ViewerID is set to caller
+type BlogFilter struct {
+	Category      string
+	AuthorID      *uuid.UUID
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	HasImage      *bool
+	Status        string
+	Tag           string
+	ViewerID      *uuid.UUID
+}
+
+// BlogSortFields are fields blogs list can be sorted by
+var BlogSortFields = []string{"created_at", "updated_at", "title"}
+
+// BlogSearchQuery contains full text query and optional filters
+type BlogSearchQuery struct {
+	Query string
+	BlogFilter
 }
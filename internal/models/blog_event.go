@@ -0,0 +1,36 @@
+package models
+
+import (
+	"encoding/json"
+
+	"github.com/google/uuid"
+)
+
+// Types of events streamed to clients watching blog
+const (
+	BlogEventCommentCreated = "comment.created"
+	BlogEventCommentUpdated = "comment.updated"
+	BlogEventCommentDeleted = "comment.deleted"
+	BlogEventCommentLikes   = "comment.likes"
+	// BlogEventReset tells client events were missed, client reloads comments instead of resuming
+	BlogEventReset = "reset"
+)
+
+// BlogEvent is change of blog pushed to clients, ID orders events of one blog and is used to resume stream
+type BlogEvent struct {
+	ID     string          `json:"id,omitempty"`
+	BlogID uuid.UUID       `json:"blog_id"`
+	Type   string          `json:"type"`
+	Data   json.RawMessage `json:"data,omitempty"`
+}
+
+// CommentDeletedEvent is data of event of deleted or hidden comment
+type CommentDeletedEvent struct {
+	CommentID uuid.UUID `json:"comment_id"`
+}
+
+// CommentLikesEvent is data of event of changed likes count of comment
+type CommentLikesEvent struct {
+	CommentID uuid.UUID `json:"comment_id"`
+	Likes     int64     `json:"likes"`
+}
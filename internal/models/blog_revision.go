@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/utils"
+)
+
+// BlogRevision is state of blog after create, update or restore, revisions of blog are numbered from 1
+type BlogRevision struct {
+	BlogID   uuid.UUID  `json:"blog_id" db:"blog_id"`
+	Revision int        `json:"revision" db:"revision"`
+	EditorID *uuid.UUID `json:"editor_id,omitempty" db:"editor_id"`
+	Editor   string     `json:"editor" db:"editor"`
+	Title    string     `json:"title" db:"title"`
+	Content  string     `json:"content,omitempty" db:"content"`
+	// ContentFormat is restored with content
+	ContentFormat string    `json:"content_format" db:"content_format"`
+	ImageURL      *string   `json:"image_url,omitempty" db:"image_url"`
+	Category      *string   `json:"category,omitempty" db:"category"`
+	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+}
+
+// BlogRevisionsList contains revisions of blog without content, newest first
+type BlogRevisionsList struct {
+	TotalCount int             `json:"total_count"`
+	TotalPages int             `json:"total_pages"`
+	Page       int             `json:"page"`
+	Size       int             `json:"size"`
+	HasMore    bool            `json:"has_more"`
+	Revisions  []*BlogRevision `json:"revisions"`
+}
+
+// BlogRevisionDiff contains line changes of title and content from one revision to other
+type BlogRevisionDiff struct {
+	BlogID  uuid.UUID        `json:"blog_id"`
+	From    int              `json:"from"`
+	To      int              `json:"to"`
+	Title   []utils.DiffLine `json:"title"`
+	Content []utils.DiffLine `json:"content"`
+}
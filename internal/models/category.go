@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Category groups blogs, blogs refer to it by slug which is made of name when it is not given and never changes
+type Category struct {
+	CategoryID  uuid.UUID  `json:"category_id" db:"category_id" validate:"omitempty,uuid"`
+	Slug        string     `json:"slug" db:"slug" validate:"omitempty,lte=64"`
+	Name        string     `json:"name" db:"name" validate:"required,lte=64"`
+	Description *string    `json:"description,omitempty" db:"description" validate:"omitempty,lte=512"`
+	BlogsCount  int        `json:"blogs_count" db:"blogs_count" validate:"-"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt   *time.Time `json:"updated_at,omitempty" db:"updated_at"`
+}
+
+// CategoryBase contains data when update category, empty fields are left unchanged
+type CategoryBase struct {
+	CategoryID  uuid.UUID `json:"-"`
+	Name        string    `json:"name" validate:"omitempty,lte=64"`
+	Description *string   `json:"description,omitempty" validate:"omitempty,lte=512"`
+}
+
+// CategoriesList contains categories ordered by name
+type CategoriesList struct {
+	TotalCount int         `json:"total_count"`
+	TotalPages int         `json:"total_pages"`
+	Page       int         `json:"page"`
+	Size       int         `json:"size"`
+	HasMore    bool        `json:"has_more"`
+	Categories []*Category `json:"categories"`
+}
@@ -10,11 +10,17 @@ import (
 type Comment struct {
 	CommentID uuid.UUID `json:"comment_id" db:"comment_id" validate:"omitempty,uuid"`
 	AuthorID  uuid.UUID `json:"author_id" db:"author_id"`
-	BlogID    uuid.UUID `json:"blog_id" db:"blog_id" validate:"required"`
-	Message   string    `json:"message" db:"message" validate:"required,gte=10"`
-	Likes     int64     `json:"likes" db:"likes" validate:"omitempty"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+	BlogID    uuid.UUID `json:"blog_id" db:"blog_id" validate:"required_without=ParentID"`
+	// ParentID is set for reply, blog of reply is blog of parent
+	ParentID  *uuid.UUID `json:"parent_id,omitempty" db:"parent_id" validate:"omitempty"`
+	Depth     int        `json:"depth" db:"depth" swaggerignore:"true"`
+	Message   string     `json:"message" db:"message" validate:"required,gte=10"`
+	Likes     int64      `json:"likes" db:"likes" validate:"omitempty"`
+	Version   int        `json:"version" db:"version" swaggerignore:"true"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at" db:"updated_at"`
+	// Hidden comment is held for moderation by content filter
+	Hidden bool `json:"hidden,omitempty" db:"hidden" swaggerignore:"true"`
 }
 
 // Base Comment response
@@ -26,16 +32,59 @@ type CommentBase struct {
 	AvatarURL *string   `json:"avatar_url" db:"avatar_url"`
 	Message   string    `json:"message" db:"message" validate:"required,gte=10"`
 	Likes     int64     `json:"likes" db:"likes" validate:"omitempty"`
+	// Version grows with every change, ETag of comment starts with it. Update and delete of version other than 0 fail if comment changed
+	Version   int       `json:"version" db:"version"`
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+	// ParentID is set for reply, Depth is 0 for comment of blog and grows by one per reply level
+	ParentID     *uuid.UUID `json:"parent_id,omitempty" db:"parent_id"`
+	Depth        int        `json:"depth" db:"depth"`
+	RepliesCount int        `json:"replies_count" db:"replies_count"`
+	// Deleted comment with replies is tombstone without author and message
+	Deleted bool `json:"deleted" db:"deleted"`
+	// Hidden comment was hidden by reports or rejected by moderator, it is shown to its author and moderators only
+	Hidden bool `json:"hidden,omitempty" db:"hidden"`
+	// Replies are set in tree listing only
+	Replies []*CommentBase `json:"replies,omitempty" db:"-"`
+	// Reactions count reactions per kind, MyReactions are kinds caller reacted with
+	Reactions   map[string]int `json:"reactions,omitempty" db:"-"`
+	MyReactions []string       `json:"my_reactions,omitempty" db:"-"`
+	// LikedByMe is set for authenticated caller only
+	LikedByMe *bool `json:"liked_by_me,omitempty" db:"-"`
+}
+
+// CommentFilter select comments of blog, optionally only replies of parent or only comments which are not replies
+type CommentFilter struct {
+	BlogID    uuid.UUID
+	ParentID  *uuid.UUID
+	RootsOnly bool
+	CommentVisibility
 }
 
-// List comments response
+// CommentVisibility select which hidden comments are listed, hidden comments of ViewerID are listed to their author
+type CommentVisibility struct {
+	ViewerID   *uuid.UUID
+	ShowHidden bool
+}
+
+const (
+	// CommentsListFlat list every comment of blog, replies have parent_id
+	CommentsListFlat = "flat"
+	// CommentsListTree list comments which are not replies with every reply nested below
+	CommentsListTree = "tree"
+)
+
+// CommentSortFields are fields comments list can be sorted by
+var CommentSortFields = []string{"created_at", "updated_at", "likes"}
+
+// List comments response, in cursor mode counts and page are not computed and cursors are set instead
 type CommentsList struct {
 	TotalCount int            `json:"total_count"`
 	TotalPages int            `json:"total_pages"`
 	Page       int            `json:"page"`
 	Size       int            `json:"size"`
 	HasMore    bool           `json:"has_more"`
+	NextCursor *string        `json:"next_cursor,omitempty"`
+	PrevCursor *string        `json:"prev_cursor,omitempty"`
 	Comments   []*CommentBase `json:"comments"`
 }
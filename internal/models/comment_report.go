@@ -0,0 +1,81 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// CommentVisible is status of comment which was not reviewed
+	CommentVisible = "visible"
+	// CommentHidden is status of comment hidden by reports until moderator reviews it
+	CommentHidden = "hidden"
+	// CommentApproved is status of reviewed comment which stays visible, reports do not hide it again until it is edited
+	CommentApproved = "approved"
+	// CommentRejected is status of reviewed comment which stays hidden
+	CommentRejected = "rejected"
+)
+
+const (
+	// ModerationApprove keeps comment visible
+	ModerationApprove = "approve"
+	// ModerationReject hides comment
+	ModerationReject = "reject"
+	// ModerationBan hides comment and bans its author from commenting
+	ModerationBan = "ban"
+)
+
+// Pending reports wait for moderator, they are resolved by any decision about their comment
+const (
+	CommentReportPending  = "pending"
+	CommentReportResolved = "resolved"
+)
+
+// CommentReportReasons are reasons comment can be reported for
+var CommentReportReasons = []string{"spam", "abuse", "harassment", "off_topic", "other"}
+
+// CommentReport of user, user has one report per comment and reporting again replaces it
+type CommentReport struct {
+	ReportID   uuid.UUID `json:"report_id" db:"report_id" swaggerignore:"true"`
+	CommentID  uuid.UUID `json:"comment_id" db:"comment_id" swaggerignore:"true"`
+	ReporterID uuid.UUID `json:"reporter_id" db:"reporter_id" swaggerignore:"true"`
+	Reason     string    `json:"reason" db:"reason" validate:"required,oneof=spam abuse harassment off_topic other"`
+	Details    string    `json:"details,omitempty" db:"details" validate:"lte=512"`
+	Status     string    `json:"status" db:"status" swaggerignore:"true"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at" swaggerignore:"true"`
+}
+
+// CommentModeration is decision of moderator about reported comment
+type CommentModeration struct {
+	CommentID   uuid.UUID
+	ModeratorID uuid.UUID
+	Action      string
+}
+
+// ReportReasons are distinct reasons of pending reports, database returns them comma separated
+type ReportReasons []string
+
+// Scan read comma separated reasons
+func (r *ReportReasons) Scan(src interface{}) error {
+	return (*Tags)(r).Scan(src)
+}
+
+// ModerationItem is comment waiting for review with summary of its pending reports, comment held by content filter can have none
+type ModerationItem struct {
+	CommentBase
+	ModerationStatus string        `json:"moderation_status" db:"moderation_status"`
+	ReportsCount     int           `json:"reports_count" db:"reports_count"`
+	Reasons          ReportReasons `json:"reasons" db:"reasons"`
+	LastReportedAt   *time.Time    `json:"last_reported_at,omitempty" db:"last_reported_at"`
+}
+
+// ModerationQueue contains hidden comments and comments with pending reports, hidden comments first and then most reported
+type ModerationQueue struct {
+	TotalCount int               `json:"total_count"`
+	TotalPages int               `json:"total_pages"`
+	Page       int               `json:"page"`
+	Size       int               `json:"size"`
+	HasMore    bool              `json:"has_more"`
+	Comments   []*ModerationItem `json:"comments"`
+}
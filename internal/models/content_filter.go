@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Actions of content filter rules from weakest to strongest, decision takes strongest action of matched rules
+const (
+	FilterAllow  = "allow"
+	FilterMask   = "mask"
+	FilterHold   = "hold"
+	FilterReject = "reject"
+)
+
+const (
+	FilterTargetComment = "comment"
+	FilterTargetBlog    = "blog"
+)
+
+// FilterInput is content of author checked by content filter, TargetID is nil for content which is not stored yet
+type FilterInput struct {
+	TargetType string
+	TargetID   *uuid.UUID
+	AuthorID   uuid.UUID
+	Texts      []string
+}
+
+// FilterMatch is rule which matched content with its action. Failed match is rule which could not check content,
+// its action was not taken and reason is its error
+type FilterMatch struct {
+	Rule   string `json:"rule"`
+	Action string `json:"action"`
+	Reason string `json:"reason"`
+	Failed bool   `json:"failed,omitempty"`
+}
+
+// FilterDecision of content filter. Texts are texts of input with parts masked by rules, every decision is recorded
+type FilterDecision struct {
+	DecisionID uuid.UUID     `json:"decision_id" db:"decision_id"`
+	TargetType string        `json:"target_type" db:"target_type"`
+	TargetID   *uuid.UUID    `json:"target_id" db:"target_id"`
+	AuthorID   uuid.UUID     `json:"author_id" db:"author_id"`
+	Action     string        `json:"action" db:"action"`
+	Matches    []FilterMatch `json:"matches" db:"-"`
+	CreatedAt  time.Time     `json:"created_at" db:"created_at"`
+	Texts      []string      `json:"-" db:"-"`
+	// Fingerprint identifies texts of author for duplicate detection
+	Fingerprint string `json:"-" db:"-"`
+}
@@ -0,0 +1,13 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FeedEntry is published blog in home feed of follower, feeds are ordered by publish time newest first
+type FeedEntry struct {
+	BlogID      uuid.UUID
+	PublishedAt time.Time
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FollowCounts of user shown on profile
+type FollowCounts struct {
+	FollowersCount int `json:"followers_count" db:"followers_count"`
+	FollowingCount int `json:"following_count" db:"following_count"`
+}
+
+// FollowState is result of following or unfollowing user
+type FollowState struct {
+	UserID    uuid.UUID `json:"user_id"`
+	Following bool      `json:"following"`
+	FollowCounts
+}
+
+// FollowUser is user in followers or following list, FollowedAt is when follow was made
+type FollowUser struct {
+	UserID     uuid.UUID `json:"user_id" db:"user_id"`
+	FirstName  string    `json:"first_name" db:"first_name"`
+	LastName   string    `json:"last_name" db:"last_name"`
+	Avatar     *string   `json:"avatar,omitempty" db:"avatar"`
+	FollowedAt time.Time `json:"followed_at" db:"followed_at"`
+}
+
+// FollowsList is followers or following of user, most recently followed first
+type FollowsList struct {
+	TotalCount int           `json:"total_count"`
+	TotalPages int           `json:"total_pages"`
+	Page       int           `json:"page"`
+	Size       int           `json:"size"`
+	HasMore    bool          `json:"has_more"`
+	Users      []*FollowUser `json:"users"`
+}
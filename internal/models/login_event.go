@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LoginEvent is a record of one login attempt
+type LoginEvent struct {
+	EventID   uuid.UUID  `json:"event_id" db:"event_id"`
+	UserID    *uuid.UUID `json:"user_id,omitempty" db:"user_id"`
+	Email     string     `json:"email" db:"email"`
+	IPAddress string     `json:"ip_address" db:"ip_address"`
+	Success   bool       `json:"success" db:"success"`
+	Reason    string     `json:"reason,omitempty" db:"reason"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+}
+
+// UnlockLogin clear failed attempts and lockout of email or client ip
+type UnlockLogin struct {
+	Email     string `json:"email" validate:"required_without=IPAddress,omitempty,email"`
+	IPAddress string `json:"ip_address" validate:"required_without=Email,omitempty,ip"`
+}
@@ -9,3 +9,15 @@ type UploadInput struct {
 	ContentType string
 	BucketName  string
 }
+
+// ImageInput contains uploaded image waiting to be processed into variants,
+// Image is set on upload and ObjectKey points to the stored upload afterwards
+type ImageInput struct {
+	ImageID    string
+	BucketName string
+	ObjectKey  string
+	Image      []byte
+}
+
+// ImageVariants contains url of every stored image variant by variant name
+type ImageVariants map[string]string
@@ -0,0 +1,93 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Notification types, user can turn off every type in preferences
+const (
+	// NotificationComment is comment on blog of recipient
+	NotificationComment = "comment"
+	// NotificationReply is reply to comment of recipient
+	NotificationReply = "reply"
+	// NotificationCommentLike is like of comment of recipient, likes are collapsed into one notification while it is unread
+	NotificationCommentLike = "comment_like"
+)
+
+// Domain events notifications are made of
+const (
+	EventCommentCreated = "comment_created"
+	EventCommentLiked   = "comment_liked"
+)
+
+// NotificationEvent is something actor did, recipients are found when event is processed
+type NotificationEvent struct {
+	Type      string
+	ActorID   uuid.UUID
+	BlogID    uuid.UUID
+	CommentID uuid.UUID
+	// ParentID is comment created comment replies to
+	ParentID *uuid.UUID
+}
+
+// Notification of recipient, ActorID is last actor and ActorsCount is how many distinct actors were collapsed into notification
+type Notification struct {
+	NotificationID uuid.UUID  `json:"notification_id" db:"notification_id"`
+	RecipientID    uuid.UUID  `json:"recipient_id" db:"recipient_id"`
+	ActorID        *uuid.UUID `json:"actor_id,omitempty" db:"actor_id"`
+	Type           string     `json:"type" db:"type"`
+	BlogID         *uuid.UUID `json:"blog_id,omitempty" db:"blog_id"`
+	CommentID      *uuid.UUID `json:"comment_id,omitempty" db:"comment_id"`
+	ActorsCount    int        `json:"actors_count" db:"actors_count"`
+	GroupKey       *string    `json:"-" db:"group_key"`
+	ReadAt         *time.Time `json:"read_at,omitempty" db:"read_at"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// NotificationsList is notifications of caller, most recently updated first
+type NotificationsList struct {
+	TotalCount    int             `json:"total_count"`
+	TotalPages    int             `json:"total_pages"`
+	Page          int             `json:"page"`
+	Size          int             `json:"size"`
+	HasMore       bool            `json:"has_more"`
+	UnreadCount   int             `json:"unread_count"`
+	Notifications []*Notification `json:"notifications"`
+}
+
+// NotificationsReadState is result of marking notifications as read
+type NotificationsReadState struct {
+	Marked      int64 `json:"marked"`
+	UnreadCount int   `json:"unread_count"`
+}
+
+// NotificationPreferences of user, every type is on until user turns it off
+type NotificationPreferences struct {
+	UserID      uuid.UUID `json:"user_id" db:"user_id" swaggerignore:"true"`
+	Comment     bool      `json:"comment" db:"comment"`
+	Reply       bool      `json:"reply" db:"reply"`
+	CommentLike bool      `json:"comment_like" db:"comment_like"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at" swaggerignore:"true"`
+}
+
+// DefaultNotificationPreferences are preferences of user who never changed them
+func DefaultNotificationPreferences(userID uuid.UUID) *NotificationPreferences {
+	return &NotificationPreferences{UserID: userID, Comment: true, Reply: true, CommentLike: true}
+}
+
+// Allows tell if user wants notifications of type
+func (p *NotificationPreferences) Allows(notificationType string) bool {
+	switch notificationType {
+	case NotificationComment:
+		return p.Comment
+	case NotificationReply:
+		return p.Reply
+	case NotificationCommentLike:
+		return p.CommentLike
+	default:
+		return false
+	}
+}
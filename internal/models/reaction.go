@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ReactionTargetBlog    = "blog"
+	ReactionTargetComment = "comment"
+
+	// ReactionLike is kind counted as like of comment
+	ReactionLike = "like"
+)
+
+// ReactionKinds are emoji users can react with, keyed by kind name
+var ReactionKinds = map[string]string{
+	ReactionLike: "👍",
+	"love":       "❤️",
+	"laugh":      "😂",
+	"wow":        "😮",
+	"sad":        "😢",
+	"angry":      "😡",
+}
+
+// Reaction of user to blog or comment, user can react with every kind once
+type Reaction struct {
+	UserID     uuid.UUID `json:"user_id" db:"user_id"`
+	TargetType string    `json:"target_type" db:"target_type"`
+	TargetID   uuid.UUID `json:"target_id" db:"target_id"`
+	Kind       string    `json:"kind" db:"kind"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
+
+// ReactionSummary is reactions of one target, counts per kind and kinds caller reacted with
+type ReactionSummary struct {
+	Counts map[string]int
+	Mine   []string
+}
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Session is a refresh token family, only the latest issued refresh token is valid
+type Session struct {
+	SessionID      uuid.UUID `json:"session_id"`
+	UserID         uuid.UUID `json:"user_id"`
+	RefreshTokenID string    `json:"refresh_token_id"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
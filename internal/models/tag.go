@@ -0,0 +1,50 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MaxBlogTags is number of tags blog can have
+const MaxBlogTags = 10
+
+// Tags are names of blog tags, database returns them comma separated.
+// Nil tags leave tags of blog unchanged on update, empty tags remove them
+type Tags []string
+
+// Scan read comma separated tag names
+func (t *Tags) Scan(src interface{}) error {
+	var names string
+	switch v := src.(type) {
+	case nil:
+	case string:
+		names = v
+	case []byte:
+		names = string(v)
+	default:
+		return fmt.Errorf("models.Tags.Scan: unsupported type %T", src)
+	}
+
+	*t = Tags{}
+	if names != "" {
+		*t = strings.Split(names, ",")
+	}
+
+	return nil
+}
+
+// Tag with number of published blogs tagged by it
+type Tag struct {
+	Name       string `json:"name" db:"name"`
+	BlogsCount int    `json:"blogs_count" db:"blogs_count"`
+}
+
+// TagsList contains tags, most used first
+type TagsList struct {
+	TotalCount int    `json:"total_count"`
+	TotalPages int    `json:"total_pages"`
+	Page       int    `json:"page"`
+	Size       int    `json:"size"`
+	HasMore    bool   `json:"has_more"`
+	Tags       []*Tag `json:"tags"`
+}
@@ -10,24 +10,28 @@ import (
 
 // User full model
 type User struct {
-	UserID      uuid.UUID  `json:"user_id" db:"user_id" validate:"omitempty"`
-	FirstName   string     `json:"first_name" db:"first_name" validate:"required,lte=30"`
-	LastName    string     `json:"last_name" db:"last_name" validate:"required,lte=30"`
-	Email       string     `json:"email,omitempty" db:"email" validate:"omitempty,lte=60,email"`
-	Password    string     `json:"password,omitempty" db:"password" validate:"omitempty,required,gte=6"`
-	Role        *string    `json:"role,omitempty" db:"role" validate:"omitempty,lte=10"`
-	About       *string    `json:"about,omitempty" db:"about" validate:"omitempty,lte=1024"`
-	Avatar      *string    `json:"avatar,omitempty" db:"avatar" validate:"omitempty,lte=512,url"`
-	PhoneNumber *string    `json:"phone_number,omitempty" db:"phone_number" validate:"omitempty,lte=20"`
-	Address     *string    `json:"address,omitempty" db:"address" validate:"omitempty,lte=250"`
-	City        *string    `json:"city,omitempty" db:"city" validate:"omitempty,lte=24"`
-	Country     *string    `json:"country,omitempty" db:"country" validate:"omitempty,lte=24"`
-	Gender      *string    `json:"gender,omitempty" db:"gender" validate:"omitempty,lte=10"`
-	Postcode    *int       `json:"postcode,omitempty" db:"postcode" validate:"omitempty"`
-	Birthday    *time.Time `json:"birthday,omitempty" db:"birthday" validate:"omitempty,lte=10"`
-	CreatedAt   time.Time  `json:"created_at,omitempty" db:"created_at"`
-	UpdatedAt   time.Time  `json:"updated_at,omitempty" db:"updated_at"`
-	LoginDate   time.Time  `json:"login_date" db:"login_date"`
+	UserID        uuid.UUID  `json:"user_id" db:"user_id" validate:"omitempty"`
+	FirstName     string     `json:"first_name" db:"first_name" validate:"required,lte=30"`
+	LastName      string     `json:"last_name" db:"last_name" validate:"required,lte=30"`
+	Email         string     `json:"email,omitempty" db:"email" validate:"omitempty,lte=60,email"`
+	Password      string     `json:"password,omitempty" db:"password" validate:"omitempty,required,gte=6"`
+	Role          *string    `json:"role,omitempty" db:"role" validate:"omitempty,lte=10"`
+	About         *string    `json:"about,omitempty" db:"about" validate:"omitempty,lte=1024"`
+	Avatar        *string    `json:"avatar,omitempty" db:"avatar" validate:"omitempty,lte=512,url"`
+	PhoneNumber   *string    `json:"phone_number,omitempty" db:"phone_number" validate:"omitempty,lte=20"`
+	Address       *string    `json:"address,omitempty" db:"address" validate:"omitempty,lte=250"`
+	City          *string    `json:"city,omitempty" db:"city" validate:"omitempty,lte=24"`
+	Country       *string    `json:"country,omitempty" db:"country" validate:"omitempty,lte=24"`
+	Gender        *string    `json:"gender,omitempty" db:"gender" validate:"omitempty,lte=10"`
+	Postcode      *int       `json:"postcode,omitempty" db:"postcode" validate:"omitempty"`
+	Birthday      *time.Time `json:"birthday,omitempty" db:"birthday" validate:"omitempty,lte=10"`
+	EmailVerified bool       `json:"email_verified" db:"email_verified" validate:"-"`
+	CreatedAt     time.Time  `json:"created_at,omitempty" db:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at,omitempty" db:"updated_at"`
+	LoginDate     time.Time  `json:"login_date" db:"login_date"`
+	DeletedAt     *time.Time `json:"-" db:"deleted_at"`
+	// FollowCounts are attached to public profile only
+	*FollowCounts
 }
 
 // HashPassword hash the password with bcrypt
@@ -75,11 +79,48 @@ func (u *User) PrepareCreate() error {
 }
 
 type UserWithToken struct {
-	User        *User  `json:"user"`
-	AccessToken string `json:"access_token"`
+	User         *User  `json:"user"`
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token,omitempty"`
 }
 
 type LoginUser struct {
 	Email    string `json:"email" validate:"omitempty,lte=60,email"`
 	Password string `json:"password" validate:"omitempty,required,gte=6"`
 }
+
+type UpdateRole struct {
+	Role string `json:"role" validate:"required,oneof=user editor moderator admin"`
+}
+
+type VerifyEmail struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type ForgotPassword struct {
+	Email string `json:"email" validate:"required,lte=60,email"`
+}
+
+type ResetPassword struct {
+	Token    string `json:"token" validate:"required"`
+	Password string `json:"password" validate:"required,gte=6"`
+}
+
+// UpdateUser contains profile fields users can change on their own
+type UpdateUser struct {
+	FirstName   string     `json:"first_name" validate:"omitempty,lte=30"`
+	LastName    string     `json:"last_name" validate:"omitempty,lte=30"`
+	About       *string    `json:"about,omitempty" validate:"omitempty,lte=1024"`
+	PhoneNumber *string    `json:"phone_number,omitempty" validate:"omitempty,lte=20"`
+	Address     *string    `json:"address,omitempty" validate:"omitempty,lte=250"`
+	City        *string    `json:"city,omitempty" validate:"omitempty,lte=24"`
+	Country     *string    `json:"country,omitempty" validate:"omitempty,lte=24"`
+	Gender      *string    `json:"gender,omitempty" validate:"omitempty,lte=10"`
+	Postcode    *int       `json:"postcode,omitempty" validate:"omitempty"`
+	Birthday    *time.Time `json:"birthday,omitempty" validate:"omitempty"`
+}
+
+type ChangePassword struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required,gte=6"`
+}
@@ -12,3 +12,10 @@ type UserComments struct {
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
 }
+
+// CommentLikeState is likes of comment after caller liked or unliked it
+type CommentLikeState struct {
+	CommentID uuid.UUID `json:"comment_id"`
+	Likes     int64     `json:"likes"`
+	LikedByMe bool      `json:"liked_by_me"`
+}
@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: distributors.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	asynq "github.com/hibiken/asynq"
+	asynq0 "github.com/scul0405/blog-clean-architecture-rest-api/internal/notification/transport/asynq"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockNotificationTaskDistributor is a mock of NotificationTaskDistributor interface.
+type MockNotificationTaskDistributor struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationTaskDistributorMockRecorder
+}
+
+// MockNotificationTaskDistributorMockRecorder is the mock recorder for MockNotificationTaskDistributor.
+type MockNotificationTaskDistributorMockRecorder struct {
+	mock *MockNotificationTaskDistributor
+}
+
+// NewMockNotificationTaskDistributor creates a new mock instance.
+func NewMockNotificationTaskDistributor(ctrl *gomock.Controller) *MockNotificationTaskDistributor {
+	mock := &MockNotificationTaskDistributor{ctrl: ctrl}
+	mock.recorder = &MockNotificationTaskDistributorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationTaskDistributor) EXPECT() *MockNotificationTaskDistributorMockRecorder {
+	return m.recorder
+}
+
+// DistributeTaskNotify mocks base method.
+func (m *MockNotificationTaskDistributor) DistributeTaskNotify(ctx context.Context, payload *asynq0.NotifyPayload, opts ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, payload}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskNotify", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskNotify indicates an expected call of DistributeTaskNotify.
+func (mr *MockNotificationTaskDistributorMockRecorder) DistributeTaskNotify(ctx, payload interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, payload}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskNotify", reflect.TypeOf((*MockNotificationTaskDistributor)(nil).DistributeTaskNotify), varargs...)
+}
@@ -0,0 +1,21 @@
+//go:generate mockgen -source pg_repo.go -destination mock/pg_repo_mock.go -package mock
+package notification
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/utils"
+)
+
+type Repository interface {
+	Create(ctx context.Context, notification *models.Notification) (*models.Notification, error)
+	List(ctx context.Context, recipientID uuid.UUID, unreadOnly bool, pq *utils.PaginationQuery) (*models.NotificationsList, error)
+	CountUnread(ctx context.Context, recipientID uuid.UUID) (int, error)
+	MarkRead(ctx context.Context, recipientID uuid.UUID, id uuid.UUID) (*models.Notification, error)
+	MarkAllRead(ctx context.Context, recipientID uuid.UUID) (int64, error)
+	GetPreferences(ctx context.Context, userID uuid.UUID) (*models.NotificationPreferences, error)
+	UpsertPreferences(ctx context.Context, preferences *models.NotificationPreferences) (*models.NotificationPreferences, error)
+	GetBlogAuthor(ctx context.Context, blogID uuid.UUID) (uuid.UUID, error)
+	GetCommentAuthor(ctx context.Context, commentID uuid.UUID) (uuid.UUID, error)
+}
@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/notification"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/utils"
+)
+
+type notificationRepo struct {
+	db *sqlx.DB
+}
+
+func NewNotificationRepository(db *sqlx.DB) notification.Repository {
+	return &notificationRepo{db: db}
+}
+
+// Create notification or collapse it into unread notification of same group
+func (r *notificationRepo) Create(ctx context.Context, notification *models.Notification) (*models.Notification, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "notificationRepo.Create")
+	defer span.Finish()
+
+	var n models.Notification
+	if err := r.db.QueryRowxContext(ctx, createNotificationQuery,
+		notification.RecipientID,
+		notification.ActorID,
+		notification.Type,
+		notification.BlogID,
+		notification.CommentID,
+		notification.GroupKey,
+	).StructScan(&n); err != nil {
+		return nil, errors.Wrap(err, "notificationRepo.Create.StructScan")
+	}
+
+	return &n, nil
+}
+
+// List notifications of recipient, most recently updated first
+func (r *notificationRepo) List(ctx context.Context, recipientID uuid.UUID, unreadOnly bool, pq *utils.PaginationQuery) (*models.NotificationsList, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "notificationRepo.List")
+	defer span.Finish()
+
+	var totalCount int
+	if err := r.db.GetContext(ctx, &totalCount, getNotificationsCountQuery, recipientID, unreadOnly); err != nil {
+		return nil, errors.Wrap(err, "notificationRepo.List.GetContext.totalCount")
+	}
+
+	unreadCount, err := r.CountUnread(ctx, recipientID)
+	if err != nil {
+		return nil, err
+	}
+
+	var notifications = make([]*models.Notification, 0, pq.GetSize())
+	if totalCount > 0 {
+		if err = r.db.SelectContext(ctx, &notifications, listNotificationsQuery, recipientID, unreadOnly, pq.GetOffset(), pq.GetLimit()); err != nil {
+			return nil, errors.Wrap(err, "notificationRepo.List.SelectContext")
+		}
+	}
+
+	return &models.NotificationsList{
+		TotalCount:    totalCount,
+		TotalPages:    utils.GetTotalPages(totalCount, pq.GetSize()),
+		Page:          pq.GetPage(),
+		Size:          pq.GetSize(),
+		HasMore:       utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+		UnreadCount:   unreadCount,
+		Notifications: notifications,
+	}, nil
+}
+
+func (r *notificationRepo) CountUnread(ctx context.Context, recipientID uuid.UUID) (int, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "notificationRepo.CountUnread")
+	defer span.Finish()
+
+	var unreadCount int
+	if err := r.db.GetContext(ctx, &unreadCount, getUnreadCountQuery, recipientID); err != nil {
+		return 0, errors.Wrap(err, "notificationRepo.CountUnread.GetContext")
+	}
+
+	return unreadCount, nil
+}
+
+// MarkRead mark notification of recipient as read, notification of other user is not found
+func (r *notificationRepo) MarkRead(ctx context.Context, recipientID uuid.UUID, id uuid.UUID) (*models.Notification, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "notificationRepo.MarkRead")
+	defer span.Finish()
+
+	var n models.Notification
+	if err := r.db.QueryRowxContext(ctx, markNotificationReadQuery, id, recipientID).StructScan(&n); err != nil {
+		return nil, errors.Wrap(err, "notificationRepo.MarkRead.StructScan")
+	}
+
+	return &n, nil
+}
+
+// MarkAllRead mark unread notifications of recipient as read and return how many were marked
+func (r *notificationRepo) MarkAllRead(ctx context.Context, recipientID uuid.UUID) (int64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "notificationRepo.MarkAllRead")
+	defer span.Finish()
+
+	result, err := r.db.ExecContext(ctx, markAllNotificationsReadQuery, recipientID)
+	if err != nil {
+		return 0, errors.Wrap(err, "notificationRepo.MarkAllRead.ExecContext")
+	}
+
+	marked, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "notificationRepo.MarkAllRead.RowsAffected")
+	}
+
+	return marked, nil
+}
+
+// GetPreferences of user, user who never changed preferences has none
+func (r *notificationRepo) GetPreferences(ctx context.Context, userID uuid.UUID) (*models.NotificationPreferences, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "notificationRepo.GetPreferences")
+	defer span.Finish()
+
+	var p models.NotificationPreferences
+	if err := r.db.QueryRowxContext(ctx, getNotificationPreferencesQuery, userID).StructScan(&p); err != nil {
+		return nil, errors.Wrap(err, "notificationRepo.GetPreferences.StructScan")
+	}
+
+	return &p, nil
+}
+
+func (r *notificationRepo) UpsertPreferences(ctx context.Context, preferences *models.NotificationPreferences) (*models.NotificationPreferences, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "notificationRepo.UpsertPreferences")
+	defer span.Finish()
+
+	var p models.NotificationPreferences
+	if err := r.db.QueryRowxContext(ctx, upsertNotificationPreferencesQuery,
+		preferences.UserID,
+		preferences.Comment,
+		preferences.Reply,
+		preferences.CommentLike,
+	).StructScan(&p); err != nil {
+		return nil, errors.Wrap(err, "notificationRepo.UpsertPreferences.StructScan")
+	}
+
+	return &p, nil
+}
+
+func (r *notificationRepo) GetBlogAuthor(ctx context.Context, blogID uuid.UUID) (uuid.UUID, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "notificationRepo.GetBlogAuthor")
+	defer span.Finish()
+
+	var authorID uuid.UUID
+	if err := r.db.GetContext(ctx, &authorID, getBlogAuthorQuery, blogID); err != nil {
+		return uuid.Nil, errors.Wrap(err, "notificationRepo.GetBlogAuthor.GetContext")
+	}
+
+	return authorID, nil
+}
+
+// GetCommentAuthor of comment which is not deleted
+func (r *notificationRepo) GetCommentAuthor(ctx context.Context, commentID uuid.UUID) (uuid.UUID, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "notificationRepo.GetCommentAuthor")
+	defer span.Finish()
+
+	var authorID uuid.UUID
+	if err := r.db.GetContext(ctx, &authorID, getCommentAuthorQuery, commentID); err != nil {
+		return uuid.Nil, errors.Wrap(err, "notificationRepo.GetCommentAuthor.GetContext")
+	}
+
+	return authorID, nil
+}
@@ -0,0 +1,43 @@
+package repository
+
+const (
+	notificationColumns = `notification_id, recipient_id, actor_id, type, blog_id, comment_id, actors_count, group_key, read_at, created_at, updated_at`
+
+	// Notification with group key is collapsed into unread notification of same group, actor already collapsed into it is not counted again
+	createNotificationQuery = `INSERT INTO notifications (recipient_id, actor_id, type, blog_id, comment_id, group_key, actor_ids)
+						VALUES ($1, $2, $3, $4, $5, $6, array_remove(ARRAY[$2::uuid], NULL))
+						ON CONFLICT (recipient_id, group_key) WHERE read_at IS NULL
+						DO UPDATE SET actor_id = EXCLUDED.actor_id, updated_at = CURRENT_TIMESTAMP,
+							actor_ids = CASE WHEN EXCLUDED.actor_id IS NULL OR EXCLUDED.actor_id = ANY (notifications.actor_ids)
+								THEN notifications.actor_ids ELSE notifications.actor_ids || EXCLUDED.actor_id END,
+							actors_count = notifications.actors_count + CASE WHEN EXCLUDED.actor_id IS NULL OR EXCLUDED.actor_id = ANY (notifications.actor_ids)
+								THEN 0 ELSE 1 END
+						RETURNING ` + notificationColumns
+
+	getNotificationsCountQuery = `SELECT count(notification_id) FROM notifications WHERE recipient_id = $1 AND (NOT $2 OR read_at IS NULL)`
+
+	listNotificationsQuery = `SELECT ` + notificationColumns + ` FROM notifications
+						WHERE recipient_id = $1 AND (NOT $2 OR read_at IS NULL)
+						ORDER BY updated_at DESC, notification_id OFFSET $3 LIMIT $4`
+
+	getUnreadCountQuery = `SELECT count(notification_id) FROM notifications WHERE recipient_id = $1 AND read_at IS NULL`
+
+	// Notification which was read keeps time it was first read
+	markNotificationReadQuery = `UPDATE notifications SET read_at = COALESCE(read_at, CURRENT_TIMESTAMP)
+						WHERE notification_id = $1 AND recipient_id = $2
+						RETURNING ` + notificationColumns
+
+	markAllNotificationsReadQuery = `UPDATE notifications SET read_at = CURRENT_TIMESTAMP WHERE recipient_id = $1 AND read_at IS NULL`
+
+	getNotificationPreferencesQuery = `SELECT user_id, comment, reply, comment_like, updated_at FROM notification_preferences WHERE user_id = $1`
+
+	upsertNotificationPreferencesQuery = `INSERT INTO notification_preferences (user_id, comment, reply, comment_like)
+						VALUES ($1, $2, $3, $4)
+						ON CONFLICT (user_id) DO UPDATE SET comment = EXCLUDED.comment, reply = EXCLUDED.reply,
+							comment_like = EXCLUDED.comment_like, updated_at = CURRENT_TIMESTAMP
+						RETURNING user_id, comment, reply, comment_like, updated_at`
+
+	getBlogAuthorQuery = `SELECT author_id FROM blogs WHERE blog_id = $1`
+
+	getCommentAuthorQuery = `SELECT author_id FROM comments WHERE comment_id = $1 AND deleted_at IS NULL`
+)
@@ -0,0 +1,11 @@
+package notification
+
+import "github.com/labstack/echo/v4"
+
+type Handlers interface {
+	List() echo.HandlerFunc
+	MarkRead() echo.HandlerFunc
+	MarkAllRead() echo.HandlerFunc
+	GetPreferences() echo.HandlerFunc
+	UpdatePreferences() echo.HandlerFunc
+}
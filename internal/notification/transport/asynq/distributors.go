@@ -0,0 +1,43 @@
+//go:generate mockgen -source distributors.go -destination ../../mock/distributors_mock.go -package mock
+package asynq
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/hibiken/asynq"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/logger"
+)
+
+type NotificationTaskDistributor interface {
+	DistributeTaskNotify(ctx context.Context, payload *NotifyPayload, opts ...asynq.Option) error
+}
+
+type notificationTaskDistributor struct {
+	client *asynq.Client
+	logger logger.Logger
+}
+
+func NewNotificationTaskDistributor(client *asynq.Client, logger logger.Logger) NotificationTaskDistributor {
+	return &notificationTaskDistributor{
+		client: client,
+		logger: logger,
+	}
+}
+
+func (distributor *notificationTaskDistributor) DistributeTaskNotify(ctx context.Context, payload *NotifyPayload, opts ...asynq.Option) error {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task payload: %w", err)
+	}
+
+	task := asynq.NewTask(TypeNotifyTask, jsonPayload, opts...)
+	info, err := distributor.client.EnqueueContext(ctx, task)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+
+	distributor.logger.Infof("type=%s, payload=%s, queue=%s, maxRetry=%d enqueued task", info.Type, info.Payload, info.Queue, info.MaxRetry)
+
+	return nil
+}
@@ -0,0 +1,7 @@
+package asynq
+
+import asynqPkg "github.com/scul0405/blog-clean-architecture-rest-api/pkg/asynq"
+
+func MapHandlers(tp *asynqPkg.RedisTaskProcessor, np NotificationProcessor) {
+	tp.RegisterHandler(TypeNotifyTask, np.ProcessTaskNotify)
+}
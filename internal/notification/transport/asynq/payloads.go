@@ -0,0 +1,17 @@
+package asynq
+
+import (
+	"github.com/google/uuid"
+)
+
+const (
+	TypeNotifyTask = "notification:notify"
+)
+
+type NotifyPayload struct {
+	Type      string
+	ActorID   uuid.UUID
+	BlogID    uuid.UUID
+	CommentID uuid.UUID
+	ParentID  *uuid.UUID
+}
@@ -0,0 +1,45 @@
+package asynq
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/hibiken/asynq"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/notification"
+	asynqPkg "github.com/scul0405/blog-clean-architecture-rest-api/pkg/asynq"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/logger"
+)
+
+type NotificationProcessor interface {
+	ProcessTaskNotify(ctx context.Context, t *asynq.Task) error
+}
+
+type notificationProcessor struct {
+	notificationUC notification.UseCase
+	logger         logger.Logger
+}
+
+func NewNotificationProcessor(notificationUC notification.UseCase, logger logger.Logger) NotificationProcessor {
+	return &notificationProcessor{
+		notificationUC: notificationUC,
+		logger:         logger,
+	}
+}
+
+func (p *notificationProcessor) ProcessTaskNotify(ctx context.Context, t *asynq.Task) error {
+	var payload NotifyPayload
+	if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+		return fmt.Errorf("json.Unmarshal failed: %v: %w", err, asynq.SkipRetry)
+	}
+
+	err := p.notificationUC.ProcessEvent(ctx, &models.NotificationEvent{
+		Type:      payload.Type,
+		ActorID:   payload.ActorID,
+		BlogID:    payload.BlogID,
+		CommentID: payload.CommentID,
+		ParentID:  payload.ParentID,
+	})
+
+	return asynqPkg.SkipRetryIfPermanent(err)
+}
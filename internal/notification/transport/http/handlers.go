@@ -0,0 +1,192 @@
+package http
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/opentracing/opentracing-go"
+	"github.com/scul0405/blog-clean-architecture-rest-api/config"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/notification"
+	httpErrors "github.com/scul0405/blog-clean-architecture-rest-api/pkg/http_errors"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/logger"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/utils"
+	"net/http"
+	"strconv"
+)
+
+type notificationHandlers struct {
+	cfg            *config.Config
+	notificationUC notification.UseCase
+	logger         logger.Logger
+}
+
+func NewNotificationHandlers(cfg *config.Config, notificationUC notification.UseCase, logger logger.Logger) notification.Handlers {
+	return &notificationHandlers{cfg: cfg, notificationUC: notificationUC, logger: logger}
+}
+
+// List godoc
+// @Summary List notifications
+// @Description list notifications of caller with count of unread ones, most recently updated first
+// @Tags Notification
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param unread query bool false "only unread notifications"
+// @Param page query int false "page number" Format(page)
+// @Param size query int false "number of elements per page" Format(size)
+// @Success 200 {object} models.NotificationsList
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 401 {object} httpErrors.RestError
+// @Failure 500 {object} httpErrors.RestError
+// @Router /notifications [get]
+func (h *notificationHandlers) List() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "notificationHandlers.List")
+		defer span.Finish()
+
+		unreadOnly := false
+		if unread := c.QueryParam("unread"); unread != "" {
+			var err error
+			if unreadOnly, err = strconv.ParseBool(unread); err != nil {
+				err = httpErrors.NewValidationError(httpErrors.FieldError{Field: "unread", Value: unread, Message: "must be true or false"})
+				utils.LogResponseError(c, h.logger, err)
+				return c.JSON(httpErrors.ErrorResponse(err))
+			}
+		}
+
+		pq, err := utils.GetPaginationFromCtx(c)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		notificationsList, err := h.notificationUC.List(ctx, unreadOnly, pq)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, notificationsList)
+	}
+}
+
+// MarkRead godoc
+// @Summary Mark notification as read
+// @Description mark notification of caller as read, returns notification
+// @Tags Notification
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param notification_id path string true "notification_id"
+// @Success 200 {object} models.Notification
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 401 {object} httpErrors.RestError
+// @Failure 404 {object} httpErrors.RestError
+// @Failure 500 {object} httpErrors.RestError
+// @Router /notifications/{notification_id}/read [post]
+func (h *notificationHandlers) MarkRead() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "notificationHandlers.MarkRead")
+		defer span.Finish()
+
+		notificationUID, err := uuid.Parse(c.Param("notification_id"))
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(httpErrors.NewBadRequestError(err)))
+		}
+
+		readNotification, err := h.notificationUC.MarkRead(ctx, notificationUID)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, readNotification)
+	}
+}
+
+// MarkAllRead godoc
+// @Summary Mark all notifications as read
+// @Description mark every unread notification of caller as read, returns how many were marked
+// @Tags Notification
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} models.NotificationsReadState
+// @Failure 401 {object} httpErrors.RestError
+// @Failure 500 {object} httpErrors.RestError
+// @Router /notifications/read [post]
+func (h *notificationHandlers) MarkAllRead() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "notificationHandlers.MarkAllRead")
+		defer span.Finish()
+
+		readState, err := h.notificationUC.MarkAllRead(ctx)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, readState)
+	}
+}
+
+// GetPreferences godoc
+// @Summary Get notification preferences
+// @Description get notification types caller gets, every type is on until caller turns it off
+// @Tags Notification
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} models.NotificationPreferences
+// @Failure 401 {object} httpErrors.RestError
+// @Failure 500 {object} httpErrors.RestError
+// @Router /notifications/preferences [get]
+func (h *notificationHandlers) GetPreferences() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "notificationHandlers.GetPreferences")
+		defer span.Finish()
+
+		preferences, err := h.notificationUC.GetPreferences(ctx)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, preferences)
+	}
+}
+
+// UpdatePreferences godoc
+// @Summary Update notification preferences
+// @Description replace notification preferences of caller, type missing in request is turned off
+// @Tags Notification
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body models.NotificationPreferences true "notification types caller gets"
+// @Success 200 {object} models.NotificationPreferences
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 401 {object} httpErrors.RestError
+// @Failure 500 {object} httpErrors.RestError
+// @Router /notifications/preferences [put]
+func (h *notificationHandlers) UpdatePreferences() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "notificationHandlers.UpdatePreferences")
+		defer span.Finish()
+
+		preferences := &models.NotificationPreferences{}
+		if err := utils.ReadRequest(c, preferences); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		updatedPreferences, err := h.notificationUC.UpdatePreferences(ctx, preferences)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, updatedPreferences)
+	}
+}
@@ -0,0 +1,15 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/middleware"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/notification"
+)
+
+func MapNotificationRoutes(notificationGroup *echo.Group, h notification.Handlers, mw *middleware.MiddlewareManager) {
+	notificationGroup.GET("", h.List(), mw.AuthPASETOMiddleware)
+	notificationGroup.POST("/read", h.MarkAllRead(), mw.AuthPASETOMiddleware)
+	notificationGroup.POST("/:notification_id/read", h.MarkRead(), mw.AuthPASETOMiddleware)
+	notificationGroup.GET("/preferences", h.GetPreferences(), mw.AuthPASETOMiddleware)
+	notificationGroup.PUT("/preferences", h.UpdatePreferences(), mw.AuthPASETOMiddleware)
+}
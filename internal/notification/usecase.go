@@ -0,0 +1,18 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase_mock.go -package mock
+package notification
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/utils"
+)
+
+type UseCase interface {
+	List(ctx context.Context, unreadOnly bool, pq *utils.PaginationQuery) (*models.NotificationsList, error)
+	MarkRead(ctx context.Context, id uuid.UUID) (*models.Notification, error)
+	MarkAllRead(ctx context.Context) (*models.NotificationsReadState, error)
+	GetPreferences(ctx context.Context) (*models.NotificationPreferences, error)
+	UpdatePreferences(ctx context.Context, preferences *models.NotificationPreferences) (*models.NotificationPreferences, error)
+	ProcessEvent(ctx context.Context, event *models.NotificationEvent) error
+}
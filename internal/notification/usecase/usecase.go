@@ -0,0 +1,188 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"github.com/scul0405/blog-clean-architecture-rest-api/config"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/notification"
+	httpErrors "github.com/scul0405/blog-clean-architecture-rest-api/pkg/http_errors"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/logger"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/utils"
+	"net/http"
+)
+
+type notificationUseCase struct {
+	cfg              *config.Config
+	notificationRepo notification.Repository
+	logger           logger.Logger
+}
+
+func NewNotificationUseCase(cfg *config.Config, notificationRepo notification.Repository, logger logger.Logger) notification.UseCase {
+	return &notificationUseCase{cfg: cfg, notificationRepo: notificationRepo, logger: logger}
+}
+
+// List notifications of caller with count of unread ones
+func (u *notificationUseCase) List(ctx context.Context, unreadOnly bool, pq *utils.PaginationQuery) (*models.NotificationsList, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "notificationUC.List")
+	defer span.Finish()
+
+	userUID, err := utils.GetUserUIDFromCtx(ctx)
+	if err != nil {
+		return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "notificationUC.List.GetUserUIDFromCtx"))
+	}
+
+	return u.notificationRepo.List(ctx, userUID, unreadOnly, pq)
+}
+
+// MarkRead mark notification of caller as read, marking it again changes nothing
+func (u *notificationUseCase) MarkRead(ctx context.Context, id uuid.UUID) (*models.Notification, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "notificationUC.MarkRead")
+	defer span.Finish()
+
+	userUID, err := utils.GetUserUIDFromCtx(ctx)
+	if err != nil {
+		return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "notificationUC.MarkRead.GetUserUIDFromCtx"))
+	}
+
+	return u.notificationRepo.MarkRead(ctx, userUID, id)
+}
+
+// MarkAllRead mark every unread notification of caller as read
+func (u *notificationUseCase) MarkAllRead(ctx context.Context) (*models.NotificationsReadState, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "notificationUC.MarkAllRead")
+	defer span.Finish()
+
+	userUID, err := utils.GetUserUIDFromCtx(ctx)
+	if err != nil {
+		return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "notificationUC.MarkAllRead.GetUserUIDFromCtx"))
+	}
+
+	marked, err := u.notificationRepo.MarkAllRead(ctx, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Notifications created meanwhile stay unread
+	unreadCount, err := u.notificationRepo.CountUnread(ctx, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.NotificationsReadState{Marked: marked, UnreadCount: unreadCount}, nil
+}
+
+func (u *notificationUseCase) GetPreferences(ctx context.Context) (*models.NotificationPreferences, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "notificationUC.GetPreferences")
+	defer span.Finish()
+
+	userUID, err := utils.GetUserUIDFromCtx(ctx)
+	if err != nil {
+		return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "notificationUC.GetPreferences.GetUserUIDFromCtx"))
+	}
+
+	return u.preferences(ctx, userUID)
+}
+
+// UpdatePreferences replace preferences of caller
+func (u *notificationUseCase) UpdatePreferences(ctx context.Context, preferences *models.NotificationPreferences) (*models.NotificationPreferences, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "notificationUC.UpdatePreferences")
+	defer span.Finish()
+
+	userUID, err := utils.GetUserUIDFromCtx(ctx)
+	if err != nil {
+		return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "notificationUC.UpdatePreferences.GetUserUIDFromCtx"))
+	}
+
+	preferences.UserID = userUID
+
+	return u.notificationRepo.UpsertPreferences(ctx, preferences)
+}
+
+// ProcessEvent find recipients of event and notify those who want notifications of its type
+func (u *notificationUseCase) ProcessEvent(ctx context.Context, event *models.NotificationEvent) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "notificationUC.ProcessEvent")
+	defer span.Finish()
+
+	switch event.Type {
+	case models.EventCommentCreated:
+		return u.notifyCommentCreated(ctx, event)
+	case models.EventCommentLiked:
+		recipientID, err := u.notificationRepo.GetCommentAuthor(ctx, event.CommentID)
+		if err != nil {
+			return err
+		}
+		return u.notify(ctx, recipientID, models.NotificationCommentLike, event)
+	default:
+		return httpErrors.NewRestError(http.StatusBadRequest, "Unknown notification event", event.Type)
+	}
+}
+
+// notifyCommentCreated notify author of replied comment and author of blog, author of both gets reply only
+func (u *notificationUseCase) notifyCommentCreated(ctx context.Context, event *models.NotificationEvent) error {
+	parentAuthorID := uuid.Nil
+	if event.ParentID != nil {
+		var err error
+		parentAuthorID, err = u.notificationRepo.GetCommentAuthor(ctx, *event.ParentID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		if parentAuthorID != uuid.Nil {
+			if err = u.notify(ctx, parentAuthorID, models.NotificationReply, event); err != nil {
+				return err
+			}
+		}
+	}
+
+	blogAuthorID, err := u.notificationRepo.GetBlogAuthor(ctx, event.BlogID)
+	if err != nil {
+		return err
+	}
+	if blogAuthorID == parentAuthorID {
+		return nil
+	}
+
+	return u.notify(ctx, blogAuthorID, models.NotificationComment, event)
+}
+
+// notify recipient unless recipient is actor or turned notifications of type off.
+// Group key makes retried event and likes of one comment collapse into one unread notification
+func (u *notificationUseCase) notify(ctx context.Context, recipientID uuid.UUID, notificationType string, event *models.NotificationEvent) error {
+	if recipientID == event.ActorID {
+		return nil
+	}
+
+	preferences, err := u.preferences(ctx, recipientID)
+	if err != nil {
+		return err
+	}
+	if !preferences.Allows(notificationType) {
+		return nil
+	}
+
+	groupKey := fmt.Sprintf("%s:%s", notificationType, event.CommentID)
+	_, err = u.notificationRepo.Create(ctx, &models.Notification{
+		RecipientID: recipientID,
+		ActorID:     &event.ActorID,
+		Type:        notificationType,
+		BlogID:      &event.BlogID,
+		CommentID:   &event.CommentID,
+		GroupKey:    &groupKey,
+	})
+
+	return err
+}
+
+// preferences of user, user who never changed them gets defaults
+func (u *notificationUseCase) preferences(ctx context.Context, userID uuid.UUID) (*models.NotificationPreferences, error) {
+	preferences, err := u.notificationRepo.GetPreferences(ctx, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.DefaultNotificationPreferences(userID), nil
+	}
+
+	return preferences, err
+}
@@ -0,0 +1,171 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/scul0405/blog-clean-architecture-rest-api/config"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/notification/mock"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/logger"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+func TestNotificationUseCase_ProcessEvent(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Logger: config.LoggerConfig{
+			Development: true,
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	mockNotificationRepo := mock.NewMockRepository(ctrl)
+	notificationUC := NewNotificationUseCase(cfg, mockNotificationRepo, apiLogger)
+
+	ctx := context.Background()
+
+	t.Run("Reply notifies parent and blog authors", func(t *testing.T) {
+		parentUID := uuid.New()
+		parentAuthorUID := uuid.New()
+		blogAuthorUID := uuid.New()
+		event := &models.NotificationEvent{
+			Type:      models.EventCommentCreated,
+			ActorID:   uuid.New(),
+			BlogID:    uuid.New(),
+			CommentID: uuid.New(),
+			ParentID:  &parentUID,
+		}
+
+		mockNotificationRepo.EXPECT().GetCommentAuthor(gomock.Any(), parentUID).Return(parentAuthorUID, nil)
+		mockNotificationRepo.EXPECT().GetPreferences(gomock.Any(), parentAuthorUID).Return(nil, errors.Wrap(sql.ErrNoRows, "GetPreferences"))
+		mockNotificationRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, n *models.Notification) (*models.Notification, error) {
+				require.Equal(t, parentAuthorUID, n.RecipientID)
+				require.Equal(t, models.NotificationReply, n.Type)
+				require.Equal(t, "reply:"+event.CommentID.String(), *n.GroupKey)
+				return n, nil
+			})
+		mockNotificationRepo.EXPECT().GetBlogAuthor(gomock.Any(), event.BlogID).Return(blogAuthorUID, nil)
+		mockNotificationRepo.EXPECT().GetPreferences(gomock.Any(), blogAuthorUID).Return(nil, errors.Wrap(sql.ErrNoRows, "GetPreferences"))
+		mockNotificationRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, n *models.Notification) (*models.Notification, error) {
+				require.Equal(t, blogAuthorUID, n.RecipientID)
+				require.Equal(t, models.NotificationComment, n.Type)
+				return n, nil
+			})
+
+		err := notificationUC.ProcessEvent(ctx, event)
+		require.NoError(t, err)
+	})
+
+	t.Run("Blog author replied to gets reply only", func(t *testing.T) {
+		parentUID := uuid.New()
+		authorUID := uuid.New()
+		event := &models.NotificationEvent{
+			Type:      models.EventCommentCreated,
+			ActorID:   uuid.New(),
+			BlogID:    uuid.New(),
+			CommentID: uuid.New(),
+			ParentID:  &parentUID,
+		}
+
+		mockNotificationRepo.EXPECT().GetCommentAuthor(gomock.Any(), parentUID).Return(authorUID, nil)
+		mockNotificationRepo.EXPECT().GetPreferences(gomock.Any(), authorUID).Return(models.DefaultNotificationPreferences(authorUID), nil)
+		mockNotificationRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&models.Notification{}, nil)
+		mockNotificationRepo.EXPECT().GetBlogAuthor(gomock.Any(), event.BlogID).Return(authorUID, nil)
+
+		err := notificationUC.ProcessEvent(ctx, event)
+		require.NoError(t, err)
+	})
+
+	t.Run("Own comment is not notified", func(t *testing.T) {
+		actorUID := uuid.New()
+		event := &models.NotificationEvent{
+			Type:      models.EventCommentLiked,
+			ActorID:   actorUID,
+			BlogID:    uuid.New(),
+			CommentID: uuid.New(),
+		}
+
+		mockNotificationRepo.EXPECT().GetCommentAuthor(gomock.Any(), event.CommentID).Return(actorUID, nil)
+
+		err := notificationUC.ProcessEvent(ctx, event)
+		require.NoError(t, err)
+	})
+
+	t.Run("Turned off type is not notified", func(t *testing.T) {
+		authorUID := uuid.New()
+		event := &models.NotificationEvent{
+			Type:      models.EventCommentLiked,
+			ActorID:   uuid.New(),
+			BlogID:    uuid.New(),
+			CommentID: uuid.New(),
+		}
+
+		preferences := models.DefaultNotificationPreferences(authorUID)
+		preferences.CommentLike = false
+		mockNotificationRepo.EXPECT().GetCommentAuthor(gomock.Any(), event.CommentID).Return(authorUID, nil)
+		mockNotificationRepo.EXPECT().GetPreferences(gomock.Any(), authorUID).Return(preferences, nil)
+
+		err := notificationUC.ProcessEvent(ctx, event)
+		require.NoError(t, err)
+	})
+
+	t.Run("Likes of comment share group", func(t *testing.T) {
+		authorUID := uuid.New()
+		event := &models.NotificationEvent{
+			Type:      models.EventCommentLiked,
+			ActorID:   uuid.New(),
+			BlogID:    uuid.New(),
+			CommentID: uuid.New(),
+		}
+
+		mockNotificationRepo.EXPECT().GetCommentAuthor(gomock.Any(), event.CommentID).Return(authorUID, nil)
+		mockNotificationRepo.EXPECT().GetPreferences(gomock.Any(), authorUID).Return(models.DefaultNotificationPreferences(authorUID), nil)
+		mockNotificationRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, n *models.Notification) (*models.Notification, error) {
+				require.Equal(t, models.NotificationCommentLike, n.Type)
+				require.Equal(t, "comment_like:"+event.CommentID.String(), *n.GroupKey)
+				return n, nil
+			})
+
+		err := notificationUC.ProcessEvent(ctx, event)
+		require.NoError(t, err)
+	})
+}
+
+func TestNotificationUseCase_MarkAllRead(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Logger: config.LoggerConfig{
+			Development: true,
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	mockNotificationRepo := mock.NewMockRepository(ctrl)
+	notificationUC := NewNotificationUseCase(cfg, mockNotificationRepo, apiLogger)
+
+	userUID := uuid.New()
+	ctx := context.WithValue(context.Background(), "user_id", userUID.String())
+
+	mockNotificationRepo.EXPECT().MarkAllRead(gomock.Any(), userUID).Return(int64(3), nil)
+	mockNotificationRepo.EXPECT().CountUnread(gomock.Any(), userUID).Return(0, nil)
+
+	readState, err := notificationUC.MarkAllRead(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), readState.Marked)
+	require.Equal(t, 0, readState.UnreadCount)
+}
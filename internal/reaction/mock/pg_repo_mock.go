@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pg_repo.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	models "github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRepository) Create(ctx context.Context, reaction *models.Reaction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, reaction)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRepositoryMockRecorder) Create(ctx, reaction interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepository)(nil).Create), ctx, reaction)
+}
+
+// Delete mocks base method.
+func (m *MockRepository) Delete(ctx context.Context, reaction *models.Reaction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, reaction)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRepositoryMockRecorder) Delete(ctx, reaction interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), ctx, reaction)
+}
+
+// Summaries mocks base method.
+func (m *MockRepository) Summaries(ctx context.Context, targetType string, targetIDs []uuid.UUID, userID uuid.UUID) (map[uuid.UUID]*models.ReactionSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Summaries", ctx, targetType, targetIDs, userID)
+	ret0, _ := ret[0].(map[uuid.UUID]*models.ReactionSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Summaries indicates an expected call of Summaries.
+func (mr *MockRepositoryMockRecorder) Summaries(ctx, targetType, targetIDs, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Summaries", reflect.TypeOf((*MockRepository)(nil).Summaries), ctx, targetType, targetIDs, userID)
+}
+
+// TargetBlog mocks base method.
+func (m *MockRepository) TargetBlog(ctx context.Context, targetType string, targetID uuid.UUID) (*models.BlogAccess, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TargetBlog", ctx, targetType, targetID)
+	ret0, _ := ret[0].(*models.BlogAccess)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TargetBlog indicates an expected call of TargetBlog.
+func (mr *MockRepositoryMockRecorder) TargetBlog(ctx, targetType, targetID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TargetBlog", reflect.TypeOf((*MockRepository)(nil).TargetBlog), ctx, targetType, targetID)
+}
@@ -0,0 +1,92 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUseCase is a mock of UseCase interface.
+type MockUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockUseCaseMockRecorder
+}
+
+// MockUseCaseMockRecorder is the mock recorder for MockUseCase.
+type MockUseCaseMockRecorder struct {
+	mock *MockUseCase
+}
+
+// NewMockUseCase creates a new mock instance.
+func NewMockUseCase(ctrl *gomock.Controller) *MockUseCase {
+	mock := &MockUseCase{ctrl: ctrl}
+	mock.recorder = &MockUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUseCase) EXPECT() *MockUseCaseMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockUseCase) Add(ctx context.Context, reaction *models.Reaction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, reaction)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockUseCaseMockRecorder) Add(ctx, reaction interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockUseCase)(nil).Add), ctx, reaction)
+}
+
+// ProcessAdd mocks base method.
+func (m *MockUseCase) ProcessAdd(ctx context.Context, reaction *models.Reaction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessAdd", ctx, reaction)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProcessAdd indicates an expected call of ProcessAdd.
+func (mr *MockUseCaseMockRecorder) ProcessAdd(ctx, reaction interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessAdd", reflect.TypeOf((*MockUseCase)(nil).ProcessAdd), ctx, reaction)
+}
+
+// ProcessRemove mocks base method.
+func (m *MockUseCase) ProcessRemove(ctx context.Context, reaction *models.Reaction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessRemove", ctx, reaction)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProcessRemove indicates an expected call of ProcessRemove.
+func (mr *MockUseCaseMockRecorder) ProcessRemove(ctx, reaction interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessRemove", reflect.TypeOf((*MockUseCase)(nil).ProcessRemove), ctx, reaction)
+}
+
+// Remove mocks base method.
+func (m *MockUseCase) Remove(ctx context.Context, reaction *models.Reaction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", ctx, reaction)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockUseCaseMockRecorder) Remove(ctx, reaction interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockUseCase)(nil).Remove), ctx, reaction)
+}
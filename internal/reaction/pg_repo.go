@@ -0,0 +1,15 @@
+//go:generate mockgen -source pg_repo.go -destination mock/pg_repo_mock.go -package mock
+package reaction
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+)
+
+type Repository interface {
+	Create(ctx context.Context, reaction *models.Reaction) error
+	Delete(ctx context.Context, reaction *models.Reaction) error
+	TargetBlog(ctx context.Context, targetType string, targetID uuid.UUID) (*models.BlogAccess, error)
+	Summaries(ctx context.Context, targetType string, targetIDs []uuid.UUID, userID uuid.UUID) (map[uuid.UUID]*models.ReactionSummary, error)
+}
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/reaction"
+	"strings"
+)
+
+type reactionRepo struct {
+	db *sqlx.DB
+}
+
+func NewReactionRepository(db *sqlx.DB) reaction.Repository {
+	return &reactionRepo{db: db}
+}
+
+// Create reaction, existing reaction and missing target are ignored
+func (r *reactionRepo) Create(ctx context.Context, reaction *models.Reaction) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "reactionRepo.Create")
+	defer span.Finish()
+
+	if _, err := r.db.ExecContext(ctx, createReactionQuery, reaction.TargetType, reaction.TargetID, reaction.UserID, reaction.Kind); err != nil {
+		return errors.Wrap(err, "reactionRepo.Create.ExecContext")
+	}
+
+	return nil
+}
+
+// Delete reaction, missing reaction is ignored
+func (r *reactionRepo) Delete(ctx context.Context, reaction *models.Reaction) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "reactionRepo.Delete")
+	defer span.Finish()
+
+	if _, err := r.db.ExecContext(ctx, deleteReactionQuery, reaction.TargetType, reaction.TargetID, reaction.UserID, reaction.Kind); err != nil {
+		return errors.Wrap(err, "reactionRepo.Delete.ExecContext")
+	}
+
+	return nil
+}
+
+// TargetBlog get blog of target, tombstone of deleted comment has none
+func (r *reactionRepo) TargetBlog(ctx context.Context, targetType string, targetID uuid.UUID) (*models.BlogAccess, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "reactionRepo.TargetBlog")
+	defer span.Finish()
+
+	blog := &models.BlogAccess{}
+	if err := r.db.GetContext(ctx, blog, getTargetBlogQuery, targetType, targetID); err != nil {
+		return nil, errors.Wrap(err, "reactionRepo.TargetBlog.GetContext")
+	}
+
+	return blog, nil
+}
+
+// Summaries count reactions of every target, userID is uuid.Nil for anonymous caller.
+// Targets without reactions are missing from result.
+func (r *reactionRepo) Summaries(ctx context.Context, targetType string, targetIDs []uuid.UUID, userID uuid.UUID) (map[uuid.UUID]*models.ReactionSummary, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "reactionRepo.Summaries")
+	defer span.Finish()
+
+	summaries := make(map[uuid.UUID]*models.ReactionSummary)
+	if len(targetIDs) == 0 {
+		return summaries, nil
+	}
+
+	ids := make([]string, 0, len(targetIDs))
+	for _, id := range targetIDs {
+		ids = append(ids, id.String())
+	}
+
+	var caller *uuid.UUID
+	if userID != uuid.Nil {
+		caller = &userID
+	}
+
+	rows := make([]struct {
+		TargetID uuid.UUID `db:"target_id"`
+		Kind     string    `db:"kind"`
+		Count    int       `db:"count"`
+		Mine     bool      `db:"mine"`
+	}, 0)
+	if err := r.db.SelectContext(ctx, &rows, getReactionSummariesQuery, targetType, strings.Join(ids, ","), caller); err != nil {
+		return nil, errors.Wrap(err, "reactionRepo.Summaries.SelectContext")
+	}
+
+	for _, row := range rows {
+		summary, ok := summaries[row.TargetID]
+		if !ok {
+			summary = &models.ReactionSummary{Counts: make(map[string]int)}
+			summaries[row.TargetID] = summary
+		}
+
+		summary.Counts[row.Kind] = row.Count
+		if row.Mine {
+			summary.Mine = append(summary.Mine, row.Kind)
+		}
+	}
+
+	return summaries, nil
+}
@@ -0,0 +1,25 @@
+package repository
+
+const (
+	// Tombstones of deleted comments can not be reacted to, it guards target deleted after usecase checked it
+	targetExistsCondition = `($1::text = 'blog' AND EXISTS (SELECT 1 FROM blogs WHERE blog_id = $2))
+					OR ($1::text = 'comment' AND EXISTS (SELECT 1 FROM comments WHERE comment_id = $2 AND deleted_at IS NULL))`
+
+	createReactionQuery = `INSERT INTO reactions (target_type, target_id, user_id, kind)
+					SELECT $1::varchar, $2::uuid, $3::uuid, $4::varchar WHERE ` + targetExistsCondition + `
+					ON CONFLICT DO NOTHING`
+
+	deleteReactionQuery = `DELETE FROM reactions WHERE target_type = $1 AND target_id = $2 AND user_id = $3 AND kind = $4`
+
+	// Blog of target, reaction of comment follows blog of comment
+	getTargetBlogQuery = `SELECT b.blog_id, b.author_id, b.status, b.held FROM blogs b
+					WHERE ($1::text = 'blog' AND b.blog_id = $2)
+					OR ($1::text = 'comment' AND b.blog_id = (SELECT c.blog_id FROM comments c WHERE c.comment_id = $2 AND c.deleted_at IS NULL))`
+
+	// Target ids are comma separated, mine is false for anonymous caller
+	getReactionSummariesQuery = `SELECT target_id, kind, count(*) as count, COALESCE(bool_or(user_id = $3::uuid), false) as mine
+					FROM reactions
+					WHERE target_type = $1 AND target_id = ANY (string_to_array($2, ',')::uuid[])
+					GROUP BY target_id, kind
+					ORDER BY target_id, kind`
+)
@@ -0,0 +1,10 @@
+package reaction
+
+import "github.com/labstack/echo/v4"
+
+type Handlers interface {
+	AddBlogReaction() echo.HandlerFunc
+	RemoveBlogReaction() echo.HandlerFunc
+	AddCommentReaction() echo.HandlerFunc
+	RemoveCommentReaction() echo.HandlerFunc
+}
@@ -0,0 +1,8 @@
+package asynq
+
+import asynqPkg "github.com/scul0405/blog-clean-architecture-rest-api/pkg/asynq"
+
+func MapHandlers(tp *asynqPkg.RedisTaskProcessor, rp ReactionProcessor) {
+	tp.RegisterHandler(TypeAddReactionTask, rp.ProcessTaskAddReaction)
+	tp.RegisterHandler(TypeRemoveReactionTask, rp.ProcessTaskRemoveReaction)
+}
@@ -0,0 +1,24 @@
+package asynq
+
+import (
+	"github.com/google/uuid"
+)
+
+const (
+	TypeAddReactionTask    = "reaction:add"
+	TypeRemoveReactionTask = "reaction:remove"
+)
+
+type AddReactionPayload struct {
+	UserID     uuid.UUID
+	TargetType string
+	TargetID   uuid.UUID
+	Kind       string
+}
+
+type RemoveReactionPayload struct {
+	UserID     uuid.UUID
+	TargetType string
+	TargetID   uuid.UUID
+	Kind       string
+}
@@ -0,0 +1,62 @@
+package asynq
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/hibiken/asynq"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/reaction"
+	asynqPkg "github.com/scul0405/blog-clean-architecture-rest-api/pkg/asynq"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/logger"
+)
+
+// Reaction tasks are no longer enqueued, their processors drain tasks which were queued before reactions became synchronous
+type ReactionProcessor interface {
+	ProcessTaskAddReaction(ctx context.Context, t *asynq.Task) error
+	ProcessTaskRemoveReaction(ctx context.Context, t *asynq.Task) error
+}
+
+type reactionProcessor struct {
+	reactionUC reaction.UseCase
+	logger     logger.Logger
+}
+
+func NewReactionProcessor(reactionUC reaction.UseCase, logger logger.Logger) ReactionProcessor {
+	return &reactionProcessor{
+		reactionUC: reactionUC,
+		logger:     logger,
+	}
+}
+
+func (p *reactionProcessor) ProcessTaskAddReaction(ctx context.Context, t *asynq.Task) error {
+	var payload AddReactionPayload
+	if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+		return fmt.Errorf("json.Unmarshal failed: %v: %w", err, asynq.SkipRetry)
+	}
+
+	err := p.reactionUC.ProcessAdd(ctx, &models.Reaction{
+		UserID:     payload.UserID,
+		TargetType: payload.TargetType,
+		TargetID:   payload.TargetID,
+		Kind:       payload.Kind,
+	})
+
+	return asynqPkg.SkipRetryIfPermanent(err)
+}
+
+func (p *reactionProcessor) ProcessTaskRemoveReaction(ctx context.Context, t *asynq.Task) error {
+	var payload RemoveReactionPayload
+	if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+		return fmt.Errorf("json.Unmarshal failed: %v: %w", err, asynq.SkipRetry)
+	}
+
+	err := p.reactionUC.ProcessRemove(ctx, &models.Reaction{
+		UserID:     payload.UserID,
+		TargetType: payload.TargetType,
+		TargetID:   payload.TargetID,
+		Kind:       payload.Kind,
+	})
+
+	return asynqPkg.SkipRetryIfPermanent(err)
+}
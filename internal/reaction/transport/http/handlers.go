@@ -0,0 +1,126 @@
+package http
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/opentracing/opentracing-go"
+	"github.com/scul0405/blog-clean-architecture-rest-api/config"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/reaction"
+	httpErrors "github.com/scul0405/blog-clean-architecture-rest-api/pkg/http_errors"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/logger"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/utils"
+	"net/http"
+)
+
+type reactionHandlers struct {
+	cfg        *config.Config
+	reactionUC reaction.UseCase
+	logger     logger.Logger
+}
+
+func NewReactionHandlers(cfg *config.Config, reactionUC reaction.UseCase, logger logger.Logger) reaction.Handlers {
+	return &reactionHandlers{cfg: cfg, reactionUC: reactionUC, logger: logger}
+}
+
+// AddBlogReaction godoc
+// @Summary React to blog
+// @Description add reaction of kind to blog, adding it twice has no effect
+// @Tags Reaction
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param blog_id path string true "blog_id"
+// @Param kind path string true "reaction kind, one of like, love, laugh, wow, sad, angry"
+// @Success 200 {string} string "success"
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 401 {object} httpErrors.RestError
+// @Failure 404 {object} httpErrors.RestError
+// @Failure 500 {object} httpErrors.RestError
+// @Router /blogs/{blog_id}/reactions/{kind} [put]
+func (h *reactionHandlers) AddBlogReaction() echo.HandlerFunc {
+	return h.react("reactionHandlers.AddBlogReaction", models.ReactionTargetBlog, "blog_id", h.reactionUC.Add)
+}
+
+// RemoveBlogReaction godoc
+// @Summary Remove reaction from blog
+// @Description remove reaction of kind from blog
+// @Tags Reaction
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param blog_id path string true "blog_id"
+// @Param kind path string true "reaction kind, one of like, love, laugh, wow, sad, angry"
+// @Success 200 {string} string "success"
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 401 {object} httpErrors.RestError
+// @Failure 500 {object} httpErrors.RestError
+// @Router /blogs/{blog_id}/reactions/{kind} [delete]
+func (h *reactionHandlers) RemoveBlogReaction() echo.HandlerFunc {
+	return h.react("reactionHandlers.RemoveBlogReaction", models.ReactionTargetBlog, "blog_id", h.reactionUC.Remove)
+}
+
+// AddCommentReaction godoc
+// @Summary React to comment
+// @Description add reaction of kind to comment, adding it twice has no effect
+// @Tags Reaction
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param comment_id path string true "comment_id"
+// @Param kind path string true "reaction kind, one of like, love, laugh, wow, sad, angry"
+// @Success 200 {string} string "success"
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 401 {object} httpErrors.RestError
+// @Failure 404 {object} httpErrors.RestError
+// @Failure 500 {object} httpErrors.RestError
+// @Router /comments/{comment_id}/reactions/{kind} [put]
+func (h *reactionHandlers) AddCommentReaction() echo.HandlerFunc {
+	return h.react("reactionHandlers.AddCommentReaction", models.ReactionTargetComment, "comment_id", h.reactionUC.Add)
+}
+
+// RemoveCommentReaction godoc
+// @Summary Remove reaction from comment
+// @Description remove reaction of kind from comment
+// @Tags Reaction
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param comment_id path string true "comment_id"
+// @Param kind path string true "reaction kind, one of like, love, laugh, wow, sad, angry"
+// @Success 200 {string} string "success"
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 401 {object} httpErrors.RestError
+// @Failure 500 {object} httpErrors.RestError
+// @Router /comments/{comment_id}/reactions/{kind} [delete]
+func (h *reactionHandlers) RemoveCommentReaction() echo.HandlerFunc {
+	return h.react("reactionHandlers.RemoveCommentReaction", models.ReactionTargetComment, "comment_id", h.reactionUC.Remove)
+}
+
+// react read reaction to target from path and pass it to apply
+func (h *reactionHandlers) react(operation string, targetType string, targetParam string,
+	apply func(ctx context.Context, reaction *models.Reaction) error) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), operation)
+		defer span.Finish()
+
+		targetID, err := uuid.Parse(c.Param(targetParam))
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		err = apply(ctx, &models.Reaction{
+			TargetType: targetType,
+			TargetID:   targetID,
+			Kind:       c.Param("kind"),
+		})
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.NoContent(http.StatusOK)
+	}
+}
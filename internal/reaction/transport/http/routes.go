@@ -0,0 +1,14 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/middleware"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/reaction"
+)
+
+func MapReactionRoutes(blogGroup *echo.Group, commentGroup *echo.Group, h reaction.Handlers, mw *middleware.MiddlewareManager) {
+	blogGroup.PUT("/:blog_id/reactions/:kind", h.AddBlogReaction(), mw.AuthPASETOMiddleware)
+	blogGroup.DELETE("/:blog_id/reactions/:kind", h.RemoveBlogReaction(), mw.AuthPASETOMiddleware)
+	commentGroup.PUT("/:comment_id/reactions/:kind", h.AddCommentReaction(), mw.AuthPASETOMiddleware)
+	commentGroup.DELETE("/:comment_id/reactions/:kind", h.RemoveCommentReaction(), mw.AuthPASETOMiddleware)
+}
@@ -0,0 +1,14 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase_mock.go -package mock
+package reaction
+
+import (
+	"context"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+)
+
+type UseCase interface {
+	Add(ctx context.Context, reaction *models.Reaction) error
+	Remove(ctx context.Context, reaction *models.Reaction) error
+	ProcessAdd(ctx context.Context, reaction *models.Reaction) error
+	ProcessRemove(ctx context.Context, reaction *models.Reaction) error
+}
@@ -0,0 +1,118 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"github.com/scul0405/blog-clean-architecture-rest-api/config"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/comment"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/reaction"
+	httpErrors "github.com/scul0405/blog-clean-architecture-rest-api/pkg/http_errors"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/logger"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/utils"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+type reactionUseCase struct {
+	cfg          *config.Config
+	reactionRepo reaction.Repository
+	commentUC    comment.UseCase
+	logger       logger.Logger
+}
+
+func NewReactionUseCase(cfg *config.Config, reactionRepo reaction.Repository, commentUC comment.UseCase, logger logger.Logger) reaction.UseCase {
+	return &reactionUseCase{cfg: cfg, reactionRepo: reactionRepo, commentUC: commentUC, logger: logger}
+}
+
+// Add check reaction and store it, so later remove of same reaction always applies after it
+func (u *reactionUseCase) Add(ctx context.Context, reaction *models.Reaction) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "reactionUC.Add")
+	defer span.Finish()
+
+	if err := u.prepare(ctx, reaction); err != nil {
+		return err
+	}
+
+	// Targets of blogs caller can not view are missing as well
+	blog, err := u.reactionRepo.TargetBlog(ctx, reaction.TargetType, reaction.TargetID)
+	if err != nil {
+		return err
+	}
+	if !utils.CanViewBlog(ctx, blog.AuthorID, blog.Status == models.BlogStatusPublished, blog.Held) {
+		return httpErrors.NewRestError(http.StatusNotFound, httpErrors.NotFound.Error(), fmt.Errorf("%s %s", reaction.TargetType, reaction.TargetID))
+	}
+
+	return u.ProcessAdd(ctx, reaction)
+}
+
+// Remove check reaction and remove it
+func (u *reactionUseCase) Remove(ctx context.Context, reaction *models.Reaction) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "reactionUC.Remove")
+	defer span.Finish()
+
+	if err := u.prepare(ctx, reaction); err != nil {
+		return err
+	}
+
+	return u.ProcessRemove(ctx, reaction)
+}
+
+// ProcessAdd store reaction of its user, tasks queued before reactions became synchronous are drained by it too
+func (u *reactionUseCase) ProcessAdd(ctx context.Context, reaction *models.Reaction) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "reactionUC.ProcessAdd")
+	defer span.Finish()
+
+	// Likes of comment go through comment likes so likes_count is kept
+	if isCommentLike(reaction) {
+		_, err := u.commentUC.Like(ctx, &models.UserComments{UserID: reaction.UserID, CommentID: reaction.TargetID})
+		return err
+	}
+
+	return u.reactionRepo.Create(ctx, reaction)
+}
+
+// ProcessRemove remove reaction of its user
+func (u *reactionUseCase) ProcessRemove(ctx context.Context, reaction *models.Reaction) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "reactionUC.ProcessRemove")
+	defer span.Finish()
+
+	if isCommentLike(reaction) {
+		_, err := u.commentUC.Dislike(ctx, &models.UserComments{UserID: reaction.UserID, CommentID: reaction.TargetID})
+		return err
+	}
+
+	return u.reactionRepo.Delete(ctx, reaction)
+}
+
+// prepare set caller as user of reaction and validate kind
+func (u *reactionUseCase) prepare(ctx context.Context, reaction *models.Reaction) error {
+	userUID, err := utils.GetUserUIDFromCtx(ctx)
+	if err != nil {
+		return httpErrors.NewUnauthorizedError(errors.WithMessage(err, "reactionUC.prepare.GetUserUIDFromCtx"))
+	}
+	reaction.UserID = userUID
+
+	if _, ok := models.ReactionKinds[reaction.Kind]; !ok {
+		kinds := make([]string, 0, len(models.ReactionKinds))
+		for kind := range models.ReactionKinds {
+			kinds = append(kinds, kind)
+		}
+		sort.Strings(kinds)
+
+		return httpErrors.NewValidationError(httpErrors.FieldError{
+			Field:   "kind",
+			Value:   reaction.Kind,
+			Message: fmt.Sprintf("must be one of %s", strings.Join(kinds, ", ")),
+		})
+	}
+
+	return nil
+}
+
+func isCommentLike(reaction *models.Reaction) bool {
+	return reaction.TargetType == models.ReactionTargetComment && reaction.Kind == models.ReactionLike
+}
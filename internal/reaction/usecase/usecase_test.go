@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"github.com/google/uuid"
+	"github.com/scul0405/blog-clean-architecture-rest-api/config"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/reaction/mock"
+	httpErrors "github.com/scul0405/blog-clean-architecture-rest-api/pkg/http_errors"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/logger"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"testing"
+)
+
+func TestReactionUseCase_Add(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		Logger: config.LoggerConfig{
+			Development: true,
+		},
+	}
+
+	apiLogger := logger.NewApiLogger(cfg)
+	mockReactionRepo := mock.NewMockRepository(ctrl)
+	reactionUC := NewReactionUseCase(cfg, mockReactionRepo, nil, apiLogger)
+
+	userUID := uuid.New()
+	ctx := context.WithValue(context.Background(), "user_id", userUID.String())
+
+	t.Run("Add", func(t *testing.T) {
+		blogUID := uuid.New()
+
+		mockReactionRepo.EXPECT().TargetBlog(gomock.Any(), models.ReactionTargetBlog, blogUID).
+			Return(&models.BlogAccess{BlogID: blogUID, Status: models.BlogStatusPublished}, nil)
+		mockReactionRepo.EXPECT().Create(gomock.Any(), gomock.Eq(&models.Reaction{
+			UserID:     userUID,
+			TargetType: models.ReactionTargetBlog,
+			TargetID:   blogUID,
+			Kind:       "love",
+		})).Return(nil)
+
+		err := reactionUC.Add(ctx, &models.Reaction{TargetType: models.ReactionTargetBlog, TargetID: blogUID, Kind: "love"})
+		require.NoError(t, err)
+	})
+
+	t.Run("Add, remove and add again", func(t *testing.T) {
+		blogUID := uuid.New()
+		stored := &models.Reaction{UserID: userUID, TargetType: models.ReactionTargetBlog, TargetID: blogUID, Kind: "wow"}
+
+		mockReactionRepo.EXPECT().TargetBlog(gomock.Any(), models.ReactionTargetBlog, blogUID).
+			Return(&models.BlogAccess{BlogID: blogUID, Status: models.BlogStatusPublished}, nil).Times(2)
+		gomock.InOrder(
+			mockReactionRepo.EXPECT().Create(gomock.Any(), gomock.Eq(stored)).Return(nil),
+			mockReactionRepo.EXPECT().Delete(gomock.Any(), gomock.Eq(stored)).Return(nil),
+			mockReactionRepo.EXPECT().Create(gomock.Any(), gomock.Eq(stored)).Return(nil),
+		)
+
+		require.NoError(t, reactionUC.Add(ctx, &models.Reaction{TargetType: models.ReactionTargetBlog, TargetID: blogUID, Kind: "wow"}))
+		require.NoError(t, reactionUC.Remove(ctx, &models.Reaction{TargetType: models.ReactionTargetBlog, TargetID: blogUID, Kind: "wow"}))
+		require.NoError(t, reactionUC.Add(ctx, &models.Reaction{TargetType: models.ReactionTargetBlog, TargetID: blogUID, Kind: "wow"}))
+	})
+
+	t.Run("Unknown kind", func(t *testing.T) {
+		err := reactionUC.Add(ctx, &models.Reaction{TargetType: models.ReactionTargetBlog, TargetID: uuid.New(), Kind: "clap"})
+		require.Error(t, err)
+		require.Equal(t, http.StatusBadRequest, httpErrors.ParseErrors(err).Status())
+	})
+
+	t.Run("Missing target", func(t *testing.T) {
+		commentUID := uuid.New()
+
+		mockReactionRepo.EXPECT().TargetBlog(gomock.Any(), models.ReactionTargetComment, commentUID).Return(nil, sql.ErrNoRows)
+
+		err := reactionUC.Add(ctx, &models.Reaction{TargetType: models.ReactionTargetComment, TargetID: commentUID, Kind: "like"})
+		require.Error(t, err)
+		require.Equal(t, http.StatusNotFound, httpErrors.ParseErrors(err).Status())
+	})
+
+	t.Run("Draft blog", func(t *testing.T) {
+		blogUID := uuid.New()
+
+		mockReactionRepo.EXPECT().TargetBlog(gomock.Any(), models.ReactionTargetBlog, blogUID).
+			Return(&models.BlogAccess{BlogID: blogUID, AuthorID: uuid.New(), Status: models.BlogStatusDraft}, nil)
+
+		err := reactionUC.Add(ctx, &models.Reaction{TargetType: models.ReactionTargetBlog, TargetID: blogUID, Kind: "love"})
+		require.Error(t, err)
+		require.Equal(t, http.StatusNotFound, httpErrors.ParseErrors(err).Status())
+	})
+
+	t.Run("Comment of scheduled blog", func(t *testing.T) {
+		commentUID := uuid.New()
+
+		mockReactionRepo.EXPECT().TargetBlog(gomock.Any(), models.ReactionTargetComment, commentUID).
+			Return(&models.BlogAccess{BlogID: uuid.New(), AuthorID: uuid.New(), Status: models.BlogStatusScheduled}, nil)
+
+		err := reactionUC.Add(ctx, &models.Reaction{TargetType: models.ReactionTargetComment, TargetID: commentUID, Kind: "wow"})
+		require.Error(t, err)
+		require.Equal(t, http.StatusNotFound, httpErrors.ParseErrors(err).Status())
+	})
+
+	t.Run("Author of draft blog", func(t *testing.T) {
+		blogUID := uuid.New()
+
+		mockReactionRepo.EXPECT().TargetBlog(gomock.Any(), models.ReactionTargetBlog, blogUID).
+			Return(&models.BlogAccess{BlogID: blogUID, AuthorID: userUID, Status: models.BlogStatusDraft}, nil)
+		mockReactionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+		err := reactionUC.Add(ctx, &models.Reaction{TargetType: models.ReactionTargetBlog, TargetID: blogUID, Kind: "love"})
+		require.NoError(t, err)
+	})
+
+	t.Run("Anonymous", func(t *testing.T) {
+		err := reactionUC.Add(context.Background(), &models.Reaction{TargetType: models.ReactionTargetBlog, TargetID: uuid.New(), Kind: "like"})
+		require.Error(t, err)
+		require.Equal(t, http.StatusUnauthorized, httpErrors.ParseErrors(err).Status())
+	})
+}
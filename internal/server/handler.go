@@ -1,23 +1,53 @@
 package server
 
 import (
+	"context"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	authRepository "github.com/scul0405/blog-clean-architecture-rest-api/internal/auth/repository"
+	authAsynq "github.com/scul0405/blog-clean-architecture-rest-api/internal/auth/transport/asynq"
 	authHttp "github.com/scul0405/blog-clean-architecture-rest-api/internal/auth/transport/http"
 	authUC "github.com/scul0405/blog-clean-architecture-rest-api/internal/auth/usecase"
 	blogRepository "github.com/scul0405/blog-clean-architecture-rest-api/internal/blog/repository"
+	blogAsynq "github.com/scul0405/blog-clean-architecture-rest-api/internal/blog/transport/asynq"
 	blogHttp "github.com/scul0405/blog-clean-architecture-rest-api/internal/blog/transport/http"
 	blogUC "github.com/scul0405/blog-clean-architecture-rest-api/internal/blog/usecase"
+	blogEventRepository "github.com/scul0405/blog-clean-architecture-rest-api/internal/blog_event/repository"
+	blogEventHttp "github.com/scul0405/blog-clean-architecture-rest-api/internal/blog_event/transport/http"
+	blogEventUC "github.com/scul0405/blog-clean-architecture-rest-api/internal/blog_event/usecase"
+	categoryRepository "github.com/scul0405/blog-clean-architecture-rest-api/internal/category/repository"
+	categoryHttp "github.com/scul0405/blog-clean-architecture-rest-api/internal/category/transport/http"
+	categoryUC "github.com/scul0405/blog-clean-architecture-rest-api/internal/category/usecase"
 	commentRepository "github.com/scul0405/blog-clean-architecture-rest-api/internal/comment/repository"
 	commentAsynq "github.com/scul0405/blog-clean-architecture-rest-api/internal/comment/transport/asynq"
 	commentHttp "github.com/scul0405/blog-clean-architecture-rest-api/internal/comment/transport/http"
 	commentUC "github.com/scul0405/blog-clean-architecture-rest-api/internal/comment/usecase"
+	contentFilterRepository "github.com/scul0405/blog-clean-architecture-rest-api/internal/content_filter/repository"
+	contentFilterUC "github.com/scul0405/blog-clean-architecture-rest-api/internal/content_filter/usecase"
+	feedRepository "github.com/scul0405/blog-clean-architecture-rest-api/internal/feed/repository"
+	feedAsynq "github.com/scul0405/blog-clean-architecture-rest-api/internal/feed/transport/asynq"
+	feedHttp "github.com/scul0405/blog-clean-architecture-rest-api/internal/feed/transport/http"
+	feedUC "github.com/scul0405/blog-clean-architecture-rest-api/internal/feed/usecase"
+	followRepository "github.com/scul0405/blog-clean-architecture-rest-api/internal/follow/repository"
+	followHttp "github.com/scul0405/blog-clean-architecture-rest-api/internal/follow/transport/http"
+	followUC "github.com/scul0405/blog-clean-architecture-rest-api/internal/follow/usecase"
 	apiMiddleware "github.com/scul0405/blog-clean-architecture-rest-api/internal/middleware"
+	notificationRepository "github.com/scul0405/blog-clean-architecture-rest-api/internal/notification/repository"
+	notificationAsynq "github.com/scul0405/blog-clean-architecture-rest-api/internal/notification/transport/asynq"
+	notificationHttp "github.com/scul0405/blog-clean-architecture-rest-api/internal/notification/transport/http"
+	notificationUC "github.com/scul0405/blog-clean-architecture-rest-api/internal/notification/usecase"
+	reactionRepository "github.com/scul0405/blog-clean-architecture-rest-api/internal/reaction/repository"
+	reactionAsynq "github.com/scul0405/blog-clean-architecture-rest-api/internal/reaction/transport/asynq"
+	reactionHttp "github.com/scul0405/blog-clean-architecture-rest-api/internal/reaction/transport/http"
+	reactionUC "github.com/scul0405/blog-clean-architecture-rest-api/internal/reaction/usecase"
+	tagRepository "github.com/scul0405/blog-clean-architecture-rest-api/internal/tag/repository"
+	tagHttp "github.com/scul0405/blog-clean-architecture-rest-api/internal/tag/transport/http"
+	tagUC "github.com/scul0405/blog-clean-architecture-rest-api/internal/tag/usecase"
 	userCommentRepository "github.com/scul0405/blog-clean-architecture-rest-api/internal/user_comment/repository"
 	echoSwagger "github.com/swaggo/echo-swagger"
 	"strings"
 
+	mailerPkg "github.com/scul0405/blog-clean-architecture-rest-api/pkg/mailer"
 	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/utils"
 	"net/http"
 )
@@ -28,28 +58,80 @@ func (s *Server) MapHandlers(e *echo.Echo) error {
 	blogRepo := blogRepository.NewBlogRepository(s.db)
 	commentRepo := commentRepository.NewCommentRepository(s.db)
 	userCommentRepo := userCommentRepository.NewUserCommentRepository(s.db)
+	reactionRepo := reactionRepository.NewReactionRepository(s.db)
+	categoryRepo := categoryRepository.NewCategoryRepository(s.db)
+	tagRepo := tagRepository.NewTagRepository(s.db)
+	contentFilterRepo := contentFilterRepository.NewContentFilterRepository(s.db)
+	notificationRepo := notificationRepository.NewNotificationRepository(s.db)
+	followRepo := followRepository.NewFollowRepository(s.db)
 
 	authRedisRepo := authRepository.NewAuthRedisRepository(s.rdb)
 	blogRedisRepo := blogRepository.NewBlogRedisRepository(s.rdb)
+	commentRedisRepo := commentRepository.NewCommentRedisRepository(s.rdb)
+	contentFilterRedisRepo := contentFilterRepository.NewContentFilterRedisRepository(s.rdb)
+	blogEventRedisRepo := blogEventRepository.NewBlogEventRedisRepository(s.rdb)
+	feedRedisRepo := feedRepository.NewFeedRedisRepository(s.rdb)
 
 	authMinioRepo := authRepository.NewAuthMinioRepository(s.minioClient)
+	blogMinioRepo := blogRepository.NewBlogMinioRepository(s.minioClient)
 
-	// Init use cases
-	authUC := authUC.NewAuthUseCase(s.cfg, authRepo, authRedisRepo, authMinioRepo, s.logger)
-	blogUC := blogUC.NewBlogUseCase(s.cfg, blogRepo, blogRedisRepo, s.logger)
-	commentUC := commentUC.NewCommentUseCase(s.cfg, commentRepo, userCommentRepo, s.logger)
+	// Init mailer
+	mailer, err := mailerPkg.NewMailer(s.cfg)
+	if err != nil {
+		return err
+	}
 
 	// Init task distributors
-	commentTD := commentAsynq.NewCommentTaskDistributor(s.asynqClient, s.logger)
+	authTD := authAsynq.NewAuthTaskDistributor(s.asynqClient, s.logger)
+	blogTD := blogAsynq.NewBlogTaskDistributor(s.asynqClient, s.asynqInspector, s.logger)
+	notificationTD := notificationAsynq.NewNotificationTaskDistributor(s.asynqClient, s.logger)
+	feedTD := feedAsynq.NewFeedTaskDistributor(s.asynqClient, s.asynqInspector, s.logger)
+
+	// Init use cases
+	contentFilter := contentFilterUC.NewContentFilter(s.cfg, contentFilterRepo, contentFilterRedisRepo, s.logger)
+	authUC := authUC.NewAuthUseCase(s.cfg, authRepo, authRedisRepo, authMinioRepo, followRepo, authTD, s.logger)
+	blogUC := blogUC.NewBlogUseCase(s.cfg, blogRepo, blogRedisRepo, blogMinioRepo, reactionRepo, blogTD, feedTD, contentFilter, s.logger)
+	blogEventUC := blogEventUC.NewBlogEventUseCase(s.cfg, blogUC, blogEventRedisRepo, s.logger)
+	commentUC := commentUC.NewCommentUseCase(s.cfg, commentRepo, commentRedisRepo, userCommentRepo, reactionRepo, contentFilter, notificationTD, blogEventUC, s.logger)
+	reactionUC := reactionUC.NewReactionUseCase(s.cfg, reactionRepo, commentUC, s.logger)
+	categoryUC := categoryUC.NewCategoryUseCase(s.cfg, categoryRepo, s.logger)
+	tagUC := tagUC.NewTagUseCase(s.cfg, tagRepo, s.logger)
+	notificationUC := notificationUC.NewNotificationUseCase(s.cfg, notificationRepo, s.logger)
+	feedUC := feedUC.NewFeedUseCase(s.cfg, blogRepo, followRepo, feedRedisRepo, s.logger)
+	followUC := followUC.NewFollowUseCase(s.cfg, followRepo, feedUC, s.logger)
+
+	// Init task processors
+	authProcessor := authAsynq.NewAuthProcessor(mailer, authUC, s.logger)
+	blogProcessor := blogAsynq.NewBlogProcessor(blogUC, s.logger)
 	commentProcessor := commentAsynq.NewCommentProcessor(commentUC, s.logger)
+	reactionProcessor := reactionAsynq.NewReactionProcessor(reactionUC, s.logger)
+	notificationProcessor := notificationAsynq.NewNotificationProcessor(notificationUC, s.logger)
+	feedProcessor := feedAsynq.NewFeedProcessor(feedUC, s.logger)
 
 	// map task process
+	authAsynq.MapHandlers(s.taskProcessor, authProcessor)
+	blogAsynq.MapHandlers(s.taskProcessor, blogProcessor)
 	commentAsynq.MapHandlers(s.taskProcessor, commentProcessor)
+	reactionAsynq.MapHandlers(s.taskProcessor, reactionProcessor)
+	notificationAsynq.MapHandlers(s.taskProcessor, notificationProcessor)
+	feedAsynq.MapHandlers(s.taskProcessor, feedProcessor)
+
+	// map periodic tasks
+	if err = commentAsynq.MapPeriodicTasks(s.taskScheduler, s.cfg); err != nil {
+		return err
+	}
 
 	// Init handlers
 	authHandler := authHttp.NewAuthHandlers(s.cfg, authUC, s.logger)
 	blogHandler := blogHttp.NewBlogHandlers(s.cfg, blogUC, s.logger)
-	commentHandler := commentHttp.NewCommentHandlers(s.cfg, commentUC, commentTD, s.logger)
+	blogEventHandler := blogEventHttp.NewBlogEventHandlers(s.cfg, blogEventUC, s.logger)
+	commentHandler := commentHttp.NewCommentHandlers(s.cfg, commentUC, s.logger)
+	reactionHandler := reactionHttp.NewReactionHandlers(s.cfg, reactionUC, s.logger)
+	categoryHandler := categoryHttp.NewCategoryHandlers(s.cfg, categoryUC, s.logger)
+	tagHandler := tagHttp.NewTagHandlers(s.cfg, tagUC, s.logger)
+	notificationHandler := notificationHttp.NewNotificationHandlers(s.cfg, notificationUC, s.logger)
+	followHandler := followHttp.NewFollowHandlers(s.cfg, followUC, s.logger)
+	feedHandler := feedHttp.NewFeedHandlers(s.cfg, feedUC, s.logger)
 
 	// Swagger
 	e.GET("/swagger/*", echoSwagger.WrapHandler)
@@ -61,6 +143,11 @@ func (s *Server) MapHandlers(e *echo.Echo) error {
 	authGroup := v1.Group("/auth")
 	blogGroup := v1.Group("/blogs")
 	commentGroup := v1.Group("/comments")
+	categoryGroup := v1.Group("/categories")
+	tagGroup := v1.Group("/tags")
+	notificationGroup := v1.Group("/notifications")
+	userGroup := v1.Group("/users")
+	feedGroup := v1.Group("/feed")
 
 	// API middleware
 	mw := apiMiddleware.NewMiddlewareManager(authUC, s.cfg, s.logger)
@@ -69,8 +156,10 @@ func (s *Server) MapHandlers(e *echo.Echo) error {
 	// echo middleware
 	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
 		AllowOrigins: []string{"*"},
-		AllowMethods: []string{http.MethodGet, http.MethodPatch, http.MethodPost, http.MethodDelete},
-		AllowHeaders: []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderXRequestID},
+		AllowMethods: []string{http.MethodGet, http.MethodPut, http.MethodPatch, http.MethodPost, http.MethodDelete},
+		AllowHeaders: []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderXRequestID,
+			utils.HeaderIfMatch, utils.HeaderIfNoneMatch},
+		ExposeHeaders: []string{utils.HeaderETag},
 	}))
 
 	e.Use(middleware.RecoverWithConfig(middleware.RecoverConfig{
@@ -84,7 +173,8 @@ func (s *Server) MapHandlers(e *echo.Echo) error {
 	e.Use(middleware.GzipWithConfig(middleware.GzipConfig{
 		Level: 5,
 		Skipper: func(c echo.Context) bool {
-			return strings.Contains(c.Request().URL.Path, "swagger")
+			// Events are streamed as they happen, gzip would buffer them
+			return strings.Contains(c.Request().URL.Path, "swagger") || strings.HasSuffix(c.Request().URL.Path, "/events")
 		},
 	}))
 	e.Use(middleware.Secure())
@@ -93,7 +183,14 @@ func (s *Server) MapHandlers(e *echo.Echo) error {
 	// Map routes
 	authHttp.MapAuthRoutes(authGroup, authHandler, mw)
 	blogHttp.MapBlogRoutes(blogGroup, blogHandler, mw)
+	blogEventHttp.MapBlogEventRoutes(blogGroup, blogEventHandler, mw)
 	commentHttp.MapCommentRoutes(commentGroup, commentHandler, mw)
+	reactionHttp.MapReactionRoutes(blogGroup, commentGroup, reactionHandler, mw)
+	categoryHttp.MapCategoryRoutes(categoryGroup, categoryHandler, mw)
+	tagHttp.MapTagRoutes(tagGroup, tagHandler)
+	notificationHttp.MapNotificationRoutes(notificationGroup, notificationHandler, mw)
+	followHttp.MapFollowRoutes(userGroup, followHandler, mw)
+	feedHttp.MapFeedRoutes(feedGroup, feedHandler, mw)
 
 	health.GET("", func(c echo.Context) error {
 		s.logger.Infof("Health check RequestID: %s", utils.GetRequestID(c))
@@ -108,5 +205,21 @@ func (s *Server) MapHandlers(e *echo.Echo) error {
 		}
 	}()
 
+	// Run receiver of blog events published by every instance
+	go func() {
+		err := blogEventUC.Run(context.Background())
+		if err != nil {
+			s.logger.Errorf("blog events receiver stopped: %v", err)
+		}
+	}()
+
+	// Run task scheduler
+	go func() {
+		err := s.taskScheduler.Start()
+		if err != nil {
+			s.logger.Info("failed to start task scheduler")
+		}
+	}()
+
 	return nil
 }
@@ -23,14 +23,16 @@ const (
 )
 
 type Server struct {
-	echo          *echo.Echo
-	cfg           *config.Config
-	db            *sqlx.DB
-	rdb           *redis.Client
-	minioClient   *minio.Client
-	asynqClient   *asynq.Client
-	taskProcessor *asynqPkg.RedisTaskProcessor
-	logger        logger.Logger
+	echo           *echo.Echo
+	cfg            *config.Config
+	db             *sqlx.DB
+	rdb            *redis.Client
+	minioClient    *minio.Client
+	asynqClient    *asynq.Client
+	asynqInspector *asynq.Inspector
+	taskProcessor  *asynqPkg.RedisTaskProcessor
+	taskScheduler  *asynqPkg.RedisTaskScheduler
+	logger         logger.Logger
 }
 
 func NewServer(
@@ -39,15 +41,19 @@ func NewServer(
 	rdb *redis.Client,
 	minioClient *minio.Client,
 	asynqClient *asynq.Client,
+	asynqInspector *asynq.Inspector,
 	taskProcessor *asynqPkg.RedisTaskProcessor,
+	taskScheduler *asynqPkg.RedisTaskScheduler,
 	logger logger.Logger) *Server {
 	return &Server{echo: echo.New(), cfg: cfg,
-		db:            db,
-		rdb:           rdb,
-		minioClient:   minioClient,
-		asynqClient:   asynqClient,
-		taskProcessor: taskProcessor,
-		logger:        logger}
+		db:             db,
+		rdb:            rdb,
+		minioClient:    minioClient,
+		asynqClient:    asynqClient,
+		asynqInspector: asynqInspector,
+		taskProcessor:  taskProcessor,
+		taskScheduler:  taskScheduler,
+		logger:         logger}
 }
 
 func (s *Server) Run() error {
@@ -78,6 +84,8 @@ func (s *Server) Run() error {
 	ctx, shutdown := context.WithTimeout(context.Background(), ctxTimeout*time.Second)
 	defer shutdown()
 
+	s.taskScheduler.Shutdown()
+
 	s.logger.Info("Server Exited Properly")
 	return s.echo.Server.Shutdown(ctx)
 }
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+	"github.com/jmoiron/sqlx"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/tag"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/utils"
+)
+
+type tagRepo struct {
+	db *sqlx.DB
+}
+
+func NewTagRepository(db *sqlx.DB) tag.Repository {
+	return &tagRepo{db: db}
+}
+
+// List tags starting with prefix, most used first
+func (r *tagRepo) List(ctx context.Context, prefix string, pq *utils.PaginationQuery) (*models.TagsList, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "tagRepo.List")
+	defer span.Finish()
+
+	var totalCount int
+	if err := r.db.GetContext(ctx, &totalCount, getTagsTotalCountQuery, prefix); err != nil {
+		return nil, errors.Wrap(err, "tagRepo.List.GetContext.totalCount")
+	}
+
+	var tags = make([]*models.Tag, 0, pq.GetSize())
+	if totalCount > 0 {
+		if err := r.db.SelectContext(ctx, &tags, listTagsQuery, prefix, pq.GetOffset(), pq.GetLimit()); err != nil {
+			return nil, errors.Wrap(err, "tagRepo.List.SelectContext")
+		}
+	}
+
+	return &models.TagsList{
+		TotalCount: totalCount,
+		TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+		Page:       pq.GetPage(),
+		Size:       pq.GetSize(),
+		HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+		Tags:       tags,
+	}, nil
+}
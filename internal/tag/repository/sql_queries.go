@@ -0,0 +1,15 @@
+package repository
+
+const (
+	// Tags are counted by published blogs only, tags without them are not listed
+	listTagsFilter = `FROM tags t
+					JOIN blog_tags bt ON bt.tag_id = t.tag_id
+					JOIN blogs b ON b.blog_id = bt.blog_id AND b.status = 'published'
+				WHERE ($1::text = '' OR t.name LIKE $1 || '%')`
+
+	getTagsTotalCountQuery = `SELECT COUNT(DISTINCT t.tag_id) ` + listTagsFilter
+
+	listTagsQuery = `SELECT t.name, COUNT(*) as blogs_count ` + listTagsFilter + `
+				GROUP BY t.tag_id, t.name
+				ORDER BY blogs_count DESC, t.name OFFSET $2 LIMIT $3`
+)
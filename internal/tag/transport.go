@@ -0,0 +1,7 @@
+package tag
+
+import "github.com/labstack/echo/v4"
+
+type Handlers interface {
+	List() echo.HandlerFunc
+}
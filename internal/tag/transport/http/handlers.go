@@ -0,0 +1,56 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/opentracing/opentracing-go"
+	"github.com/scul0405/blog-clean-architecture-rest-api/config"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/tag"
+	httpErrors "github.com/scul0405/blog-clean-architecture-rest-api/pkg/http_errors"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/logger"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/utils"
+	"net/http"
+)
+
+type tagHandlers struct {
+	cfg    *config.Config
+	tagUC  tag.UseCase
+	logger logger.Logger
+}
+
+func NewTagHandlers(cfg *config.Config, tagUC tag.UseCase, logger logger.Logger) tag.Handlers {
+	return &tagHandlers{cfg: cfg, tagUC: tagUC, logger: logger}
+}
+
+// List godoc
+// @Summary List tags
+// @Description list tags with number of published blogs, most used first
+// @Tags Tag
+// @Accept json
+// @Produce json
+// @Param prefix query string false "only tags starting with prefix"
+// @Param page query int false "page number" Format(page)
+// @Param size query int false "number of elements per page" Format(size)
+// @Success 200 {object} models.TagsList
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 500 {object} httpErrors.RestError
+// @Router /tags [get]
+func (h *tagHandlers) List() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "tagHandlers.List")
+		defer span.Finish()
+
+		pq, err := utils.GetPaginationFromCtx(c)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		tagsList, err := h.tagUC.List(ctx, c.QueryParam("prefix"), pq)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, tagsList)
+	}
+}
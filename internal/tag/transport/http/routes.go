@@ -0,0 +1,10 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/tag"
+)
+
+func MapTagRoutes(tagGroup *echo.Group, h tag.Handlers) {
+	tagGroup.GET("", h.List())
+}
@@ -0,0 +1,12 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase_mock.go -package mock
+package tag
+
+import (
+	"context"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/utils"
+)
+
+type UseCase interface {
+	List(ctx context.Context, prefix string, pq *utils.PaginationQuery) (*models.TagsList, error)
+}
@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"context"
+	"github.com/opentracing/opentracing-go"
+	"github.com/scul0405/blog-clean-architecture-rest-api/config"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	"github.com/scul0405/blog-clean-architecture-rest-api/internal/tag"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/logger"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/utils"
+)
+
+type tagUseCase struct {
+	cfg     *config.Config
+	tagRepo tag.Repository
+	logger  logger.Logger
+}
+
+func NewTagUseCase(cfg *config.Config, tagRepo tag.Repository, logger logger.Logger) tag.UseCase {
+	return &tagUseCase{cfg: cfg, tagRepo: tagRepo, logger: logger}
+}
+
+// List tags by popularity, prefix is normalised like tag names so it matches them
+func (u *tagUseCase) List(ctx context.Context, prefix string, pq *utils.PaginationQuery) (*models.TagsList, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "tagUC.List")
+	defer span.Finish()
+
+	return u.tagRepo.List(ctx, utils.Slugify(prefix), pq)
+}
@@ -3,11 +3,17 @@ package user_comment
 
 import (
 	"context"
+	"github.com/google/uuid"
 	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
 )
 
 type Repository interface {
 	GetByID(ctx context.Context, userComment *models.UserComments) error
-	Create(ctx context.Context, userComment *models.UserComments) error
-	Delete(ctx context.Context, userComment *models.UserComments) error
+	// Create and Delete change likes_count of comment in same transaction, unless countLater when it is recounted by RecountLikes.
+	// They report false when like already was in wanted state
+	Create(ctx context.Context, userComment *models.UserComments, countLater bool) (bool, error)
+	Delete(ctx context.Context, userComment *models.UserComments, countLater bool) (bool, error)
+	GetLikesCount(ctx context.Context, commentID uuid.UUID) (int64, error)
+	RecountLikes(ctx context.Context, commentIDs []uuid.UUID) error
+	ReconcileLikesCounts(ctx context.Context) (int64, error)
 }
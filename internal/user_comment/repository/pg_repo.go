@@ -2,12 +2,13 @@ package repository
 
 import (
 	"context"
-	"database/sql"
+	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	"github.com/opentracing/opentracing-go"
 	"github.com/pkg/errors"
 	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
 	"github.com/scul0405/blog-clean-architecture-rest-api/internal/user_comment"
+	"strings"
 )
 
 type userCommentRepo struct {
@@ -18,24 +19,11 @@ func NewUserCommentRepository(db *sqlx.DB) user_comment.Repository {
 	return &userCommentRepo{db: db}
 }
 
-func (r *userCommentRepo) Create(ctx context.Context, userComment *models.UserComments) error {
+func (r *userCommentRepo) Create(ctx context.Context, userComment *models.UserComments, countLater bool) (bool, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "userCommentRepo.Create")
 	defer span.Finish()
 
-	result, err := r.db.ExecContext(ctx, createUserCommentQuery, userComment.UserID, userComment.CommentID)
-	if err != nil {
-		return errors.Wrap(err, "userCommentRepo.Create.StructScan")
-	}
-
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return errors.Wrap(err, "userCommentRepo.Create.RowsAffected")
-	}
-	if rowsAffected == 0 {
-		return errors.Wrap(sql.ErrNoRows, "userCommentRepo.Create.rowsAffected")
-	}
-
-	return nil
+	return r.changeLike(ctx, createUserCommentQuery, userComment, 1, countLater)
 }
 
 func (r *userCommentRepo) GetByID(ctx context.Context, userComment *models.UserComments) error {
@@ -50,22 +38,91 @@ func (r *userCommentRepo) GetByID(ctx context.Context, userComment *models.UserC
 	return nil
 }
 
-func (r *userCommentRepo) Delete(ctx context.Context, userComment *models.UserComments) error {
+func (r *userCommentRepo) Delete(ctx context.Context, userComment *models.UserComments, countLater bool) (bool, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "userCommentRepo.Delete")
 	defer span.Finish()
 
-	result, err := r.db.ExecContext(ctx, deleteUserCommentQuery, userComment.UserID, userComment.CommentID)
+	return r.changeLike(ctx, deleteUserCommentQuery, userComment, -1, countLater)
+}
+
+func (r *userCommentRepo) GetLikesCount(ctx context.Context, commentID uuid.UUID) (int64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "userCommentRepo.GetLikesCount")
+	defer span.Finish()
+
+	var likes int64
+	if err := r.db.GetContext(ctx, &likes, getLikesCountQuery, commentID); err != nil {
+		return 0, errors.Wrap(err, "userCommentRepo.GetLikesCount.GetContext")
+	}
+
+	return likes, nil
+}
+
+// RecountLikes set likes_count of comments to number of their likes. Recount does not depend on changes counted before,
+// so comment recounted more than once for same change is still counted right
+func (r *userCommentRepo) RecountLikes(ctx context.Context, commentIDs []uuid.UUID) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "userCommentRepo.RecountLikes")
+	defer span.Finish()
+
+	ids := make([]string, 0, len(commentIDs))
+	for _, commentID := range commentIDs {
+		ids = append(ids, commentID.String())
+	}
+
+	if _, err := r.db.ExecContext(ctx, recountLikesQuery, strings.Join(ids, ",")); err != nil {
+		return errors.Wrap(err, "userCommentRepo.RecountLikes.ExecContext")
+	}
+
+	return nil
+}
+
+// ReconcileLikesCounts recompute likes_count from likes, returns number of comments which had drifted
+func (r *userCommentRepo) ReconcileLikesCounts(ctx context.Context) (int64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "userCommentRepo.ReconcileLikesCounts")
+	defer span.Finish()
+
+	result, err := r.db.ExecContext(ctx, reconcileLikesCountsQuery)
 	if err != nil {
-		return errors.Wrap(err, "userCommentRepo.Delete.StructScan")
+		return 0, errors.Wrap(err, "userCommentRepo.ReconcileLikesCounts.ExecContext")
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return errors.Wrap(err, "userCommentRepo.Delete.RowsAffected")
+		return 0, errors.Wrap(err, "userCommentRepo.ReconcileLikesCounts.RowsAffected")
+	}
+
+	return rowsAffected, nil
+}
+
+// changeLike run like query and change likes_count by delta in one transaction, nothing is changed when query affects no rows
+func (r *userCommentRepo) changeLike(ctx context.Context, query string, userComment *models.UserComments, delta int64, countLater bool) (bool, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, errors.Wrap(err, "userCommentRepo.changeLike.BeginTxx")
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, query, userComment.UserID, userComment.CommentID)
+	if err != nil {
+		return false, errors.Wrap(err, "userCommentRepo.changeLike.ExecContext")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "userCommentRepo.changeLike.RowsAffected")
 	}
 	if rowsAffected == 0 {
-		return errors.Wrap(sql.ErrNoRows, "userCommentRepo.Delete.rowsAffected")
+		return false, nil
 	}
 
-	return nil
+	if !countLater {
+		if _, err = tx.ExecContext(ctx, updateLikesCountQuery, userComment.CommentID, delta); err != nil {
+			return false, errors.Wrap(err, "userCommentRepo.changeLike.ExecContext.likesCount")
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return false, errors.Wrap(err, "userCommentRepo.changeLike.Commit")
+	}
+
+	return true, nil
 }
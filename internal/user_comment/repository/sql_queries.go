@@ -1,11 +1,32 @@
 package repository
 
+// Like of comment is reaction of kind like
 const (
-	createUserCommentQuery = `INSERT INTO user_comments (user_id, comment_id) VALUES ($1, $2)`
+	createUserCommentQuery = `INSERT INTO reactions (user_id, target_type, target_id, kind)
+						SELECT $1, 'comment', $2, 'like' WHERE EXISTS (SELECT 1 FROM comments WHERE comment_id = $2 AND deleted_at IS NULL)
+						ON CONFLICT DO NOTHING`
 
-	getUserCommentQuery = `SELECT *	
-						FROM user_comments
-						WHERE user_id = $1 AND comment_id = $2`
+	getUserCommentQuery = `SELECT user_id, target_id as comment_id, created_at
+						FROM reactions
+						WHERE user_id = $1 AND target_type = 'comment' AND target_id = $2 AND kind = 'like'`
 
-	deleteUserCommentQuery = `DELETE FROM user_comments WHERE user_id = $1 AND comment_id = $2`
+	deleteUserCommentQuery = `DELETE FROM reactions WHERE user_id = $1 AND target_type = 'comment' AND target_id = $2 AND kind = 'like'`
+
+	getLikesCountQuery = `SELECT likes_count FROM comments WHERE comment_id = $1`
+
+	updateLikesCountQuery = `UPDATE comments SET likes_count = GREATEST(likes_count + $2, 0) WHERE comment_id = $1`
+
+	// Comment ids are comma separated
+	recountLikesQuery = `UPDATE comments c
+						SET likes_count = (SELECT count(*) FROM reactions r
+							WHERE r.target_type = 'comment' AND r.target_id = c.comment_id AND r.kind = 'like')
+						WHERE c.comment_id = ANY (string_to_array($1, ',')::uuid[])`
+
+	reconcileLikesCountsQuery = `UPDATE comments c
+						SET likes_count = l.count
+						FROM (SELECT cc.comment_id, count(r.user_id) as count
+							FROM comments cc
+								LEFT JOIN reactions r ON r.target_type = 'comment' AND r.target_id = cc.comment_id AND r.kind = 'like'
+							GROUP BY cc.comment_id) l
+						WHERE l.comment_id = c.comment_id AND c.likes_count <> l.count`
 )
@@ -0,0 +1,4 @@
+UPDATE users SET role = 'user' WHERE role NOT IN ('user', 'editor', 'moderator', 'admin');
+
+ALTER TABLE users
+    ADD CONSTRAINT users_role_check CHECK ( role IN ('user', 'editor', 'moderator', 'admin') );
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS login_events
+(
+    event_id   UUID PRIMARY KEY                  DEFAULT uuid_generate_v4(),
+    user_id    UUID REFERENCES users (user_id) ON DELETE SET NULL,
+    email      VARCHAR(64)              NOT NULL,
+    ip_address VARCHAR(64)              NOT NULL DEFAULT '',
+    success    BOOLEAN                  NOT NULL,
+    reason     VARCHAR(64)              NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS login_events_email_created_at_idx ON login_events (email, created_at DESC);
+CREATE INDEX IF NOT EXISTS login_events_user_id_created_at_idx ON login_events (user_id, created_at DESC);
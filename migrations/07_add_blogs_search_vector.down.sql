@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS blogs_search_vector_idx;
+ALTER TABLE blogs DROP COLUMN IF EXISTS search_vector;
@@ -0,0 +1,6 @@
+ALTER TABLE blogs
+    ADD COLUMN IF NOT EXISTS search_vector tsvector
+        GENERATED ALWAYS AS (setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+                             setweight(to_tsvector('english', coalesce(content, '')), 'B')) STORED;
+
+CREATE INDEX IF NOT EXISTS blogs_search_vector_idx ON blogs USING GIN (search_vector);
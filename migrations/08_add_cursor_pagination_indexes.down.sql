@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS comments_blog_id_created_at_comment_id_idx;
+DROP INDEX IF EXISTS blogs_created_at_blog_id_idx;
@@ -0,0 +1,2 @@
+CREATE INDEX IF NOT EXISTS blogs_created_at_blog_id_idx ON blogs (created_at, blog_id);
+CREATE INDEX IF NOT EXISTS comments_blog_id_created_at_comment_id_idx ON comments (blog_id, created_at, comment_id);
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS comments_parent_id_created_at_idx;
+
+DELETE FROM comments WHERE deleted_at IS NOT NULL;
+
+ALTER TABLE comments
+    DROP COLUMN IF EXISTS deleted_at,
+    DROP COLUMN IF EXISTS depth,
+    DROP COLUMN IF EXISTS parent_id;
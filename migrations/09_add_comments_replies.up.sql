@@ -0,0 +1,6 @@
+ALTER TABLE comments
+    ADD COLUMN IF NOT EXISTS parent_id  UUID REFERENCES comments (comment_id) ON DELETE CASCADE,
+    ADD COLUMN IF NOT EXISTS depth      INTEGER NOT NULL DEFAULT 0 CHECK ( depth >= 0 ),
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS comments_parent_id_created_at_idx ON comments (parent_id, created_at, comment_id);
@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS user_comments
+(
+    user_id    UUID                                               NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    comment_id UUID                                               NOT NULL REFERENCES comments (comment_id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT user_comments_pkey PRIMARY KEY (user_id, comment_id)
+);
+
+INSERT INTO user_comments (user_id, comment_id, created_at)
+SELECT r.user_id, r.target_id, r.created_at
+FROM reactions r
+         JOIN comments c ON c.comment_id = r.target_id
+WHERE r.target_type = 'comment'
+  AND r.kind = 'like'
+ON CONFLICT DO NOTHING;
+
+DROP TRIGGER IF EXISTS comments_delete_reactions ON comments;
+DROP TRIGGER IF EXISTS blogs_delete_reactions ON blogs;
+DROP FUNCTION IF EXISTS delete_comment_reactions();
+DROP FUNCTION IF EXISTS delete_blog_reactions();
+DROP TABLE IF EXISTS reactions CASCADE;
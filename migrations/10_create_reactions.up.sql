@@ -0,0 +1,47 @@
+CREATE TABLE IF NOT EXISTS reactions
+(
+    user_id     UUID                                               NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    target_type VARCHAR(16)                                        NOT NULL CHECK ( target_type IN ('blog', 'comment') ),
+    target_id   UUID                                               NOT NULL,
+    kind        VARCHAR(16)                                        NOT NULL CHECK ( kind IN ('like', 'love', 'laugh', 'wow', 'sad', 'angry') ),
+    created_at  TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CONSTRAINT reactions_pkey PRIMARY KEY (target_type, target_id, user_id, kind)
+);
+
+CREATE INDEX IF NOT EXISTS reactions_user_id_idx ON reactions (user_id);
+
+-- target is either blog or comment so reactions are removed by triggers instead of foreign keys
+CREATE OR REPLACE FUNCTION delete_blog_reactions() RETURNS TRIGGER AS
+$$
+BEGIN
+    DELETE FROM reactions WHERE target_type = 'blog' AND target_id = OLD.blog_id;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION delete_comment_reactions() RETURNS TRIGGER AS
+$$
+BEGIN
+    DELETE FROM reactions WHERE target_type = 'comment' AND target_id = OLD.comment_id;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER blogs_delete_reactions
+    AFTER DELETE
+    ON blogs
+    FOR EACH ROW
+EXECUTE FUNCTION delete_blog_reactions();
+
+CREATE TRIGGER comments_delete_reactions
+    AFTER DELETE
+    ON comments
+    FOR EACH ROW
+EXECUTE FUNCTION delete_comment_reactions();
+
+INSERT INTO reactions (user_id, target_type, target_id, kind, created_at)
+SELECT user_id, 'comment', comment_id, 'like', created_at
+FROM user_comments
+ON CONFLICT DO NOTHING;
+
+DROP TABLE IF EXISTS user_comments CASCADE;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS comments_blog_id_likes_count_idx;
+
+ALTER TABLE comments
+    DROP COLUMN IF EXISTS likes_count;
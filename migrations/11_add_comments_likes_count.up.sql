@@ -0,0 +1,13 @@
+ALTER TABLE comments
+    ADD COLUMN IF NOT EXISTS likes_count BIGINT NOT NULL DEFAULT 0 CHECK ( likes_count >= 0 );
+
+UPDATE comments c
+SET likes_count = l.count
+FROM (SELECT target_id, count(*) as count
+      FROM reactions
+      WHERE target_type = 'comment'
+        AND kind = 'like'
+      GROUP BY target_id) l
+WHERE l.target_id = c.comment_id;
+
+CREATE INDEX IF NOT EXISTS comments_blog_id_likes_count_idx ON comments (blog_id, likes_count);
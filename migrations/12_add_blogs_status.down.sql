@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS blogs_status_created_at_blog_id_idx;
+
+ALTER TABLE blogs
+    DROP COLUMN IF EXISTS published_at,
+    DROP COLUMN IF EXISTS status;
@@ -0,0 +1,11 @@
+ALTER TABLE blogs
+    ADD COLUMN IF NOT EXISTS status       VARCHAR(16) NOT NULL DEFAULT 'draft'
+        CHECK ( status IN ('draft', 'scheduled', 'published', 'archived') ),
+    ADD COLUMN IF NOT EXISTS published_at TIMESTAMP WITH TIME ZONE;
+
+-- Blogs written before statuses existed were public
+UPDATE blogs
+SET status       = 'published',
+    published_at = created_at;
+
+CREATE INDEX IF NOT EXISTS blogs_status_created_at_blog_id_idx ON blogs (status, created_at, blog_id);
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS blog_revisions
+(
+    blog_id    UUID                     NOT NULL REFERENCES blogs (blog_id) ON DELETE CASCADE,
+    revision   INT                      NOT NULL CHECK ( revision > 0 ),
+    editor_id  UUID REFERENCES users (user_id) ON DELETE SET NULL,
+    title      VARCHAR(250)             NOT NULL,
+    content    TEXT                     NOT NULL,
+    image_url  VARCHAR(1024),
+    category   VARCHAR(250),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    PRIMARY KEY (blog_id, revision)
+);
+
+-- Current state of every blog is its first revision
+INSERT INTO blog_revisions (blog_id, revision, editor_id, title, content, image_url, category, created_at)
+SELECT blog_id, 1, author_id, title, content, image_url, category, COALESCE(updated_at, created_at)
+FROM blogs
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,5 @@
+ALTER TABLE comments
+    DROP COLUMN IF EXISTS version;
+
+ALTER TABLE blogs
+    DROP COLUMN IF EXISTS version;
@@ -0,0 +1,5 @@
+ALTER TABLE blogs
+    ADD COLUMN IF NOT EXISTS version INT NOT NULL DEFAULT 1 CHECK ( version > 0 );
+
+ALTER TABLE comments
+    ADD COLUMN IF NOT EXISTS version INT NOT NULL DEFAULT 1 CHECK ( version > 0 );
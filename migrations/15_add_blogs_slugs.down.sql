@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS blog_slugs;
+
+ALTER TABLE blogs
+    DROP COLUMN IF EXISTS slug;
@@ -0,0 +1,129 @@
+-- Slug is set in same transaction which creates blog, so it stays nullable between insert and update
+ALTER TABLE blogs
+    ADD COLUMN IF NOT EXISTS slug VARCHAR(100) UNIQUE;
+
+-- Every slug blog ever had, old slugs redirect to current one
+CREATE TABLE IF NOT EXISTS blog_slugs
+(
+    slug       VARCHAR(100) PRIMARY KEY,
+    blog_id    UUID                     NOT NULL REFERENCES blogs (blog_id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS blog_slugs_blog_id_idx ON blog_slugs (blog_id);
+
+-- Existing blogs get slug utils.Slugify makes of their title, so writes which keep title keep slug too. Letters are
+-- transliterated as utils.Slugify does, latin and cyrillic combining marks are dropped and every other run of characters
+-- becomes hyphen
+CREATE FUNCTION pg_temp.slugify(title TEXT) RETURNS TEXT AS
+$$
+DECLARE
+    letters CONSTANT JSONB := '{
+          "": "ЪЬъь",
+          "a": "ÀÁÂÃÄÅàáâãäåĀāĂăĄąǍǎǞǟǠǡǺǻȀȁȂȃȦȧАаӐӑӒӓḀḁẠạẢảẤấẦầẨẩẪẫẬậẮắẰằẲẳẴẵẶặ",
+          "ae": "ÆæǢǣǼǽ",
+          "b": "БбḂḃḄḅḆḇ",
+          "c": "ÇçĆćĈĉĊċČčḈḉ",
+          "ch": "ЧчӴӵ",
+          "d": "ÐðĎďĐđДдḊḋḌḍḎḏḐḑḒḓ",
+          "e": "ÈÉÊËèéêëĒēĔĕĖėĘęĚěȄȅȆȇȨȩЀЁЕЭеэѐёӖӗӬӭḔḕḖḗḘḙḚḛḜḝẸẹẺẻẼẽẾếỀềỂểỄễỆệ",
+          "f": "ФфḞḟ",
+          "g": "ĜĝĞğĠġĢģǦǧǴǵЃГгѓҐґḠḡ",
+          "h": "ĤĥȞȟḢḣḤḥḦḧḨḩḪḫẖ",
+          "i": "ÌÍÎÏìíîïĨĩĪīĬĭĮįİıǏǐȈȉȊȋІЇЍИЙийіїѝӢӣӤӥḬḭḮḯỈỉỊị",
+          "j": "Ĵĵǰ",
+          "k": "ĶķǨǩЌКкќḰḱḲḳḴḵ",
+          "kh": "Хх",
+          "l": "ĹĺĻļĽľŁłЛлḶḷḸḹḺḻḼḽ",
+          "m": "МмḾḿṀṁṂṃ",
+          "n": "ÑñŃńŅņŇňǸǹНнṄṅṆṇṈṉṊṋ",
+          "o": "ÒÓÔÕÖØòóôõöøŌōŎŏŐőƠơǑǒǪǫǬǭǾǿȌȍȎȏȪȫȬȭȮȯȰȱОоӦӧṌṍṎṏṐṑṒṓỌọỎỏỐốỒồỔổỖỗỘộỚớỜờỞởỠỡỢợ",
+          "oe": "Œœ",
+          "p": "ПпṔṕṖṗ",
+          "r": "ŔŕŖŗŘřȐȑȒȓРрṘṙṚṛṜṝṞṟ",
+          "s": "ŚśŜŝŞşŠšȘșСсṠṡṢṣṤṥṦṧṨṩ",
+          "sh": "Шш",
+          "shch": "Щщ",
+          "ss": "ßẞ",
+          "t": "ŢţŤťȚțТтṪṫṬṭṮṯṰṱẗ",
+          "th": "Þþ",
+          "ts": "Цц",
+          "u": "ÙÚÛÜùúûüŨũŪūŬŭŮůŰűŲųƯưǓǔǕǖǗǘǙǚǛǜȔȕȖȗЎУуўӮӯӰӱӲӳṲṳṴṵṶṷṸṹṺṻỤụỦủỨứỪừỬửỮữỰự",
+          "v": "ВвṼṽṾṿ",
+          "w": "ŴŵẀẁẂẃẄẅẆẇẈẉẘ",
+          "x": "ẊẋẌẍ",
+          "y": "ÝýÿŶŷŸȲȳЫыӸӹẎẏẙỲỳỴỵỶỷỸỹ",
+          "ya": "Яя",
+          "ye": "Єє",
+          "yu": "Юю",
+          "z": "ŹźŻżŽžЗзӞӟẐẑẒẓẔẕ",
+          "zh": "ЖжӁӂӜӝ"
+        }';
+    slug             TEXT    := '';
+    hyphen           BOOLEAN := false;
+    ch               TEXT;
+    word             TEXT;
+BEGIN
+    FOREACH ch IN ARRAY regexp_split_to_array(title, '')
+        LOOP
+            IF ch ~ '^[A-Za-z0-9]$' THEN
+                word := lower(ch);
+            -- Combining diacritical marks and cyrillic titlo marks
+            ELSIF ascii(ch) BETWEEN 768 AND 879 OR ascii(ch) BETWEEN 1155 AND 1159 THEN
+                CONTINUE;
+            ELSE
+                SELECT l.key INTO word FROM jsonb_each_text(letters) l WHERE strpos(l.value, ch) > 0;
+            END IF;
+
+            IF word IS NULL THEN
+                hyphen := slug <> '';
+                CONTINUE;
+            END IF;
+            CONTINUE WHEN word = '';
+            -- Word which does not fit is dropped whole, single word longer than limit is cut
+            IF length(slug) + length(word) + (CASE WHEN hyphen THEN 1 ELSE 0 END) > 80 THEN
+                IF NOT hyphen AND strpos(slug, '-') > 0 THEN
+                    slug := regexp_replace(slug, '-[^-]*$', '');
+                END IF;
+                EXIT;
+            END IF;
+            IF hyphen THEN
+                slug := slug || '-';
+                hyphen := false;
+            END IF;
+            slug := slug || word;
+        END LOOP;
+
+    RETURN slug;
+END
+$$ LANGUAGE plpgsql;
+
+-- Slug taken by older blog gets first free collision suffix, blog without letters in title gets first part of its id
+DO
+$$
+    DECLARE
+        b         RECORD;
+        base      TEXT;
+        candidate TEXT;
+        n         INT;
+    BEGIN
+        FOR b IN SELECT blog_id, title FROM blogs WHERE slug IS NULL ORDER BY created_at, blog_id
+            LOOP
+                base := pg_temp.slugify(b.title);
+                IF base = '' THEN
+                    base := split_part(b.blog_id::text, '-', 1);
+                END IF;
+
+                candidate := base;
+                n := 1;
+                WHILE EXISTS (SELECT 1 FROM blog_slugs WHERE slug = candidate)
+                    LOOP
+                        n := n + 1;
+                        candidate := base || '-' || n;
+                    END LOOP;
+
+                INSERT INTO blog_slugs (slug, blog_id) VALUES (candidate, b.blog_id);
+                UPDATE blogs SET slug = candidate WHERE blog_id = b.blog_id;
+            END LOOP;
+    END
+$$;
@@ -0,0 +1,49 @@
+CREATE TABLE IF NOT EXISTS categories
+(
+    category_id UUID PRIMARY KEY                                   DEFAULT uuid_generate_v4(),
+    slug        VARCHAR(64)                                        NOT NULL UNIQUE CHECK ( slug <> '' ),
+    name        VARCHAR(64)                                        NOT NULL CHECK ( name <> '' ),
+    description VARCHAR(512),
+    created_at  TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at  TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Free text categories become categories named by their first spelling, blogs keep slug of their category
+INSERT INTO categories (slug, name)
+SELECT slug, MIN(name)
+FROM (SELECT trim(BOTH '-' FROM regexp_replace(lower(category), '[^a-z0-9]+', '-', 'g')) AS slug,
+             left(trim(category), 64)                                                   AS name
+      FROM blogs
+      WHERE category IS NOT NULL) c
+WHERE slug <> ''
+GROUP BY slug
+ON CONFLICT DO NOTHING;
+
+UPDATE blogs
+SET category = NULLIF(trim(BOTH '-' FROM regexp_replace(lower(category), '[^a-z0-9]+', '-', 'g')), '')
+WHERE category IS NOT NULL;
+
+UPDATE blog_revisions
+SET category = NULLIF(trim(BOTH '-' FROM regexp_replace(lower(category), '[^a-z0-9]+', '-', 'g')), '')
+WHERE category IS NOT NULL;
+
+ALTER TABLE blogs
+    ADD CONSTRAINT blogs_category_fkey FOREIGN KEY (category) REFERENCES categories (slug) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS blogs_category_idx ON blogs (category);
+
+CREATE TABLE IF NOT EXISTS tags
+(
+    tag_id     UUID PRIMARY KEY                                   DEFAULT uuid_generate_v4(),
+    name       VARCHAR(32)                                        NOT NULL UNIQUE CHECK ( name <> '' ),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS blog_tags
+(
+    blog_id UUID NOT NULL REFERENCES blogs (blog_id) ON DELETE CASCADE,
+    tag_id  UUID NOT NULL REFERENCES tags (tag_id) ON DELETE CASCADE,
+    PRIMARY KEY (blog_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS blog_tags_tag_id_blog_id_idx ON blog_tags (tag_id, blog_id);
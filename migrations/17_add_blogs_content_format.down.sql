@@ -0,0 +1,9 @@
+ALTER TABLE blog_revisions
+    DROP COLUMN IF EXISTS content_format;
+
+ALTER TABLE blogs
+    DROP COLUMN IF EXISTS content_format,
+    DROP COLUMN IF EXISTS content_html,
+    DROP COLUMN IF EXISTS excerpt,
+    DROP COLUMN IF EXISTS word_count,
+    DROP COLUMN IF EXISTS reading_time;
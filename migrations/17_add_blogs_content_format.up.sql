@@ -0,0 +1,21 @@
+ALTER TABLE blogs
+    ADD COLUMN IF NOT EXISTS content_format VARCHAR(16) NOT NULL DEFAULT 'plain'
+        CHECK ( content_format IN ('plain', 'markdown') ),
+    ADD COLUMN IF NOT EXISTS content_html   TEXT,
+    ADD COLUMN IF NOT EXISTS excerpt        VARCHAR(256) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS word_count     INT          NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS reading_time   INT          NOT NULL DEFAULT 0;
+
+ALTER TABLE blog_revisions
+    ADD COLUMN IF NOT EXISTS content_format VARCHAR(16) NOT NULL DEFAULT 'plain';
+
+-- Existing blogs are plain text, their HTML is rendered on first read and fields of text are estimated here
+UPDATE blogs
+SET word_count = COALESCE(array_length(regexp_split_to_array(trim(content), '\s+'), 1), 0),
+    excerpt    = CASE
+                     WHEN length(regexp_replace(trim(content), '\s+', ' ', 'g')) > 200
+                         THEN left(regexp_replace(trim(content), '\s+', ' ', 'g'), 200) || '…'
+                     ELSE regexp_replace(trim(content), '\s+', ' ', 'g') END;
+
+UPDATE blogs
+SET reading_time = CEIL(word_count / 200.0);
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS comment_reports;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS comments_banned_at;
+
+ALTER TABLE comments
+    DROP COLUMN IF EXISTS moderation_status;
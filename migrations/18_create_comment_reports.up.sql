@@ -0,0 +1,24 @@
+ALTER TABLE comments
+    ADD COLUMN IF NOT EXISTS moderation_status VARCHAR(16) NOT NULL DEFAULT 'visible'
+        CHECK ( moderation_status IN ('visible', 'hidden', 'approved', 'rejected') );
+
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS comments_banned_at TIMESTAMP WITH TIME ZONE;
+
+CREATE TABLE IF NOT EXISTS comment_reports
+(
+    report_id   UUID PRIMARY KEY                                   DEFAULT uuid_generate_v4(),
+    comment_id  UUID                                               NOT NULL REFERENCES comments (comment_id) ON DELETE CASCADE,
+    reporter_id UUID                                               NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    reason      VARCHAR(16)                                        NOT NULL
+        CHECK ( reason IN ('spam', 'abuse', 'harassment', 'off_topic', 'other') ),
+    details     VARCHAR(512)                                       NOT NULL DEFAULT '',
+    status      VARCHAR(16)                                        NOT NULL DEFAULT 'pending'
+        CHECK ( status IN ('pending', 'resolved') ),
+    resolved_by UUID REFERENCES users (user_id) ON DELETE SET NULL,
+    resolved_at TIMESTAMP WITH TIME ZONE,
+    created_at  TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    UNIQUE (comment_id, reporter_id)
+);
+
+CREATE INDEX IF NOT EXISTS comment_reports_pending_idx ON comment_reports (comment_id) WHERE status = 'pending';
@@ -0,0 +1,14 @@
+-- Decisions are audit log, they outlive their targets and authors
+CREATE TABLE IF NOT EXISTS content_filter_decisions
+(
+    decision_id UUID PRIMARY KEY                                   DEFAULT uuid_generate_v4(),
+    target_type VARCHAR(16)                                        NOT NULL CHECK ( target_type IN ('comment', 'blog') ),
+    target_id   UUID,
+    author_id   UUID                                               NOT NULL,
+    action      VARCHAR(16)                                        NOT NULL CHECK ( action IN ('mask', 'hold', 'reject') ),
+    matches     JSONB                                              NOT NULL DEFAULT '[]',
+    created_at  TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS content_filter_decisions_author_id_idx ON content_filter_decisions (author_id, created_at);
+CREATE INDEX IF NOT EXISTS content_filter_decisions_target_id_idx ON content_filter_decisions (target_id);
@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS notifications
+(
+    notification_id UUID PRIMARY KEY                                   DEFAULT uuid_generate_v4(),
+    recipient_id    UUID                                               NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    actor_id        UUID REFERENCES users (user_id) ON DELETE SET NULL,
+    type            VARCHAR(16)                                        NOT NULL
+        CHECK ( type IN ('comment', 'reply', 'comment_like') ),
+    blog_id         UUID REFERENCES blogs (blog_id) ON DELETE CASCADE,
+    comment_id      UUID REFERENCES comments (comment_id) ON DELETE CASCADE,
+    actors_count    INTEGER                                            NOT NULL DEFAULT 1,
+    -- distinct actors collapsed into notification, actors_count is their count
+    actor_ids       UUID[]                                             NOT NULL DEFAULT '{}',
+    -- notifications with same group key are collapsed into one while it is unread
+    group_key       VARCHAR(64),
+    read_at         TIMESTAMP WITH TIME ZONE,
+    created_at      TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at      TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS notifications_recipient_idx ON notifications (recipient_id, updated_at DESC);
+CREATE INDEX IF NOT EXISTS notifications_unread_idx ON notifications (recipient_id) WHERE read_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS notifications_unread_group_idx ON notifications (recipient_id, group_key) WHERE read_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS notification_preferences
+(
+    user_id      UUID PRIMARY KEY REFERENCES users (user_id) ON DELETE CASCADE,
+    comment      BOOLEAN                                            NOT NULL DEFAULT TRUE,
+    reply        BOOLEAN                                            NOT NULL DEFAULT TRUE,
+    comment_like BOOLEAN                                            NOT NULL DEFAULT TRUE,
+    updated_at   TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
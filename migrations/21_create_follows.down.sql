@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS blogs_author_id_published_at_idx;
+
+DROP TABLE IF EXISTS follows;
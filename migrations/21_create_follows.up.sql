@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS follows
+(
+    follower_id UUID                                               NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    followee_id UUID                                               NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    created_at  TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY (follower_id, followee_id),
+    CHECK ( follower_id <> followee_id )
+);
+
+CREATE INDEX IF NOT EXISTS follows_followee_id_idx ON follows (followee_id, follower_id);
+
+-- feed reads recent published blogs of followed authors
+CREATE INDEX IF NOT EXISTS blogs_author_id_published_at_idx ON blogs (author_id, published_at, blog_id) WHERE status = 'published';
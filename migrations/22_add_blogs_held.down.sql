@@ -0,0 +1,2 @@
+ALTER TABLE blogs
+    DROP COLUMN IF EXISTS held;
@@ -0,0 +1,3 @@
+-- Held blog waits for moderator, it cannot be published until moderator releases it
+ALTER TABLE blogs
+    ADD COLUMN IF NOT EXISTS held BOOLEAN NOT NULL DEFAULT false;
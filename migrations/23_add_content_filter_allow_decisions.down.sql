@@ -0,0 +1,7 @@
+DELETE
+FROM content_filter_decisions
+WHERE action = 'allow';
+
+ALTER TABLE content_filter_decisions
+    DROP CONSTRAINT IF EXISTS content_filter_decisions_action_check,
+    ADD CONSTRAINT content_filter_decisions_action_check CHECK ( action IN ('mask', 'hold', 'reject') );
@@ -0,0 +1,4 @@
+-- Decisions which allow content are audited too, their matches show checks which failed
+ALTER TABLE content_filter_decisions
+    DROP CONSTRAINT IF EXISTS content_filter_decisions_action_check,
+    ADD CONSTRAINT content_filter_decisions_action_check CHECK ( action IN ('allow', 'mask', 'hold', 'reject') );
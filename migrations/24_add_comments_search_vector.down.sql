@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS comments_search_vector_idx;
+ALTER TABLE comments DROP COLUMN IF EXISTS search_vector;
@@ -0,0 +1,6 @@
+-- Comments rank below title and content of their blog, weight D counts least in ts_rank
+ALTER TABLE comments
+    ADD COLUMN IF NOT EXISTS search_vector tsvector
+        GENERATED ALWAYS AS (setweight(to_tsvector('english', coalesce(message, '')), 'D')) STORED;
+
+CREATE INDEX IF NOT EXISTS comments_search_vector_idx ON comments USING GIN (search_vector);
@@ -1,7 +1,9 @@
 package asynq
 
 import (
+	"context"
 	"github.com/hibiken/asynq"
+	"github.com/pkg/errors"
 )
 
 func NewAsynqClient(redisOpt asynq.RedisClientOpt) *asynq.Client {
@@ -9,3 +11,50 @@ func NewAsynqClient(redisOpt asynq.RedisClientOpt) *asynq.Client {
 
 	return client
 }
+
+func NewAsynqInspector(redisOpt asynq.RedisClientOpt) *asynq.Inspector {
+	inspector := asynq.NewInspector(redisOpt)
+
+	return inspector
+}
+
+// EnqueueReplacingArchived enqueue task, archived or completed task which holds its TaskID is deleted first.
+// Task which still waits or runs with same TaskID gives asynq.ErrTaskIDConflict
+func EnqueueReplacingArchived(ctx context.Context, client *asynq.Client, inspector *asynq.Inspector, task *asynq.Task, opts ...asynq.Option) (*asynq.TaskInfo, error) {
+	info, err := client.EnqueueContext(ctx, task, opts...)
+	if !errors.Is(err, asynq.ErrTaskIDConflict) {
+		return info, err
+	}
+
+	queue, taskID := queueAndTaskID(opts)
+	held, inspectErr := inspector.GetTaskInfo(queue, taskID)
+	if inspectErr != nil && !errors.Is(inspectErr, asynq.ErrTaskNotFound) {
+		return nil, errors.Wrap(inspectErr, "asynq.EnqueueReplacingArchived.GetTaskInfo")
+	}
+	if held != nil {
+		if held.State != asynq.TaskStateArchived && held.State != asynq.TaskStateCompleted {
+			return nil, err
+		}
+		if err = inspector.DeleteTask(queue, taskID); err != nil && !errors.Is(err, asynq.ErrTaskNotFound) {
+			return nil, errors.Wrap(err, "asynq.EnqueueReplacingArchived.DeleteTask")
+		}
+	}
+
+	return client.EnqueueContext(ctx, task, opts...)
+}
+
+// queueAndTaskID read queue and TaskID from options, later option wins like it does in asynq
+func queueAndTaskID(opts []asynq.Option) (string, string) {
+	queue := QueueDefault
+	var taskID string
+	for _, opt := range opts {
+		switch opt.Type() {
+		case asynq.QueueOpt:
+			queue = opt.Value().(string)
+		case asynq.TaskIDOpt:
+			taskID = opt.Value().(string)
+		}
+	}
+
+	return queue, taskID
+}
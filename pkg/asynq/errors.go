@@ -0,0 +1,45 @@
+package asynq
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/hibiken/asynq"
+	"github.com/pkg/errors"
+	httpErrors "github.com/scul0405/blog-clean-architecture-rest-api/pkg/http_errors"
+)
+
+// SkipRetryIfPermanent mark errors which retrying cannot fix, asynq archives such task at once
+// and archived tasks are the dead-letter queue which can be inspected and re-run later.
+// Archived task keeps its TaskID reserved until it is deleted or re-run
+func SkipRetryIfPermanent(err error) error {
+	if err == nil || !isPermanent(err) {
+		return err
+	}
+
+	return fmt.Errorf("%v: %w", err, asynq.SkipRetry)
+}
+
+// isPermanent report missing rows and client errors
+func isPermanent(err error) bool {
+	if errors.Is(err, sql.ErrNoRows) {
+		return true
+	}
+
+	var restErr httpErrors.RestErr
+	if errors.As(err, &restErr) {
+		return restErr.Status() >= 400 && restErr.Status() < 500
+	}
+
+	return false
+}
+
+// IgnoreTaskIDConflict treat task which is enqueued already with same TaskID as enqueued.
+// Archived task keeps its TaskID reserved as well, so task with TaskID is enqueued with EnqueueReplacingArchived
+// and conflict left means task which still waits or runs
+func IgnoreTaskIDConflict(err error) error {
+	if errors.Is(err, asynq.ErrTaskIDConflict) {
+		return nil
+	}
+
+	return err
+}
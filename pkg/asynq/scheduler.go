@@ -0,0 +1,40 @@
+package asynq
+
+import (
+	"github.com/hibiken/asynq"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/logger"
+)
+
+type RedisTaskScheduler struct {
+	scheduler *asynq.Scheduler
+}
+
+func NewRedisTaskScheduler(redisOpt asynq.RedisClientOpt, logger logger.Logger) *RedisTaskScheduler {
+	scheduler := asynq.NewScheduler(
+		redisOpt,
+		&asynq.SchedulerOpts{
+			Logger: logger,
+			PostEnqueueFunc: func(info *asynq.TaskInfo, err error) {
+				if err != nil {
+					logger.Errorf("enqueue periodic task failed: err=%v", err)
+				}
+			},
+		},
+	)
+
+	return &RedisTaskScheduler{scheduler: scheduler}
+}
+
+// Register enqueue task every time cronspec matches, e.g. "@every 30s"
+func (s *RedisTaskScheduler) Register(cronspec string, task *asynq.Task, opts ...asynq.Option) error {
+	_, err := s.scheduler.Register(cronspec, task, opts...)
+	return err
+}
+
+func (s *RedisTaskScheduler) Start() error {
+	return s.scheduler.Start()
+}
+
+func (s *RedisTaskScheduler) Shutdown() {
+	s.scheduler.Shutdown()
+}
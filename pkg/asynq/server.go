@@ -2,6 +2,7 @@ package asynq
 
 import (
 	"context"
+	"errors"
 	"github.com/hibiken/asynq"
 	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/logger"
 )
@@ -25,7 +26,15 @@ func NewRedisTaskProcessor(redisOpt asynq.RedisClientOpt, logger logger.Logger)
 				QueueDefault:  5,
 			},
 			ErrorHandler: asynq.ErrorHandlerFunc(func(ctx context.Context, task *asynq.Task, err error) {
-				logger.Errorf("process task failed: type=%s, payload=%s, err=%v", task.Type(), task.Payload(), err)
+				// Payload is not logged, it may be large or carry tokens sent by email
+				taskID, _ := asynq.GetTaskID(ctx)
+				retried, _ := asynq.GetRetryCount(ctx)
+				maxRetry, _ := asynq.GetMaxRetry(ctx)
+				if errors.Is(err, asynq.SkipRetry) || retried >= maxRetry {
+					logger.Errorf("process task failed, moved to dead-letter queue: type=%s, id=%s, err=%v", task.Type(), taskID, err)
+					return
+				}
+				logger.Errorf("process task failed: type=%s, id=%s, retried=%d, err=%v", task.Type(), taskID, retried, err)
 			}),
 			Logger: logger,
 		},
@@ -23,6 +23,10 @@ var (
 	InternalServerError   = errors.New("Internal Server Error")
 	RequestTimeoutError   = errors.New("Request Timeout")
 	NotAllowedImageHeader = errors.New("Not allowed image header")
+	ImageTooLarge         = errors.New("Image is too large")
+	DiffTooLarge          = errors.New("Revisions are too large to diff")
+	TooManyRequests       = errors.New("Too many requests")
+	PreconditionFailed    = errors.New("Precondition Failed")
 )
 
 type RestErr interface {
@@ -108,6 +112,85 @@ func NewForbiddenError(causes interface{}) RestErr {
 	}
 }
 
+// New Forbidden Error which explains missing permission
+func NewMissingPermissionError(permission string, causes interface{}) RestErr {
+	return RestError{
+		ErrStatus: http.StatusForbidden,
+		ErrError:  fmt.Sprintf("%s: missing permission %s", Forbidden.Error(), permission),
+		ErrCauses: causes,
+	}
+}
+
+// New Forbidden Error which explains required roles
+func NewMissingRoleError(roles []string, causes interface{}) RestErr {
+	return RestError{
+		ErrStatus: http.StatusForbidden,
+		ErrError:  fmt.Sprintf("%s: requires one of roles %s", Forbidden.Error(), strings.Join(roles, ", ")),
+		ErrCauses: causes,
+	}
+}
+
+// FieldError describes one invalid request field
+type FieldError struct {
+	Field   string `json:"field"`
+	Value   string `json:"value"`
+	Message string `json:"message"`
+}
+
+// ValidationError is bad request listing every invalid field
+type ValidationError struct {
+	RestError
+	Fields []FieldError `json:"fields"`
+}
+
+// New Validation Error
+func NewValidationError(fields ...FieldError) RestErr {
+	return ValidationError{
+		RestError: RestError{
+			ErrStatus: http.StatusBadRequest,
+			ErrError:  BadRequest.Error(),
+			ErrCauses: fields,
+		},
+		Fields: fields,
+	}
+}
+
+// TooManyRequestsError tells client how many seconds to wait before retry
+type TooManyRequestsError struct {
+	RestError
+	RetryAfter int `json:"retry_after"`
+}
+
+// New Too Many Requests Error
+func NewTooManyRequestsError(retryAfter int, causes interface{}) RestErr {
+	return TooManyRequestsError{
+		RestError: RestError{
+			ErrStatus: http.StatusTooManyRequests,
+			ErrError:  TooManyRequests.Error(),
+			ErrCauses: causes,
+		},
+		RetryAfter: retryAfter,
+	}
+}
+
+// PreconditionFailedError tells client resource changed since it was read, CurrentETag is empty when resource is gone
+type PreconditionFailedError struct {
+	RestError
+	CurrentETag string `json:"current_etag,omitempty"`
+}
+
+// New Precondition Failed Error
+func NewPreconditionFailedError(currentETag string, causes interface{}) RestErr {
+	return PreconditionFailedError{
+		RestError: RestError{
+			ErrStatus: http.StatusPreconditionFailed,
+			ErrError:  PreconditionFailed.Error(),
+			ErrCauses: causes,
+		},
+		CurrentETag: currentETag,
+	}
+}
+
 // New Internal Server Error
 func NewInternalServerError(causes interface{}) RestErr {
 	result := RestError{
@@ -0,0 +1,42 @@
+package mailer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// fileMailer write every mail as json file into dir, used for local development
+type fileMailer struct {
+	dir string
+}
+
+func NewFileMailer(dir string) (Mailer, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("os.MkdirAll: %w", err)
+	}
+
+	return &fileMailer{dir: dir}, nil
+}
+
+func (m *fileMailer) Send(ctx context.Context, mail *Mail) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	mailBytes, err := json.MarshalIndent(mail, "", "  ")
+	if err != nil {
+		return fmt.Errorf("json.MarshalIndent: %w", err)
+	}
+
+	fileName := fmt.Sprintf("%d-%s.json", time.Now().UnixNano(), uuid.New().String())
+	if err = os.WriteFile(filepath.Join(m.dir, fileName), mailBytes, 0o644); err != nil {
+		return fmt.Errorf("os.WriteFile: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"github.com/scul0405/blog-clean-architecture-rest-api/config"
+)
+
+const (
+	DriverSMTP   = "smtp"
+	DriverFile   = "file"
+	DriverMemory = "memory"
+)
+
+type Mail struct {
+	To      string `json:"to"`
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+// Mailer deliver mails, implementations must be safe for concurrent use
+type Mailer interface {
+	Send(ctx context.Context, mail *Mail) error
+}
+
+// NewMailer create mailer for driver from config
+func NewMailer(cfg *config.Config) (Mailer, error) {
+	switch cfg.Mailer.Driver {
+	case DriverSMTP:
+		return NewSMTPMailer(cfg), nil
+	case DriverFile:
+		return NewFileMailer(cfg.Mailer.OutputDir)
+	case DriverMemory, "":
+		return NewMemoryMailer(), nil
+	default:
+		return nil, fmt.Errorf("unknown mailer driver: %s", cfg.Mailer.Driver)
+	}
+}
@@ -0,0 +1,38 @@
+package mailer
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryMailer keep sent mails in memory, used for tests
+type MemoryMailer struct {
+	mu    sync.Mutex
+	mails []*Mail
+}
+
+func NewMemoryMailer() *MemoryMailer {
+	return &MemoryMailer{}
+}
+
+func (m *MemoryMailer) Send(ctx context.Context, mail *Mail) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.mails = append(m.mails, mail)
+	return nil
+}
+
+// Mails get copy of sent mails
+func (m *MemoryMailer) Mails() []*Mail {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	mails := make([]*Mail, len(m.mails))
+	copy(mails, m.mails)
+	return mails
+}
@@ -0,0 +1,50 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"github.com/scul0405/blog-clean-architecture-rest-api/config"
+	"net/smtp"
+	"strings"
+)
+
+type smtpMailer struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+func NewSMTPMailer(cfg *config.Config) Mailer {
+	var auth smtp.Auth
+	if cfg.Mailer.SMTPUser != "" {
+		auth = smtp.PlainAuth("", cfg.Mailer.SMTPUser, cfg.Mailer.SMTPPassword, cfg.Mailer.SMTPHost)
+	}
+
+	return &smtpMailer{
+		addr: fmt.Sprintf("%s:%d", cfg.Mailer.SMTPHost, cfg.Mailer.SMTPPort),
+		from: cfg.Mailer.From,
+		auth: auth,
+	}
+}
+
+func (m *smtpMailer) Send(ctx context.Context, mail *Mail) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	msg := strings.Join([]string{
+		"From: " + m.from,
+		"To: " + mail.To,
+		"Subject: " + mail.Subject,
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=\"utf-8\"",
+		"",
+		mail.Body,
+	}, "\r\n")
+
+	if err := smtp.SendMail(m.addr, m.auth, m.from, []string{mail.To}, []byte(msg)); err != nil {
+		return fmt.Errorf("smtp.SendMail: %w", err)
+	}
+
+	return nil
+}
@@ -2,32 +2,58 @@ package paseto
 
 import (
 	"errors"
+	"github.com/google/uuid"
 	"github.com/o1egl/paseto"
 	"github.com/scul0405/blog-clean-architecture-rest-api/config"
 	"github.com/scul0405/blog-clean-architecture-rest-api/internal/models"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/rbac"
 	"time"
 )
 
+const (
+	AccessToken  = "access"
+	RefreshToken = "refresh"
+
+	defaultAccessTokenExpire  = 60 * 60
+	defaultRefreshTokenExpire = 7 * 24 * 60 * 60
+)
+
 var (
 	ErrInvalidToken = errors.New("token is invalid")
 	ErrExpiredToken = errors.New("token has expired")
 )
 
 type Payload struct {
+	TokenID   string    `json:"jti"`
+	SessionID string    `json:"sid"`
+	Type      string    `json:"typ"`
 	Email     string    `json:"email"`
 	ID        string    `json:"id"`
+	Role      string    `json:"role"`
 	IssueAt   time.Time `json:"issue_at"`
 	ExpiredAt time.Time `json:"expired_at"`
 }
 
-func GeneratePASETOToken(user *models.User, config *config.Config) (string, error) {
-	payload := &Payload{
+// NewPayload create payload with a fresh token id for user's session
+func NewPayload(user *models.User, sessionID string, tokenType string, duration time.Duration) *Payload {
+	role := rbac.RoleUser
+	if user.Role != nil {
+		role = *user.Role
+	}
+
+	return &Payload{
+		TokenID:   uuid.New().String(),
+		SessionID: sessionID,
+		Type:      tokenType,
 		Email:     user.Email,
 		ID:        user.UserID.String(),
+		Role:      role,
 		IssueAt:   time.Now(),
-		ExpiredAt: time.Now().Add(time.Minute * 60),
+		ExpiredAt: time.Now().Add(duration),
 	}
+}
 
+func GeneratePASETOToken(payload *Payload, config *config.Config) (string, error) {
 	token, err := paseto.NewV2().Encrypt([]byte(config.Server.SymmetricKey), payload, nil)
 	if err != nil {
 		return "", err
@@ -58,3 +84,19 @@ func (p *Payload) IsValid() error {
 
 	return nil
 }
+
+// AccessTokenExpire get access token lifetime in seconds
+func AccessTokenExpire(config *config.Config) int {
+	if config.Server.AccessTokenExpire <= 0 {
+		return defaultAccessTokenExpire
+	}
+	return config.Server.AccessTokenExpire
+}
+
+// RefreshTokenExpire get refresh token lifetime in seconds
+func RefreshTokenExpire(config *config.Config) int {
+	if config.Server.RefreshTokenExpire <= 0 {
+		return defaultRefreshTokenExpire
+	}
+	return config.Server.RefreshTokenExpire
+}
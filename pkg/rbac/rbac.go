@@ -0,0 +1,59 @@
+package rbac
+
+const (
+	RoleUser      = "user"
+	RoleEditor    = "editor"
+	RoleModerator = "moderator"
+	RoleAdmin     = "admin"
+)
+
+type Permission string
+
+const (
+	BlogCreate       Permission = "blog:create"
+	BlogUpdateAny    Permission = "blog:update:any"
+	BlogDeleteAny    Permission = "blog:delete:any"
+	BlogModerate     Permission = "blog:moderate"
+	CommentCreate    Permission = "comment:create"
+	CommentUpdateAny Permission = "comment:update:any"
+	CommentDeleteAny Permission = "comment:delete:any"
+	CommentModerate  Permission = "comment:moderate"
+	UserManageRoles  Permission = "user:manage_roles"
+	UserUpdateAny    Permission = "user:update:any"
+	UserUnlockLogin  Permission = "user:unlock_login"
+	CategoryManage   Permission = "category:manage"
+)
+
+var rolePermissions = map[string][]Permission{
+	RoleUser:      {BlogCreate, CommentCreate},
+	RoleEditor:    {BlogCreate, CommentCreate, BlogUpdateAny},
+	RoleModerator: {BlogCreate, CommentCreate, BlogModerate, CommentUpdateAny, CommentDeleteAny, CommentModerate},
+	RoleAdmin: {BlogCreate, CommentCreate, BlogUpdateAny, BlogDeleteAny, BlogModerate, CommentUpdateAny, CommentDeleteAny, CommentModerate,
+		UserManageRoles, UserUpdateAny, UserUnlockLogin, CategoryManage},
+}
+
+// IsValidRole check role is one of known roles
+func IsValidRole(role string) bool {
+	_, ok := rolePermissions[role]
+	return ok
+}
+
+// HasPermission check role is granted permission, unknown roles have no permissions
+func HasPermission(role string, permission Permission) bool {
+	for _, p := range rolePermissions[role] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// HasRole check role is one of roles
+func HasRole(role string, roles ...string) bool {
+	for _, r := range roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
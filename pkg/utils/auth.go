@@ -2,8 +2,11 @@ package utils
 
 import (
 	"context"
+	"errors"
+	"github.com/google/uuid"
 	httpErrors "github.com/scul0405/blog-clean-architecture-rest-api/pkg/http_errors"
 	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/logger"
+	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/rbac"
 )
 
 // ValidateIsOwner validate is user from owner of content
@@ -24,3 +27,35 @@ func ValidateIsOwner(ctx context.Context, creatorID string, logger logger.Logger
 
 	return nil
 }
+
+// ValidateIsOwnerOrHasPermission validate is user owner of content or user's role grants permission on any content
+func ValidateIsOwnerOrHasPermission(ctx context.Context, creatorID string, permission rbac.Permission, logger logger.Logger) error {
+	if err := ValidateIsOwner(ctx, creatorID, logger); err == nil {
+		return nil
+	} else if !errors.Is(err, httpErrors.Forbidden) {
+		return httpErrors.NewUnauthorizedError(err)
+	}
+
+	if !rbac.HasPermission(GetRoleFromCtx(ctx), permission) {
+		return httpErrors.NewMissingPermissionError(string(permission), httpErrors.Forbidden)
+	}
+
+	return nil
+}
+
+// CanViewBlog report whether caller can read blog, blogs which are not published are read by author and editors only.
+// Held blogs are read by moderators too
+func CanViewBlog(ctx context.Context, authorID uuid.UUID, published bool, held bool) bool {
+	if published {
+		return true
+	}
+
+	userUID, err := GetUserUIDFromCtx(ctx)
+	if err != nil {
+		return false
+	}
+
+	role := GetRoleFromCtx(ctx)
+	return userUID == authorID || rbac.HasPermission(role, rbac.BlogUpdateAny) ||
+		held && rbac.HasPermission(role, rbac.BlogModerate)
+}
@@ -0,0 +1,115 @@
+package utils
+
+import (
+	"bytes"
+	"html"
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+)
+
+const (
+	// ExcerptMaxLength is maximum number of characters of excerpt, ellipsis is not counted
+	ExcerptMaxLength = 200
+	// readingWordsPerMinute is average reading speed reading time is estimated by
+	readingWordsPerMinute = 200
+)
+
+var (
+	// markdown renders GitHub flavored markdown, raw HTML of source is omitted
+	markdown = goldmark.New(goldmark.WithExtensions(extension.GFM))
+	// htmlPolicy allows formatting of user generated content only, it is safe to share between goroutines
+	htmlPolicy = bluemonday.UGCPolicy()
+	// textPolicy strips every tag
+	textPolicy = bluemonday.StrictPolicy()
+)
+
+// RenderedContent is content rendered to sanitised HTML with fields derived from its text
+type RenderedContent struct {
+	HTML        string
+	Excerpt     string
+	WordCount   int
+	ReadingTime int
+}
+
+// RenderContent render markdown or plain text content to sanitised HTML, plain text paragraphs are separated by blank lines
+func RenderContent(content string, isMarkdown bool) (*RenderedContent, error) {
+	var rendered string
+	if isMarkdown {
+		var buf bytes.Buffer
+		if err := markdown.Convert([]byte(content), &buf); err != nil {
+			return nil, err
+		}
+		rendered = buf.String()
+	} else {
+		rendered = renderPlain(content)
+	}
+
+	// Tags are spaced before they are stripped so words of adjacent paragraphs are not glued together
+	safeHTML := htmlPolicy.Sanitize(rendered)
+	words := strings.Fields(html.UnescapeString(textPolicy.Sanitize(strings.ReplaceAll(safeHTML, "<", " <"))))
+
+	return &RenderedContent{
+		HTML:        safeHTML,
+		Excerpt:     excerpt(words),
+		WordCount:   len(words),
+		ReadingTime: int(math.Ceil(float64(len(words)) / readingWordsPerMinute)),
+	}, nil
+}
+
+// renderPlain escape text and wrap its paragraphs, single line breaks are kept
+func renderPlain(content string) string {
+	var sb strings.Builder
+	for _, paragraph := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n\n") {
+		paragraph = strings.TrimSpace(paragraph)
+		if paragraph == "" {
+			continue
+		}
+		sb.WriteString("<p>")
+		sb.WriteString(strings.ReplaceAll(html.EscapeString(paragraph), "\n", "<br>"))
+		sb.WriteString("</p>\n")
+	}
+	return sb.String()
+}
+
+// excerpt join words up to ExcerptMaxLength characters, cut text ends with ellipsis
+func excerpt(words []string) string {
+	var sb strings.Builder
+	length := 0
+	for i, word := range words {
+		wordLength := utf8.RuneCountInString(word)
+		if i > 0 {
+			wordLength++
+		}
+		if length+wordLength > ExcerptMaxLength {
+			if i == 0 {
+				sb.WriteString(string([]rune(word)[:ExcerptMaxLength]))
+			}
+			sb.WriteString("…")
+			break
+		}
+		if i > 0 {
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(word)
+		length += wordLength
+	}
+	return sb.String()
+}
+
+// Search headline wraps matches in these sentinels instead of markup, MarkHeadline swaps them for <mark>
+const (
+	HeadlineStartSel = "\x02"
+	HeadlineStopSel  = "\x03"
+)
+
+// MarkHeadline escape headline made from text of content and wrap matches marked by sentinels in <mark>.
+// Text stripped of sanitised HTML keeps its entities, so it is unescaped first and is not escaped twice
+func MarkHeadline(headline string) string {
+	escaped := html.EscapeString(html.UnescapeString(headline))
+	return strings.NewReplacer(HeadlineStartSel, "<mark>", HeadlineStopSel, "</mark>").Replace(escaped)
+}
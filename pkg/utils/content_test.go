@@ -0,0 +1,191 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderContent_Sanitise(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		content     string
+		isMarkdown  bool
+		contains    []string
+		notContains []string
+	}{
+		{
+			name:        "Script in markdown",
+			content:     "Hello\n\n<script>alert(1)</script>",
+			isMarkdown:  true,
+			contains:    []string{"<p>Hello</p>"},
+			notContains: []string{"<script", "alert(1)"},
+		},
+		{
+			name:        "Iframe in markdown",
+			content:     `<iframe src="https://evil.example"></iframe>`,
+			isMarkdown:  true,
+			notContains: []string{"<iframe", "evil.example"},
+		},
+		{
+			name:        "Script in plain text",
+			content:     "<script>alert(1)</script>",
+			contains:    []string{"&lt;script&gt;alert(1)&lt;/script&gt;"},
+			notContains: []string{"<script"},
+		},
+		{
+			name:        "Javascript link",
+			content:     "[click](javascript:alert(1))",
+			isMarkdown:  true,
+			contains:    []string{"click"},
+			notContains: []string{"javascript:"},
+		},
+		{
+			name:        "Data image",
+			content:     "![pixel](data:text/html;base64,PHNjcmlwdD4=)",
+			isMarkdown:  true,
+			notContains: []string{"data:"},
+		},
+		{
+			name:        "Event handler attribute",
+			content:     `<img src="https://example.com/a.png" onerror="alert(1)">`,
+			isMarkdown:  true,
+			notContains: []string{"onerror", "alert(1)"},
+		},
+		{
+			name:        "Event handler in plain text",
+			content:     `<b onclick="alert(1)">bold</b>`,
+			contains:    []string{"&lt;b onclick="},
+			notContains: []string{"<b "},
+		},
+		{
+			name:        "Raw HTML is not passed through",
+			content:     "<div class=\"box\"><b>bold</b></div>",
+			isMarkdown:  true,
+			notContains: []string{"<div", "<b>"},
+		},
+		{
+			name:       "Markdown formatting is kept",
+			content:    "# Title\n\n**bold** and [link](https://example.com)",
+			isMarkdown: true,
+			contains:   []string{"<h1>Title</h1>", "<strong>bold</strong>", `href="https://example.com"`},
+		},
+		{
+			name:     "Plain paragraphs and line breaks",
+			content:  "first line\r\nsecond line\r\n\r\nnext paragraph",
+			contains: []string{"<p>first line<br>second line</p>", "<p>next paragraph</p>"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rendered, err := RenderContent(tt.content, tt.isMarkdown)
+			require.NoError(t, err)
+			for _, s := range tt.contains {
+				require.Contains(t, rendered.HTML, s)
+			}
+			for _, s := range tt.notContains {
+				require.NotContains(t, rendered.HTML, s)
+			}
+		})
+	}
+}
+
+func TestRenderContent_Text(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		content     string
+		isMarkdown  bool
+		excerpt     string
+		wordCount   int
+		readingTime int
+	}{
+		{
+			name:    "Empty",
+			content: "",
+		},
+		{
+			name:        "Paragraphs are not glued",
+			content:     "one two\n\nthree",
+			excerpt:     "one two three",
+			wordCount:   3,
+			readingTime: 1,
+		},
+		{
+			name:        "Markup is not counted",
+			content:     "# Title\n\nSome *text* with [a link](https://example.com)",
+			isMarkdown:  true,
+			excerpt:     "Title Some text with a link",
+			wordCount:   6,
+			readingTime: 1,
+		},
+		{
+			name:        "Entities are unescaped",
+			content:     "Tom & Jerry",
+			excerpt:     "Tom & Jerry",
+			wordCount:   3,
+			readingTime: 1,
+		},
+		{
+			name:        "Reading time rounds up",
+			content:     strings.Repeat("word ", readingWordsPerMinute+1),
+			excerpt:     strings.Repeat("word ", ExcerptMaxLength/5-1) + "word…",
+			wordCount:   readingWordsPerMinute + 1,
+			readingTime: 2,
+		},
+		{
+			name:        "Reading time of whole minutes",
+			content:     strings.Repeat("word ", readingWordsPerMinute*2),
+			excerpt:     strings.Repeat("word ", ExcerptMaxLength/5-1) + "word…",
+			wordCount:   readingWordsPerMinute * 2,
+			readingTime: 2,
+		},
+		{
+			name:        "Single long word is cut",
+			content:     strings.Repeat("é", ExcerptMaxLength+10),
+			excerpt:     strings.Repeat("é", ExcerptMaxLength) + "…",
+			wordCount:   1,
+			readingTime: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rendered, err := RenderContent(tt.content, tt.isMarkdown)
+			require.NoError(t, err)
+			require.Equal(t, tt.excerpt, rendered.Excerpt)
+			require.Equal(t, tt.wordCount, rendered.WordCount)
+			require.Equal(t, tt.readingTime, rendered.ReadingTime)
+		})
+	}
+}
+
+func TestExcerpt(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Cut at word boundary", func(t *testing.T) {
+		words := strings.Fields(strings.Repeat("abcdefghi ", 30))
+
+		cut := excerpt(words)
+		require.True(t, strings.HasSuffix(cut, "abcdefghi…"))
+		require.LessOrEqual(t, utf8.RuneCountInString(strings.TrimSuffix(cut, "…")), ExcerptMaxLength)
+	})
+
+	t.Run("Exact length is not cut", func(t *testing.T) {
+		words := []string{strings.Repeat("a", ExcerptMaxLength-2), "b"}
+
+		require.Equal(t, strings.Join(words, " "), excerpt(words))
+	})
+}
@@ -0,0 +1,147 @@
+package utils
+
+import (
+	"net/http"
+	"strings"
+
+	httpErrors "github.com/scul0405/blog-clean-architecture-rest-api/pkg/http_errors"
+)
+
+const (
+	DiffEqual  = "equal"
+	DiffInsert = "insert"
+	DiffDelete = "delete"
+	// DiffMaxLines is most lines text can have to be compared, diff takes time of product of line counts
+	DiffMaxLines = 10000
+)
+
+// DiffLine is line of text kept, inserted or deleted between two versions
+type DiffLine struct {
+	Op   string `json:"op"`
+	Text string `json:"text"`
+}
+
+// DiffLines compare texts line by line using longest common subsequence, deletions come before insertions.
+// Memory is linear in line count, texts longer than DiffMaxLines lines are refused
+func DiffLines(from, to string) ([]DiffLine, error) {
+	a, b := splitLines(from), splitLines(to)
+	if len(a) > DiffMaxLines || len(b) > DiffMaxLines {
+		return nil, httpErrors.NewRestError(http.StatusRequestEntityTooLarge, httpErrors.DiffTooLarge.Error(), nil)
+	}
+
+	// Common prefix and suffix are kept as they are, only the middle is compared
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	diff := make([]DiffLine, 0, len(a)+len(b))
+	for _, line := range a[:prefix] {
+		diff = append(diff, DiffLine{Op: DiffEqual, Text: line})
+	}
+
+	middleA, middleB := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	i, j := 0, 0
+	for _, match := range commonLines(middleA, middleB, 0, 0, nil) {
+		for ; i < match[0]; i++ {
+			diff = append(diff, DiffLine{Op: DiffDelete, Text: middleA[i]})
+		}
+		for ; j < match[1]; j++ {
+			diff = append(diff, DiffLine{Op: DiffInsert, Text: middleB[j]})
+		}
+		diff = append(diff, DiffLine{Op: DiffEqual, Text: middleA[i]})
+		i++
+		j++
+	}
+	for ; i < len(middleA); i++ {
+		diff = append(diff, DiffLine{Op: DiffDelete, Text: middleA[i]})
+	}
+	for ; j < len(middleB); j++ {
+		diff = append(diff, DiffLine{Op: DiffInsert, Text: middleB[j]})
+	}
+
+	for _, line := range a[len(a)-suffix:] {
+		diff = append(diff, DiffLine{Op: DiffEqual, Text: line})
+	}
+
+	return diff, nil
+}
+
+// commonLines append index pairs of lines of longest common subsequence of a and b to matches in order.
+// It splits a in half and finds where to split b by lengths of subsequences before and after the split (Hirschberg)
+func commonLines(a, b []string, aOffset, bOffset int, matches [][2]int) [][2]int {
+	if len(a) == 0 || len(b) == 0 {
+		return matches
+	}
+
+	if len(a) == 1 {
+		for j, line := range b {
+			if line == a[0] {
+				return append(matches, [2]int{aOffset, bOffset + j})
+			}
+		}
+		return matches
+	}
+
+	mid := len(a) / 2
+	before := prefixLengths(a[:mid], b)
+	after := suffixLengths(a[mid:], b)
+
+	split, best := 0, -1
+	for j := 0; j <= len(b); j++ {
+		if length := before[j] + after[j]; length > best {
+			split, best = j, length
+		}
+	}
+
+	matches = commonLines(a[:mid], b[:split], aOffset, bOffset, matches)
+	return commonLines(a[mid:], b[split:], aOffset+mid, bOffset+split, matches)
+}
+
+// prefixLengths get length of longest common subsequence of a and b[:j] for every j
+func prefixLengths(a, b []string) []int {
+	lengths := make([]int, len(b)+1)
+	for _, line := range a {
+		diagonal := 0
+		for j := 1; j <= len(b); j++ {
+			previous := lengths[j]
+			if line == b[j-1] {
+				lengths[j] = diagonal + 1
+			} else if lengths[j-1] > lengths[j] {
+				lengths[j] = lengths[j-1]
+			}
+			diagonal = previous
+		}
+	}
+	return lengths
+}
+
+// suffixLengths get length of longest common subsequence of a and b[j:] for every j
+func suffixLengths(a, b []string) []int {
+	lengths := make([]int, len(b)+1)
+	for i := len(a) - 1; i >= 0; i-- {
+		diagonal := 0
+		for j := len(b) - 1; j >= 0; j-- {
+			previous := lengths[j]
+			if a[i] == b[j] {
+				lengths[j] = diagonal + 1
+			} else if lengths[j+1] > lengths[j] {
+				lengths[j] = lengths[j+1]
+			}
+			diagonal = previous
+		}
+	}
+	return lengths
+}
+
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+
+	return strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+}
@@ -0,0 +1,182 @@
+package utils
+
+import (
+	"math/rand"
+	"net/http"
+	"strings"
+	"testing"
+
+	httpErrors "github.com/scul0405/blog-clean-architecture-rest-api/pkg/http_errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffLines(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		from string
+		to   string
+		diff []DiffLine
+	}{
+		{
+			name: "Both empty",
+			diff: []DiffLine{},
+		},
+		{
+			name: "Identical",
+			from: "a\nb",
+			to:   "a\nb",
+			diff: []DiffLine{{DiffEqual, "a"}, {DiffEqual, "b"}},
+		},
+		{
+			name: "From empty",
+			to:   "a\nb",
+			diff: []DiffLine{{DiffInsert, "a"}, {DiffInsert, "b"}},
+		},
+		{
+			name: "To empty",
+			from: "a\nb",
+			diff: []DiffLine{{DiffDelete, "a"}, {DiffDelete, "b"}},
+		},
+		{
+			name: "Pure insert",
+			from: "a\nc",
+			to:   "a\nb\nc",
+			diff: []DiffLine{{DiffEqual, "a"}, {DiffInsert, "b"}, {DiffEqual, "c"}},
+		},
+		{
+			name: "Pure delete",
+			from: "a\nb\nc",
+			to:   "a\nc",
+			diff: []DiffLine{{DiffEqual, "a"}, {DiffDelete, "b"}, {DiffEqual, "c"}},
+		},
+		{
+			name: "Changed line deletes before it inserts",
+			from: "a\nb\nc",
+			to:   "a\nx\nc",
+			diff: []DiffLine{{DiffEqual, "a"}, {DiffDelete, "b"}, {DiffInsert, "x"}, {DiffEqual, "c"}},
+		},
+		{
+			name: "Reordered lines",
+			from: "a\nb\nc",
+			to:   "c\na\nb",
+			diff: []DiffLine{{DiffInsert, "c"}, {DiffEqual, "a"}, {DiffEqual, "b"}, {DiffDelete, "c"}},
+		},
+		{
+			name: "CRLF compares equal to LF",
+			from: "a\r\nb\r\n",
+			to:   "a\nb\n",
+			diff: []DiffLine{{DiffEqual, "a"}, {DiffEqual, "b"}, {DiffEqual, ""}},
+		},
+		{
+			name: "Trailing newline",
+			from: "a",
+			to:   "a\n",
+			diff: []DiffLine{{DiffEqual, "a"}, {DiffInsert, ""}},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			diff, err := DiffLines(tt.from, tt.to)
+			require.NoError(t, err)
+			require.Equal(t, tt.diff, diff)
+		})
+	}
+}
+
+func TestDiffLines_TooLarge(t *testing.T) {
+	t.Parallel()
+
+	atLimit := strings.Repeat("line\n", DiffMaxLines-1) + "line"
+	overLimit := atLimit + "\nline"
+
+	t.Run("At limit", func(t *testing.T) {
+		diff, err := DiffLines(atLimit, atLimit+"x")
+		require.NoError(t, err)
+		require.Len(t, diff, DiffMaxLines+1)
+	})
+
+	t.Run("From over limit", func(t *testing.T) {
+		diff, err := DiffLines(overLimit, "")
+		require.Nil(t, diff)
+		require.Equal(t, http.StatusRequestEntityTooLarge, httpErrors.ParseErrors(err).Status())
+	})
+
+	t.Run("To over limit", func(t *testing.T) {
+		diff, err := DiffLines("", overLimit)
+		require.Nil(t, diff)
+		require.Equal(t, http.StatusRequestEntityTooLarge, httpErrors.ParseErrors(err).Status())
+	})
+}
+
+// TestDiffLines_NaiveLCS check diff keeps as many lines as quadratic LCS table finds and rebuilds both texts
+func TestDiffLines_NaiveLCS(t *testing.T) {
+	t.Parallel()
+
+	random := rand.New(rand.NewSource(1))
+	alphabet := []string{"a", "b", "c", "d"}
+	randomLines := func() []string {
+		lines := make([]string, random.Intn(12))
+		for i := range lines {
+			lines[i] = alphabet[random.Intn(len(alphabet))]
+		}
+		return lines
+	}
+
+	for n := 0; n < 2000; n++ {
+		a, b := randomLines(), randomLines()
+
+		diff, err := DiffLines(strings.Join(a, "\n"), strings.Join(b, "\n"))
+		require.NoError(t, err)
+
+		var kept, fromLines, toLines []string
+		for _, line := range diff {
+			switch line.Op {
+			case DiffEqual:
+				kept = append(kept, line.Text)
+				fromLines = append(fromLines, line.Text)
+				toLines = append(toLines, line.Text)
+			case DiffDelete:
+				fromLines = append(fromLines, line.Text)
+			case DiffInsert:
+				toLines = append(toLines, line.Text)
+			}
+		}
+
+		require.Equal(t, nilIfEmpty(a), nilIfEmpty(fromLines), "from %q to %q", a, b)
+		require.Equal(t, nilIfEmpty(b), nilIfEmpty(toLines), "from %q to %q", a, b)
+		require.Equal(t, naiveLCSLength(a, b), len(kept), "from %q to %q", a, b)
+	}
+}
+
+func naiveLCSLength(a, b []string) int {
+	table := make([][]int, len(a)+1)
+	for i := range table {
+		table[i] = make([]int, len(b)+1)
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			switch {
+			case a[i-1] == b[j-1]:
+				table[i][j] = table[i-1][j-1] + 1
+			case table[i-1][j] > table[i][j-1]:
+				table[i][j] = table[i-1][j]
+			default:
+				table[i][j] = table[i][j-1]
+			}
+		}
+	}
+	return table[len(a)][len(b)]
+}
+
+func nilIfEmpty(lines []string) []string {
+	if len(lines) == 0 {
+		return nil
+	}
+	return lines
+}
@@ -0,0 +1,130 @@
+package utils
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	httpErrors "github.com/scul0405/blog-clean-architecture-rest-api/pkg/http_errors"
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	HeaderETag        = "ETag"
+	HeaderIfMatch     = "If-Match"
+	HeaderIfNoneMatch = "If-None-Match"
+)
+
+// ETag of resource version. Representation which also depends on state not counted by version, like reactions or
+// query params, passes that state as variant. Its tag starts with version too, so it can be sent in If-Match
+func ETag(version int, variant ...string) string {
+	if len(variant) == 0 {
+		return fmt.Sprintf(`"%d"`, version)
+	}
+
+	h := fnv.New64a()
+	for _, v := range variant {
+		h.Write([]byte(v))
+		h.Write([]byte{0})
+	}
+
+	return fmt.Sprintf(`"%d-%x"`, version, h.Sum64())
+}
+
+// SetETag set ETag header of response
+func SetETag(c echo.Context, version int, variant ...string) {
+	c.Response().Header().Set(HeaderETag, ETag(version, variant...))
+}
+
+// ReactionsVariant is ETag variant of reaction counts and reactions of caller
+func ReactionsVariant(reactions map[string]int, mine []string) string {
+	parts := make([]string, 0, len(reactions)+len(mine))
+	for kind, count := range reactions {
+		parts = append(parts, kind+"="+strconv.Itoa(count))
+	}
+	sort.Strings(parts)
+
+	sortedMine := append([]string(nil), mine...)
+	sort.Strings(sortedMine)
+
+	return strings.Join(parts, ",") + ";" + strings.Join(sortedMine, ",")
+}
+
+// SetCallerCache mark response which depends on caller, it varies with Authorization and copy of authenticated
+// caller is kept by caller's own cache only
+func SetCallerCache(c echo.Context, ctx context.Context) {
+	c.Response().Header().Add(echo.HeaderVary, echo.HeaderAuthorization)
+	if _, err := GetUserUIDFromCtx(ctx); err == nil {
+		c.Response().Header().Set(echo.HeaderCacheControl, "private")
+	}
+}
+
+// GetIfMatchVersion read version required by If-Match header, 0 means any version. Variant of tag is ignored,
+// writes depend on version only. Weak tag can never match, so it fails precondition, tag which is not one quoted
+// positive version is bad request
+func GetIfMatchVersion(c echo.Context) (int, error) {
+	header := strings.TrimSpace(c.Request().Header.Get(HeaderIfMatch))
+	if header == "" || header == "*" {
+		return 0, nil
+	}
+
+	if strings.HasPrefix(header, "W/") {
+		return 0, httpErrors.NewPreconditionFailedError("", fmt.Errorf("If-Match can not match weak ETag %s", header))
+	}
+
+	tag, ok := strings.CutPrefix(header, `"`)
+	if ok {
+		tag, ok = strings.CutSuffix(tag, `"`)
+	}
+	tag, _, _ = strings.Cut(tag, "-")
+	version, err := strconv.Atoi(tag)
+	if !ok || err != nil || version < 1 {
+		return 0, httpErrors.NewBadRequestError(fmt.Errorf("If-Match must be one ETag, got %s", header))
+	}
+
+	return version, nil
+}
+
+// IfNoneMatch report whether If-None-Match header lists ETag of version and variant, tags are compared weakly
+func IfNoneMatch(c echo.Context, version int, variant ...string) bool {
+	header := c.Request().Header.Get(HeaderIfNoneMatch)
+	if header == "" {
+		return false
+	}
+
+	etag := ETag(version, variant...)
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || tag == etag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ValidateVersion fail precondition when client expects version other than current, expected 0 accepts any version.
+// Negative version is bad request
+func ValidateVersion(current, expected int) error {
+	if expected < 0 {
+		return httpErrors.NewBadRequestError(fmt.Errorf("version must not be negative, got %d", expected))
+	}
+	if expected == 0 || expected == current {
+		return nil
+	}
+
+	return httpErrors.NewPreconditionFailedError(ETag(current), fmt.Errorf("version is %d, expected %d", current, expected))
+}
+
+// VersionConflict turn missing row of change with expected version into failed precondition, row was changed concurrently
+func VersionConflict(err error, expected int) error {
+	if expected != 0 && errors.Is(err, sql.ErrNoRows) {
+		return httpErrors.NewPreconditionFailedError("", err)
+	}
+
+	return err
+}
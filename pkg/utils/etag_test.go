@@ -0,0 +1,119 @@
+package utils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	httpErrors "github.com/scul0405/blog-clean-architecture-rest-api/pkg/http_errors"
+	"github.com/stretchr/testify/require"
+)
+
+func newETagContext(header, value string) echo.Context {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if value != "" {
+		req.Header.Set(header, value)
+	}
+	return echo.New().NewContext(req, httptest.NewRecorder())
+}
+
+func TestETag(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, `"3"`, ETag(3))
+	require.Regexp(t, `^"3-[0-9a-f]+"$`, ETag(3, "variant"))
+	require.Equal(t, ETag(3, "a", "b"), ETag(3, "a", "b"))
+	require.NotEqual(t, ETag(3, "a", "b"), ETag(3, "ab"))
+	require.NotEqual(t, ETag(3, "a"), ETag(4, "a"))
+	require.Equal(t, ReactionsVariant(map[string]int{"like": 2, "wow": 1}, []string{"wow", "like"}),
+		ReactionsVariant(map[string]int{"wow": 1, "like": 2}, []string{"like", "wow"}))
+	require.NotEqual(t, ReactionsVariant(map[string]int{"like": 2}, nil), ReactionsVariant(map[string]int{"like": 2}, []string{"like"}))
+}
+
+func TestIfNoneMatch(t *testing.T) {
+	t.Parallel()
+
+	variant := ReactionsVariant(map[string]int{"like": 2}, []string{"like"})
+
+	tests := []struct {
+		name    string
+		header  string
+		variant []string
+		match   bool
+	}{
+		{name: "No header", header: "", match: false},
+		{name: "Same tag", header: `"3"`, match: true},
+		{name: "Other version", header: `"2"`, match: false},
+		{name: "Weak tag", header: `W/"3"`, match: true},
+		{name: "Any", header: "*", match: true},
+		{name: "List", header: `"1", "2",  "3"`, match: true},
+		{name: "List with weak tag", header: `"1", W/"3"`, match: true},
+		{name: "List without tag", header: `"1", "2"`, match: false},
+		{name: "Bare value", header: "3", match: false},
+		{name: "Tag of variant", header: ETag(3, variant), variant: []string{variant}, match: true},
+		{name: "Weak tag of variant", header: "W/" + ETag(3, variant), variant: []string{variant}, match: true},
+		{name: "Tag without variant", header: `"3"`, variant: []string{variant}, match: false},
+		{name: "Tag of other variant", header: ETag(3, "other"), variant: []string{variant}, match: false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := newETagContext(HeaderIfNoneMatch, tt.header)
+			require.Equal(t, tt.match, IfNoneMatch(c, 3, tt.variant...))
+		})
+	}
+}
+
+func TestGetIfMatchVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		header  string
+		version int
+		status  int
+	}{
+		{name: "No header", header: "", version: 0},
+		{name: "Any", header: "*", version: 0},
+		{name: "Tag", header: `"3"`, version: 3},
+		{name: "Tag with spaces", header: ` "3" `, version: 3},
+		{name: "Tag of variant", header: ETag(3, "variant"), version: 3},
+		{name: "Weak tag", header: `W/"3"`, status: http.StatusPreconditionFailed},
+		{name: "Bare value", header: "3", status: http.StatusBadRequest},
+		{name: "Open quote", header: `"3`, status: http.StatusBadRequest},
+		{name: "Not a number", header: `"abc"`, status: http.StatusBadRequest},
+		{name: "Empty tag", header: `""`, status: http.StatusBadRequest},
+		{name: "Zero", header: `"0"`, status: http.StatusBadRequest},
+		{name: "Negative", header: `"-3"`, status: http.StatusBadRequest},
+		{name: "List", header: `"1", "3"`, status: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			version, err := GetIfMatchVersion(newETagContext(HeaderIfMatch, tt.header))
+			if tt.status != 0 {
+				require.Error(t, err)
+				require.Equal(t, tt.status, httpErrors.ParseErrors(err).Status())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.version, version)
+		})
+	}
+}
+
+func TestValidateVersion(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, ValidateVersion(3, 0))
+	require.NoError(t, ValidateVersion(3, 3))
+	require.Equal(t, http.StatusPreconditionFailed, httpErrors.ParseErrors(ValidateVersion(3, 2)).Status())
+	require.Equal(t, http.StatusBadRequest, httpErrors.ParseErrors(ValidateVersion(3, -1)).Status())
+}
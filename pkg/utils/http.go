@@ -9,6 +9,7 @@ import (
 	"github.com/scul0405/blog-clean-architecture-rest-api/pkg/logger"
 	"mime/multipart"
 	"net/http"
+	"time"
 )
 
 // GetRequestID get the request id from echo context
@@ -97,3 +98,44 @@ func GetUserUIDFromCtx(ctx context.Context) (uuid.UUID, error) {
 
 	return userUID, err
 }
+
+// GetSessionIDFromCtx get session id of access token from context
+func GetSessionIDFromCtx(ctx context.Context) (string, error) {
+	sessionID, ok := ctx.Value("session_id").(string)
+	if !ok || sessionID == "" {
+		return "", httpErrors.Unauthorized
+	}
+
+	return sessionID, nil
+}
+
+// GetTokenIDFromCtx get access token id from context
+func GetTokenIDFromCtx(ctx context.Context) (string, error) {
+	tokenID, ok := ctx.Value("token_id").(string)
+	if !ok || tokenID == "" {
+		return "", httpErrors.Unauthorized
+	}
+
+	return tokenID, nil
+}
+
+// GetRoleFromCtx get user role from context, empty when request is not authenticated
+func GetRoleFromCtx(ctx context.Context) string {
+	role, _ := ctx.Value("role").(string)
+	return role
+}
+
+// ParseTimeQuery parse optional time query param given as RFC3339 time or date, empty param gives nil
+func ParseTimeQuery(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return &t, nil
+		}
+	}
+
+	return nil, errors.Errorf("time %q is neither RFC3339 nor date", value)
+}
@@ -1,12 +1,52 @@
 package utils
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
 	httpErrors "github.com/scul0405/blog-clean-architecture-rest-api/pkg/http_errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
 	"mime/multipart"
 	"net/http"
 	"net/textproto"
+	"path"
+	"strings"
 )
 
+// MaxImageSize is the largest image in bytes accepted for upload
+const MaxImageSize int64 = 1 << 20
+
+const (
+	ImageVariantThumbnail = "thumbnail"
+	ImageVariantMedium    = "medium"
+	ImageVariantOriginal  = "original"
+
+	imageUploadName = "upload"
+
+	// maxImagePixels guard against small files decoding into huge images
+	maxImagePixels = 40_000_000
+	jpegQuality    = 85
+)
+
+// ImageVariant is a resized copy of an image, MaxSide 0 keeps original size
+type ImageVariant struct {
+	Name    string
+	MaxSide int
+}
+
+// ImageVariants every processed upload is stored as
+var ImageVariants = []ImageVariant{
+	{Name: ImageVariantThumbnail, MaxSide: 150},
+	{Name: ImageVariantMedium, MaxSide: 800},
+	{Name: ImageVariantOriginal},
+}
+
+var ErrImageTooManyPixels = errors.New("image dimensions are too large")
+
 var allowedImagesContentType = map[string]string{
 	"image/png":  "png",
 	"image/jpg":  "jpg",
@@ -64,3 +104,152 @@ func IsAllowedImageContentType(image []byte) bool {
 	_, allowed := GetImageContentType(image)
 	return allowed
 }
+
+// ReadImageFile read uploaded image up to maxSize bytes, content is sniffed to be an allowed image
+func ReadImageFile(image *multipart.FileHeader, maxSize int64) ([]byte, error) {
+	if image.Size > maxSize {
+		return nil, httpErrors.NewRestError(http.StatusRequestEntityTooLarge, httpErrors.ImageTooLarge.Error(), nil)
+	}
+
+	file, err := image.Open()
+	if err != nil {
+		return nil, httpErrors.NewBadRequestError(err)
+	}
+	defer file.Close()
+
+	// Read one byte more than allowed so we notice header lying about size
+	binaryImage := bytes.NewBuffer(nil)
+	if _, err = io.Copy(binaryImage, io.LimitReader(file, maxSize+1)); err != nil {
+		return nil, httpErrors.NewBadRequestError(err)
+	}
+	if int64(binaryImage.Len()) > maxSize {
+		return nil, httpErrors.NewRestError(http.StatusRequestEntityTooLarge, httpErrors.ImageTooLarge.Error(), nil)
+	}
+
+	if !IsAllowedImageContentType(binaryImage.Bytes()) {
+		return nil, httpErrors.NewRestError(http.StatusBadRequest, httpErrors.NotAllowedImageHeader.Error(), nil)
+	}
+
+	return binaryImage.Bytes(), nil
+}
+
+// ProcessImage decode image and re-encode every variant from pixels only, so EXIF and other metadata are dropped.
+// Returns encoded variants by name and content type they are encoded in
+func ProcessImage(data []byte) (map[string][]byte, string, error) {
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("image.DecodeConfig: %w", err)
+	}
+	if config.Width*config.Height > maxImagePixels {
+		return nil, "", ErrImageTooManyPixels
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("image.Decode: %w", err)
+	}
+
+	variants := make(map[string][]byte, len(ImageVariants))
+	for _, variant := range ImageVariants {
+		buf := bytes.NewBuffer(nil)
+		resized := resizeImage(src, variant.MaxSide)
+
+		switch format {
+		case "png":
+			err = png.Encode(buf, resized)
+		default:
+			err = jpeg.Encode(buf, resized, &jpeg.Options{Quality: jpegQuality})
+		}
+		if err != nil {
+			return nil, "", fmt.Errorf("encode %s: %w", variant.Name, err)
+		}
+
+		variants[variant.Name] = buf.Bytes()
+	}
+
+	if format == "png" {
+		return variants, "image/png", nil
+	}
+	return variants, "image/jpeg", nil
+}
+
+// ImageVariantKey build deterministic object key of variant, prefix identifies the upload
+func ImageVariantKey(prefix string, variant string, contentType string) string {
+	extension, ok := allowedImagesContentType[contentType]
+	if !ok {
+		extension = "jpeg"
+	}
+	return fmt.Sprintf("%s/%s.%s", prefix, variant, extension)
+}
+
+// ImageUploadKey make key of uploaded image stored next to its variants until it is processed
+func ImageUploadKey(prefix string) string {
+	return fmt.Sprintf("%s/%s", prefix, imageUploadName)
+}
+
+// ImageVariantKeys get keys of every variant stored next to key, key not made by ImageVariantKey is returned alone
+func ImageVariantKeys(key string) []string {
+	dir, file := path.Split(key)
+	name, extension, ok := strings.Cut(file, ".")
+	if !ok || dir == "" || name != ImageVariantOriginal {
+		return []string{key}
+	}
+
+	keys := make([]string, 0, len(ImageVariants))
+	for _, variant := range ImageVariants {
+		keys = append(keys, fmt.Sprintf("%s%s.%s", dir, variant.Name, extension))
+	}
+	return keys
+}
+
+// resizeImage scale image down to fit maxSide by averaging source pixels, smaller images are kept as is
+func resizeImage(src image.Image, maxSide int) image.Image {
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if maxSide <= 0 || (width <= maxSide && height <= maxSide) {
+		return src
+	}
+
+	newWidth, newHeight := maxSide, maxSide
+	if width > height {
+		newHeight = maxInt(1, height*maxSide/width)
+	} else {
+		newWidth = maxInt(1, width*maxSide/height)
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, newWidth, newHeight))
+	for y := 0; y < newHeight; y++ {
+		y0 := bounds.Min.Y + y*height/newHeight
+		y1 := maxInt(y0+1, bounds.Min.Y+(y+1)*height/newHeight)
+
+		for x := 0; x < newWidth; x++ {
+			x0 := bounds.Min.X + x*width/newWidth
+			x1 := maxInt(x0+1, bounds.Min.X+(x+1)*width/newWidth)
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(cr), g+uint64(cg), b+uint64(cb), a+uint64(ca)
+					n++
+				}
+			}
+
+			dst.Set(x, y, color.RGBA64{
+				R: uint16(r / n),
+				G: uint16(g / n),
+				B: uint16(b / n),
+				A: uint16(a / n),
+			})
+		}
+	}
+
+	return dst
+}
+
+func maxInt(a int, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
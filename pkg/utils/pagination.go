@@ -1,15 +1,22 @@
 package utils
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"math"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
+	httpErrors "github.com/scul0405/blog-clean-architecture-rest-api/pkg/http_errors"
 )
 
 const (
-	defaultSize = 10
+	defaultSize    = 10
+	maxCursorLimit = 100
 )
 
 // Pagination query params
@@ -17,6 +24,25 @@ type PaginationQuery struct {
 	Size    int    `json:"size,omitempty"`
 	Page    int    `json:"page,omitempty"`
 	OrderBy string `json:"orderBy,omitempty"`
+	// Sort is OrderBy checked against allowed fields by SetSort
+	Sort []SortField `json:"-"`
+	// CursorMode is set by cursor query param, rows are then paged by Cursor newest first instead of page
+	CursorMode bool    `json:"-"`
+	Cursor     *Cursor `json:"-"`
+}
+
+// Cursor points to the row a keyset page starts after, rows are ordered by (created_at, id)
+type Cursor struct {
+	CreatedAt time.Time `json:"t"`
+	ID        uuid.UUID `json:"id"`
+	// Prev page is the rows before the cursor row
+	Prev bool `json:"p,omitempty"`
+}
+
+// SortField is one field of sort spec, spec "-created_at,title" sorts by created_at descending then by title
+type SortField struct {
+	Field string
+	Desc  bool
 }
 
 // Set page size
@@ -54,6 +80,42 @@ func (q *PaginationQuery) SetOrderBy(orderByQuery string) {
 	q.OrderBy = orderByQuery
 }
 
+// Set cursor from opaque cursor query, empty cursor is the first page
+func (q *PaginationQuery) SetCursor(cursorQuery string) error {
+	q.CursorMode = true
+	if cursorQuery == "" {
+		q.Cursor = nil
+		return nil
+	}
+
+	cursor, err := DecodeCursor(cursorQuery)
+	if err != nil {
+		return err
+	}
+	q.Cursor = cursor
+
+	return nil
+}
+
+// Set sort from order by, only allowed fields are accepted
+func (q *PaginationQuery) SetSort(allowed ...string) error {
+	if q.CursorMode && q.OrderBy != "" {
+		return httpErrors.NewValidationError(httpErrors.FieldError{
+			Field:   "sort",
+			Value:   q.OrderBy,
+			Message: "sort is not supported with cursor, cursor pages are newest first",
+		})
+	}
+
+	sort, err := ParseSort(q.OrderBy, allowed...)
+	if err != nil {
+		return err
+	}
+	q.Sort = sort
+
+	return nil
+}
+
 // Get offset
 func (q *PaginationQuery) GetOffset() int {
 	if q.Page == 0 {
@@ -72,6 +134,21 @@ func (q *PaginationQuery) GetOrderBy() string {
 	return q.OrderBy
 }
 
+// Get Sort
+func (q *PaginationQuery) GetSort() []SortField {
+	return q.Sort
+}
+
+// Is cursor mode
+func (q *PaginationQuery) IsCursorMode() bool {
+	return q.CursorMode
+}
+
+// Get Cursor
+func (q *PaginationQuery) GetCursor() *Cursor {
+	return q.Cursor
+}
+
 // Get OrderBy
 func (q *PaginationQuery) GetPage() int {
 	return q.Page
@@ -88,6 +165,10 @@ func (q *PaginationQuery) GetQueryString() string {
 
 // Get pagination query struct from
 func GetPaginationFromCtx(c echo.Context) (*PaginationQuery, error) {
+	if c.QueryParams().Has("cursor") {
+		return GetCursorPaginationFromCtx(c)
+	}
+
 	q := &PaginationQuery{}
 	if err := q.SetPage(c.QueryParam("page")); err != nil {
 		return nil, err
@@ -95,7 +176,29 @@ func GetPaginationFromCtx(c echo.Context) (*PaginationQuery, error) {
 	if err := q.SetSize(c.QueryParam("size")); err != nil {
 		return nil, err
 	}
-	q.SetOrderBy(c.QueryParam("orderBy"))
+	if sort := c.QueryParam("sort"); sort != "" {
+		q.SetOrderBy(sort)
+	} else {
+		q.SetOrderBy(c.QueryParam("orderBy"))
+	}
+
+	return q, nil
+}
+
+// GetCursorPaginationFromCtx get cursor pagination query, request without cursor reads first page
+func GetCursorPaginationFromCtx(c echo.Context) (*PaginationQuery, error) {
+	q := &PaginationQuery{}
+	if err := q.SetCursor(c.QueryParam("cursor")); err != nil {
+		return nil, err
+	}
+	if err := q.SetSize(c.QueryParam("limit")); err != nil || q.Size < 1 || q.Size > maxCursorLimit {
+		return nil, httpErrors.NewValidationError(httpErrors.FieldError{
+			Field:   "limit",
+			Value:   c.QueryParam("limit"),
+			Message: fmt.Sprintf("must be number from 1 to %d", maxCursorLimit),
+		})
+	}
+	q.SetOrderBy(c.QueryParam("sort"))
 
 	return q, nil
 }
@@ -106,7 +209,143 @@ func GetTotalPages(totalCount int, pageSize int) int {
 	return int(math.Ceil(d))
 }
 
-// Get has more
+// Get has more, last page which is not full counts as page too
 func GetHasMore(currentPage int, totalCount int, pageSize int) bool {
-	return currentPage < totalCount/pageSize
+	return pageSize > 0 && currentPage < GetTotalPages(totalCount, pageSize)
+}
+
+// EncodeCursor encode cursor into opaque url safe string
+func EncodeCursor(cursor Cursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor decode cursor made by EncodeCursor
+func DecodeCursor(value string) (*Cursor, error) {
+	invalid := httpErrors.NewValidationError(httpErrors.FieldError{
+		Field:   "cursor",
+		Value:   value,
+		Message: "must be next_cursor or prev_cursor of previous response",
+	})
+
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, invalid
+	}
+
+	cursor := &Cursor{}
+	if err = json.Unmarshal(data, cursor); err != nil || cursor.CreatedAt.IsZero() || cursor.ID == uuid.Nil {
+		return nil, invalid
+	}
+
+	return cursor, nil
+}
+
+// CursorKeyset get row comparison operator and order direction to read rows after cursor, newest first
+func CursorKeyset(cursor *Cursor) (operator string, direction string) {
+	if cursor != nil && cursor.Prev {
+		return ">", "ASC"
+	}
+	return "<", "DESC"
+}
+
+// CursorPage trim rows read by CursorKeyset with limit one more than page size and build cursors of neighbour pages.
+// Rows of prev page are read oldest first and are reversed here.
+func CursorPage[T any](rows []T, q *PaginationQuery, key func(T) (time.Time, uuid.UUID)) (page []T, nextCursor *string, prevCursor *string) {
+	hasMore := len(rows) > q.GetSize()
+	if hasMore {
+		rows = rows[:q.GetSize()]
+	}
+
+	backward := q.Cursor != nil && q.Cursor.Prev
+	if backward {
+		for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+			rows[i], rows[j] = rows[j], rows[i]
+		}
+	}
+
+	if len(rows) == 0 {
+		return rows, nil, nil
+	}
+
+	cursorOf := func(row T, prev bool) *string {
+		createdAt, id := key(row)
+		value := EncodeCursor(Cursor{CreatedAt: createdAt, ID: id, Prev: prev})
+		return &value
+	}
+
+	// Going forward there is a prev page only when came from cursor, going backward there is always a next page
+	if hasMore || backward {
+		nextCursor = cursorOf(rows[len(rows)-1], false)
+	}
+	if (backward && hasMore) || (!backward && q.Cursor != nil) {
+		prevCursor = cursorOf(rows[0], true)
+	}
+
+	return rows, nextCursor, prevCursor
+}
+
+// ParseSort parse sort spec like "-created_at,title", every field must be one of allowed
+func ParseSort(spec string, allowed ...string) ([]SortField, error) {
+	if strings.TrimSpace(spec) == "" {
+		return nil, nil
+	}
+
+	sort := make([]SortField, 0)
+	invalid := make([]httpErrors.FieldError, 0)
+	seen := make(map[string]bool)
+
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		field := SortField{Field: strings.TrimPrefix(part, "-"), Desc: strings.HasPrefix(part, "-")}
+
+		if !isAllowedSortField(field.Field, allowed) || seen[field.Field] {
+			invalid = append(invalid, httpErrors.FieldError{
+				Field:   "sort",
+				Value:   part,
+				Message: fmt.Sprintf("sort by one of %s at most once, prefix with - to sort descending", strings.Join(allowed, ", ")),
+			})
+			continue
+		}
+
+		seen[field.Field] = true
+		sort = append(sort, field)
+	}
+
+	if len(invalid) > 0 {
+		return nil, httpErrors.NewValidationError(invalid...)
+	}
+
+	return sort, nil
+}
+
+// OrderByClause translate sort into ORDER BY list using only columns mapped to sort fields, defaultOrder is used for empty sort
+func OrderByClause(sort []SortField, columns map[string]string, defaultOrder string) string {
+	clauses := make([]string, 0, len(sort))
+	for _, field := range sort {
+		column, ok := columns[field.Field]
+		if !ok {
+			continue
+		}
+
+		if field.Desc {
+			clauses = append(clauses, column+" DESC")
+		} else {
+			clauses = append(clauses, column+" ASC")
+		}
+	}
+
+	if len(clauses) == 0 {
+		return defaultOrder
+	}
+	return strings.Join(clauses, ", ")
+}
+
+func isAllowedSortField(field string, allowed []string) bool {
+	for _, a := range allowed {
+		if a == field {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,225 @@
+package utils
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	httpErrors "github.com/scul0405/blog-clean-architecture-rest-api/pkg/http_errors"
+	"github.com/stretchr/testify/require"
+)
+
+func newPaginationContext(query string) echo.Context {
+	req := httptest.NewRequest(http.MethodGet, "/?"+query, nil)
+	return echo.New().NewContext(req, httptest.NewRecorder())
+}
+
+func TestCursor_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, cursor := range []Cursor{
+		{CreatedAt: time.Date(2024, 5, 1, 10, 30, 0, 123456000, time.UTC), ID: uuid.New()},
+		{CreatedAt: time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC), ID: uuid.New(), Prev: true},
+	} {
+		value := EncodeCursor(cursor)
+		require.NotContains(t, value, "=")
+
+		decoded, err := DecodeCursor(value)
+		require.NoError(t, err)
+		require.True(t, cursor.CreatedAt.Equal(decoded.CreatedAt))
+		require.Equal(t, cursor.ID, decoded.ID)
+		require.Equal(t, cursor.Prev, decoded.Prev)
+	}
+}
+
+func TestDecodeCursor_Invalid(t *testing.T) {
+	t.Parallel()
+
+	valid := EncodeCursor(Cursor{CreatedAt: time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC), ID: uuid.New()})
+
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{name: "Not base64", cursor: "not a cursor!"},
+		{name: "Padded base64", cursor: base64.URLEncoding.EncodeToString([]byte(`{"t":"2024-05-01T10:30:00Z"}`))},
+		{name: "Truncated", cursor: valid[:len(valid)/2]},
+		{name: "Tampered", cursor: "X" + valid[1:]},
+		{name: "Not JSON", cursor: base64.RawURLEncoding.EncodeToString([]byte("created_at=1"))},
+		{name: "Empty object", cursor: base64.RawURLEncoding.EncodeToString([]byte("{}"))},
+		{name: "Missing id", cursor: base64.RawURLEncoding.EncodeToString([]byte(`{"t":"2024-05-01T10:30:00Z"}`))},
+		{name: "Bad id", cursor: base64.RawURLEncoding.EncodeToString([]byte(`{"t":"2024-05-01T10:30:00Z","id":"1"}`))},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cursor, err := DecodeCursor(tt.cursor)
+			require.Nil(t, cursor)
+			require.Equal(t, http.StatusBadRequest, httpErrors.ParseErrors(err).Status())
+		})
+	}
+}
+
+func TestGetPaginationFromCtx_Cursor(t *testing.T) {
+	t.Parallel()
+
+	cursor := EncodeCursor(Cursor{CreatedAt: time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC), ID: uuid.New()})
+
+	t.Run("First page", func(t *testing.T) {
+		q, err := GetPaginationFromCtx(newPaginationContext("cursor=&limit=5"))
+		require.NoError(t, err)
+		require.True(t, q.IsCursorMode())
+		require.Nil(t, q.GetCursor())
+		require.Equal(t, 5, q.GetSize())
+		require.NoError(t, q.SetSort("created_at", "title"))
+	})
+
+	t.Run("Tampered cursor", func(t *testing.T) {
+		_, err := GetPaginationFromCtx(newPaginationContext("cursor=" + cursor[:len(cursor)-3] + "&limit=5"))
+		require.Equal(t, http.StatusBadRequest, httpErrors.ParseErrors(err).Status())
+	})
+
+	t.Run("Cursor with sort", func(t *testing.T) {
+		q, err := GetPaginationFromCtx(newPaginationContext("cursor=" + cursor + "&sort=title"))
+		require.NoError(t, err)
+
+		err = q.SetSort("created_at", "title")
+		require.Equal(t, http.StatusBadRequest, httpErrors.ParseErrors(err).Status())
+	})
+
+	t.Run("Limit out of range", func(t *testing.T) {
+		for _, limit := range []string{"0", "-1", "101", "ten"} {
+			_, err := GetPaginationFromCtx(newPaginationContext("cursor=&limit=" + limit))
+			require.Equal(t, http.StatusBadRequest, httpErrors.ParseErrors(err).Status(), "limit %s", limit)
+		}
+	})
+}
+
+type cursorRow struct {
+	createdAt time.Time
+	id        uuid.UUID
+}
+
+func cursorRowKey(row cursorRow) (time.Time, uuid.UUID) {
+	return row.createdAt, row.id
+}
+
+// newestFirst make rows one minute apart, newest first like CursorKeyset reads them going forward
+func newestFirst(n int) []cursorRow {
+	start := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	rows := make([]cursorRow, n)
+	for i := range rows {
+		rows[i] = cursorRow{createdAt: start.Add(-time.Duration(i) * time.Minute), id: uuid.New()}
+	}
+	return rows
+}
+
+func TestCursorPage(t *testing.T) {
+	t.Parallel()
+
+	t.Run("First page has more", func(t *testing.T) {
+		rows := newestFirst(4)
+		q := &PaginationQuery{Size: 3, CursorMode: true}
+
+		page, next, prev := CursorPage(rows, q, cursorRowKey)
+		require.Equal(t, rows[:3], page)
+		require.Nil(t, prev)
+		require.NotNil(t, next)
+
+		cursor, err := DecodeCursor(*next)
+		require.NoError(t, err)
+		require.Equal(t, rows[2].id, cursor.ID)
+		require.False(t, cursor.Prev)
+	})
+
+	t.Run("Last full page", func(t *testing.T) {
+		rows := newestFirst(3)
+		q := &PaginationQuery{Size: 3, CursorMode: true, Cursor: &Cursor{CreatedAt: time.Now(), ID: uuid.New()}}
+
+		page, next, prev := CursorPage(rows, q, cursorRowKey)
+		require.Len(t, page, 3)
+		require.Nil(t, next)
+		require.NotNil(t, prev)
+
+		cursor, err := DecodeCursor(*prev)
+		require.NoError(t, err)
+		require.Equal(t, rows[0].id, cursor.ID)
+		require.True(t, cursor.Prev)
+	})
+
+	t.Run("Only page", func(t *testing.T) {
+		rows := newestFirst(2)
+		q := &PaginationQuery{Size: 3, CursorMode: true}
+
+		page, next, prev := CursorPage(rows, q, cursorRowKey)
+		require.Len(t, page, 2)
+		require.Nil(t, next)
+		require.Nil(t, prev)
+	})
+
+	t.Run("Empty page", func(t *testing.T) {
+		q := &PaginationQuery{Size: 3, CursorMode: true, Cursor: &Cursor{CreatedAt: time.Now(), ID: uuid.New()}}
+
+		page, next, prev := CursorPage([]cursorRow{}, q, cursorRowKey)
+		require.Empty(t, page)
+		require.Nil(t, next)
+		require.Nil(t, prev)
+	})
+
+	t.Run("Prev page is reversed", func(t *testing.T) {
+		rows := newestFirst(4)
+		oldestFirst := []cursorRow{rows[3], rows[2], rows[1], rows[0]}
+		q := &PaginationQuery{Size: 3, CursorMode: true, Cursor: &Cursor{CreatedAt: time.Now(), ID: uuid.New(), Prev: true}}
+
+		page, next, prev := CursorPage(oldestFirst, q, cursorRowKey)
+		require.Equal(t, []cursorRow{rows[1], rows[2], rows[3]}, page)
+		require.NotNil(t, next)
+		require.NotNil(t, prev)
+	})
+
+	t.Run("First page reached going back", func(t *testing.T) {
+		rows := newestFirst(2)
+		oldestFirst := []cursorRow{rows[1], rows[0]}
+		q := &PaginationQuery{Size: 3, CursorMode: true, Cursor: &Cursor{CreatedAt: time.Now(), ID: uuid.New(), Prev: true}}
+
+		page, next, prev := CursorPage(oldestFirst, q, cursorRowKey)
+		require.Equal(t, rows, page)
+		require.NotNil(t, next)
+		require.Nil(t, prev)
+	})
+}
+
+func TestGetHasMore(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		page       int
+		totalCount int
+		size       int
+		hasMore    bool
+		totalPages int
+	}{
+		{name: "First of many", page: 1, totalCount: 25, size: 10, hasMore: true, totalPages: 3},
+		{name: "Before last partial page", page: 2, totalCount: 25, size: 10, hasMore: true, totalPages: 3},
+		{name: "Last partial page", page: 3, totalCount: 25, size: 10, hasMore: false, totalPages: 3},
+		{name: "Last full page", page: 2, totalCount: 20, size: 10, hasMore: false, totalPages: 2},
+		{name: "Past last page", page: 5, totalCount: 20, size: 10, hasMore: false, totalPages: 2},
+		{name: "Empty", page: 1, totalCount: 0, size: 10, hasMore: false, totalPages: 0},
+		{name: "Zero size", page: 1, totalCount: 5, size: 0, hasMore: false},
+	}
+
+	for _, tt := range tests {
+		require.Equal(t, tt.hasMore, GetHasMore(tt.page, tt.totalCount, tt.size), tt.name)
+		if tt.size > 0 {
+			require.Equal(t, tt.totalPages, GetTotalPages(tt.totalCount, tt.size), tt.name)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package utils
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// SlugMaxLength is maximum length of slug base, collision suffix is added after it
+const SlugMaxLength = 80
+
+// slugTransliterations spell letters which do not decompose to latin letter with marks. Cyrillic letters lose marks first,
+// so ё, й and ї are spelled as е, и and і like migration 15 does
+var slugTransliterations = map[rune]string{
+	'đ': "d", 'ð': "d", 'ß': "ss", 'æ': "ae", 'œ': "oe", 'ø': "o", 'ł': "l", 'þ': "th", 'ı': "i",
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ж': "zh", 'з': "z", 'и': "i", 'к': "k", 'л': "l",
+	'м': "m", 'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f",
+	'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu", 'я': "ya",
+	'і': "i", 'є': "ye", 'ґ': "g",
+}
+
+// Slugify make url friendly slug of title, letters are transliterated to ascii and every other run of characters becomes hyphen.
+// Slug longer than SlugMaxLength is cut before word which does not fit. Result is empty when title has nothing to transliterate
+func Slugify(title string) string {
+	stripMarks := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	plain, _, err := transform.String(stripMarks, strings.ToLower(title))
+	if err != nil {
+		plain = strings.ToLower(title)
+	}
+
+	var sb strings.Builder
+	hyphen := false
+	for _, r := range plain {
+		word, ok := slugTransliterations[r]
+		if !ok && r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			word, ok = string(r), true
+		}
+		if !ok {
+			hyphen = sb.Len() > 0
+			continue
+		}
+		if word == "" {
+			continue
+		}
+		length := sb.Len() + len(word)
+		if hyphen {
+			length++
+		}
+		if length > SlugMaxLength {
+			return cutToWord(sb.String(), hyphen)
+		}
+		if hyphen {
+			sb.WriteByte('-')
+			hyphen = false
+		}
+		sb.WriteString(word)
+	}
+
+	return sb.String()
+}
+
+// cutToWord drop word slug was cut in, slug which ends before next word or has single word is kept
+func cutToWord(slug string, atWordEnd bool) string {
+	if i := strings.LastIndexByte(slug, '-'); !atWordEnd && i > 0 {
+		return slug[:i]
+	}
+	return slug
+}
+
+// SlugCandidate return n-th slug for base, first is base itself and next ones have collision suffix -2, -3, ...
+func SlugCandidate(base string, n int) string {
+	if n <= 1 {
+		return base
+	}
+	return base + "-" + strconv.Itoa(n)
+}
+
+// SlugHasBase report whether slug is base or base with collision suffix
+func SlugHasBase(slug, base string) bool {
+	if slug == base {
+		return true
+	}
+	suffix, ok := strings.CutPrefix(slug, base+"-")
+	if !ok {
+		return false
+	}
+	n, err := strconv.Atoi(suffix)
+	return err == nil && n > 1 && SlugCandidate(base, n) == slug
+}
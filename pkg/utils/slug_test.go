@@ -0,0 +1,151 @@
+package utils
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlugify(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		title string
+		slug  string
+	}{
+		{name: "Words", title: "Hello World", slug: "hello-world"},
+		{name: "Digits", title: "Go 1.20 released", slug: "go-1-20-released"},
+		{name: "Punctuation runs collapse", title: "Hello,   World!!! -- again?", slug: "hello-world-again"},
+		{name: "Leading and trailing symbols", title: "  --Hello--  ", slug: "hello"},
+		{name: "Latin marks", title: "Crème Brûlée à la carte", slug: "creme-brulee-a-la-carte"},
+		{name: "Decomposed marks", title: "Cre\u0300me", slug: "creme"},
+		{name: "Vietnamese", title: "Đường phố Hà Nội", slug: "duong-pho-ha-noi"},
+		{name: "German", title: "Straße Größe", slug: "strasse-grosse"},
+		{name: "Ligatures and strokes", title: "Æsir Œuvre Øre Łódź", slug: "aesir-oeuvre-ore-lodz"},
+		{name: "Cyrillic", title: "Привет, мир", slug: "privet-mir"},
+		{name: "Cyrillic multi letter", title: "Щука Жук Юла Ящик", slug: "shchuka-zhuk-yula-yashchik"},
+		{name: "Cyrillic signs are dropped", title: "Объезд", slug: "obezd"},
+		{name: "Ukrainian letters with marks lose them", title: "Їжак Єва Ґанок", slug: "izhak-yeva-ganok"},
+		{name: "Empty", title: "", slug: ""},
+		{name: "All symbols", title: "!!! ??? ***", slug: ""},
+		{name: "Untransliterated script", title: "你好 世界", slug: ""},
+		{name: "Untransliterated script between words", title: "Go 你好 lang", slug: "go-lang"},
+		{name: "Emoji", title: "Go 🚀", slug: "go"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.slug, Slugify(tt.title))
+		})
+	}
+}
+
+func TestSlugify_MaxLength(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		title string
+		slug  string
+	}{
+		{
+			name:  "Fits exactly",
+			title: strings.Repeat("abcdefghi ", 8),
+			slug:  strings.TrimSuffix(strings.Repeat("abcdefghi-", 8), "-"),
+		},
+		{
+			name:  "Word which does not fit is dropped",
+			title: strings.Repeat("abcdefghi ", 8) + "j",
+			slug:  strings.TrimSuffix(strings.Repeat("abcdefghi-", 8), "-"),
+		},
+		{
+			name:  "Word cut in the middle is dropped",
+			title: strings.Repeat("abcdefghi ", 7) + "abcdefghijklmnop",
+			slug:  strings.TrimSuffix(strings.Repeat("abcdefghi-", 7), "-"),
+		},
+		{
+			name:  "Multi letter transliteration does not fit",
+			title: strings.Repeat("a", SlugMaxLength-2) + "щ",
+			slug:  strings.Repeat("a", SlugMaxLength-2),
+		},
+		{
+			name:  "Single long word is cut",
+			title: strings.Repeat("a", SlugMaxLength+20),
+			slug:  strings.Repeat("a", SlugMaxLength),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			slug := Slugify(tt.title)
+			require.Equal(t, tt.slug, slug)
+			require.LessOrEqual(t, len(slug), SlugMaxLength)
+		})
+	}
+}
+
+// TestSlugify_MigrationLetters check letters backfill of migration 15 transliterates are transliterated same way,
+// slugs of blogs written before slugs existed are compared with Slugify of their title
+func TestSlugify_MigrationLetters(t *testing.T) {
+	t.Parallel()
+
+	migration, err := os.ReadFile("../../migrations/15_add_blogs_slugs.up.sql")
+	require.NoError(t, err)
+
+	_, rest, found := strings.Cut(string(migration), "letters CONSTANT JSONB := '")
+	require.True(t, found)
+	letters, _, found := strings.Cut(rest, "';")
+	require.True(t, found)
+
+	var words map[string]string
+	require.NoError(t, json.Unmarshal([]byte(letters), &words))
+	require.NotEmpty(t, words)
+
+	for word, chars := range words {
+		for _, ch := range chars {
+			require.Equal(t, "x"+word+"y", Slugify("x"+string(ch)+"y"), "letter %q", ch)
+		}
+	}
+}
+
+func TestSlugCandidate(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "hello", SlugCandidate("hello", 0))
+	require.Equal(t, "hello", SlugCandidate("hello", 1))
+	require.Equal(t, "hello-2", SlugCandidate("hello", 2))
+	require.Equal(t, "hello-10", SlugCandidate("hello", 10))
+}
+
+func TestSlugHasBase(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		slug    string
+		base    string
+		hasBase bool
+	}{
+		{slug: "hello", base: "hello", hasBase: true},
+		{slug: "hello-2", base: "hello", hasBase: true},
+		{slug: "hello-15", base: "hello", hasBase: true},
+		{slug: "hello-1", base: "hello", hasBase: false},
+		{slug: "hello-02", base: "hello", hasBase: false},
+		{slug: "hello-world", base: "hello", hasBase: false},
+		{slug: "hello-2", base: "hello-2", hasBase: true},
+		{slug: "hello", base: "hello-world", hasBase: false},
+	}
+
+	for _, tt := range tests {
+		require.Equal(t, tt.hasBase, SlugHasBase(tt.slug, tt.base), "slug %q base %q", tt.slug, tt.base)
+	}
+}
@@ -0,0 +1,28 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+const oneTimeTokenBytes = 32
+
+// GenerateOneTimeToken generate random url safe token
+func GenerateOneTimeToken() (string, error) {
+	b := make([]byte, oneTimeTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// SignOneTimeToken sign token with key, only signature is stored so leaked storage can not be used to forge links
+func SignOneTimeToken(token string, key string) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(token))
+	return hex.EncodeToString(mac.Sum(nil))
+}